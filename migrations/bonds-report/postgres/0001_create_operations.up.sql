@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS operations (
+    id SERIAL PRIMARY KEY,
+    chatId BIGINT,
+    broker_account_id TEXT,
+    currency TEXT,
+    operation_id TEXT,
+    parent_operation_id TEXT,
+    name TEXT,
+    date TIMESTAMP,
+    type INTEGER,
+    description TEXT,
+    instrument_uid TEXT,
+    figi TEXT,
+    instrument_type TEXT,
+    instrument_kind TEXT,
+    position_uid TEXT,
+    payment NUMERIC(14, 4),
+    price NUMERIC(14, 4),
+    commission NUMERIC(14, 4),
+    yield NUMERIC(14, 4),
+    yield_relative NUMERIC(6, 2),
+    accrued_int NUMERIC(14, 4),
+    quantity_done INTEGER,
+    asset_uid TEXT
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS operations_chatid_account_operation_uidx;
@@ -0,0 +1,9 @@
+DELETE FROM operations a
+USING operations b
+WHERE a.id > b.id
+  AND a.chatId = b.chatId
+  AND a.broker_account_id = b.broker_account_id
+  AND a.operation_id = b.operation_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS operations_chatid_account_operation_uidx
+ON operations (chatId, broker_account_id, operation_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS operations_sync (
+    chatId BIGINT,
+    broker_account_id TEXT,
+    date_from TIMESTAMP,
+    date_to TIMESTAMP,
+    cursor TEXT,
+    pages INTEGER,
+    operations_saved INTEGER,
+    completed BOOLEAN,
+    updated_at TIMESTAMP,
+    PRIMARY KEY (chatId, broker_account_id)
+);
@@ -47,6 +47,8 @@ func main() {
 		return
 	}
 
+	ratingSource := app.InitRatings(logg, &conf)
+
 	bondReporter := app.InitBondReportProcessor(logg)
 
 	cbrCurrencyGetter := app.InitCBRCurrencyGetter(logg, cbrClient, repo)
@@ -67,7 +69,7 @@ func main() {
 
 	dividerByAssetType := app.InitDividerByAssetType(logg, tinkoffApiHelper, cbrCurrencyGetter, conf.WorkersNubmer)
 
-	externalApis := usecases.NewExternalApis(moexClient, cbrClient, sberClient)
+	externalApis := usecases.NewExternalApis(moexClient, cbrClient, sberClient, ratingSource)
 
 	helpers := usecases.NewHelpers(bondReporter,
 		cbrCurrencyGetter,
@@ -85,6 +87,7 @@ func main() {
 	serviceClient := usecases.NewService(
 		logg,
 		conf.WorkersNubmer,
+		conf.IssuerShareLimit,
 		externalApis,
 		helpers,
 		repo,
@@ -100,6 +103,7 @@ func main() {
 	router.Use(handl.ContextHeaderTraceIdMiddleWare())
 	router.Use(handl.LoggerMiddleware())
 	router.Use(handl.AuthMiddleware())
+	router.Use(handl.LanguageMiddleware())
 
 	router.GET("/bondReportService/accounts", handl.GetAccountsList)
 	router.GET("/bondReportService/getBondReportsByFifo", handl.GetBondReportsByFifo)
@@ -108,6 +112,11 @@ func main() {
 	router.GET("/bondReportService/getPortfolioStructure", handl.GetPortfolioStructure)
 	router.GET("/bondReportService/getUnionPortfolioStructure", handl.GetUnionPortfolioStructure)
 	router.GET("/bondReportService/getUnionPortfolioStructureWithSber", handl.GetUnionPortfolioStructureWithSber)
+	router.GET("/bondReportService/getCash", handl.GetCash)
+	router.GET("/bondReportService/getDividends", handl.GetDividends)
+	router.GET("/bondReportService/getBondChart", handl.GetBondChart)
+	router.GET("/bondReportService/getBondScreener", handl.GetBondScreener)
+	router.GET("/bondReportService/getRateScenario", handl.GetRateScenario)
 
 	address := conf.Clients.BondReportService.GetBondReportServiceAppAddress()
 
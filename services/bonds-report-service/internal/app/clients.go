@@ -5,6 +5,7 @@ import (
 	cbrtransport "bonds-report-service/internal/infrastructure/cbr/transport"
 	moex "bonds-report-service/internal/infrastructure/moex/client"
 	moextransport "bonds-report-service/internal/infrastructure/moex/transport"
+	"bonds-report-service/internal/infrastructure/ratings"
 	"bonds-report-service/internal/infrastructure/sber"
 	"log/slog"
 
@@ -35,3 +36,8 @@ func InitSberClient(logger *slog.Logger, conf *config.Config) (*sber.Client, err
 	logger.Info("initialize Sber client", slog.String("address", conf.SberConfigPath))
 	return sber.NewClient(conf.RootPath, conf.SberConfigPath)
 }
+
+func InitRatings(logger *slog.Logger, conf *config.Config) *ratings.File {
+	logger.Info("initialize ratings", slog.String("address", conf.RatingsPath))
+	return ratings.NewFile(conf.RootPath, conf.RatingsPath)
+}
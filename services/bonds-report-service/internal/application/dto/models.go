@@ -6,6 +6,12 @@ type BondReportsResponce struct {
 
 type AccountListResponce struct {
 	Accounts string
+	List     []Account // открытые счета по названию, для выбора счета в боте
+}
+
+type Account struct {
+	ID   string
+	Name string
 }
 
 type MediaGroup struct {
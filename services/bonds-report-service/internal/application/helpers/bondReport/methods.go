@@ -2,6 +2,7 @@ package bondreport
 
 import (
 	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
 	report "bonds-report-service/internal/domain/report"
 	report_position "bonds-report-service/internal/domain/report_position"
 	"bonds-report-service/internal/utils/logging"
@@ -15,6 +16,7 @@ func (s *BondReporter) CreateBondReport(
 	currentPositions []report_position.PositionByFIFO,
 	moexBuyDateData domain.ValuesMoex,
 	moexNowData domain.ValuesMoex,
+	schedule *bondmath.Schedule,
 ) (_ report.Report, err error) {
 	const op = "service.CreateBondReport"
 
@@ -24,7 +26,7 @@ func (s *BondReporter) CreateBondReport(
 
 	for i := range currentPositions {
 		position := &currentPositions[i]
-		err := resultReports.Add(position, moexBuyDateData, moexNowData, s.now())
+		err := resultReports.Add(position, moexBuyDateData, moexNowData, schedule, s.now())
 		if err != nil {
 			return report.Report{}, e.WrapIfErr("failed to add result reports", err)
 		}
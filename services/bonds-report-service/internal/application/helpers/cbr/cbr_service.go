@@ -42,3 +42,44 @@ func (h *CbrHelper) GetCurrencyFromCB(ctx context.Context, charCode string, date
 	}
 	return vunit_rate, nil
 }
+
+// macroPeriodYears - за сколько лет запрашиваются ставки и инфляция. ИПЦ публикуется
+// с задержкой около месяца, поэтому последний известный месяц всегда попадает в период.
+const macroPeriodYears = 1
+
+// GetMacroIndicators возвращает ключевую ставку и инфляцию, последние известные на дату.
+// Если за период данных нет, соответствующий показатель остается незаполненным.
+func (h *CbrHelper) GetMacroIndicators(ctx context.Context, date time.Time) (_ domain.MacroIndicators, err error) {
+	const op = "service.GetMacroIndicators"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	from := date.AddDate(-macroPeriodYears, 0, 0)
+
+	keyRates, err := h.Cbr.GetKeyRate(ctx, from, date)
+	if err != nil {
+		return domain.MacroIndicators{}, e.WrapIfErr("filed to get key rate from cbr", err)
+	}
+	inflation, err := h.Cbr.GetInflation(ctx, from, date)
+	if err != nil {
+		return domain.MacroIndicators{}, e.WrapIfErr("filed to get inflation from cbr", err)
+	}
+
+	var res domain.MacroIndicators
+	var keyRateDate, inflationDate time.Time
+	for _, rate := range keyRates {
+		if rate.Date.After(date) || rate.Date.Before(keyRateDate) {
+			continue
+		}
+		keyRateDate = rate.Date
+		res.KeyRate = domain.NewNullFloat64(rate.Rate, true, false)
+	}
+	for _, month := range inflation {
+		if month.Date.After(date) || month.Date.Before(inflationDate) {
+			continue
+		}
+		inflationDate = month.Date
+		res.Inflation = domain.NewNullFloat64(month.Inflation, true, false)
+	}
+	return res, nil
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func getCbrHelperForTest(logger *slog.Logger, cbrHelper ports.CbrClient, storage ports.Storage) *CbrHelper {
@@ -123,3 +124,54 @@ func TestGetCurrencyFromCB(t *testing.T) {
 		mockCbr.AssertExpectations(t)
 	})
 }
+
+func TestGetMacroIndicators(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	date := time.Date(2025, 10, 19, 0, 0, 0, 0, time.UTC)
+	from := date.AddDate(-1, 0, 0)
+
+	t.Run("Success: latest values up to date", func(t *testing.T) {
+		mockCbr := mocks.NewCbrClient(t)
+		srvs := getCbrHelperForTest(logger, mockCbr, mocks.NewStorage(t))
+
+		mockCbr.On("GetKeyRate", ctx, from, date).Return([]domain.KeyRate{
+			{Date: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC), Rate: 18},
+			{Date: time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), Rate: 17},
+		}, nil)
+		mockCbr.On("GetInflation", ctx, from, date).Return([]domain.Inflation{
+			{Date: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Inflation: 8.14},
+			{Date: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Inflation: 8.79},
+		}, nil)
+
+		got, err := srvs.GetMacroIndicators(ctx, date)
+		require.NoError(t, err)
+		require.Equal(t, domain.NewNullFloat64(17, true, false), got.KeyRate)
+		require.Equal(t, domain.NewNullFloat64(8.14, true, false), got.Inflation)
+	})
+
+	t.Run("Success: no inflation in period", func(t *testing.T) {
+		mockCbr := mocks.NewCbrClient(t)
+		srvs := getCbrHelperForTest(logger, mockCbr, mocks.NewStorage(t))
+
+		mockCbr.On("GetKeyRate", ctx, from, date).Return([]domain.KeyRate{
+			{Date: time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), Rate: 17},
+		}, nil)
+		mockCbr.On("GetInflation", ctx, from, date).Return([]domain.Inflation{}, nil)
+
+		got, err := srvs.GetMacroIndicators(ctx, date)
+		require.NoError(t, err)
+		require.True(t, got.KeyRate.IsHasValue())
+		require.False(t, got.Inflation.IsHasValue())
+	})
+
+	t.Run("Err: CBR returns error", func(t *testing.T) {
+		mockCbr := mocks.NewCbrClient(t)
+		srvs := getCbrHelperForTest(logger, mockCbr, mocks.NewStorage(t))
+
+		mockCbr.On("GetKeyRate", ctx, from, date).Return(nil, errors.New("CB error"))
+
+		_, err := srvs.GetMacroIndicators(ctx, date)
+		require.ErrorContains(t, err, "CB error")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"bonds-report-service/internal/utils/logging"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -14,7 +15,10 @@ import (
 	"github.com/gladinov/e"
 )
 
-var ErrEmptyPosition = errors.New("positions are empty")
+var (
+	ErrEmptyPosition    = errors.New("positions are empty")
+	ErrNoInstrumentData = errors.New("no instrument data in tinkoff response")
+)
 
 const (
 	bond     = "bond"
@@ -71,6 +75,11 @@ func (d *DividerByAssetType) DivideByType(ctx context.Context, positions []domai
 			return nil, ErrEmptyPosition
 		}
 
+		refs, err := d.fetchInstruments(ctx, positions)
+		if err != nil {
+			return nil, err
+		}
+
 		ctxWorkers, cancel := context.WithCancel(ctx)
 		defer cancel()
 		errCh := make(chan error, 1)
@@ -86,7 +95,7 @@ func (d *DividerByAssetType) DivideByType(ctx context.Context, positions []domai
 			wgStage1.Add(1)
 			go func() {
 				defer wgStage1.Done()
-				d.worker(pipeline, positionCh, portfiliosCh)
+				d.worker(pipeline, refs, positionCh, portfiliosCh)
 			}()
 		}
 		go func() {
@@ -116,8 +125,73 @@ func (d *DividerByAssetType) DivideByType(ctx context.Context, positions []domai
 	}
 }
 
+// instrumentRefs хранит справочные данные по фьючерсам и валютам портфеля,
+// полученные одним пакетным запросом до запуска воркеров.
+type instrumentRefs struct {
+	futures    map[string]domain.Future
+	currencies map[string]domain.Currency
+}
+
+func (d *DividerByAssetType) fetchInstruments(ctx context.Context, positions []domain.PortfolioPosition) (_ instrumentRefs, err error) {
+	const op = "service.fetchInstruments"
+
+	defer logging.LogOperation_Debug(ctx, d.logger, op, &err)()
+
+	var futuresFigis, currenciesFigis []string
+	for _, pos := range positions {
+		switch pos.InstrumentType {
+		case futures:
+			futuresFigis = append(futuresFigis, pos.Figi)
+		case currency:
+			currenciesFigis = append(currenciesFigis, pos.Figi)
+		}
+	}
+
+	var refs instrumentRefs
+	if len(futuresFigis) != 0 {
+		refs.futures, err = d.TinkoffHelper.TinkoffGetFuturesBy(ctx, futuresFigis)
+		if err != nil {
+			return instrumentRefs{}, e.WrapIfErr("can't get futures data", err)
+		}
+	}
+	if len(currenciesFigis) != 0 {
+		refs.currencies, err = d.TinkoffHelper.TinkoffGetCurrenciesBy(ctx, currenciesFigis)
+		if err != nil {
+			return instrumentRefs{}, e.WrapIfErr("can't get currencies by figi from tinkoff", err)
+		}
+	}
+	return refs, nil
+}
+
+// future берет фьючерс из пакетного ответа. Фьючерс, которого в пакете нет, запрашивается
+// отдельно: пакет пропускает инструменты, не найденные Invest API, и один такой
+// инструмент не должен ломать весь отчет.
+func (d *DividerByAssetType) future(ctx context.Context, figi string, futuresByFigi map[string]domain.Future) (domain.Future, error) {
+	if future, ok := futuresByFigi[figi]; ok {
+		return future, nil
+	}
+	future, err := d.TinkoffHelper.TinkoffGetFutureBy(ctx, figi)
+	if err != nil {
+		return domain.Future{}, fmt.Errorf("%w: future %s: %w", ErrNoInstrumentData, figi, err)
+	}
+	return future, nil
+}
+
+// currency берет валюту из пакетного ответа, а пропущенную пакетом запрашивает отдельно.
+func (d *DividerByAssetType) currency(ctx context.Context, figi string, currenciesByFigi map[string]domain.Currency) (domain.Currency, error) {
+	if curr, ok := currenciesByFigi[figi]; ok {
+		return curr, nil
+	}
+	curr, err := d.TinkoffHelper.TinkoffGetCurrencyBy(ctx, figi)
+	if err != nil {
+		return domain.Currency{}, fmt.Errorf("%w: currency %s: %w", ErrNoInstrumentData, figi, err)
+	}
+	return curr, nil
+}
+
 func (d *DividerByAssetType) worker(
 	p *pipeline,
+	refs instrumentRefs,
 	in <-chan domain.PortfolioPosition,
 	out chan<- *domain.PortfolioByTypeAndCurrency,
 ) {
@@ -128,7 +202,7 @@ func (d *DividerByAssetType) worker(
 			return
 		}
 
-		portfolio, err := d.processAsset(p.ctx, pos, vunit)
+		portfolio, err := d.processAsset(p.ctx, pos, vunit, refs)
 		if err != nil {
 			p.sendErr(err)
 			return
@@ -170,7 +244,7 @@ func (d *DividerByAssetType) getCurrencyVunitRate(ctx context.Context, position
 	return vunit_rate, nil
 }
 
-func (d *DividerByAssetType) processAsset(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64) (_ *domain.PortfolioByTypeAndCurrency, err error) {
+func (d *DividerByAssetType) processAsset(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64, refs instrumentRefs) (_ *domain.PortfolioByTypeAndCurrency, err error) {
 	var portfolioWithOnePos *domain.PortfolioByTypeAndCurrency
 	switch pos.InstrumentType {
 	case bond:
@@ -178,14 +252,14 @@ func (d *DividerByAssetType) processAsset(ctx context.Context, pos domain.Portfo
 	case share:
 		portfolioWithOnePos = d.processShare(pos, vunit_rate)
 	case futures:
-		portfolioWithOnePos, err = d.processFutures(ctx, pos, vunit_rate)
+		portfolioWithOnePos, err = d.processFutures(ctx, pos, vunit_rate, refs.futures)
 		if err != nil {
 			return nil, e.WrapIfErr("failed to process futures", err)
 		}
 	case etf:
 		portfolioWithOnePos = d.processEtf(pos, vunit_rate)
 	case currency:
-		portfolioWithOnePos, err = d.processCurrency(ctx, pos, vunit_rate)
+		portfolioWithOnePos, err = d.processCurrency(ctx, pos, vunit_rate, refs.currencies)
 		if err != nil {
 			return nil, e.WrapIfErr("failed to process currency", err)
 		}
@@ -219,12 +293,12 @@ func (d *DividerByAssetType) processShare(pos domain.PortfolioPosition, vunit_ra
 	return portfolioWithOnePos
 }
 
-func (d *DividerByAssetType) processFutures(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64) (*domain.PortfolioByTypeAndCurrency, error) {
+func (d *DividerByAssetType) processFutures(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64, futuresByFigi map[string]domain.Future) (*domain.PortfolioByTypeAndCurrency, error) {
 	portfolioWithOnePos := domain.NewPortfolioByTypeAndCurrency()
 	positionPrice := pos.Quantity.ToFloat() * pos.CurrentPrice.ToFloat() * vunit_rate
-	futures, err := d.TinkoffHelper.TinkoffGetFutureBy(ctx, pos.Figi)
+	futures, err := d.future(ctx, pos.Figi, futuresByFigi)
 	if err != nil {
-		return nil, e.WrapIfErr("can't get future data", err)
+		return nil, err
 	}
 
 	positionPrice = positionPrice / futures.MinPriceIncrement.ToFloat() * futures.MinPriceIncrementAmount.ToFloat()
@@ -276,12 +350,12 @@ func (d *DividerByAssetType) processEtf(pos domain.PortfolioPosition, vunit_rate
 	return portfolioWithOnePos
 }
 
-func (d *DividerByAssetType) processCurrency(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64) (*domain.PortfolioByTypeAndCurrency, error) {
+func (d *DividerByAssetType) processCurrency(ctx context.Context, pos domain.PortfolioPosition, vunit_rate float64, currenciesByFigi map[string]domain.Currency) (*domain.PortfolioByTypeAndCurrency, error) {
 	portfolioWithOnePos := domain.NewPortfolioByTypeAndCurrency()
 	positionPrice := pos.Quantity.ToFloat() * pos.CurrentPrice.ToFloat() * vunit_rate
-	curr, err := d.TinkoffHelper.TinkoffGetCurrencyBy(ctx, pos.Figi)
+	curr, err := d.currency(ctx, pos.Figi, currenciesByFigi)
 	if err != nil {
-		return nil, e.WrapIfErr("can't get currency by figi from tinkoff", err)
+		return nil, err
 	}
 	currName := curr.Isin
 	portfolioWithOnePos.CurrenciesAssets.SumOfAssets += positionPrice
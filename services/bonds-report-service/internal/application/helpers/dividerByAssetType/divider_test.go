@@ -0,0 +1,69 @@
+//go:build unit
+
+package dividerbyassettype
+
+import (
+	tinkoffHelper "bonds-report-service/internal/application/helpers/tinkoff"
+	"bonds-report-service/internal/application/ports/mocks"
+	"bonds-report-service/internal/domain"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessCurrency_FallsBackToSingleRequest(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	pos := domain.PortfolioPosition{
+		Figi:           "FIGI_CNY",
+		InstrumentType: currency,
+		Quantity:       domain.Quotation{Units: 100},
+		CurrentPrice:   domain.NewMoneyValue(rub, 11, 0),
+	}
+
+	t.Run("missing in batch", func(t *testing.T) {
+		instruments := mocks.NewTinkoffInstrumentsClient(t)
+		instruments.On("GetCurrencyBy", ctx, "FIGI_CNY").Return(domain.Currency{Isin: "cny"}, nil)
+		d := NewDividerByAssetType(logger, tinkoffHelper.NewTinkoffHelper(logger, instruments, nil, nil), nil, 1)
+
+		got, err := d.processCurrency(ctx, pos, 1, map[string]domain.Currency{})
+		require.NoError(t, err)
+		require.InDelta(t, 1100.0, got.CurrenciesAssets.AssetsByCurrency["cny"].SumOfAssets, 1e-9)
+	})
+
+	t.Run("single request fails", func(t *testing.T) {
+		instruments := mocks.NewTinkoffInstrumentsClient(t)
+		instruments.On("GetCurrencyBy", ctx, "FIGI_CNY").Return(domain.Currency{}, errors.New("not found"))
+		d := NewDividerByAssetType(logger, tinkoffHelper.NewTinkoffHelper(logger, instruments, nil, nil), nil, 1)
+
+		_, err := d.processCurrency(ctx, pos, 1, nil)
+		require.ErrorIs(t, err, ErrNoInstrumentData)
+	})
+}
+
+func TestProcessFutures_UsesBatchResponse(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	instruments := mocks.NewTinkoffInstrumentsClient(t)
+	d := NewDividerByAssetType(logger, tinkoffHelper.NewTinkoffHelper(logger, instruments, nil, nil), nil, 1)
+	pos := domain.PortfolioPosition{
+		Figi:           "FIGI_BR",
+		InstrumentType: futures,
+		Quantity:       domain.Quotation{Units: 2},
+		CurrentPrice:   domain.NewMoneyValue(futuresPt, 80, 0),
+	}
+	future := domain.Future{
+		Name:                    "BR",
+		AssetType:               commodityType,
+		MinPriceIncrement:       domain.Quotation{Nano: 10000000},
+		MinPriceIncrementAmount: domain.Quotation{Units: 7},
+	}
+
+	got, err := d.processFutures(ctx, pos, 1, map[string]domain.Future{"FIGI_BR": future})
+	require.NoError(t, err)
+	require.InDelta(t, 2*80/0.01*7, got.FuturesAssets.AssetsByType.Commodity.AssetsByCurrency["BR"].SumOfAssets, 1e-6)
+}
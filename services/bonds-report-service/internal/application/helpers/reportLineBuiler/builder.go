@@ -6,6 +6,8 @@ import (
 	"bonds-report-service/internal/domain"
 	"bonds-report-service/internal/utils/logging"
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"sync"
 	"time"
@@ -13,6 +15,8 @@ import (
 	"github.com/gladinov/e"
 )
 
+var ErrNoInstrumentData = errors.New("no instrument data in tinkoff response")
+
 type ReportLineBuilder struct {
 	logger            *slog.Logger
 	TinkoffHelper     *tinkoffHelper.TinkoffHelper
@@ -25,32 +29,44 @@ func NewReportLineBuilder(logger *slog.Logger, tinkoffHelper *tinkoffHelper.Tink
 		logger:            logger,
 		TinkoffHelper:     tinkoffHelper,
 		CbrCurrencyGetter: cbrCurrencyGetter,
+		now:               time.Now,
 	}
 }
 
-// TODO: нужно добавить решение через worker pool + limiter для ограничения запросов в АПИ
-func (b *ReportLineBuilder) CreateNewReportLines(
+// CreateNewReportLinesBatch строит строки отчета сразу по всем позициям:
+// цены и данные облигаций запрашиваются в tinkoffApi пачкой, а не по запросу на позицию.
+// Результат индексирован по InstrumentUid позиции.
+func (b *ReportLineBuilder) CreateNewReportLinesBatch(
 	ctx context.Context,
-	position domain.PortfolioPositionsWithAssetUid,
-	operationsDb []domain.OperationWithoutCustomTypes,
-) (_ *domain.ReportLine, err error) {
-	const op = "service.CreateNewReportLines"
+	positions []domain.PortfolioPositionsWithAssetUid,
+	operationsByAssetUid map[string][]domain.OperationWithoutCustomTypes,
+) (_ map[string]*domain.ReportLine, err error) {
+	const op = "service.CreateNewReportLinesBatch"
 	defer logging.LogOperation_Debug(ctx, b.logger, op, &err)()
 
+	reportLines := make(map[string]*domain.ReportLine, len(positions))
+	if len(positions) == 0 {
+		return reportLines, nil
+	}
+
+	instrumentUids := make([]string, 0, len(positions))
+	for _, position := range positions {
+		instrumentUids = append(instrumentUids, position.InstrumentUid)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	var wg sync.WaitGroup
 	errCh := make(chan error, 1)
 
-	var lastPrice domain.LastPrice
-	var bondActions domain.BondIdentIdentifiers
-	var vunitRate domain.Rate
+	var lastPrices map[string]domain.LastPrice
+	var bondsActions map[string]domain.BondIdentIdentifiers
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		price, e := b.TinkoffHelper.TinkoffGetLastPriceInPersentageToNominal(ctx, position.InstrumentUid)
+		prices, e := b.TinkoffHelper.TinkoffGetLastPricesInPersentageToNominal(ctx, instrumentUids)
 		if e != nil {
 			select {
 			case errCh <- e:
@@ -60,13 +76,13 @@ func (b *ReportLineBuilder) CreateNewReportLines(
 			return
 		}
 
-		lastPrice = price
+		lastPrices = prices
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		actions, e := b.TinkoffHelper.TinkoffGetBondActions(ctx, position.InstrumentUid)
+		actions, e := b.TinkoffHelper.TinkoffGetBondsActionsBatch(ctx, instrumentUids)
 		if e != nil {
 			select {
 			case errCh <- e:
@@ -76,7 +92,7 @@ func (b *ReportLineBuilder) CreateNewReportLines(
 			return
 		}
 
-		bondActions = actions
+		bondsActions = actions
 	}()
 
 	wg.Wait()
@@ -87,19 +103,55 @@ func (b *ReportLineBuilder) CreateNewReportLines(
 	default:
 	}
 
-	vunitRate, err = b.buildVunitRate(ctx, bondActions)
-	if err != nil {
-		return nil, err
+	for _, position := range positions {
+		lastPrice, bondActions, err := b.instrumentData(ctx, position.InstrumentUid, lastPrices, bondsActions)
+		if err != nil {
+			return nil, err
+		}
+
+		vunitRate, err := b.buildVunitRate(ctx, bondActions)
+		if err != nil {
+			return nil, err
+		}
+
+		reportLine := domain.NewReportLine(
+			operationsByAssetUid[position.AssetUid],
+			bondActions,
+			lastPrice,
+			vunitRate,
+		)
+		reportLines[position.InstrumentUid] = &reportLine
 	}
 
-	reportLine := domain.NewReportLine(
-		operationsDb,
-		bondActions,
-		lastPrice,
-		vunitRate,
-	)
+	return reportLines, nil
+}
 
-	return &reportLine, nil
+// instrumentData берет цену и данные облигации из пакетного ответа. Инструмент, которого
+// в пакете нет, запрашивается отдельно: пакет пропускает бумаги, которые Invest API
+// не нашел, и одна такая бумага не должна ломать весь отчет.
+func (b *ReportLineBuilder) instrumentData(
+	ctx context.Context,
+	instrumentUid string,
+	lastPrices map[string]domain.LastPrice,
+	bondsActions map[string]domain.BondIdentIdentifiers,
+) (domain.LastPrice, domain.BondIdentIdentifiers, error) {
+	lastPrice, ok := lastPrices[instrumentUid]
+	if !ok {
+		price, err := b.TinkoffHelper.TinkoffGetLastPriceInPersentageToNominal(ctx, instrumentUid)
+		if err != nil {
+			return domain.LastPrice{}, domain.BondIdentIdentifiers{}, fmt.Errorf("%w: last price for instrument %s: %w", ErrNoInstrumentData, instrumentUid, err)
+		}
+		lastPrice = price
+	}
+	bondActions, ok := bondsActions[instrumentUid]
+	if !ok {
+		actions, err := b.TinkoffHelper.TinkoffGetBondActions(ctx, instrumentUid)
+		if err != nil {
+			return domain.LastPrice{}, domain.BondIdentIdentifiers{}, fmt.Errorf("%w: bond actions for instrument %s: %w", ErrNoInstrumentData, instrumentUid, err)
+		}
+		bondActions = actions
+	}
+	return lastPrice, bondActions, nil
 }
 
 func (b *ReportLineBuilder) buildVunitRate(ctx context.Context, bondActions domain.BondIdentIdentifiers) (domain.Rate, error) {
@@ -0,0 +1,74 @@
+//go:build unit
+
+package reportlinebuiler
+
+import (
+	tinkoffHelper "bonds-report-service/internal/application/helpers/tinkoff"
+	"bonds-report-service/internal/application/ports/mocks"
+	"bonds-report-service/internal/domain"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	factories "bonds-report-service/internal/application/testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNewReportLinesBatch(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	positions := []domain.PortfolioPositionsWithAssetUid{
+		{InstrumentUid: "uid-1", AssetUid: "asset-1"},
+		{InstrumentUid: "uid-2", AssetUid: "asset-2"},
+	}
+	uids := []string{"uid-1", "uid-2"}
+	operations := map[string][]domain.OperationWithoutCustomTypes{
+		"asset-1": {{AssetUid: "asset-1"}},
+	}
+
+	t.Run("missing instrument is requested separately", func(t *testing.T) {
+		analytics := mocks.NewTinkoffAnalyticsClient(t)
+		analytics.On("GetLastPricesInPersentageToNominal", mock.Anything, uids).
+			Return(map[string]domain.LastPrice{"uid-1": factories.NewLastPrice()}, nil).Once()
+		analytics.On("GetBondsActionsBatch", mock.Anything, uids).
+			Return(map[string]domain.BondIdentIdentifiers{
+				"uid-1": factories.NewBondIdentIdentifiers(),
+				"uid-2": factories.NewBondIdentIdentifiers(),
+			}, nil).Once()
+		analytics.On("GetLastPriceInPersentageToNominal", mock.Anything, "uid-2").
+			Return(factories.NewLastPrice(), nil).Once()
+
+		builder := NewReportLineBuilder(logger, tinkoffHelper.NewTinkoffHelper(logger, nil, nil, analytics), nil)
+
+		got, err := builder.CreateNewReportLinesBatch(ctx, positions, operations)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, operations["asset-1"], got["uid-1"].Operation)
+		assert.Equal(t, factories.NewLastPrice(), got["uid-2"].LastPrice)
+	})
+
+	t.Run("fallback fails", func(t *testing.T) {
+		analytics := mocks.NewTinkoffAnalyticsClient(t)
+		analytics.On("GetLastPricesInPersentageToNominal", mock.Anything, uids).
+			Return(map[string]domain.LastPrice{
+				"uid-1": factories.NewLastPrice(),
+				"uid-2": factories.NewLastPrice(),
+			}, nil).Once()
+		analytics.On("GetBondsActionsBatch", mock.Anything, uids).
+			Return(map[string]domain.BondIdentIdentifiers{"uid-1": factories.NewBondIdentIdentifiers()}, nil).Once()
+		analytics.On("GetBondsActions", mock.Anything, "uid-2").
+			Return(domain.BondIdentIdentifiers{}, errors.New("not found")).Once()
+
+		builder := NewReportLineBuilder(logger, tinkoffHelper.NewTinkoffHelper(logger, nil, nil, analytics), nil)
+
+		_, err := builder.CreateNewReportLinesBatch(ctx, positions, operations)
+
+		require.ErrorIs(t, err, ErrNoInstrumentData)
+	})
+}
@@ -2,8 +2,10 @@ package tinkoffHelper
 
 import (
 	"bonds-report-service/internal/domain"
+	httperrors "bonds-report-service/internal/infrastructure/http"
 	"bonds-report-service/internal/utils/logging"
 	"context"
+	"time"
 
 	"github.com/gladinov/e"
 )
@@ -36,6 +38,60 @@ func (h *TinkoffHelper) TinkoffGetPortfolio(ctx context.Context, account domain.
 	return portfolio, nil
 }
 
+func (h *TinkoffHelper) TinkoffGetPositions(ctx context.Context, accountID string) (_ domain.Positions, err error) {
+	const op = "service.TinkoffGetPositions"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if accountID == "" {
+		return domain.Positions{}, domain.ErrEmptyAccountIdInRequest
+	}
+	positions, err := h.Portfolio.GetPositions(ctx, accountID)
+	if err != nil {
+		return domain.Positions{}, e.WrapIfErr("failed to get positions", err)
+	}
+	return positions, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetWithdrawLimits(ctx context.Context, accountID string) (_ domain.WithdrawLimits, err error) {
+	const op = "service.TinkoffGetWithdrawLimits"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if accountID == "" {
+		return domain.WithdrawLimits{}, domain.ErrEmptyAccountIdInRequest
+	}
+	limits, err := h.Portfolio.GetWithdrawLimits(ctx, accountID)
+	if err != nil {
+		return domain.WithdrawLimits{}, e.WrapIfErr("failed to get withdraw limits", err)
+	}
+	return limits, nil
+}
+
+// tinkoffCodeMarginDisabled - Invest API отвечает этим кодом на запрос маржинальных
+// показателей счета, для которого маржинальная торговля не подключена.
+const tinkoffCodeMarginDisabled = "30051"
+
+// TinkoffGetMarginAttributes возвращает nil, если маржинальная торговля на счете не подключена.
+// Остальные ошибки, в том числе другие ошибки запроса, возвращаются как есть.
+func (h *TinkoffHelper) TinkoffGetMarginAttributes(ctx context.Context, accountID string) (_ *domain.MarginAttributes, err error) {
+	const op = "service.TinkoffGetMarginAttributes"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if accountID == "" {
+		return nil, domain.ErrEmptyAccountIdInRequest
+	}
+	margin, err := h.Portfolio.GetMarginAttributes(ctx, accountID)
+	if err != nil {
+		if httperrors.TinkoffCode(err) == tinkoffCodeMarginDisabled {
+			return nil, nil
+		}
+		return nil, e.WrapIfErr("failed to get margin attributes", err)
+	}
+	return &margin, nil
+}
+
 func (h *TinkoffHelper) TinkoffGetOperations(ctx context.Context, opRequest domain.OperationsRequest) (_ []domain.Operation, err error) {
 	const op = "service.TinkoffGetOperations"
 
@@ -52,6 +108,22 @@ func (h *TinkoffHelper) TinkoffGetOperations(ctx context.Context, opRequest doma
 	return tinkoffOperations, nil
 }
 
+func (h *TinkoffHelper) TinkoffGetOperationsPage(ctx context.Context, req domain.OperationsPageRequest) (_ domain.OperationsPage, err error) {
+	const op = "service.TinkoffGetOperationsPage"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if err := domain.NewOperationsRequest(req.AccountID, req.From).Validate(h.now()); err != nil {
+		return domain.OperationsPage{}, e.WrapIfErr("failed to validate", err)
+	}
+
+	page, err := h.Portfolio.GetOperationsPage(ctx, req)
+	if err != nil {
+		return domain.OperationsPage{}, e.WrapIfErr("failed get operations page from tinkoff", err)
+	}
+	return page, nil
+}
+
 func (h *TinkoffHelper) TinkoffGetBondActions(ctx context.Context, instrumentUid string) (_ domain.BondIdentIdentifiers, err error) {
 	const op = "service.TinkoffGetBondActions"
 
@@ -82,6 +154,36 @@ func (h *TinkoffHelper) TinkoffGetFutureBy(ctx context.Context, figi string) (_
 	return future, nil
 }
 
+func (h *TinkoffHelper) TinkoffGetDividends(ctx context.Context, figi string, from, to time.Time) (_ []domain.Dividend, err error) {
+	const op = "service.TinkoffGetDividends"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if figi == "" {
+		return nil, domain.ErrEmptyFigi
+	}
+	dividends, err := h.Instruments.GetDividends(ctx, figi, from, to)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get dividends from tinkoff", err)
+	}
+	return dividends, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetAssetReports(ctx context.Context, instrumentUid string, from, to time.Time) (_ []domain.AssetReport, err error) {
+	const op = "service.TinkoffGetAssetReports"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if instrumentUid == "" {
+		return nil, domain.ErrEmptyUid
+	}
+	reports, err := h.Instruments.GetAssetReports(ctx, instrumentUid, from, to)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get asset reports from tinkoff", err)
+	}
+	return reports, nil
+}
+
 func (h *TinkoffHelper) TinkoffGetBondByUid(ctx context.Context, uid string) (_ domain.Bond, err error) {
 	const op = "service.TinkoffGetBondByUid"
 
@@ -97,6 +199,23 @@ func (h *TinkoffHelper) TinkoffGetBondByUid(ctx context.Context, uid string) (_
 	return bond, nil
 }
 
+// TinkoffGetBondsByUids возвращает облигации по instrument uid одним запросом.
+// Не найденные в Т-Инвестициях облигации в ответ не попадают.
+func (h *TinkoffHelper) TinkoffGetBondsByUids(ctx context.Context, uids []string) (_ map[string]domain.Bond, err error) {
+	const op = "service.TinkoffGetBondsByUids"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if len(uids) == 0 {
+		return nil, domain.ErrEmptyUids
+	}
+	bonds, err := fetchBatched(ctx, uids, h.Instruments.GetBondsByUids)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get bonds by uids from tinkoff", err)
+	}
+	return bonds, nil
+}
+
 func (h *TinkoffHelper) TinkoffGetCurrencyBy(ctx context.Context, figi string) (_ domain.Currency, err error) {
 	const op = "service.TinkoffGetCurrencyBy"
 
@@ -168,3 +287,96 @@ func (h *TinkoffHelper) TinkoffGetLastPriceInPersentageToNominal(ctx context.Con
 	}
 	return lastPrice, nil
 }
+
+// batchSize совпадает с лимитом пакетных ручек tinkoffApi: большие портфели
+// запрашиваются несколькими пакетами.
+const batchSize = 300
+
+func validateIds(ids []string, errEmptyId error) error {
+	for _, id := range ids {
+		if id == "" {
+			return errEmptyId
+		}
+	}
+	return nil
+}
+
+// fetchBatched разбивает идентификаторы на пачки не больше batchSize и объединяет ответы.
+func fetchBatched[V any](
+	ctx context.Context,
+	ids []string,
+	fetch func(ctx context.Context, ids []string) (map[string]V, error),
+) (map[string]V, error) {
+	res := make(map[string]V, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		part, err := fetch(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for id, v := range part {
+			res[id] = v
+		}
+	}
+	return res, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetLastPricesInPersentageToNominal(ctx context.Context, instrumentUids []string) (_ map[string]domain.LastPrice, err error) {
+	const op = "service.TinkoffGetLastPricesInPersentageToNominal"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if err := validateIds(instrumentUids, domain.ErrEmptyInstrumentUid); err != nil {
+		return nil, err
+	}
+	lastPrices, err := fetchBatched(ctx, instrumentUids, h.Analytics.GetLastPricesInPersentageToNominal)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get last prices in persentage to nominal from tinkoff", err)
+	}
+	return lastPrices, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetBondsActionsBatch(ctx context.Context, instrumentUids []string) (_ map[string]domain.BondIdentIdentifiers, err error) {
+	const op = "service.TinkoffGetBondsActionsBatch"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if err := validateIds(instrumentUids, domain.ErrEmptyInstrumentUid); err != nil {
+		return nil, err
+	}
+	bondsActions, err := fetchBatched(ctx, instrumentUids, h.Analytics.GetBondsActionsBatch)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get bonds actions from tinkoff", err)
+	}
+	return bondsActions, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetFuturesBy(ctx context.Context, figis []string) (_ map[string]domain.Future, err error) {
+	const op = "service.TinkoffGetFuturesBy"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if err := validateIds(figis, domain.ErrEmptyFigi); err != nil {
+		return nil, err
+	}
+	futures, err := fetchBatched(ctx, figis, h.Instruments.GetFuturesBy)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get futures by from tinkoff", err)
+	}
+	return futures, nil
+}
+
+func (h *TinkoffHelper) TinkoffGetCurrenciesBy(ctx context.Context, figis []string) (_ map[string]domain.Currency, err error) {
+	const op = "service.TinkoffGetCurrenciesBy"
+
+	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
+
+	if err := validateIds(figis, domain.ErrEmptyFigi); err != nil {
+		return nil, err
+	}
+	currencies, err := fetchBatched(ctx, figis, h.Instruments.GetCurrenciesBy)
+	if err != nil {
+		return nil, e.WrapIfErr("failed get currencies by from tinkoff", err)
+	}
+	return currencies, nil
+}
@@ -7,12 +7,15 @@ import (
 	"bonds-report-service/internal/domain"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"testing"
 	"time"
 
 	factories "bonds-report-service/internal/application/testing"
+	httperrors "bonds-report-service/internal/infrastructure/http"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,6 +308,56 @@ func TestTinkoffGetBondByUid(t *testing.T) {
 	})
 }
 
+func TestTinkoffGetBondsByUids(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	uids := []string{"bond-uid-1", "bond-uid-2"}
+
+	t.Run("Success", func(t *testing.T) {
+		want := map[string]domain.Bond{"bond-uid-1": factories.NewBond()}
+
+		mockInstruments := mocks.NewTinkoffInstrumentsClient(t)
+		mockInstruments.
+			On("GetBondsByUids", ctx, uids).
+			Return(want, nil)
+
+		srv := &TinkoffHelper{
+			logger:      logger,
+			Instruments: mockInstruments,
+		}
+		got, err := srv.TinkoffGetBondsByUids(ctx, uids)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Err: empty uids", func(t *testing.T) {
+		srv := &TinkoffHelper{
+			logger: logger,
+		}
+
+		_, err := srv.TinkoffGetBondsByUids(ctx, nil)
+
+		assert.ErrorIs(t, err, domain.ErrEmptyUids)
+	})
+
+	t.Run("Err: instruments client fails", func(t *testing.T) {
+		mockInstruments := mocks.NewTinkoffInstrumentsClient(t)
+		mockInstruments.
+			On("GetBondsByUids", ctx, uids).
+			Return(nil, errors.New("client error"))
+
+		srv := &TinkoffHelper{
+			logger:      logger,
+			Instruments: mockInstruments,
+		}
+
+		_, err := srv.TinkoffGetBondsByUids(ctx, uids)
+
+		assert.ErrorContains(t, err, "failed get bonds by uids from tinkoff")
+	})
+}
+
 func TestTinkoffGetCurrencyBy(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	ctx := context.Background()
@@ -622,3 +675,171 @@ func TestService_TinkoffGetLastPriceInPersentageToNominal(t *testing.T) {
 		mockAnalytics.AssertExpectations(t)
 	})
 }
+
+func TestService_TinkoffGetLastPricesInPersentageToNominal(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("success", func(t *testing.T) {
+		instrumentUids := []string{"uid-1", "uid-2"}
+		want := map[string]domain.LastPrice{
+			"uid-1": factories.NewLastPrice(),
+			"uid-2": factories.NewLastPrice(),
+		}
+
+		mockAnalytics := mocks.NewTinkoffAnalyticsClient(t)
+		mockAnalytics.
+			On("GetLastPricesInPersentageToNominal", ctx, instrumentUids).
+			Return(want, nil).
+			Once()
+
+		srv := &TinkoffHelper{
+			logger:    logger,
+			Analytics: mockAnalytics,
+			now:       time.Now,
+		}
+
+		got, err := srv.TinkoffGetLastPricesInPersentageToNominal(ctx, instrumentUids)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("splits into batches", func(t *testing.T) {
+		instrumentUids := make([]string, batchSize+1)
+		for i := range instrumentUids {
+			instrumentUids[i] = fmt.Sprintf("uid-%d", i)
+		}
+
+		mockAnalytics := mocks.NewTinkoffAnalyticsClient(t)
+		mockAnalytics.
+			On("GetLastPricesInPersentageToNominal", ctx, instrumentUids[:batchSize]).
+			Return(map[string]domain.LastPrice{"uid-0": factories.NewLastPrice()}, nil).
+			Once()
+		mockAnalytics.
+			On("GetLastPricesInPersentageToNominal", ctx, instrumentUids[batchSize:]).
+			Return(map[string]domain.LastPrice{instrumentUids[batchSize]: factories.NewLastPrice()}, nil).
+			Once()
+
+		srv := &TinkoffHelper{
+			logger:    logger,
+			Analytics: mockAnalytics,
+			now:       time.Now,
+		}
+
+		got, err := srv.TinkoffGetLastPricesInPersentageToNominal(ctx, instrumentUids)
+
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("empty instrumentUid in batch", func(t *testing.T) {
+		srv := &TinkoffHelper{
+			logger: logger,
+			now:    time.Now,
+		}
+
+		_, err := srv.TinkoffGetLastPricesInPersentageToNominal(ctx, []string{"uid-1", ""})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrEmptyInstrumentUid)
+	})
+
+	t.Run("analytics returns error", func(t *testing.T) {
+		instrumentUids := []string{"uid-1"}
+
+		mockAnalytics := mocks.NewTinkoffAnalyticsClient(t)
+		mockAnalytics.
+			On("GetLastPricesInPersentageToNominal", ctx, instrumentUids).
+			Return(nil, errors.New("client error"))
+
+		srv := &TinkoffHelper{
+			logger:    logger,
+			Analytics: mockAnalytics,
+			now:       time.Now,
+		}
+
+		got, err := srv.TinkoffGetLastPricesInPersentageToNominal(ctx, instrumentUids)
+
+		require.Error(t, err)
+		assert.Nil(t, got)
+		assert.Contains(t, err.Error(), "failed get last prices in persentage to nominal from tinkoff")
+	})
+}
+
+func TestService_TinkoffGetFuturesBy(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("success", func(t *testing.T) {
+		figis := []string{"FIGI1"}
+		want := map[string]domain.Future{"FIGI1": {Name: "Si-12.25"}}
+
+		mockInstruments := mocks.NewTinkoffInstrumentsClient(t)
+		mockInstruments.
+			On("GetFuturesBy", ctx, figis).
+			Return(want, nil)
+
+		srv := &TinkoffHelper{
+			logger:      logger,
+			Instruments: mockInstruments,
+			now:         time.Now,
+		}
+
+		got, err := srv.TinkoffGetFuturesBy(ctx, figis)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("empty figi in batch", func(t *testing.T) {
+		srv := &TinkoffHelper{
+			logger: logger,
+			now:    time.Now,
+		}
+
+		_, err := srv.TinkoffGetFuturesBy(ctx, []string{""})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrEmptyFigi)
+	})
+}
+
+func TestTinkoffGetMarginAttributes(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	const accountID = "acc-1"
+
+	newHelper := func(t *testing.T, margin domain.MarginAttributes, err error) *TinkoffHelper {
+		mockPortfolioClient := mocks.NewTinkoffPortfolioClient(t)
+		mockPortfolioClient.On("GetMarginAttributes", ctx, accountID).Return(margin, err).Once()
+		return &TinkoffHelper{logger: logger, Portfolio: mockPortfolioClient}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		want := domain.MarginAttributes{}
+		got, err := newHelper(t, want, nil).TinkoffGetMarginAttributes(ctx, accountID)
+		require.NoError(t, err)
+		require.Equal(t, &want, got)
+	})
+
+	t.Run("Margin disabled", func(t *testing.T) {
+		apiErr := httperrors.MapHTTPError(http.StatusBadRequest,
+			[]byte(`{"code":"invalid_argument","message":"account margin status is disabled","tinkoffCode":"30051"}`))
+		got, err := newHelper(t, domain.MarginAttributes{}, apiErr).TinkoffGetMarginAttributes(ctx, accountID)
+		require.NoError(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("Err: other bad request", func(t *testing.T) {
+		apiErr := httperrors.MapHTTPError(http.StatusBadRequest,
+			[]byte(`{"code":"invalid_argument","message":"account not found","tinkoffCode":"50004"}`))
+		_, err := newHelper(t, domain.MarginAttributes{}, apiErr).TinkoffGetMarginAttributes(ctx, accountID)
+		require.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("Err: not found", func(t *testing.T) {
+		_, err := newHelper(t, domain.MarginAttributes{}, httperrors.ErrNotFound).TinkoffGetMarginAttributes(ctx, accountID)
+		require.ErrorIs(t, err, httperrors.ErrNotFound)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"bonds-report-service/internal/utils/logging"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -18,6 +19,7 @@ type Updater struct {
 	logger        *slog.Logger
 	Storage       ports.Storage
 	TinkoffHelper *tinkoffHelper.TinkoffHelper
+	now           func() time.Time
 }
 
 func NewUpdater(logger *slog.Logger, storage ports.Storage, tinkoffHelper *tinkoffHelper.TinkoffHelper) *Updater {
@@ -25,9 +27,13 @@ func NewUpdater(logger *slog.Logger, storage ports.Storage, tinkoffHelper *tinko
 		logger:        logger,
 		Storage:       storage,
 		TinkoffHelper: tinkoffHelper,
+		now:           time.Now,
 	}
 }
 
+// UpdateOperations догружает операции по счету постранично.
+// Каждая страница сохраняется вместе с курсором, поэтому прерванная выгрузка
+// продолжается со следующей страницы, а не начинается заново.
 func (h *Updater) UpdateOperations(ctx context.Context,
 	chatID int,
 	accountID string,
@@ -37,32 +43,73 @@ func (h *Updater) UpdateOperations(ctx context.Context,
 
 	defer logging.LogOperation_Debug(ctx, h.logger, op, &err)()
 
-	fromDate, err := h.Storage.LastOperationTime(ctx, chatID, accountID)
-	// TODO: Если fromDate будет больше time.Now, то будет ошибка.
-	// Есть вероятность такой ошибки, поэтому при тестировании функции нужно придумать другой способ вызова функции по последней операции
-	fromDate = fromDate.Add(time.Microsecond * 1)
-
+	state, err := h.syncState(ctx, chatID, accountID, openDate)
 	if err != nil {
-		if errors.Is(err, domain.ErrNoOpperations) {
-			fromDate = openDate
-		} else {
-			return e.WrapIfErr("can't get last op from storage", err)
-		}
+		return err
+	}
+
+	req := domain.OperationsPageRequest{
+		AccountID: accountID,
+		From:      state.From,
+		To:        state.To,
+		Cursor:    state.Cursor,
 	}
+	for {
+		page, err := h.TinkoffHelper.TinkoffGetOperationsPage(ctx, req)
+		if err != nil {
+			return e.WrapIfErr("can't get operations from tinkoff", err)
+		}
+		if page.HasNext && (page.NextCursor == "" || page.NextCursor == req.Cursor) {
+			return fmt.Errorf("%s: %w: %q", op, domain.ErrOperationsCursorStuck, page.NextCursor)
+		}
 
-	opRequest := domain.NewOperationsRequest(accountID, fromDate)
+		// НЕ domain mapper
+		operations := mapper.MapOperationToOperationWithoutCustomTypes(page.Operations)
 
-	tinkoffOperations, err := h.TinkoffHelper.TinkoffGetOperations(ctx, opRequest)
-	if err != nil {
-		return e.WrapIfErr("can't get operations from tinkoff", err)
+		state.From = page.From
+		state.To = page.To
+		state.Cursor = page.NextCursor
+		state.Pages++
+		state.OperationsSaved += len(operations)
+		state.Completed = !page.HasNext
+		state.UpdatedAt = h.now()
+
+		err = h.Storage.SaveOperationsPage(ctx, chatID, accountID, operations, state)
+		if err != nil {
+			return e.WrapIfErr("can't save ops to Storage", err)
+		}
+		if state.Completed {
+			return nil
+		}
+
+		req.From = page.From
+		req.To = page.To
+		req.Cursor = page.NextCursor
 	}
+}
 
-	// НЕ domain mapper
-	operations := mapper.MapOperationToOperationWithoutCustomTypes(tinkoffOperations)
+// syncState возвращает состояние, с которого нужно продолжить выгрузку:
+// незавершенную выгрузку - как есть, после завершенной - новый период от ее конца.
+func (h *Updater) syncState(ctx context.Context, chatID int, accountID string, openDate time.Time) (domain.OperationsSyncState, error) {
+	state, err := h.Storage.GetOperationsSyncState(ctx, chatID, accountID)
+	switch {
+	case err == nil && !state.Completed:
+		return state, nil
+	case err == nil:
+		return domain.OperationsSyncState{AccountID: accountID, From: state.To}, nil
+	case !errors.Is(err, domain.ErrNoOperationsSyncState):
+		return domain.OperationsSyncState{}, e.WrapIfErr("can't get operations sync state from storage", err)
+	}
 
-	err = h.Storage.SaveOperations(ctx, chatID, accountID, operations)
+	// Операции могли быть загружены до появления постраничной выгрузки
+	fromDate, err := h.Storage.LastOperationTime(ctx, chatID, accountID)
 	if err != nil {
-		return e.WrapIfErr("can't save ops to Storage", err)
+		if !errors.Is(err, domain.ErrNoOpperations) {
+			return domain.OperationsSyncState{}, e.WrapIfErr("can't get last op from storage", err)
+		}
+		fromDate = openDate
+	} else {
+		fromDate = fromDate.Add(time.Microsecond * 1)
 	}
-	return nil
+	return domain.OperationsSyncState{AccountID: accountID, From: fromDate}, nil
 }
@@ -0,0 +1,242 @@
+//go:build unit
+
+package updateoperations
+
+import (
+	tinkoffHelper "bonds-report-service/internal/application/helpers/tinkoff"
+	"bonds-report-service/internal/application/ports"
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/infrastructure/tinkoffApi/client/portfolioclient"
+	"bonds-report-service/internal/infrastructure/tinkoffApi/dto"
+	"bonds-report-service/internal/infrastructure/tinkoffApi/transport"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	contextkeys "github.com/gladinov/contracts/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOperationsServer отдает операции страницами по pageSize, курсор - индекс следующей операции.
+type fakeOperationsServer struct {
+	mu         sync.Mutex
+	operations []dto.Operation
+	pageSize   int
+	to         time.Time
+	requests   []dto.OperationsPageRequest
+	// failOn - номер запроса (с 1), на котором сервер ответит ошибкой
+	failOn int
+	// stuckCursor - отдавать один и тот же курсор вместо следующего
+	stuckCursor bool
+}
+
+func (s *fakeOperationsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path != "/tinkoff/operations/page" {
+		http.NotFound(w, r)
+		return
+	}
+	var req dto.OperationsPageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.requests = append(s.requests, req)
+	if len(s.requests) == s.failOn {
+		http.Error(w, "invest api unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	start := 0
+	if req.Cursor != "" {
+		var err error
+		start, err = strconv.Atoi(req.Cursor)
+		if err != nil {
+			http.Error(w, "bad cursor", http.StatusBadRequest)
+			return
+		}
+	}
+	end := min(start+s.pageSize, len(s.operations))
+	to := req.To
+	if to.IsZero() {
+		to = s.to
+	}
+	page := dto.OperationsPage{
+		Operations: s.operations[start:end],
+		HasNext:    end < len(s.operations),
+		From:       req.From,
+		To:         to,
+	}
+	if page.HasNext {
+		page.NextCursor = strconv.Itoa(end)
+		if s.stuckCursor {
+			page.NextCursor = req.Cursor
+		}
+	}
+	_ = json.NewEncoder(w).Encode(page)
+}
+
+// memStorage хранит операции и прогресс выгрузки в памяти.
+type memStorage struct {
+	ports.Storage
+	operations []domain.OperationWithoutCustomTypes
+	state      *domain.OperationsSyncState
+	lastOpTime time.Time
+}
+
+func (s *memStorage) GetOperationsSyncState(ctx context.Context, chatID int, accountId string) (domain.OperationsSyncState, error) {
+	if s.state == nil {
+		return domain.OperationsSyncState{}, domain.ErrNoOperationsSyncState
+	}
+	return *s.state, nil
+}
+
+func (s *memStorage) SaveOperationsPage(ctx context.Context, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes, state domain.OperationsSyncState) error {
+	s.operations = append(s.operations, operations...)
+	s.state = &state
+	return nil
+}
+
+func (s *memStorage) LastOperationTime(ctx context.Context, chatID int, accountId string) (time.Time, error) {
+	if s.lastOpTime.IsZero() {
+		return time.Time{}, domain.ErrNoOpperations
+	}
+	return s.lastOpTime, nil
+}
+
+func newOperations(n int) []dto.Operation {
+	ops := make([]dto.Operation, 0, n)
+	for i := range n {
+		ops = append(ops, dto.Operation{
+			OperationID: fmt.Sprintf("op-%d", i),
+			Date:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return ops
+}
+
+func setupUpdater(t *testing.T, server *fakeOperationsServer, storage *memStorage) *Updater {
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tr := transport.NewTransport(logger, ts.Listener.Addr().String())
+	portfolio := portfolioclient.NewPortfolioTinkoffClient(logger, tr)
+	helper := tinkoffHelper.NewTinkoffHelper(logger, nil, portfolio, nil)
+	return NewUpdater(logger, storage, helper)
+}
+
+func TestUpdateOperations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "1")
+	openDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	syncTo := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("loads all pages without limit", func(t *testing.T) {
+		server := &fakeOperationsServer{operations: newOperations(25), pageSize: 10, to: syncTo}
+		storage := &memStorage{}
+		updater := setupUpdater(t, server, storage)
+
+		err := updater.UpdateOperations(ctx, 1, "acc", openDate)
+		require.NoError(t, err)
+
+		assert.Len(t, storage.operations, 25)
+		assert.Equal(t, "op-24", storage.operations[24].OperationID)
+		require.NotNil(t, storage.state)
+		assert.True(t, storage.state.Completed)
+		assert.Equal(t, 3, storage.state.Pages)
+		assert.Equal(t, 25, storage.state.OperationsSaved)
+		assert.Empty(t, storage.state.Cursor)
+		assert.True(t, syncTo.Equal(storage.state.To))
+
+		require.Len(t, server.requests, 3)
+		assert.True(t, openDate.Equal(server.requests[0].From))
+		assert.Empty(t, server.requests[0].Cursor)
+		assert.Equal(t, "10", server.requests[1].Cursor)
+		// Следующие страницы запрашиваются в том же периоде
+		assert.True(t, syncTo.Equal(server.requests[2].To))
+	})
+
+	t.Run("resumes from saved cursor after failure", func(t *testing.T) {
+		server := &fakeOperationsServer{operations: newOperations(25), pageSize: 10, to: syncTo, failOn: 2}
+		storage := &memStorage{}
+		updater := setupUpdater(t, server, storage)
+
+		err := updater.UpdateOperations(ctx, 1, "acc", openDate)
+		require.Error(t, err)
+		assert.Len(t, storage.operations, 10)
+		require.NotNil(t, storage.state)
+		assert.False(t, storage.state.Completed)
+		assert.Equal(t, "10", storage.state.Cursor)
+
+		err = updater.UpdateOperations(ctx, 1, "acc", openDate)
+		require.NoError(t, err)
+
+		assert.Len(t, storage.operations, 25)
+		seen := make(map[string]struct{})
+		for _, op := range storage.operations {
+			seen[op.OperationID] = struct{}{}
+		}
+		assert.Len(t, seen, 25, "operations must not be duplicated")
+		assert.True(t, storage.state.Completed)
+		assert.Equal(t, 3, storage.state.Pages)
+
+		require.Len(t, server.requests, 4)
+		assert.Equal(t, "10", server.requests[2].Cursor)
+		assert.True(t, syncTo.Equal(server.requests[2].To))
+	})
+
+	t.Run("completed sync starts new window from its end", func(t *testing.T) {
+		server := &fakeOperationsServer{pageSize: 10, to: syncTo.Add(24 * time.Hour)}
+		storage := &memStorage{state: &domain.OperationsSyncState{
+			AccountID: "acc",
+			From:      openDate,
+			To:        syncTo,
+			Pages:     3,
+			Completed: true,
+		}}
+		updater := setupUpdater(t, server, storage)
+
+		err := updater.UpdateOperations(ctx, 1, "acc", openDate)
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 1)
+		assert.True(t, syncTo.Equal(server.requests[0].From))
+		assert.Empty(t, server.requests[0].Cursor)
+		assert.Equal(t, 1, storage.state.Pages)
+		assert.True(t, storage.state.Completed)
+	})
+
+	t.Run("first sync continues after last stored operation", func(t *testing.T) {
+		lastOpTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		server := &fakeOperationsServer{pageSize: 10, to: syncTo}
+		storage := &memStorage{lastOpTime: lastOpTime}
+		updater := setupUpdater(t, server, storage)
+
+		err := updater.UpdateOperations(ctx, 1, "acc", openDate)
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 1)
+		assert.True(t, lastOpTime.Add(time.Microsecond).Equal(server.requests[0].From))
+	})
+
+	t.Run("stuck cursor", func(t *testing.T) {
+		server := &fakeOperationsServer{operations: newOperations(25), pageSize: 10, to: syncTo, stuckCursor: true}
+		storage := &memStorage{}
+		updater := setupUpdater(t, server, storage)
+
+		err := updater.UpdateOperations(ctx, 1, "acc", openDate)
+		assert.ErrorIs(t, err, domain.ErrOperationsCursorStuck)
+		assert.Empty(t, storage.operations)
+	})
+}
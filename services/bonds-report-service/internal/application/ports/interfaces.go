@@ -2,6 +2,7 @@ package ports
 
 import (
 	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
 	"bonds-report-service/internal/domain/generalbondreport"
 	report "bonds-report-service/internal/domain/report"
 	report_position "bonds-report-service/internal/domain/report_position"
@@ -24,6 +25,8 @@ type OperationStorage interface {
 	SaveOperations(ctx context.Context, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes) error
 	GetOperations(ctx context.Context, chatId int, assetUid string, accountId string) ([]domain.OperationWithoutCustomTypes, error)
 	GetAllOperations(ctx context.Context, chatId int, accountId string) ([]domain.OperationWithoutCustomTypes, error)
+	GetOperationsSyncState(ctx context.Context, chatID int, accountId string) (domain.OperationsSyncState, error)
+	SaveOperationsPage(ctx context.Context, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes, state domain.OperationsSyncState) error
 }
 
 type BondReportStorage interface {
@@ -65,7 +68,7 @@ type GeneralBondReportProcessor interface {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=BondReportProcessor
 type BondReportProcessor interface {
-	CreateBondReport(ctx context.Context, currentPositions []report_position.PositionByFIFO, moexBuyDateData domain.ValuesMoex, moexNowData domain.ValuesMoex) (_ report.Report, err error)
+	CreateBondReport(ctx context.Context, currentPositions []report_position.PositionByFIFO, moexBuyDateData domain.ValuesMoex, moexNowData domain.ValuesMoex, schedule *bondmath.Schedule) (_ report.Report, err error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ReportProcessor
@@ -82,11 +85,22 @@ type UidProvider interface {
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=CbrClient
 type CbrClient interface {
 	GetAllCurrencies(ctx context.Context, date time.Time) (res domain.CurrenciesCBR, err error)
+	GetKeyRate(ctx context.Context, from, to time.Time) (res []domain.KeyRate, err error)
+	GetInflation(ctx context.Context, from, to time.Time) (res []domain.Inflation, err error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=MoexClient
 type MoexClient interface {
 	GetSpecifications(ctx context.Context, ticker string, date time.Time) (data domain.ValuesMoex, err error)
+	GetHistory(ctx context.Context, ticker string, from, to time.Time) (data domain.MoexHistory, err error)
+	GetBonds(ctx context.Context, boards []string) (data []domain.MoexBond, err error)
+	GetSecurities(ctx context.Context, shares, etfs, currencies []string) (data domain.MoexSecurities, err error)
+	GetIssuers(ctx context.Context, secIDs []string) (data map[string]domain.MoexIssuer, err error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=RatingSource
+type RatingSource interface {
+	GetRatings(ctx context.Context) (domain.Ratings, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TinkoffInstrumentsClient
@@ -96,6 +110,11 @@ type TinkoffInstrumentsClient interface {
 	GetCurrencyBy(ctx context.Context, figi string) (domain.Currency, error)
 	GetFutureBy(ctx context.Context, figi string) (domain.Future, error)
 	GetShareCurrencyBy(ctx context.Context, figi string) (domain.ShareCurrency, error)
+	GetBondsByUids(ctx context.Context, uids []string) (map[string]domain.Bond, error)
+	GetFuturesBy(ctx context.Context, figis []string) (map[string]domain.Future, error)
+	GetCurrenciesBy(ctx context.Context, figis []string) (map[string]domain.Currency, error)
+	GetDividends(ctx context.Context, figi string, from, to time.Time) ([]domain.Dividend, error)
+	GetAssetReports(ctx context.Context, instrumentID string, from, to time.Time) ([]domain.AssetReport, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TinkoffPortfolioClient
@@ -103,6 +122,10 @@ type TinkoffPortfolioClient interface {
 	GetAccounts(ctx context.Context) (_ map[string]domain.Account, err error)
 	GetPortfolio(ctx context.Context, accountID string, accountStatus int64) (domain.Portfolio, error)
 	GetOperations(ctx context.Context, accountId string, date time.Time) (_ []domain.Operation, err error)
+	GetOperationsPage(ctx context.Context, req domain.OperationsPageRequest) (_ domain.OperationsPage, err error)
+	GetPositions(ctx context.Context, accountID string) (_ domain.Positions, err error)
+	GetWithdrawLimits(ctx context.Context, accountID string) (_ domain.WithdrawLimits, err error)
+	GetMarginAttributes(ctx context.Context, accountID string) (_ domain.MarginAttributes, err error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=TinkoffAnalyticsClient
@@ -110,11 +133,14 @@ type TinkoffAnalyticsClient interface {
 	GetLastPriceInPersentageToNominal(ctx context.Context, instrumentUid string) (domain.LastPrice, error)
 	GetAllAssetUids(ctx context.Context) (map[string]string, error)
 	GetBondsActions(ctx context.Context, instrumentUid string) (domain.BondIdentIdentifiers, error)
+	GetLastPricesInPersentageToNominal(ctx context.Context, instrumentUids []string) (map[string]domain.LastPrice, error)
+	GetBondsActionsBatch(ctx context.Context, instrumentUids []string) (map[string]domain.BondIdentIdentifiers, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=CbrCurrencyGetter
 type CbrCurrencyGetter interface {
 	GetCurrencyFromCB(ctx context.Context, charCode string, date time.Time) (vunit_rate float64, err error)
+	GetMacroIndicators(ctx context.Context, date time.Time) (res domain.MacroIndicators, err error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=MoexSpecificationGetter
@@ -134,7 +160,7 @@ type PositionProcessor interface {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ReportLineBuilder
 type ReportLineBuilder interface {
-	CreateNewReportLines(ctx context.Context, position domain.PortfolioPositionsWithAssetUid, operationsDb []domain.OperationWithoutCustomTypes) (_ *domain.ReportLine, err error)
+	CreateNewReportLinesBatch(ctx context.Context, positions []domain.PortfolioPositionsWithAssetUid, operationsByAssetUid map[string][]domain.OperationWithoutCustomTypes) (_ map[string]*domain.ReportLine, err error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=DividerByAssetType
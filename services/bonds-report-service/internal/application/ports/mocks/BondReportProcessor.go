@@ -4,6 +4,7 @@ package mocks
 
 import (
 	domain "bonds-report-service/internal/domain"
+	bondmath "bonds-report-service/internal/domain/bondmath"
 	domainreport "bonds-report-service/internal/domain/report"
 	context "context"
 
@@ -17,9 +18,9 @@ type BondReportProcessor struct {
 	mock.Mock
 }
 
-// CreateBondReport provides a mock function with given fields: ctx, currentPositions, moexBuyDateData, moexNowData
-func (_m *BondReportProcessor) CreateBondReport(ctx context.Context, currentPositions []report.PositionByFIFO, moexBuyDateData domain.ValuesMoex, moexNowData domain.ValuesMoex) (domainreport.Report, error) {
-	ret := _m.Called(ctx, currentPositions, moexBuyDateData, moexNowData)
+// CreateBondReport provides a mock function with given fields: ctx, currentPositions, moexBuyDateData, moexNowData, schedule
+func (_m *BondReportProcessor) CreateBondReport(ctx context.Context, currentPositions []report.PositionByFIFO, moexBuyDateData domain.ValuesMoex, moexNowData domain.ValuesMoex, schedule *bondmath.Schedule) (domainreport.Report, error) {
+	ret := _m.Called(ctx, currentPositions, moexBuyDateData, moexNowData, schedule)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateBondReport")
@@ -27,17 +28,17 @@ func (_m *BondReportProcessor) CreateBondReport(ctx context.Context, currentPosi
 
 	var r0 domainreport.Report
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex) (domainreport.Report, error)); ok {
-		return rf(ctx, currentPositions, moexBuyDateData, moexNowData)
+	if rf, ok := ret.Get(0).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex, *bondmath.Schedule) (domainreport.Report, error)); ok {
+		return rf(ctx, currentPositions, moexBuyDateData, moexNowData, schedule)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex) domainreport.Report); ok {
-		r0 = rf(ctx, currentPositions, moexBuyDateData, moexNowData)
+	if rf, ok := ret.Get(0).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex, *bondmath.Schedule) domainreport.Report); ok {
+		r0 = rf(ctx, currentPositions, moexBuyDateData, moexNowData, schedule)
 	} else {
 		r0 = ret.Get(0).(domainreport.Report)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex) error); ok {
-		r1 = rf(ctx, currentPositions, moexBuyDateData, moexNowData)
+	if rf, ok := ret.Get(1).(func(context.Context, []report.PositionByFIFO, domain.ValuesMoex, domain.ValuesMoex, *bondmath.Schedule) error); ok {
+		r1 = rf(ctx, currentPositions, moexBuyDateData, moexNowData, schedule)
 	} else {
 		r1 = ret.Error(1)
 	}
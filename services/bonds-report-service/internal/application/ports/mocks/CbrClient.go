@@ -44,6 +44,66 @@ func (_m *CbrClient) GetAllCurrencies(ctx context.Context, date time.Time) (doma
 	return r0, r1
 }
 
+// GetInflation provides a mock function with given fields: ctx, from, to
+func (_m *CbrClient) GetInflation(ctx context.Context, from time.Time, to time.Time) ([]domain.Inflation, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInflation")
+	}
+
+	var r0 []domain.Inflation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]domain.Inflation, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []domain.Inflation); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Inflation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyRate provides a mock function with given fields: ctx, from, to
+func (_m *CbrClient) GetKeyRate(ctx context.Context, from time.Time, to time.Time) ([]domain.KeyRate, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetKeyRate")
+	}
+
+	var r0 []domain.KeyRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]domain.KeyRate, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []domain.KeyRate); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.KeyRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCbrClient creates a new instance of CbrClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCbrClient(t interface {
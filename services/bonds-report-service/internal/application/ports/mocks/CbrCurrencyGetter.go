@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	domain "bonds-report-service/internal/domain"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -43,6 +44,34 @@ func (_m *CbrCurrencyGetter) GetCurrencyFromCB(ctx context.Context, charCode str
 	return r0, r1
 }
 
+// GetMacroIndicators provides a mock function with given fields: ctx, date
+func (_m *CbrCurrencyGetter) GetMacroIndicators(ctx context.Context, date time.Time) (domain.MacroIndicators, error) {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMacroIndicators")
+	}
+
+	var r0 domain.MacroIndicators
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (domain.MacroIndicators, error)); ok {
+		return rf(ctx, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) domain.MacroIndicators); ok {
+		r0 = rf(ctx, date)
+	} else {
+		r0 = ret.Get(0).(domain.MacroIndicators)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCbrCurrencyGetter creates a new instance of CbrCurrencyGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCbrCurrencyGetter(t interface {
@@ -16,6 +16,122 @@ type MoexClient struct {
 	mock.Mock
 }
 
+// GetBonds provides a mock function with given fields: ctx, boards
+func (_m *MoexClient) GetBonds(ctx context.Context, boards []string) ([]domain.MoexBond, error) {
+	ret := _m.Called(ctx, boards)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBonds")
+	}
+
+	var r0 []domain.MoexBond
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.MoexBond, error)); ok {
+		return rf(ctx, boards)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.MoexBond); ok {
+		r0 = rf(ctx, boards)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.MoexBond)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, boards)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, ticker, from, to
+func (_m *MoexClient) GetHistory(ctx context.Context, ticker string, from time.Time, to time.Time) (domain.MoexHistory, error) {
+	ret := _m.Called(ctx, ticker, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 domain.MoexHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (domain.MoexHistory, error)); ok {
+		return rf(ctx, ticker, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) domain.MoexHistory); ok {
+		r0 = rf(ctx, ticker, from, to)
+	} else {
+		r0 = ret.Get(0).(domain.MoexHistory)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, ticker, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIssuers provides a mock function with given fields: ctx, secIDs
+func (_m *MoexClient) GetIssuers(ctx context.Context, secIDs []string) (map[string]domain.MoexIssuer, error) {
+	ret := _m.Called(ctx, secIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIssuers")
+	}
+
+	var r0 map[string]domain.MoexIssuer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.MoexIssuer, error)); ok {
+		return rf(ctx, secIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.MoexIssuer); ok {
+		r0 = rf(ctx, secIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.MoexIssuer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, secIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSecurities provides a mock function with given fields: ctx, shares, etfs, currencies
+func (_m *MoexClient) GetSecurities(ctx context.Context, shares []string, etfs []string, currencies []string) (domain.MoexSecurities, error) {
+	ret := _m.Called(ctx, shares, etfs, currencies)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecurities")
+	}
+
+	var r0 domain.MoexSecurities
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string, []string) (domain.MoexSecurities, error)); ok {
+		return rf(ctx, shares, etfs, currencies)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, []string, []string) domain.MoexSecurities); ok {
+		r0 = rf(ctx, shares, etfs, currencies)
+	} else {
+		r0 = ret.Get(0).(domain.MoexSecurities)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, []string, []string) error); ok {
+		r1 = rf(ctx, shares, etfs, currencies)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSpecifications provides a mock function with given fields: ctx, ticker, date
 func (_m *MoexClient) GetSpecifications(ctx context.Context, ticker string, date time.Time) (domain.ValuesMoex, error) {
 	ret := _m.Called(ctx, ticker, date)
@@ -14,29 +14,29 @@ type ReportLineBuilder struct {
 	mock.Mock
 }
 
-// CreateNewReportLines provides a mock function with given fields: ctx, position, operationsDb
-func (_m *ReportLineBuilder) CreateNewReportLines(ctx context.Context, position domain.PortfolioPositionsWithAssetUid, operationsDb []domain.OperationWithoutCustomTypes) (*domain.ReportLine, error) {
-	ret := _m.Called(ctx, position, operationsDb)
+// CreateNewReportLinesBatch provides a mock function with given fields: ctx, positions, operationsByAssetUid
+func (_m *ReportLineBuilder) CreateNewReportLinesBatch(ctx context.Context, positions []domain.PortfolioPositionsWithAssetUid, operationsByAssetUid map[string][]domain.OperationWithoutCustomTypes) (map[string]*domain.ReportLine, error) {
+	ret := _m.Called(ctx, positions, operationsByAssetUid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateNewReportLines")
+		panic("no return value specified for CreateNewReportLinesBatch")
 	}
 
-	var r0 *domain.ReportLine
+	var r0 map[string]*domain.ReportLine
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PortfolioPositionsWithAssetUid, []domain.OperationWithoutCustomTypes) (*domain.ReportLine, error)); ok {
-		return rf(ctx, position, operationsDb)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.PortfolioPositionsWithAssetUid, map[string][]domain.OperationWithoutCustomTypes) (map[string]*domain.ReportLine, error)); ok {
+		return rf(ctx, positions, operationsByAssetUid)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.PortfolioPositionsWithAssetUid, []domain.OperationWithoutCustomTypes) *domain.ReportLine); ok {
-		r0 = rf(ctx, position, operationsDb)
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.PortfolioPositionsWithAssetUid, map[string][]domain.OperationWithoutCustomTypes) map[string]*domain.ReportLine); ok {
+		r0 = rf(ctx, positions, operationsByAssetUid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.ReportLine)
+			r0 = ret.Get(0).(map[string]*domain.ReportLine)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.PortfolioPositionsWithAssetUid, []domain.OperationWithoutCustomTypes) error); ok {
-		r1 = rf(ctx, position, operationsDb)
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.PortfolioPositionsWithAssetUid, map[string][]domain.OperationWithoutCustomTypes) error); ok {
+		r1 = rf(ctx, positions, operationsByAssetUid)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -148,6 +148,34 @@ func (_m *Storage) GetOperations(ctx context.Context, chatId int, assetUid strin
 	return r0, r1
 }
 
+// GetOperationsSyncState provides a mock function with given fields: ctx, chatID, accountId
+func (_m *Storage) GetOperationsSyncState(ctx context.Context, chatID int, accountId string) (domain.OperationsSyncState, error) {
+	ret := _m.Called(ctx, chatID, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationsSyncState")
+	}
+
+	var r0 domain.OperationsSyncState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (domain.OperationsSyncState, error)); ok {
+		return rf(ctx, chatID, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) domain.OperationsSyncState); ok {
+		r0 = rf(ctx, chatID, accountId)
+	} else {
+		r0 = ret.Get(0).(domain.OperationsSyncState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, chatID, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUid provides a mock function with given fields: ctx, instrumentUid
 func (_m *Storage) GetUid(ctx context.Context, instrumentUid string) (string, error) {
 	ret := _m.Called(ctx, instrumentUid)
@@ -304,6 +332,24 @@ func (_m *Storage) SaveOperations(ctx context.Context, chatID int, accountId str
 	return r0
 }
 
+// SaveOperationsPage provides a mock function with given fields: ctx, chatID, accountId, operations, state
+func (_m *Storage) SaveOperationsPage(ctx context.Context, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes, state domain.OperationsSyncState) error {
+	ret := _m.Called(ctx, chatID, accountId, operations, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOperationsPage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, []domain.OperationWithoutCustomTypes, domain.OperationsSyncState) error); ok {
+		r0 = rf(ctx, chatID, accountId, operations, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveUids provides a mock function with given fields: ctx, uids
 func (_m *Storage) SaveUids(ctx context.Context, uids map[string]string) error {
 	ret := _m.Called(ctx, uids)
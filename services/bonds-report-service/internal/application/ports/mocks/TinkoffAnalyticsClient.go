@@ -72,6 +72,36 @@ func (_m *TinkoffAnalyticsClient) GetBondsActions(ctx context.Context, instrumen
 	return r0, r1
 }
 
+// GetBondsActionsBatch provides a mock function with given fields: ctx, instrumentUids
+func (_m *TinkoffAnalyticsClient) GetBondsActionsBatch(ctx context.Context, instrumentUids []string) (map[string]domain.BondIdentIdentifiers, error) {
+	ret := _m.Called(ctx, instrumentUids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBondsActionsBatch")
+	}
+
+	var r0 map[string]domain.BondIdentIdentifiers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.BondIdentIdentifiers, error)); ok {
+		return rf(ctx, instrumentUids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.BondIdentIdentifiers); ok {
+		r0 = rf(ctx, instrumentUids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.BondIdentIdentifiers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, instrumentUids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastPriceInPersentageToNominal provides a mock function with given fields: ctx, instrumentUid
 func (_m *TinkoffAnalyticsClient) GetLastPriceInPersentageToNominal(ctx context.Context, instrumentUid string) (domain.LastPrice, error) {
 	ret := _m.Called(ctx, instrumentUid)
@@ -100,6 +130,36 @@ func (_m *TinkoffAnalyticsClient) GetLastPriceInPersentageToNominal(ctx context.
 	return r0, r1
 }
 
+// GetLastPricesInPersentageToNominal provides a mock function with given fields: ctx, instrumentUids
+func (_m *TinkoffAnalyticsClient) GetLastPricesInPersentageToNominal(ctx context.Context, instrumentUids []string) (map[string]domain.LastPrice, error) {
+	ret := _m.Called(ctx, instrumentUids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastPricesInPersentageToNominal")
+	}
+
+	var r0 map[string]domain.LastPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.LastPrice, error)); ok {
+		return rf(ctx, instrumentUids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.LastPrice); ok {
+		r0 = rf(ctx, instrumentUids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.LastPrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, instrumentUids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTinkoffAnalyticsClient creates a new instance of TinkoffAnalyticsClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTinkoffAnalyticsClient(t interface {
@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TinkoffInstrumentsClient is an autogenerated mock type for the TinkoffInstrumentsClient type
@@ -44,6 +46,36 @@ func (_m *TinkoffInstrumentsClient) FindBy(ctx context.Context, query string) (d
 	return r0, r1
 }
 
+// GetAssetReports provides a mock function with given fields: ctx, instrumentID, from, to
+func (_m *TinkoffInstrumentsClient) GetAssetReports(ctx context.Context, instrumentID string, from time.Time, to time.Time) ([]domain.AssetReport, error) {
+	ret := _m.Called(ctx, instrumentID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetReports")
+	}
+
+	var r0 []domain.AssetReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]domain.AssetReport, error)); ok {
+		return rf(ctx, instrumentID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []domain.AssetReport); ok {
+		r0 = rf(ctx, instrumentID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AssetReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, instrumentID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBondByUid provides a mock function with given fields: ctx, uid
 func (_m *TinkoffInstrumentsClient) GetBondByUid(ctx context.Context, uid string) (domain.Bond, error) {
 	ret := _m.Called(ctx, uid)
@@ -72,6 +104,66 @@ func (_m *TinkoffInstrumentsClient) GetBondByUid(ctx context.Context, uid string
 	return r0, r1
 }
 
+// GetBondsByUids provides a mock function with given fields: ctx, uids
+func (_m *TinkoffInstrumentsClient) GetBondsByUids(ctx context.Context, uids []string) (map[string]domain.Bond, error) {
+	ret := _m.Called(ctx, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBondsByUids")
+	}
+
+	var r0 map[string]domain.Bond
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.Bond, error)); ok {
+		return rf(ctx, uids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.Bond); ok {
+		r0 = rf(ctx, uids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.Bond)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, uids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrenciesBy provides a mock function with given fields: ctx, figis
+func (_m *TinkoffInstrumentsClient) GetCurrenciesBy(ctx context.Context, figis []string) (map[string]domain.Currency, error) {
+	ret := _m.Called(ctx, figis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrenciesBy")
+	}
+
+	var r0 map[string]domain.Currency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.Currency, error)); ok {
+		return rf(ctx, figis)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.Currency); ok {
+		r0 = rf(ctx, figis)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.Currency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, figis)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCurrencyBy provides a mock function with given fields: ctx, figi
 func (_m *TinkoffInstrumentsClient) GetCurrencyBy(ctx context.Context, figi string) (domain.Currency, error) {
 	ret := _m.Called(ctx, figi)
@@ -100,6 +192,36 @@ func (_m *TinkoffInstrumentsClient) GetCurrencyBy(ctx context.Context, figi stri
 	return r0, r1
 }
 
+// GetDividends provides a mock function with given fields: ctx, figi, from, to
+func (_m *TinkoffInstrumentsClient) GetDividends(ctx context.Context, figi string, from time.Time, to time.Time) ([]domain.Dividend, error) {
+	ret := _m.Called(ctx, figi, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDividends")
+	}
+
+	var r0 []domain.Dividend
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]domain.Dividend, error)); ok {
+		return rf(ctx, figi, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []domain.Dividend); ok {
+		r0 = rf(ctx, figi, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Dividend)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, figi, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFutureBy provides a mock function with given fields: ctx, figi
 func (_m *TinkoffInstrumentsClient) GetFutureBy(ctx context.Context, figi string) (domain.Future, error) {
 	ret := _m.Called(ctx, figi)
@@ -128,6 +250,36 @@ func (_m *TinkoffInstrumentsClient) GetFutureBy(ctx context.Context, figi string
 	return r0, r1
 }
 
+// GetFuturesBy provides a mock function with given fields: ctx, figis
+func (_m *TinkoffInstrumentsClient) GetFuturesBy(ctx context.Context, figis []string) (map[string]domain.Future, error) {
+	ret := _m.Called(ctx, figis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFuturesBy")
+	}
+
+	var r0 map[string]domain.Future
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.Future, error)); ok {
+		return rf(ctx, figis)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.Future); ok {
+		r0 = rf(ctx, figis)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.Future)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, figis)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetShareCurrencyBy provides a mock function with given fields: ctx, figi
 func (_m *TinkoffInstrumentsClient) GetShareCurrencyBy(ctx context.Context, figi string) (domain.ShareCurrency, error) {
 	ret := _m.Called(ctx, figi)
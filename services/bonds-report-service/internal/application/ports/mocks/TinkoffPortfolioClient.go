@@ -46,6 +46,34 @@ func (_m *TinkoffPortfolioClient) GetAccounts(ctx context.Context) (map[string]d
 	return r0, r1
 }
 
+// GetMarginAttributes provides a mock function with given fields: ctx, accountID
+func (_m *TinkoffPortfolioClient) GetMarginAttributes(ctx context.Context, accountID string) (domain.MarginAttributes, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarginAttributes")
+	}
+
+	var r0 domain.MarginAttributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.MarginAttributes, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.MarginAttributes); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(domain.MarginAttributes)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOperations provides a mock function with given fields: ctx, accountId, date
 func (_m *TinkoffPortfolioClient) GetOperations(ctx context.Context, accountId string, date time.Time) ([]domain.Operation, error) {
 	ret := _m.Called(ctx, accountId, date)
@@ -76,6 +104,34 @@ func (_m *TinkoffPortfolioClient) GetOperations(ctx context.Context, accountId s
 	return r0, r1
 }
 
+// GetOperationsPage provides a mock function with given fields: ctx, req
+func (_m *TinkoffPortfolioClient) GetOperationsPage(ctx context.Context, req domain.OperationsPageRequest) (domain.OperationsPage, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationsPage")
+	}
+
+	var r0 domain.OperationsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OperationsPageRequest) (domain.OperationsPage, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OperationsPageRequest) domain.OperationsPage); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(domain.OperationsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OperationsPageRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPortfolio provides a mock function with given fields: ctx, accountID, accountStatus
 func (_m *TinkoffPortfolioClient) GetPortfolio(ctx context.Context, accountID string, accountStatus int64) (domain.Portfolio, error) {
 	ret := _m.Called(ctx, accountID, accountStatus)
@@ -104,6 +160,62 @@ func (_m *TinkoffPortfolioClient) GetPortfolio(ctx context.Context, accountID st
 	return r0, r1
 }
 
+// GetPositions provides a mock function with given fields: ctx, accountID
+func (_m *TinkoffPortfolioClient) GetPositions(ctx context.Context, accountID string) (domain.Positions, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPositions")
+	}
+
+	var r0 domain.Positions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.Positions, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Positions); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(domain.Positions)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWithdrawLimits provides a mock function with given fields: ctx, accountID
+func (_m *TinkoffPortfolioClient) GetWithdrawLimits(ctx context.Context, accountID string) (domain.WithdrawLimits, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithdrawLimits")
+	}
+
+	var r0 domain.WithdrawLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.WithdrawLimits, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.WithdrawLimits); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(domain.WithdrawLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTinkoffPortfolioClient creates a new instance of TinkoffPortfolioClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTinkoffPortfolioClient(t interface {
@@ -0,0 +1,238 @@
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+const (
+	chartDateLayout = "02.01.06"
+	chartGridLines  = 5
+	chartDateTicks  = 6
+)
+
+var (
+	chartPriceColor = color.RGBA{31, 119, 180, 255}
+	chartYieldColor = color.RGBA{255, 127, 14, 255}
+	chartBuyColor   = color.RGBA{44, 160, 44, 255}
+	chartGridColor  = color.RGBA{220, 220, 220, 255}
+)
+
+type chartPoint struct {
+	date  time.Time
+	value float64
+}
+
+// chartPanel - прямоугольник графика с масштабом по датам и значениям.
+type chartPanel struct {
+	x, y, w, h float64
+	from, to   time.Time
+	min, max   float64
+}
+
+// newChartPanel подбирает масштаб по всем значениям панели с запасом 5% сверху и снизу.
+func newChartPanel(x, y, w, h float64, from, to time.Time, values []float64) chartPanel {
+	p := chartPanel{x: x, y: y, w: w, h: h, from: from, to: to, min: math.Inf(1), max: math.Inf(-1)}
+	for _, v := range values {
+		p.min = math.Min(p.min, v)
+		p.max = math.Max(p.max, v)
+	}
+	if len(values) == 0 {
+		p.min, p.max = 0, 1
+	}
+	pad := (p.max - p.min) * 0.05
+	if pad == 0 {
+		pad = math.Max(math.Abs(p.max)*0.01, 0.5)
+	}
+	p.min -= pad
+	p.max += pad
+	return p
+}
+
+func (p chartPanel) px(date time.Time) float64 {
+	span := p.to.Sub(p.from)
+	if span <= 0 {
+		return p.x
+	}
+	return p.x + p.w*float64(date.Sub(p.from))/float64(span)
+}
+
+func (p chartPanel) py(value float64) float64 {
+	return p.y + p.h*(p.max-value)/(p.max-p.min)
+}
+
+// GenerateBondChartPNG рисует два графика с общей осью дат: чистую цену в процентах
+// от номинала с точками покупок и доходность к погашению по дневным итогам MOEX.
+func GenerateBondChartPNG(ctx context.Context, logger *slog.Logger, chart domain.BondChart) (_ []byte, err error) {
+	const op = "presenter.GenerateBondChartPNG"
+
+	defer logging.LogOperation_Debug(ctx, logger, op, &err)()
+
+	const (
+		width       = 1200
+		height      = 800
+		marginLeft  = 80.0
+		marginRight = 50.0
+		titleHeight = 95.0
+		panelGap    = 50.0
+		axisHeight  = 40.0
+	)
+
+	prices, yields := chartSeries(chart.History)
+
+	dc := gg.NewContext(width, height)
+
+	font, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	face, err := opentype.NewFace(font, &opentype.FaceOptions{
+		Size: 13,
+		DPI:  72,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dc.SetFontFace(face)
+
+	dc.SetColor(color.White)
+	dc.Clear()
+
+	dc.SetColor(color.Black)
+	title := fmt.Sprintf("%s (%s) %s - %s", chart.Name, chart.Ticker,
+		chart.From.Format(chartDateLayout), chart.To.Format(chartDateLayout))
+	dc.DrawStringAnchored(title, width/2, 25, 0.5, 0.5)
+	drawChartLegend(ctx, dc, marginLeft, 55)
+
+	panelWidth := width - marginLeft - marginRight
+	panelHeight := (height - titleHeight - panelGap - axisHeight) / 2
+
+	priceValues := chartValues(prices)
+	for _, point := range chart.BuyPoints {
+		priceValues = append(priceValues, point.Price)
+	}
+	pricePanel := newChartPanel(marginLeft, titleHeight, panelWidth, panelHeight, chart.From, chart.To, priceValues)
+	yieldPanel := newChartPanel(marginLeft, titleHeight+panelHeight+panelGap, panelWidth, panelHeight, chart.From, chart.To, chartValues(yields))
+
+	drawChartGrid(dc, pricePanel, tr(ctx, textChartPrice))
+	drawChartGrid(dc, yieldPanel, tr(ctx, textChartYield))
+	drawChartDates(dc, pricePanel, yieldPanel.y+yieldPanel.h+20)
+
+	drawChartLine(dc, pricePanel, prices, chartPriceColor)
+	drawChartLine(dc, yieldPanel, yields, chartYieldColor)
+
+	dc.SetColor(chartBuyColor)
+	for _, point := range chart.BuyPoints {
+		x, y := pricePanel.px(point.Date), pricePanel.py(point.Price)
+		dc.DrawCircle(x, y, 5)
+		dc.Fill()
+		dc.DrawStringAnchored(strconv.FormatFloat(point.Quantity, 'f', -1, 64), x, y-12, 0.5, 0)
+	}
+
+	return EncodePNGToBuffer(dc)
+}
+
+// BondChartCaption - подпись к графику в Telegram.
+func BondChartCaption(ctx context.Context, chart domain.BondChart) string {
+	return fmt.Sprintf(tr(ctx, textChartCaption), chart.Name, chart.Ticker)
+}
+
+// chartSeries достает из дневного ряда цену закрытия и доходность, пропуская дни без значений.
+func chartSeries(history []domain.ValuesMoex) (prices, yields []chartPoint) {
+	for _, values := range history {
+		date, err := time.Parse(layout, values.TradeDate.Value)
+		if err != nil {
+			continue
+		}
+		if values.Close.IsSet && !values.Close.IsNull {
+			prices = append(prices, chartPoint{date: date, value: values.Close.Value})
+		}
+		if values.YieldToMaturity.IsSet && !values.YieldToMaturity.IsNull {
+			yields = append(yields, chartPoint{date: date, value: values.YieldToMaturity.Value})
+		}
+	}
+	return prices, yields
+}
+
+func chartValues(points []chartPoint) []float64 {
+	res := make([]float64, 0, len(points))
+	for _, point := range points {
+		res = append(res, point.value)
+	}
+	return res
+}
+
+func drawChartGrid(dc *gg.Context, p chartPanel, label string) {
+	dc.SetLineWidth(1)
+	for i := 0; i <= chartGridLines; i++ {
+		value := p.min + (p.max-p.min)*float64(i)/chartGridLines
+		y := p.py(value)
+		dc.SetColor(chartGridColor)
+		dc.DrawLine(p.x, y, p.x+p.w, y)
+		dc.Stroke()
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(strconv.FormatFloat(value, 'f', 2, 64), p.x-8, y, 1, 0.5)
+	}
+	dc.SetColor(color.Gray{Y: 120})
+	dc.DrawRectangle(p.x, p.y, p.w, p.h)
+	dc.Stroke()
+	dc.SetColor(color.Black)
+	dc.DrawStringAnchored(label, p.x, p.y-10, 0, 0)
+}
+
+// drawChartDates подписывает даты под нижней панелью и проводит вертикальную сетку через обе.
+func drawChartDates(dc *gg.Context, top chartPanel, labelY float64) {
+	for i := 0; i <= chartDateTicks; i++ {
+		date := top.from.Add(time.Duration(float64(top.to.Sub(top.from)) * float64(i) / chartDateTicks))
+		x := top.px(date)
+		dc.SetColor(chartGridColor)
+		dc.DrawLine(x, top.y, x, labelY-15)
+		dc.Stroke()
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(date.Format(chartDateLayout), x, labelY, 0.5, 0.5)
+	}
+}
+
+func drawChartLine(dc *gg.Context, p chartPanel, points []chartPoint, c color.Color) {
+	if len(points) == 0 {
+		return
+	}
+	dc.SetColor(c)
+	dc.SetLineWidth(2)
+	dc.MoveTo(p.px(points[0].date), p.py(points[0].value))
+	for _, point := range points[1:] {
+		dc.LineTo(p.px(point.date), p.py(point.value))
+	}
+	dc.Stroke()
+}
+
+func drawChartLegend(ctx context.Context, dc *gg.Context, x, y float64) {
+	items := []struct {
+		text  string
+		color color.Color
+	}{
+		{tr(ctx, textChartPrice), chartPriceColor},
+		{tr(ctx, textChartYield), chartYieldColor},
+		{tr(ctx, textChartBuys), chartBuyColor},
+	}
+	for _, item := range items {
+		dc.SetColor(item.color)
+		dc.DrawRectangle(x, y-5, 12, 10)
+		dc.Fill()
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(item.text, x+18, y, 0, 0.5)
+		w, _ := dc.MeasureString(item.text)
+		x += w + 50
+	}
+}
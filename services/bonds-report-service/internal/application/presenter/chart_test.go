@@ -0,0 +1,85 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bytes"
+	"context"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartSeries(t *testing.T) {
+	history := []domain.ValuesMoex{
+		{
+			TradeDate:       domain.NewNullString("2025-11-13", true, false),
+			Close:           domain.NewNullFloat64(61.2, true, false),
+			YieldToMaturity: domain.NewNullFloat64(14.1, true, false),
+		},
+		{
+			// торгов не было: доходность посчитана, цены закрытия нет
+			TradeDate:       domain.NewNullString("2025-11-14", true, false),
+			Close:           domain.NewNullFloat64(0, true, true),
+			YieldToMaturity: domain.NewNullFloat64(14.2, true, false),
+		},
+		{TradeDate: domain.NewNullString("bad date", true, false)},
+	}
+
+	prices, yields := chartSeries(history)
+	require.Len(t, prices, 1)
+	require.Equal(t, 61.2, prices[0].value)
+	require.Len(t, yields, 2)
+	require.Equal(t, time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC), yields[1].date)
+}
+
+func TestChartPanelScale(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+	p := newChartPanel(0, 0, 100, 100, from, to, []float64{90, 110})
+
+	require.InDelta(t, 50.0, p.px(from.AddDate(0, 0, 5)), 1e-9)
+	require.Greater(t, p.py(90), p.py(110), "larger values are drawn higher")
+	require.Less(t, p.py(110), 100.0)
+	require.Greater(t, p.py(90), 0.0)
+
+	flat := newChartPanel(0, 0, 100, 100, from, to, []float64{100})
+	require.InDelta(t, 50.0, flat.py(100), 1e-9)
+}
+
+func TestGenerateBondChartPNG(t *testing.T) {
+	ctx := WithLang(context.Background(), LangEN)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	chart := domain.BondChart{
+		Ticker: "SU26238RMFS4",
+		Name:   "ОФЗ 26238",
+		From:   time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC),
+		History: []domain.ValuesMoex{
+			{
+				TradeDate:       domain.NewNullString("2025-11-13", true, false),
+				Close:           domain.NewNullFloat64(61.2, true, false),
+				YieldToMaturity: domain.NewNullFloat64(14.1, true, false),
+			},
+			{
+				TradeDate:       domain.NewNullString("2025-11-14", true, false),
+				Close:           domain.NewNullFloat64(61.5, true, false),
+				YieldToMaturity: domain.NewNullFloat64(14.0, true, false),
+			},
+		},
+		BuyPoints: []domain.ChartBuyPoint{{Date: time.Date(2025, 11, 13, 0, 0, 0, 0, time.UTC), Price: 60.9, Quantity: 5}},
+	}
+
+	data, err := GenerateBondChartPNG(ctx, logger, chart)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, 1200, img.Bounds().Dx())
+
+	require.Equal(t, "ОФЗ 26238 (SU26238RMFS4): price and yield", BondChartCaption(ctx, chart))
+}
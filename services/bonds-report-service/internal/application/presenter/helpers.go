@@ -1,6 +1,7 @@
 package presenter
 
 import (
+	"bonds-report-service/internal/domain"
 	"fmt"
 	"time"
 )
@@ -17,6 +18,22 @@ func formatPercent(value float64) string {
 	return fmt.Sprintf("%.2f%%", value)
 }
 
+// formatOptionalPercent выводит прочерк, если показатель не рассчитан.
+func formatOptionalPercent(value domain.NullFloat64) string {
+	if !value.IsHasValue() {
+		return "-"
+	}
+	return formatPercent(value.Value)
+}
+
 func formatTime(value time.Time) string {
 	return value.Format(layout)
 }
+
+// formatOptionalTime выводит прочерк вместо незаполненной даты.
+func formatOptionalTime(value time.Time) string {
+	if value.IsZero() {
+		return "-"
+	}
+	return formatTime(value)
+}
@@ -0,0 +1,445 @@
+package presenter
+
+import (
+	"context"
+	"strings"
+)
+
+// Lang - язык таблиц и подписей. Приходит от бота в заголовке Accept-Language.
+type Lang string
+
+const (
+	LangRU Lang = "ru"
+	LangEN Lang = "en"
+
+	defaultLang = LangRU
+)
+
+type langKey struct{}
+
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+func langFromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return defaultLang
+}
+
+// ParseLang возвращает первый поддерживаемый язык из значения Accept-Language
+// ("en-US,en;q=0.9,ru;q=0.8"). Веса q не учитываются: бот передает один язык.
+func ParseLang(header string) Lang {
+	for _, tag := range strings.Split(header, ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		tag, _, _ = strings.Cut(tag, "-")
+		switch Lang(tag) {
+		case LangRU, LangEN:
+			return Lang(tag)
+		}
+	}
+	return defaultLang
+}
+
+type textKey int
+
+const (
+	textReplacedBondsTitle textKey = iota
+	textRubBondsTitle
+	textEuroBondsTitle
+	textReplacedBondsCaption
+	textRubBondsCaption
+	textEuroBondsCaption
+
+	textColTicker
+	textColName
+	textColCurrency
+	textColQuantity
+	textColPercentOfPortfolio
+	textColMaturityDate
+	textColDuration
+	textColBuyDate
+	textColPositionPrice
+	textColYieldOnPurchase
+	textColYield
+	textColCurrentPrice
+	textColNominal
+	textColProfit
+	textColProfitInPercentage
+	textColRealYield
+	textColKeyRateSpread
+
+	textUnionPortfTitle
+	textUnionPortfWithSberTitle
+	textEachPortfTitle
+	textColAsset
+	textColValue
+	textColShare
+	textPortfolio
+	textBonds
+	textShares
+	textEtfs
+	textFutures
+	textFuturesCommodity
+	textFuturesCurrency
+	textFuturesSecurity
+	textFuturesIndex
+	textCurrencies
+	textCurrenciesBlocked
+	textLeverage
+
+	textCashTitle
+	textNoCash
+	textColAvailable
+	textColBlocked
+	textColWithdrawable
+	textColMarginParam
+	textLiquidPortfolio
+	textStartingMargin
+	textMinimalMargin
+	textFundsSufficiency
+	textMissingFunds
+	textNoMargin
+
+	textDividendsTitle
+	textNoDividends
+	textColRecordDate
+	textColPaymentDate
+	textColDividend
+	textColDividendGross
+	textColAfterTax
+	textForeignDividendTax
+	textReportsTitle
+	textColReportDate
+	textColReportPeriod
+	textPeriodQuarter
+	textPeriodHalfYear
+	textPeriodYear
+
+	textChartPrice
+	textChartYield
+	textChartBuys
+	textChartCaption
+
+	textMoexSecuritiesTitle
+	textColLot
+	textColSector
+	textColLastPrice
+
+	textScreenerTitle
+	textNoScreenerBonds
+	textScreenerNote
+	textScreenerHeld
+	textColCoupon
+	textColLevel
+	textColVsHoldings
+	textCouponFixed
+	textCouponFloat
+	textCouponZero
+
+	textScenarioTitle
+	textScenarioCaption
+	textScenarioShift
+	textScenarioNoBonds
+	textScenarioNote
+	textScenarioEstimated
+	textScenarioRUB
+	textScenarioCNY
+	textScenarioReplaced
+	textScenarioEuro
+	textColGroup
+	textColModDuration
+	textColConvexity
+	textColParallelShift
+	textColKeyRate
+	textColChange
+
+	textIssuersTitle
+	textIssuersCaption
+	textIssuersNoBonds
+	textIssuersOverLimit
+	textIssuersWithinLimit
+	textIssuersRatingsTitle
+	textColIssuer
+	textColIssues
+	textColRisk
+	textColRating
+	textRiskLow
+	textRiskModerate
+	textRiskHigh
+	textRatingCCC
+	textRatingNone
+
+	textAccountsHeader
+)
+
+var texts = map[Lang]map[textKey]string{
+	LangRU: {
+		textReplacedBondsTitle:   "Отчет по замещающим облигациям",
+		textRubBondsTitle:        "Отчет по рублевым облигациям",
+		textEuroBondsTitle:       "Отчет по валютным облигациям",
+		textReplacedBondsCaption: "Замещающие облигации",
+		textRubBondsCaption:      "Рублевые облигации",
+		textEuroBondsCaption:     "Валютные облигации",
+
+		textColTicker:             "Тикер",
+		textColName:               "Название",
+		textColCurrency:           "Валюта",
+		textColQuantity:           "Кол-во",
+		textColPercentOfPortfolio: "% от портфеля",
+		textColMaturityDate:       "Дата погашения",
+		textColDuration:           "Дюрация",
+		textColBuyDate:            "Дата покупки",
+		textColPositionPrice:      "Ср.цена",
+		textColYieldOnPurchase:    "Дох-ть при покупке",
+		textColYield:              "Дох-ть тек.",
+		textColCurrentPrice:       "Тек.цена",
+		textColNominal:            "Номинал",
+		textColProfit:             "Доход",
+		textColProfitInPercentage: "Дох-ть в %",
+		textColRealYield:          "Реальная дох-ть",
+		textColKeyRateSpread:      "Спред к КС",
+
+		textUnionPortfTitle:         "Струтура всех открытых счетов в Тинькофф Инвестициях\n",
+		textUnionPortfWithSberTitle: "Струтура всех инвестиций\n",
+		textEachPortfTitle:          "Струтура брокерского счета: %s\n",
+		textColAsset:                "Актив",
+		textColValue:                "Стоимость",
+		textColShare:                "Доля",
+		textPortfolio:               "Портфель",
+		textBonds:                   "Облигации",
+		textShares:                  "Акции",
+		textEtfs:                    "ETF",
+		textFutures:                 "Фьючерсы",
+		textFuturesCommodity:        "на товары",
+		textFuturesCurrency:         "на валюты",
+		textFuturesSecurity:         "на акции",
+		textFuturesIndex:            "на индексы",
+		textCurrencies:              "Валюты",
+		textCurrenciesBlocked:       "в т.ч. заблокировано",
+		textLeverage:                "Леверидж",
+
+		textCashTitle:        "Денежные средства на счете: %s\n",
+		textNoCash:           "Денежных средств на счете нет\n",
+		textColAvailable:     "Доступно",
+		textColBlocked:       "Заблок.",
+		textColWithdrawable:  "К выводу",
+		textColMarginParam:   "Маржинальные показатели",
+		textLiquidPortfolio:  "Ликвидный портфель",
+		textStartingMargin:   "Начальная маржа",
+		textMinimalMargin:    "Минимальная маржа",
+		textFundsSufficiency: "Уровень достаточности",
+		textMissingFunds:     "Недостаток средств",
+		textNoMargin:         "Маржинальная торговля не подключена\n",
+
+		textDividendsTitle:     "Ближайшие дивиденды по счету: %s\n",
+		textNoDividends:        "Объявленных дивидендов по акциям счета нет\n",
+		textColRecordDate:      "Дата реестра",
+		textColPaymentDate:     "Дата выплаты",
+		textColDividend:        "На акцию",
+		textColDividendGross:   "До налога",
+		textColAfterTax:        "После НДФЛ 13%",
+		textForeignDividendTax: "По валютным дивидендам налог зависит от страны эмитента, показана сумма до налога\n",
+		textReportsTitle:       "Ближайшие отчетности эмитентов\n",
+		textColReportDate:      "Дата публикации",
+		textColReportPeriod:    "Период",
+		textPeriodQuarter:      "%d квартал %d",
+		textPeriodHalfYear:     "%d полугодие %d",
+		textPeriodYear:         "%d год",
+
+		textChartPrice:   "Цена, % от номинала",
+		textChartYield:   "Доходность к погашению, %",
+		textChartBuys:    "Покупки (подпись - количество)",
+		textChartCaption: "%s (%s): цена и доходность",
+
+		textMoexSecuritiesTitle: "\nАкции, фонды и валюты по данным MOEX\n",
+		textColLot:              "Лот",
+		textColSector:           "Сектор",
+		textColLastPrice:        "Цена MOEX",
+
+		textScreenerTitle:   "Облигации MOEX по фильтру: подошло %d, показано %d\n",
+		textNoScreenerBonds: "Под фильтр не подошла ни одна облигация\n",
+		textScreenerNote:    "К портфелю - разница со средней доходностью ваших облигаций в той же валюте, п.п.\n",
+		textScreenerHeld:    "есть %d шт.",
+		textColCoupon:       "Купон",
+		textColLevel:        "Ур.",
+		textColVsHoldings:   "К портфелю",
+		textCouponFixed:     "фикс",
+		textCouponFloat:     "флоатер",
+		textCouponZero:      "дисконт",
+
+		textScenarioTitle:     "Сценарий %s: изменение стоимости облигаций",
+		textScenarioCaption:   "Облигации при изменении ставок на %s",
+		textScenarioShift:     "%+d б.п.",
+		textScenarioNoBonds:   "Облигаций на счетах нет",
+		textScenarioNote:      "Параллельный сдвиг меняет доходность всех облигаций, ключевая ставка - только рублевых. Флоатеры чувствительны к ставкам лишь до ближайшего купона.",
+		textScenarioEstimated: "* нет графика купонов MOEX: дюрация из отчета, выпуклость оценена сверху",
+		textScenarioRUB:       "Рублевые",
+		textScenarioCNY:       "Юаневые",
+		textScenarioReplaced:  "Замещающие",
+		textScenarioEuro:      "Еврооблигации",
+		textColGroup:          "Группа / бумага",
+		textColModDuration:    "Мод. дюрация",
+		textColConvexity:      "Выпуклость",
+		textColParallelShift:  "Параллельный сдвиг",
+		textColKeyRate:        "Ключевая ставка",
+		textColChange:         "%",
+
+		textIssuersTitle:        "Эмитенты и кредитные рейтинги",
+		textIssuersCaption:      "Концентрация по эмитентам и рейтингам",
+		textIssuersNoBonds:      "Облигаций в портфеле нет",
+		textIssuersOverLimit:    "Эмитент %s - %s портфеля, больше лимита %s",
+		textIssuersWithinLimit:  "Доля каждого эмитента не больше лимита %s",
+		textIssuersRatingsTitle: "Рейтинговые группы",
+		textColIssuer:           "Эмитент",
+		textColIssues:           "Выпуски",
+		textColRisk:             "Риск",
+		textColRating:           "Рейтинг",
+		textRiskLow:             "низкий",
+		textRiskModerate:        "средний",
+		textRiskHigh:            "высокий",
+		textRatingCCC:           "CCC и ниже",
+		textRatingNone:          "без рейтинга",
+
+		textAccountsHeader: "По данному аккаунту доступны следующие счета:",
+	},
+	LangEN: {
+		textReplacedBondsTitle:   "Replacement bonds report",
+		textRubBondsTitle:        "RUB bonds report",
+		textEuroBondsTitle:       "Foreign currency bonds report",
+		textReplacedBondsCaption: "Replacement bonds",
+		textRubBondsCaption:      "RUB bonds",
+		textEuroBondsCaption:     "Foreign currency bonds",
+
+		textColTicker:             "Ticker",
+		textColName:               "Name",
+		textColCurrency:           "Currency",
+		textColQuantity:           "Qty",
+		textColPercentOfPortfolio: "% of portfolio",
+		textColMaturityDate:       "Maturity date",
+		textColDuration:           "Duration",
+		textColBuyDate:            "Buy date",
+		textColPositionPrice:      "Avg.price",
+		textColYieldOnPurchase:    "Yield at purchase",
+		textColYield:              "Cur. yield",
+		textColCurrentPrice:       "Cur.price",
+		textColNominal:            "Nominal",
+		textColProfit:             "Profit",
+		textColProfitInPercentage: "Profit, %",
+		textColRealYield:          "Real yield",
+		textColKeyRateSpread:      "Key rate spread",
+
+		textUnionPortfTitle:         "Structure of all open T-Investments accounts\n",
+		textUnionPortfWithSberTitle: "Structure of all investments\n",
+		textEachPortfTitle:          "Brokerage account structure: %s\n",
+		textColAsset:                "Asset",
+		textColValue:                "Value",
+		textColShare:                "Share",
+		textPortfolio:               "Portfolio",
+		textBonds:                   "Bonds",
+		textShares:                  "Shares",
+		textEtfs:                    "ETF",
+		textFutures:                 "Futures",
+		textFuturesCommodity:        "commodities",
+		textFuturesCurrency:         "currencies",
+		textFuturesSecurity:         "shares",
+		textFuturesIndex:            "indices",
+		textCurrencies:              "Currencies",
+		textCurrenciesBlocked:       "incl. blocked",
+		textLeverage:                "Leverage",
+
+		textCashTitle:        "Cash on account: %s\n",
+		textNoCash:           "No cash on the account\n",
+		textColAvailable:     "Available",
+		textColBlocked:       "Blocked",
+		textColWithdrawable:  "Withdrawable",
+		textColMarginParam:   "Margin",
+		textLiquidPortfolio:  "Liquid portfolio",
+		textStartingMargin:   "Starting margin",
+		textMinimalMargin:    "Minimal margin",
+		textFundsSufficiency: "Funds sufficiency level",
+		textMissingFunds:     "Missing funds",
+		textNoMargin:         "Margin trading is disabled\n",
+
+		textDividendsTitle:     "Upcoming dividends on account: %s\n",
+		textNoDividends:        "No announced dividends for shares on the account\n",
+		textColRecordDate:      "Record date",
+		textColPaymentDate:     "Payment date",
+		textColDividend:        "Per share",
+		textColDividendGross:   "Before tax",
+		textColAfterTax:        "After 13% tax",
+		textForeignDividendTax: "Tax on foreign currency dividends depends on the issuer's country, the amount is shown before tax\n",
+		textReportsTitle:       "Upcoming issuer reports\n",
+		textColReportDate:      "Report date",
+		textColReportPeriod:    "Period",
+		textPeriodQuarter:      "Q%d %d",
+		textPeriodHalfYear:     "H%d %d",
+		textPeriodYear:         "FY %d",
+
+		textChartPrice:   "Price, % of nominal",
+		textChartYield:   "Yield to maturity, %",
+		textChartBuys:    "Buys (label - quantity)",
+		textChartCaption: "%s (%s): price and yield",
+
+		textMoexSecuritiesTitle: "\nShares, ETFs and currencies by MOEX data\n",
+		textColLot:              "Lot",
+		textColSector:           "Sector",
+		textColLastPrice:        "MOEX price",
+
+		textScreenerTitle:   "MOEX bonds matching the filter: %d found, %d shown\n",
+		textNoScreenerBonds: "No bonds match the filter\n",
+		textScreenerNote:    "Vs portfolio - difference from the average yield of your bonds in the same currency, pp\n",
+		textScreenerHeld:    "held %d",
+		textColCoupon:       "Coupon",
+		textColLevel:        "Lvl",
+		textColVsHoldings:   "Vs portfolio",
+		textCouponFixed:     "fixed",
+		textCouponFloat:     "floater",
+		textCouponZero:      "discount",
+
+		textScenarioTitle:     "Scenario %s: change in bond value",
+		textScenarioCaption:   "Bonds under a %s rate move",
+		textScenarioShift:     "%+d bp",
+		textScenarioNoBonds:   "There are no bonds in the accounts",
+		textScenarioNote:      "A parallel shift moves the yields of all bonds, the key rate moves RUB bonds only. Floaters are sensitive to rates only until the next coupon.",
+		textScenarioEstimated: "* no MOEX coupon schedule: report duration, convexity is an upper estimate",
+		textScenarioRUB:       "RUB",
+		textScenarioCNY:       "CNY",
+		textScenarioReplaced:  "Replacement",
+		textScenarioEuro:      "Eurobonds",
+		textColGroup:          "Group / bond",
+		textColModDuration:    "Mod. duration",
+		textColConvexity:      "Convexity",
+		textColParallelShift:  "Parallel shift",
+		textColKeyRate:        "Key rate",
+		textColChange:         "%",
+
+		textIssuersTitle:        "Issuers and credit ratings",
+		textIssuersCaption:      "Issuer and rating concentration",
+		textIssuersNoBonds:      "There are no bonds in the portfolio",
+		textIssuersOverLimit:    "Issuer %s is %s of the portfolio, above the %s limit",
+		textIssuersWithinLimit:  "Every issuer is within the %s limit",
+		textIssuersRatingsTitle: "Rating groups",
+		textColIssuer:           "Issuer",
+		textColIssues:           "Issues",
+		textColRisk:             "Risk",
+		textColRating:           "Rating",
+		textRiskLow:             "low",
+		textRiskModerate:        "moderate",
+		textRiskHigh:            "high",
+		textRatingCCC:           "CCC and below",
+		textRatingNone:          "not rated",
+
+		textAccountsHeader: "The following accounts are available for this token:",
+	},
+}
+
+// tr возвращает текст на языке запроса, а при отсутствии перевода - на русском.
+func tr(ctx context.Context, key textKey) string {
+	if text, ok := texts[langFromContext(ctx)][key]; ok {
+		return text
+	}
+	return texts[defaultLang][key]
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Lang
+	}{
+		{header: "en", want: LangEN},
+		{header: "en-US,en;q=0.9", want: LangEN},
+		{header: "de-DE, ru;q=0.8", want: LangRU},
+		{header: "de", want: LangRU},
+		{header: "", want: LangRU},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, ParseLang(tt.header), tt.header)
+	}
+}
+
+func TestTextsComplete(t *testing.T) {
+	for lang, langTexts := range texts {
+		require.Len(t, langTexts, len(texts[defaultLang]), "lang %s", lang)
+		for key := range texts[defaultLang] {
+			require.NotEmpty(t, langTexts[key], "lang %s, key %d", lang, key)
+		}
+	}
+}
+
+func TestResponsePortfolioStructureLang(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	portfolio := domain.NewPortfolioByTypeAndCurrency()
+	portfolio.AllAssets = 100
+
+	ru := ResponsePortfolioStructure(context.Background(), logger, portfolio, eachPortf, "ИИС")
+	require.Contains(t, ru, "Струтура брокерского счета: ИИС")
+	require.Contains(t, ru, "Портфель")
+
+	en := ResponsePortfolioStructure(WithLang(context.Background(), LangEN), logger, portfolio, eachPortf, "IIS")
+	require.Contains(t, en, "Brokerage account structure: IIS")
+	require.Contains(t, en, "Portfolio")
+	require.Contains(t, en, "Leverage")
+	require.NotContains(t, en, "Портфель")
+}
+
+func TestBondsCaption(t *testing.T) {
+	ctx := WithLang(context.Background(), LangEN)
+	require.Equal(t, "RUB bonds", bondsCaption(ctx, domain.RubBonds))
+	require.Equal(t, "Замещающие облигации", bondsCaption(context.Background(), domain.ReplacedBonds))
+}
+
+func TestResponseDividendsReports(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	accounts := []domain.AccountDividends{{
+		Account: domain.Account{Name: "ИИС"},
+		Reports: []domain.UpcomingReport{{
+			Ticker: "SBER",
+			AssetReport: domain.AssetReport{
+				ReportDate: time.Date(2026, 7, 30, 0, 0, 0, 0, time.UTC),
+				PeriodYear: 2026,
+				PeriodNum:  2,
+				PeriodType: "PERIOD_TYPE_QUARTER",
+			},
+		}},
+	}}
+
+	ru := ResponseDividends(context.Background(), logger, accounts)
+	require.Contains(t, ru, "Объявленных дивидендов по акциям счета нет")
+	require.Contains(t, ru, "Ближайшие отчетности эмитентов")
+	require.Contains(t, ru, "2 квартал 2026")
+
+	en := ResponseDividends(WithLang(context.Background(), LangEN), logger, accounts)
+	require.Contains(t, en, "Q2 2026")
+
+	accounts[0].Reports = nil
+	require.NotContains(t, ResponseDividends(context.Background(), logger, accounts), "отчетности")
+}
@@ -0,0 +1,243 @@
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// issuerTickersLimit - сколько выпусков эмитента показывать в таблице, остальные - числом.
+const issuerTickersLimit = 3
+
+// issuerRow - строка таблицы эмитентов.
+type issuerRow struct {
+	name      string
+	tickers   string
+	sector    string
+	risk      string
+	rating    string
+	percent   float64
+	overLimit bool
+}
+
+// GenerateIssuerConcentrationPNG рисует доли эмитентов в портфеле с сектором, уровнем риска
+// и рейтингом, распределение по рейтинговым группам и предупреждения о превышении лимита.
+func GenerateIssuerConcentrationPNG(ctx context.Context, logger *slog.Logger, concentration generalbondreport.IssuerConcentration) (_ []byte, err error) {
+	const op = "presenter.GenerateIssuerConcentrationPNG"
+
+	defer logging.LogOperation_Debug(ctx, logger, op, &err)()
+
+	const (
+		width        = 1200
+		margin       = 20.0
+		titleHeight  = 50.0
+		rowHeight    = 28.0
+		headerHeight = 40.0
+		ratingsWidth = 400.0
+	)
+
+	rows := issuerRows(ctx, concentration)
+	warnings := issuerWarnings(ctx, concentration)
+	height := int(titleHeight + headerHeight + float64(max(len(rows), 1))*rowHeight +
+		titleHeight + headerHeight + float64(max(len(concentration.Ratings), 1))*rowHeight +
+		float64(len(warnings)+2)*rowHeight + margin)
+
+	dc := gg.NewContext(width, height)
+
+	font, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	face, err := opentype.NewFace(font, &opentype.FaceOptions{
+		Size: 13,
+		DPI:  72,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dc.SetFontFace(face)
+
+	dc.SetColor(color.White)
+	dc.Clear()
+
+	dc.SetColor(color.Black)
+	dc.DrawStringAnchored(tr(ctx, textIssuersTitle), width/2, titleHeight/2, 0.5, 0.5)
+
+	columns := []struct {
+		title string
+		width float64
+	}{
+		{tr(ctx, textColIssuer), 0},
+		{tr(ctx, textColIssues), 330},
+		{tr(ctx, textColSector), 150},
+		{tr(ctx, textColRisk), 100},
+		{tr(ctx, textColRating), 120},
+		{tr(ctx, textColShare), 100},
+	}
+	fixed := 0.0
+	for _, col := range columns[1:] {
+		fixed += col.width
+	}
+	columns[0].width = width - 2*margin - fixed
+
+	y := titleHeight
+	x := margin
+	for _, col := range columns {
+		drawIssuerHeader(dc, x, y, headerHeight, col.title, col.width)
+		x += col.width
+	}
+	y += headerHeight
+
+	if len(rows) == 0 {
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(tr(ctx, textIssuersNoBonds), width/2, y+rowHeight/2, 0.5, 0.5)
+		y += rowHeight
+	}
+	for _, row := range rows {
+		textColor := color.Color(color.Black)
+		if row.overLimit {
+			textColor = scenarioLossColor
+		}
+		dc.SetColor(textColor)
+		cells := []string{row.name, row.tickers, row.sector, row.risk, row.rating, formatPercent(row.percent)}
+		x = margin
+		for i, cell := range cells {
+			if i <= 1 {
+				dc.DrawStringAnchored(cell, x+8, y+rowHeight/2, 0, 0.5)
+			} else {
+				dc.DrawStringAnchored(cell, x+columns[i].width/2, y+rowHeight/2, 0.5, 0.5)
+			}
+			x += columns[i].width
+		}
+		y += rowHeight
+	}
+
+	dc.SetColor(color.Black)
+	dc.DrawStringAnchored(tr(ctx, textIssuersRatingsTitle), margin, y+titleHeight/2, 0, 0.5)
+	y += titleHeight
+	drawIssuerHeader(dc, margin, y, headerHeight, tr(ctx, textColRating), ratingsWidth/2)
+	drawIssuerHeader(dc, margin+ratingsWidth/2, y, headerHeight, tr(ctx, textColShare), ratingsWidth/2)
+	y += headerHeight
+	if len(concentration.Ratings) == 0 {
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored("-", margin+ratingsWidth/2, y+rowHeight/2, 0.5, 0.5)
+		y += rowHeight
+	}
+	for _, rating := range concentration.Ratings {
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(ratingBucketTitle(ctx, rating.Bucket), margin+8, y+rowHeight/2, 0, 0.5)
+		dc.DrawStringAnchored(formatPercent(rating.Percent), margin+ratingsWidth*3/4, y+rowHeight/2, 0.5, 0.5)
+		y += rowHeight
+	}
+
+	y += rowHeight
+	warningColor := color.Color(scenarioLossColor)
+	if concentration.OverLimit() == nil {
+		warningColor = color.Gray{Y: 80}
+	}
+	dc.SetColor(warningColor)
+	for _, warning := range warnings {
+		dc.DrawStringAnchored(warning, margin, y+rowHeight/2, 0, 0.5)
+		y += rowHeight
+	}
+
+	return EncodePNGToBuffer(dc)
+}
+
+// IssuerConcentrationCaption - подпись к таблице эмитентов в Telegram с предупреждениями о лимите.
+func IssuerConcentrationCaption(ctx context.Context, concentration generalbondreport.IssuerConcentration) string {
+	caption := tr(ctx, textIssuersCaption)
+	if concentration.OverLimit() == nil {
+		return caption
+	}
+	return caption + "\n" + strings.Join(issuerWarnings(ctx, concentration), "\n")
+}
+
+// issuerRows переводит доли эмитентов в строки таблицы.
+func issuerRows(ctx context.Context, concentration generalbondreport.IssuerConcentration) []issuerRow {
+	rows := make([]issuerRow, 0, len(concentration.Issuers))
+	for _, share := range concentration.Issuers {
+		rows = append(rows, issuerRow{
+			name:      share.Name,
+			tickers:   formatTickers(share.Tickers),
+			sector:    orDash(share.Issuer.Sector),
+			risk:      riskLevelTitle(ctx, share.Issuer.RiskLevel),
+			rating:    orDash(share.Issuer.Rating),
+			percent:   share.Percent,
+			overLimit: concentration.Limit > 0 && share.Percent > concentration.Limit,
+		})
+	}
+	return rows
+}
+
+// issuerWarnings - предупреждение на каждого эмитента выше лимита, а если таких нет -
+// строка о соблюдении лимита. Без лимита предупреждений нет.
+func issuerWarnings(ctx context.Context, concentration generalbondreport.IssuerConcentration) []string {
+	if concentration.Limit <= 0 || len(concentration.Issuers) == 0 {
+		return nil
+	}
+	limit := formatLimit(concentration.Limit)
+	overLimit := concentration.OverLimit()
+	if len(overLimit) == 0 {
+		return []string{fmt.Sprintf(tr(ctx, textIssuersWithinLimit), limit)}
+	}
+	res := make([]string, 0, len(overLimit))
+	for _, share := range overLimit {
+		res = append(res, fmt.Sprintf(tr(ctx, textIssuersOverLimit), share.Name, fmt.Sprintf("%.1f%%", share.Percent), limit))
+	}
+	return res
+}
+
+func drawIssuerHeader(dc *gg.Context, x, y, height float64, title string, width float64) {
+	dc.SetColor(scenarioHeaderColor)
+	dc.DrawRectangle(x, y, width, height)
+	dc.Fill()
+	dc.SetColor(color.Black)
+	dc.DrawStringAnchored(title, x+width/2, y+height/2, 0.5, 0.5)
+}
+
+// formatTickers выводит первые выпуски эмитента, а остальные - их числом.
+func formatTickers(tickers []string) string {
+	if len(tickers) <= issuerTickersLimit {
+		return strings.Join(tickers, ", ")
+	}
+	return fmt.Sprintf("%s +%d", strings.Join(tickers[:issuerTickersLimit], ", "), len(tickers)-issuerTickersLimit)
+}
+
+// formatLimit выводит лимит без лишних нулей: 10%, 7.5%.
+func formatLimit(limit float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", limit), "0"), ".") + "%"
+}
+
+func riskLevelTitle(ctx context.Context, level domain.RiskLevel) string {
+	switch level {
+	case domain.RiskLevelLow:
+		return tr(ctx, textRiskLow)
+	case domain.RiskLevelModerate:
+		return tr(ctx, textRiskModerate)
+	case domain.RiskLevelHigh:
+		return tr(ctx, textRiskHigh)
+	default:
+		return "-"
+	}
+}
+
+func ratingBucketTitle(ctx context.Context, bucket domain.RatingBucket) string {
+	switch bucket {
+	case domain.RatingCCC:
+		return tr(ctx, textRatingCCC)
+	case domain.RatingNone:
+		return tr(ctx, textRatingNone)
+	default:
+		return string(bucket)
+	}
+}
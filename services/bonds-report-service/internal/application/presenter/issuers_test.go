@@ -0,0 +1,90 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"bytes"
+	"context"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConcentration() generalbondreport.IssuerConcentration {
+	return generalbondreport.IssuerConcentration{
+		Limit: 10,
+		Issuers: []generalbondreport.IssuerShare{
+			{
+				Issuer:  domain.BondIssuer{ID: 1, Name: "Минфин России", Sector: "government", RiskLevel: domain.RiskLevelLow, Rating: "AAA(RU)"},
+				Name:    "Минфин России",
+				Tickers: []string{"SU26207RMFS9", "SU26224RMFS4", "SU26238RMFS4", "SU29006RMFS2"},
+				Percent: 14.25,
+			},
+			{
+				Name:    "Самолет БО-П13",
+				Tickers: []string{"RU000A105A95"},
+				Percent: 4,
+			},
+		},
+		Ratings: []generalbondreport.RatingShare{
+			{Bucket: domain.RatingAAA, Percent: 14.25},
+			{Bucket: domain.RatingNone, Percent: 4},
+		},
+	}
+}
+
+func TestIssuerRows(t *testing.T) {
+	rows := issuerRows(context.Background(), newTestConcentration())
+	require.Equal(t, []issuerRow{
+		{
+			name:      "Минфин России",
+			tickers:   "SU26207RMFS9, SU26224RMFS4, SU26238RMFS4 +1",
+			sector:    "government",
+			risk:      "низкий",
+			rating:    "AAA(RU)",
+			percent:   14.25,
+			overLimit: true,
+		},
+		{
+			name:    "Самолет БО-П13",
+			tickers: "RU000A105A95",
+			sector:  "-",
+			risk:    "-",
+			rating:  "-",
+			percent: 4,
+		},
+	}, rows)
+}
+
+func TestIssuerWarnings(t *testing.T) {
+	ctx := context.Background()
+	concentration := newTestConcentration()
+	require.Equal(t, []string{"Эмитент Минфин России - 14.2% портфеля, больше лимита 10%"}, issuerWarnings(ctx, concentration))
+	require.Equal(t, "Концентрация по эмитентам и рейтингам\nЭмитент Минфин России - 14.2% портфеля, больше лимита 10%",
+		IssuerConcentrationCaption(ctx, concentration))
+
+	concentration.Limit = 15.5
+	require.Equal(t, []string{"Every issuer is within the 15.5% limit"}, issuerWarnings(WithLang(ctx, LangEN), concentration))
+	require.Equal(t, "Issuer and rating concentration", IssuerConcentrationCaption(WithLang(ctx, LangEN), concentration))
+
+	concentration.Limit = 0
+	require.Empty(t, issuerWarnings(ctx, concentration), "no limit - no warnings")
+}
+
+func TestGenerateIssuerConcentrationPNG(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, c := range []generalbondreport.IssuerConcentration{newTestConcentration(), {Limit: 10}} {
+		data, err := GenerateIssuerConcentrationPNG(context.Background(), logger, c)
+		require.NoError(t, err)
+		_, err = png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "CCC и ниже", ratingBucketTitle(context.Background(), domain.RatingCCC))
+	require.Equal(t, "not rated", ratingBucketTitle(WithLang(context.Background(), LangEN), domain.RatingNone))
+}
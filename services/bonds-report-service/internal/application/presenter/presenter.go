@@ -89,7 +89,7 @@ func GenerateTablePNG(ctx context.Context, logger *slog.Logger,
 				imageData := dto.NewImageData()
 				imageData.Name = fmt.Sprintf("file%s_%v", typeOfBonds, count)
 				imageData.Data = pngData
-				imageData.Caption = typeOfBonds
+				imageData.Caption = bondsCaption(ctx, typeOfBonds)
 
 				mediaGroup.Reports = append(mediaGroup.Reports, imageData)
 				count += 1
@@ -109,12 +109,12 @@ func generateTablePNGInByte(ctx context.Context,
 	defer logging.LogOperation_Debug(ctx, logger, op, &err)()
 
 	const (
-		width        = 1200 // Увеличим ширину для лучшего отображения
+		width        = 1400 // Увеличим ширину для лучшего отображения
 		heightPerRow = 40
 		margin       = 20.0
 		headerHeight = 60
 		rowHeight    = 30
-		colCount     = 15 // Количество отображаемых колонок
+		colCount     = 17 // Количество отображаемых колонок
 	)
 
 	// Рассчитываем высоту изображения
@@ -150,11 +150,11 @@ func generateTablePNGInByte(ctx context.Context,
 	var name string
 	switch typeOfBonds {
 	case domain.ReplacedBonds:
-		name = "Отчет по замещающим облигациям"
+		name = tr(ctx, textReplacedBondsTitle)
 	case domain.RubBonds:
-		name = "Отчет по рублевым облигациям"
+		name = tr(ctx, textRubBondsTitle)
 	case domain.EuroBonds:
-		name = "Отчет по валютным облигациям"
+		name = tr(ctx, textEuroBondsTitle)
 	}
 	dc.DrawStringAnchored(name, width/2, margin, 0.5, 0.5)
 	dc.SetFontFace(face)
@@ -165,21 +165,23 @@ func generateTablePNGInByte(ctx context.Context,
 		Width float64
 		Flex  float64
 	}{
-		{"Тикер", 120, 0},              // 1
-		{"Название", 180, 2},           // 2
-		{"Валюта", 60, 0},              // 3
-		{"Кол-во", 70, 0},              // 4
-		{"% от портфеля", 90, 0},       // 5
-		{"Дата погашения", 110, 0},     // 6
-		{"Дюрация", 80, 0},             // 7
-		{"Дата покупки", 90, 0},        // 8
-		{"Ср.цена", 80, 0},             // 9
-		{"Дох-ть при покупке", 120, 0}, // 10
-		{"Дох-ть тек.", 90, 0},         // 11 (сокращенный заголовок)
-		{"Тек.цена", 80, 0},            // 12 (сокращенный заголовок)
-		{"Номинал", 80, 0},             // 13
-		{"Доход", 80, 0},               // 14
-		{"Дох-ть в %", 80, 0},          // 15
+		{tr(ctx, textColTicker), 120, 0},            // 1
+		{tr(ctx, textColName), 180, 2},              // 2
+		{tr(ctx, textColCurrency), 60, 0},           // 3
+		{tr(ctx, textColQuantity), 70, 0},           // 4
+		{tr(ctx, textColPercentOfPortfolio), 90, 0}, // 5
+		{tr(ctx, textColMaturityDate), 110, 0},      // 6
+		{tr(ctx, textColDuration), 80, 0},           // 7
+		{tr(ctx, textColBuyDate), 90, 0},            // 8
+		{tr(ctx, textColPositionPrice), 80, 0},      // 9
+		{tr(ctx, textColYieldOnPurchase), 120, 0},   // 10
+		{tr(ctx, textColYield), 90, 0},              // 11 (сокращенный заголовок)
+		{tr(ctx, textColCurrentPrice), 80, 0},       // 12 (сокращенный заголовок)
+		{tr(ctx, textColNominal), 80, 0},            // 13
+		{tr(ctx, textColProfit), 80, 0},             // 14
+		{tr(ctx, textColProfitInPercentage), 80, 0}, // 15
+		{tr(ctx, textColRealYield), 90, 0},          // 16
+		{tr(ctx, textColKeyRateSpread), 90, 0},      // 17
 	}
 
 	// Проверяем, что сумма минимальных ширин не превышает доступную ширину
@@ -261,6 +263,8 @@ func generateTablePNGInByte(ctx context.Context,
 			formatFloat(report.Nominal),
 			formatFloat(report.Profit),
 			formatPercent(report.ProfitInPercentage),
+			formatOptionalPercent(report.RealYield),
+			formatOptionalPercent(report.KeyRateSpread),
 		}
 
 		for i, col := range columns {
@@ -340,6 +344,8 @@ func sortGeneralBondReports(ctx context.Context,
 	return result
 }
 
+// ResponsePortfolioStructure возвращает структуру портфеля в виде таблицы,
+// выровненной под моноширинный шрифт (бот отправляет ее блоком <pre>).
 func ResponsePortfolioStructure(ctx context.Context, logger *slog.Logger, portfolio *domain.PortfolioByTypeAndCurrency, title int, accountName string) string {
 	const op = "service.ResponsePortfolioStructure"
 
@@ -347,16 +353,15 @@ func ResponsePortfolioStructure(ctx context.Context, logger *slog.Logger, portfo
 	var accountTitle string
 	switch title {
 	case unionPortf:
-		accountTitle = "Струтура всех открытых счетов в Тинькофф Инвестициях\n"
+		accountTitle = tr(ctx, textUnionPortfTitle)
 	case unionPortfWithSber:
-		accountTitle = "Струтура всех инвестиций\n"
+		accountTitle = tr(ctx, textUnionPortfWithSberTitle)
 	case eachPortf:
-		accountTitle = fmt.Sprintf("Струтура брокерского счета: %s\n", accountName)
+		accountTitle = fmt.Sprintf(tr(ctx, textEachPortfTitle), accountName)
 	default:
 		accountTitle = ""
 	}
 
-	var output string
 	totalAmount := portfolio.AllAssets
 	totalBonds := portfolio.BondsAssets.SumOfAssets
 	totalShares := portfolio.SharesAssets.SumOfAssets
@@ -365,121 +370,203 @@ func ResponsePortfolioStructure(ctx context.Context, logger *slog.Logger, portfo
 	totalCurrencies := portfolio.CurrenciesAssets.SumOfAssets
 	totalLeverageRatio := (totalBonds + totalShares + totalEtfs + totalFutures) / totalAmount
 
-	totalBondsInPerc := totalBonds / totalAmount * 100
-	totalSharesInPerc := totalShares / totalAmount * 100
-	totalEtfsInPerc := totalEtfs / totalAmount * 100
-	totalFuturesInPerc := totalFutures / totalAmount * 100
-	totalCurrenciesInPerc := totalCurrencies / totalAmount * 100
-	totalLeverageRatioInPers := totalLeverageRatio * 100
-
-	totalAmountOut := fmt.Sprintf("Общая стоимость портфеля составляет: %.2f\n", totalAmount)
-	totalBondsOut := fmt.Sprintf("  Стоимость облигаций: %.2f (%.2f%%от портфеля)\n", totalBonds, totalBondsInPerc)
-	totalSharesOut := fmt.Sprintf("  Стоимость акций: %.2f (%.2f%%от портфеля)\n", totalShares, totalSharesInPerc)
-	totalEtfsOut := fmt.Sprintf("  Стоимость ETF: %.2f (%.2f%%от портфеля)\n", totalEtfs, totalEtfsInPerc)
-	totalFuturesOut := fmt.Sprintf("  Стоимость фьючерсов: %.2f (%.2f%%от портфеля)\n", totalFutures, totalFuturesInPerc)
-	totalCurrenciesOut := fmt.Sprintf("  Стоимость валют: %.2f (%.2f%%от портфеля)\n", totalCurrencies, totalCurrenciesInPerc)
-	totalLeverageRatioOut := fmt.Sprintf("  Общий коэффициент левериджа: %.2f (%.2f%%от портфеля)\n", totalLeverageRatio, totalLeverageRatioInPers)
-
-	var bondsByCurrencies string
-	for k, v := range portfolio.BondsAssets.AssetsByCurrency {
-		bondsByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-		bondsByCurrInPers := utils.RoundFloat(bondsByCurr/totalAmount*100, 2)
-		bondsByCurrencies += "    " + fmt.Sprintf("Стоимость облигаций в %s: %.2f (%.2f%%от портфеля)\n", k, bondsByCurr, bondsByCurrInPers)
+	table := newTextTable(tr(ctx, textColAsset), tr(ctx, textColValue), tr(ctx, textColShare))
+	addAsset := func(name string, value float64) {
+		value = utils.RoundFloat(value, 2)
+		table.addRow(name, formatFloat(value), formatPercent(value/totalAmount*100))
+	}
+	addByCurrency := func(indent string, assets map[string]*domain.AssetByParam) {
+		for _, currency := range sortedKeys(assets) {
+			addAsset(indent+currency, assets[currency].SumOfAssets)
+		}
 	}
 
-	var sharesByCurrencies string
-	for k, v := range portfolio.SharesAssets.AssetsByCurrency {
-		AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-		AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-		sharesByCurrencies += "    " + fmt.Sprintf("Стоимость акций в %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+	addAsset(tr(ctx, textPortfolio), totalAmount)
+	addAsset(" "+tr(ctx, textBonds), totalBonds)
+	addByCurrency("   ", portfolio.BondsAssets.AssetsByCurrency)
+	addAsset(" "+tr(ctx, textShares), totalShares)
+	addByCurrency("   ", portfolio.SharesAssets.AssetsByCurrency)
+	addAsset(" "+tr(ctx, textEtfs), totalEtfs)
+	addByCurrency("   ", portfolio.EtfsAssets.AssetsByCurrency)
+	addAsset(" "+tr(ctx, textFutures), totalFutures)
+
+	futuresByType := []struct {
+		name   string
+		assets domain.FuturesType
+	}{
+		{name: "  " + tr(ctx, textFuturesCommodity), assets: portfolio.FuturesAssets.AssetsByType.Commodity},
+		{name: "  " + tr(ctx, textFuturesCurrency), assets: portfolio.FuturesAssets.AssetsByType.Currency},
+		{name: "  " + tr(ctx, textFuturesSecurity), assets: portfolio.FuturesAssets.AssetsByType.Security},
+		{name: "  " + tr(ctx, textFuturesIndex), assets: portfolio.FuturesAssets.AssetsByType.Index},
+	}
+	for _, futures := range futuresByType {
+		if futures.assets.SumOfAssets == 0 {
+			continue
+		}
+		addAsset(futures.name, futures.assets.SumOfAssets)
+		addByCurrency("    ", futures.assets.AssetsByCurrency)
 	}
 
-	var etfsByCurrencies string
-	for k, v := range portfolio.EtfsAssets.AssetsByCurrency {
-		AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-		AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-		etfsByCurrencies += "    " + fmt.Sprintf("Стоимость Etf в %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+	addAsset(" "+tr(ctx, textCurrencies), totalCurrencies)
+	addByCurrency("   ", portfolio.CurrenciesAssets.AssetsByCurrency)
+	if portfolio.CurrenciesAssets.Blocked > 0 {
+		addAsset("   "+tr(ctx, textCurrenciesBlocked), portfolio.CurrenciesAssets.Blocked)
 	}
+	table.addRow(tr(ctx, textLeverage), formatFloat(totalLeverageRatio), formatPercent(totalLeverageRatio*100))
 
-	var futuresByCurrencies string
+	return accountTitle + table.String()
+}
+
+// ResponseCash возвращает по каждому счету таблицу денежных средств по валютам
+// и маржинальные показатели, выровненные под моноширинный шрифт.
+func ResponseCash(ctx context.Context, logger *slog.Logger, accounts []domain.AccountCash) string {
+	const op = "service.ResponseCash"
 
-	if portfolio.FuturesAssets.AssetsByType.Commodity.SumOfAssets != 0 {
-		futuresWithCommodityBase := portfolio.FuturesAssets.AssetsByType.Commodity.SumOfAssets
-		futuresWithCommodityBaseInPers := futuresWithCommodityBase / totalAmount * 100
+	defer logging.LogOperation_Debug(ctx, logger, op, nil)()
 
-		futuresByCurrencies += "    " + fmt.Sprintf("Фьючерсы на товары стоят: %.2f (%.2f%%от портфеля)\n", futuresWithCommodityBase, futuresWithCommodityBaseInPers)
-		for k, v := range portfolio.FuturesAssets.AssetsByType.Commodity.AssetsByCurrency {
-			AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-			AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-			futuresByCurrencies += "      " + fmt.Sprintf("Стоимость фьючерса %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+	var b strings.Builder
+	for i, account := range accounts {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf(tr(ctx, textCashTitle), account.Account.Name))
+
+		if len(account.Currencies) == 0 {
+			b.WriteString(tr(ctx, textNoCash))
+		} else {
+			table := newTextTable(tr(ctx, textColCurrency), tr(ctx, textColAvailable), tr(ctx, textColBlocked), tr(ctx, textColWithdrawable))
+			for _, cash := range account.Currencies {
+				table.addRow(strings.ToUpper(cash.Currency),
+					formatFloat(utils.RoundFloat(cash.Available, 2)),
+					formatFloat(utils.RoundFloat(cash.Blocked, 2)),
+					formatFloat(utils.RoundFloat(cash.Withdrawable, 2)))
+			}
+			b.WriteString(table.String())
 		}
+
+		if account.Margin == nil {
+			b.WriteString(tr(ctx, textNoMargin))
+			continue
+		}
+		margin := account.Margin
+		table := newTextTable(tr(ctx, textColMarginParam), tr(ctx, textColValue))
+		addMoney := func(key textKey, value domain.MoneyValue) {
+			table.addRow(tr(ctx, key), formatFloat(utils.RoundFloat(value.ToFloat(), 2))+" "+strings.ToUpper(value.Currency))
+		}
+		addMoney(textLiquidPortfolio, margin.LiquidPortfolio)
+		addMoney(textStartingMargin, margin.StartingMargin)
+		addMoney(textMinimalMargin, margin.MinimalMargin)
+		table.addRow(tr(ctx, textFundsSufficiency), formatFloat(margin.FundsSufficiencyLevel.ToFloat()))
+		addMoney(textMissingFunds, margin.AmountOfMissingFunds)
+		b.WriteString(table.String())
 	}
+	return b.String()
+}
 
-	if portfolio.FuturesAssets.AssetsByType.Currency.SumOfAssets != 0 {
-		futuresWithcurrencyBase := portfolio.FuturesAssets.AssetsByType.Currency.SumOfAssets
-		futuresWithcurrencyBaseInPers := futuresWithcurrencyBase / totalAmount * 100
+func ResponseDividends(ctx context.Context, logger *slog.Logger, accounts []domain.AccountDividends) string {
+	const op = "service.ResponseDividends"
 
-		futuresByCurrencies += "    " + fmt.Sprintf("Фьючерсы на валюты стоят: %.2f (%.2f%%от портфеля)\n", futuresWithcurrencyBase, futuresWithcurrencyBaseInPers)
+	defer logging.LogOperation_Debug(ctx, logger, op, nil)()
 
-		for k, v := range portfolio.FuturesAssets.AssetsByType.Currency.AssetsByCurrency {
-			AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-			AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-			futuresByCurrencies += "      " + fmt.Sprintf("Стоимость фьючерса %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+	var b strings.Builder
+	for i, account := range accounts {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf(tr(ctx, textDividendsTitle), account.Account.Name))
+		b.WriteString(dividendsTable(ctx, account.Dividends))
+		if len(account.Reports) != 0 {
+			b.WriteString("\n")
+			b.WriteString(tr(ctx, textReportsTitle))
+			b.WriteString(reportsTable(ctx, account.Reports))
 		}
-
 	}
-	if portfolio.FuturesAssets.AssetsByType.Security.SumOfAssets != 0 {
-		futuresWithcurrencyBase := portfolio.FuturesAssets.AssetsByType.Security.SumOfAssets
-		futuresWithcurrencyBaseInPers := futuresWithcurrencyBase / totalAmount * 100
-
-		futuresByCurrencies += "    " + fmt.Sprintf("Фьючерсы на акции стоят: %.2f (%.2f%%от портфеля)\n", futuresWithcurrencyBase, futuresWithcurrencyBaseInPers)
+	return b.String()
+}
 
-		for k, v := range portfolio.FuturesAssets.AssetsByType.Security.AssetsByCurrency {
-			AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-			AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-			futuresByCurrencies += "      " + fmt.Sprintf("Стоимость фьючерсов в %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+func dividendsTable(ctx context.Context, dividends []domain.UpcomingDividend) string {
+	if len(dividends) == 0 {
+		return tr(ctx, textNoDividends)
+	}
+	table := newTextTable(tr(ctx, textColTicker), tr(ctx, textColName), tr(ctx, textColRecordDate), tr(ctx, textColPaymentDate),
+		tr(ctx, textColQuantity), tr(ctx, textColDividend), tr(ctx, textColDividendGross), tr(ctx, textColAfterTax))
+	grossOnly := false
+	for _, dividend := range dividends {
+		currency := strings.ToUpper(dividend.DividendNet.Currency)
+		var afterTax string
+		if dividend.TaxKnown {
+			afterTax = formatFloat(utils.RoundFloat(dividend.AfterTax, 2)) + " " + currency
+		} else {
+			grossOnly = true
 		}
+		table.addRow(dividend.Ticker,
+			orDash(dividend.Name),
+			formatOptionalTime(dividend.RecordDate),
+			formatOptionalTime(dividend.PaymentDate),
+			formatInt(int64(dividend.Quantity)),
+			formatFloat(dividend.DividendNet.ToFloat())+" "+currency,
+			formatFloat(utils.RoundFloat(dividend.Amount, 2))+" "+currency,
+			orDash(afterTax))
+	}
+	var b strings.Builder
+	b.WriteString(table.String())
+	if grossOnly {
+		b.WriteString(tr(ctx, textForeignDividendTax))
 	}
+	return b.String()
+}
 
-	if portfolio.FuturesAssets.AssetsByType.Index.SumOfAssets != 0 {
-		futuresWithcurrencyBase := portfolio.FuturesAssets.AssetsByType.Index.SumOfAssets
-		futuresWithcurrencyBaseInPers := futuresWithcurrencyBase / totalAmount * 100
+func reportsTable(ctx context.Context, reports []domain.UpcomingReport) string {
+	table := newTextTable(tr(ctx, textColTicker), tr(ctx, textColName), tr(ctx, textColReportDate), tr(ctx, textColReportPeriod))
+	for _, report := range reports {
+		table.addRow(report.Ticker,
+			orDash(report.Name),
+			formatOptionalTime(report.ReportDate),
+			orDash(reportPeriod(ctx, report.AssetReport)))
+	}
+	return table.String()
+}
 
-		futuresByCurrencies += "    " + fmt.Sprintf("Фьючерсы на индексы стоят: %.2f (%.2f%%от портфеля)\n", futuresWithcurrencyBase, futuresWithcurrencyBaseInPers)
-		futuresByCurrencies += "    " + "Фьючерсы на индексы\n"
-		for k, v := range portfolio.FuturesAssets.AssetsByType.Index.AssetsByCurrency {
-			AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-			AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-			futuresByCurrencies += "      " + fmt.Sprintf("Стоимость фьючерса %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
-		}
+// reportPeriod подписывает отчетный период; тип периода приходит из Invest API как PERIOD_TYPE_*.
+func reportPeriod(ctx context.Context, report domain.AssetReport) string {
+	if report.PeriodYear == 0 {
+		return ""
+	}
+	switch report.PeriodType {
+	case "PERIOD_TYPE_QUARTER":
+		return fmt.Sprintf(tr(ctx, textPeriodQuarter), report.PeriodNum, report.PeriodYear)
+	case "PERIOD_TYPE_SEMIANNUAL":
+		return fmt.Sprintf(tr(ctx, textPeriodHalfYear), report.PeriodNum, report.PeriodYear)
+	case "PERIOD_TYPE_ANNUAL":
+		return fmt.Sprintf(tr(ctx, textPeriodYear), report.PeriodYear)
+	default:
+		return fmt.Sprintf("%d", report.PeriodYear)
 	}
-	var currenciesByCurrencies string
-	for k, v := range portfolio.CurrenciesAssets.AssetsByCurrency {
-		AssetByCurr := utils.RoundFloat(v.SumOfAssets, 2)
-		AssetByCurrInPers := utils.RoundFloat(AssetByCurr/totalAmount*100, 2)
-		currenciesByCurrencies += "      " + fmt.Sprintf("Стоимость валюты %s: %.2f (%.2f%%от портфеля)\n", k, AssetByCurr, AssetByCurrInPers)
+}
+
+// bondsCaption - подпись к картинке с таблицей облигаций.
+func bondsCaption(ctx context.Context, typeOfBonds string) string {
+	switch typeOfBonds {
+	case domain.ReplacedBonds:
+		return tr(ctx, textReplacedBondsCaption)
+	case domain.EuroBonds:
+		return tr(ctx, textEuroBondsCaption)
+	default:
+		return tr(ctx, textRubBondsCaption)
 	}
+}
 
-	output += totalAmountOut +
-		totalBondsOut +
-		bondsByCurrencies +
-		totalSharesOut +
-		sharesByCurrencies +
-		totalEtfsOut +
-		etfsByCurrencies +
-		totalFuturesOut +
-		futuresByCurrencies +
-		totalCurrenciesOut +
-		currenciesByCurrencies +
-		totalLeverageRatioOut
-
-	response := accountTitle + output
-
-	return response
+func sortedKeys(assets map[string]*domain.AssetByParam) []string {
+	keys := make([]string, 0, len(assets))
+	for k := range assets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func GetAccount(ctx context.Context, accs map[string]domain.Account) dto.AccountListResponce {
-	var accStr string = "По данному аккаунту доступны следующие счета:"
+	var accStr string = tr(ctx, textAccountsHeader)
 	for _, account := range accs {
 		accStr += fmt.Sprintf("\n ID:%s, Type: %s, Name: %s, Status: %v \n", account.ID, account.Type, account.Name, account.Status)
 	}
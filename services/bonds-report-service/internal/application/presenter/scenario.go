@@ -0,0 +1,240 @@
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"image/color"
+	"log/slog"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+var (
+	scenarioHeaderColor = color.RGBA{200, 200, 200, 255}
+	scenarioGroupColor  = color.RGBA{235, 235, 235, 255}
+	scenarioLossColor   = color.RGBA{200, 40, 40, 255}
+	scenarioGainColor   = color.RGBA{30, 130, 50, 255}
+)
+
+// scenarioRow - строка таблицы сценария: итог по группе или отдельная бумага.
+type scenarioRow struct {
+	group     bool
+	title     string
+	value     float64
+	duration  float64
+	convexity float64
+	parallel  float64
+	keyRate   float64
+}
+
+// GenerateRateScenarioPNG рисует таблицу изменения стоимости облигаций при сдвиге ставок:
+// строка-итог по каждой группе и валюте, под ней бумаги группы.
+func GenerateRateScenarioPNG(ctx context.Context, logger *slog.Logger, scenario domain.RateScenario) (_ []byte, err error) {
+	const op = "presenter.GenerateRateScenarioPNG"
+
+	defer logging.LogOperation_Debug(ctx, logger, op, &err)()
+
+	const (
+		width        = 1200
+		margin       = 20.0
+		titleHeight  = 50.0
+		rowHeight    = 28.0
+		headerHeight = 40.0
+		notesHeight  = 80.0
+	)
+
+	rows, estimated := scenarioRows(ctx, scenario)
+	height := int(titleHeight + headerHeight + float64(max(len(rows), 1))*rowHeight + notesHeight + margin)
+
+	dc := gg.NewContext(width, height)
+
+	font, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, err
+	}
+	face, err := opentype.NewFace(font, &opentype.FaceOptions{
+		Size: 13,
+		DPI:  72,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dc.SetFontFace(face)
+
+	dc.SetColor(color.White)
+	dc.Clear()
+
+	shift := scenarioShift(ctx, scenario)
+	dc.SetColor(color.Black)
+	dc.DrawStringAnchored(fmt.Sprintf(tr(ctx, textScenarioTitle), shift), width/2, titleHeight/2, 0.5, 0.5)
+
+	columns := []struct {
+		title string
+		width float64
+	}{
+		{tr(ctx, textColGroup), 0},
+		{tr(ctx, textColValue), 130},
+		{tr(ctx, textColModDuration), 100},
+		{tr(ctx, textColConvexity), 100},
+		{tr(ctx, textColParallelShift) + " " + shift, 190},
+		{tr(ctx, textColChange), 70},
+		{tr(ctx, textColKeyRate) + " " + shift, 170},
+		{tr(ctx, textColChange), 70},
+	}
+	fixed := 0.0
+	for _, col := range columns[1:] {
+		fixed += col.width
+	}
+	columns[0].width = width - 2*margin - fixed
+
+	y := titleHeight
+	x := margin
+	for _, col := range columns {
+		dc.SetColor(scenarioHeaderColor)
+		dc.DrawRectangle(x, y, col.width, headerHeight)
+		dc.Fill()
+		dc.SetColor(color.Black)
+		dc.DrawStringAnchored(col.title, x+col.width/2, y+headerHeight/2, 0.5, 0.5)
+		x += col.width
+	}
+	y += headerHeight
+
+	if len(rows) == 0 {
+		dc.DrawStringAnchored(tr(ctx, textScenarioNoBonds), width/2, y+rowHeight/2, 0.5, 0.5)
+		y += rowHeight
+	}
+	for _, row := range rows {
+		if row.group {
+			dc.SetColor(scenarioGroupColor)
+			dc.DrawRectangle(margin, y, width-2*margin, rowHeight)
+			dc.Fill()
+		}
+		cells := []struct {
+			text  string
+			color color.Color
+		}{
+			{row.title, color.Black},
+			{formatFloat(row.value), color.Black},
+			{formatFloat(row.duration), color.Black},
+			{formatFloat(row.convexity), color.Black},
+			{formatSignedFloat(row.parallel), changeColor(row.parallel)},
+			{formatChangePercent(row.parallel, row.value), changeColor(row.parallel)},
+			{formatSignedFloat(row.keyRate), changeColor(row.keyRate)},
+			{formatChangePercent(row.keyRate, row.value), changeColor(row.keyRate)},
+		}
+		x = margin
+		for i, cell := range cells {
+			dc.SetColor(cell.color)
+			if i == 0 {
+				dc.DrawStringAnchored(cell.text, x+8, y+rowHeight/2, 0, 0.5)
+			} else {
+				dc.DrawStringAnchored(cell.text, x+columns[i].width/2, y+rowHeight/2, 0.5, 0.5)
+			}
+			x += columns[i].width
+		}
+		y += rowHeight
+	}
+
+	note := tr(ctx, textScenarioNote)
+	if estimated {
+		note += "\n" + tr(ctx, textScenarioEstimated)
+	}
+	dc.SetColor(color.Gray{Y: 80})
+	dc.DrawStringWrapped(note, margin, y+15, 0, 0, width-2*margin, 1.5, gg.AlignLeft)
+
+	return EncodePNGToBuffer(dc)
+}
+
+// RateScenarioCaption - подпись к таблице сценария в Telegram.
+func RateScenarioCaption(ctx context.Context, scenario domain.RateScenario) string {
+	return fmt.Sprintf(tr(ctx, textScenarioCaption), scenarioShift(ctx, scenario))
+}
+
+// scenarioRows раскладывает группы сценария в строки таблицы и сообщает, есть ли
+// бумаги с оценочной дюрацией, чтобы добавить сноску.
+func scenarioRows(ctx context.Context, scenario domain.RateScenario) (_ []scenarioRow, estimated bool) {
+	shift := scenario.Shift()
+	var rows []scenarioRow
+	for _, group := range scenario.Groups() {
+		rows = append(rows, scenarioRow{
+			group:     true,
+			title:     scenarioBucketTitle(ctx, group.Bucket) + ", " + strings.ToUpper(group.Currency),
+			value:     group.Value,
+			duration:  group.Duration,
+			convexity: group.Convexity,
+			parallel:  group.Parallel,
+			keyRate:   group.KeyRate,
+		})
+		for _, position := range group.Positions {
+			title := "   " + strings.TrimSpace(position.Ticker+" "+position.Name)
+			if position.Floater {
+				title += " (" + tr(ctx, textCouponFloat) + ")"
+			}
+			if position.Estimated {
+				title += " *"
+				estimated = true
+			}
+			rows = append(rows, scenarioRow{
+				title:     title,
+				value:     position.Value,
+				duration:  position.Parallel.Duration,
+				convexity: position.Parallel.Convexity,
+				parallel:  position.Parallel.Change(position.Value, shift),
+				keyRate:   position.KeyRate.Change(position.Value, shift),
+			})
+		}
+	}
+	return rows, estimated
+}
+
+func scenarioBucketTitle(ctx context.Context, bucket domain.ScenarioBucket) string {
+	switch bucket {
+	case domain.ScenarioRUB:
+		return tr(ctx, textScenarioRUB)
+	case domain.ScenarioCNY:
+		return tr(ctx, textScenarioCNY)
+	case domain.ScenarioReplaced:
+		return tr(ctx, textScenarioReplaced)
+	default:
+		return tr(ctx, textScenarioEuro)
+	}
+}
+
+func scenarioShift(ctx context.Context, scenario domain.RateScenario) string {
+	return fmt.Sprintf(tr(ctx, textScenarioShift), scenario.ShiftBP)
+}
+
+// formatSignedFloat выводит знак изменения, а нулевое изменение - без знака.
+func formatSignedFloat(value float64) string {
+	if value == 0 {
+		return formatFloat(0)
+	}
+	return fmt.Sprintf("%+.2f", value)
+}
+
+// formatChangePercent - изменение в процентах от стоимости, без стоимости - прочерк.
+func formatChangePercent(change, value float64) string {
+	if value == 0 {
+		return "-"
+	}
+	if change == 0 {
+		return formatPercent(0)
+	}
+	return fmt.Sprintf("%+.2f%%", change/value*100)
+}
+
+func changeColor(value float64) color.Color {
+	switch {
+	case value < 0:
+		return scenarioLossColor
+	case value > 0:
+		return scenarioGainColor
+	default:
+		return color.Black
+	}
+}
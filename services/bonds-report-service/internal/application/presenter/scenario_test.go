@@ -0,0 +1,70 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bytes"
+	"context"
+	"image/png"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioRows(t *testing.T) {
+	fixed := domain.RateSensitivity{Duration: 8, Convexity: 100}
+	scenario := domain.RateScenario{ShiftBP: 100, Positions: []domain.ScenarioPosition{
+		{Ticker: "XS0191754729", Bucket: domain.ScenarioReplaced, Currency: "usd", Value: 1000,
+			Parallel: domain.RateSensitivity{Duration: 3}},
+		{Ticker: "SU29006RMFS2", Bucket: domain.ScenarioRUB, Currency: "rub", Value: 1000, Floater: true,
+			Parallel: domain.RateSensitivity{Duration: 2}, KeyRate: domain.RateSensitivity{Duration: 0.1}},
+		{Ticker: "SU26238RMFS4", Bucket: domain.ScenarioRUB, Currency: "rub", Value: 3000,
+			Parallel: fixed, KeyRate: fixed},
+		{Ticker: "RU000A105A95", Bucket: domain.ScenarioCNY, Currency: "cny", Value: 500, Estimated: true,
+			Parallel: domain.RateSensitivity{Duration: 1}},
+	}}
+
+	rows, estimated := scenarioRows(context.Background(), scenario)
+	require.True(t, estimated)
+	require.Len(t, rows, 7)
+
+	rub := rows[0]
+	require.True(t, rub.group)
+	require.Equal(t, "Рублевые, RUB", rub.title)
+	require.Equal(t, 4000.0, rub.value)
+	require.InDelta(t, 6.5, rub.duration, 1e-9, "value-weighted")
+	// -8% + 100 * 0.0001 / 2 = -7.5% от 3000 и -2% от 1000
+	require.InDelta(t, -225-20, rub.parallel, 1e-9)
+	require.InDelta(t, -225-1, rub.keyRate, 1e-9)
+
+	require.Equal(t, "   SU26238RMFS4", rows[1].title, "bonds go by value")
+	require.Equal(t, "   SU29006RMFS2 (флоатер)", rows[2].title)
+	require.Equal(t, "Юаневые, CNY", rows[3].title)
+	require.Equal(t, "   RU000A105A95 *", rows[4].title)
+	require.Zero(t, rows[4].keyRate)
+	require.Equal(t, "Замещающие, USD", rows[5].title)
+}
+
+func TestGenerateRateScenarioPNG(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	scenario := domain.RateScenario{ShiftBP: -50, Positions: []domain.ScenarioPosition{
+		{Ticker: "SU26238RMFS4", Bucket: domain.ScenarioRUB, Currency: "rub", Value: 3000,
+			Parallel: domain.RateSensitivity{Duration: 8, Convexity: 100}},
+	}}
+
+	for _, s := range []domain.RateScenario{scenario, {ShiftBP: 100}} {
+		data, err := GenerateRateScenarioPNG(context.Background(), logger, s)
+		require.NoError(t, err)
+		_, err = png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "Облигации при изменении ставок на -50 б.п.", RateScenarioCaption(context.Background(), scenario))
+	require.Equal(t, "Bonds under a -50 bp rate move", RateScenarioCaption(WithLang(context.Background(), LangEN), scenario))
+	require.Equal(t, "+1.50", formatSignedFloat(1.5))
+	require.Equal(t, "0.00", formatSignedFloat(0))
+	require.Equal(t, "-", formatChangePercent(1, 0))
+}
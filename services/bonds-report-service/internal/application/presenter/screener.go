@@ -0,0 +1,79 @@
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// ResponseBondScreener выводит результат скринера таблицей для моноширинного шрифта.
+func ResponseBondScreener(ctx context.Context, logger *slog.Logger, screener domain.BondScreener) string {
+	const op = "service.ResponseBondScreener"
+
+	defer logging.LogOperation_Debug(ctx, logger, op, nil)()
+
+	if len(screener.Rows) == 0 {
+		return tr(ctx, textNoScreenerBonds)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(tr(ctx, textScreenerTitle), screener.Matched, len(screener.Rows)))
+	table := newTextTable(tr(ctx, textColTicker), tr(ctx, textColName), tr(ctx, textColYield),
+		tr(ctx, textColDuration), tr(ctx, textColMaturityDate), tr(ctx, textColCoupon),
+		tr(ctx, textColCurrency), tr(ctx, textColLevel), tr(ctx, textColVsHoldings))
+	for _, row := range screener.Rows {
+		bond := row.Bond
+		maturity := "-"
+		if date, ok := bond.RedemptionDate(); ok {
+			maturity = formatTime(date)
+		}
+		table.addRow(bond.SecID,
+			bond.ShortName,
+			formatPercent(bond.Yield.Value),
+			formatScreenerDuration(bond.Duration),
+			maturity,
+			formatCoupon(ctx, bond),
+			strings.ToUpper(bond.Currency()),
+			strconv.Itoa(bond.ListLevel),
+			formatVsHoldings(ctx, row))
+	}
+	b.WriteString(table.String())
+	b.WriteString(tr(ctx, textScreenerNote))
+	return b.String()
+}
+
+// formatScreenerDuration переводит дюрацию MOEX из дней в годы.
+func formatScreenerDuration(duration domain.NullFloat64) string {
+	if !duration.IsHasValue() {
+		return "-"
+	}
+	return formatFloat(duration.Value / 365)
+}
+
+func formatCoupon(ctx context.Context, bond domain.MoexBond) string {
+	switch bond.CouponType() {
+	case domain.CouponZero:
+		return tr(ctx, textCouponZero)
+	case domain.CouponFloat:
+		return tr(ctx, textCouponFloat)
+	default:
+		return tr(ctx, textCouponFixed) + " " + formatOptionalPercent(bond.CouponPercent)
+	}
+}
+
+// formatVsHoldings - колонка сравнения с портфелем: купленные бумаги отмечаются
+// количеством, для остальных выводится разница доходности со средней по портфелю.
+func formatVsHoldings(ctx context.Context, row domain.ScreenerRow) string {
+	switch {
+	case row.Held > 0:
+		return fmt.Sprintf(tr(ctx, textScreenerHeld), row.Held)
+	case row.VsHeld.IsHasValue():
+		return fmt.Sprintf("%+.2f", row.VsHeld.Value)
+	default:
+		return "-"
+	}
+}
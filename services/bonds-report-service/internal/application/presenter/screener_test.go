@@ -0,0 +1,49 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseBondScreener(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	bond := domain.MoexBond{
+		SecID:         "SU26238RMFS4",
+		ShortName:     "ОФЗ 26238",
+		FaceUnit:      "SUR",
+		ListLevel:     1,
+		MaturityDate:  domain.NewNullString("2041-05-15", true, false),
+		CouponPercent: domain.NewNullFloat64(7.1, true, false),
+		CouponValue:   domain.NewNullFloat64(35.4, true, false),
+		Yield:         domain.NewNullFloat64(14.55, true, false),
+		Duration:      domain.NewNullFloat64(3650, true, false),
+	}
+	screener := domain.BondScreener{
+		Matched: 12,
+		Rows: []domain.ScreenerRow{
+			{Bond: bond, VsHeld: domain.NewNullFloat64(-1.5, true, false)},
+			{Bond: bond, Held: 7},
+		},
+	}
+
+	got := ResponseBondScreener(context.Background(), logger, screener)
+	lines := strings.Split(got, "\n")
+	require.Equal(t, "Облигации MOEX по фильтру: подошло 12, показано 2", lines[0])
+	require.Contains(t, lines[3], "10.00")
+	require.Contains(t, lines[3], "2041-05-15")
+	require.Contains(t, lines[3], "фикс 7.10%")
+	require.Contains(t, lines[3], "RUB")
+	require.True(t, strings.HasSuffix(lines[3], "-1.50"))
+	require.True(t, strings.HasSuffix(lines[4], "есть 7 шт."))
+
+	got = ResponseBondScreener(WithLang(context.Background(), LangEN), logger, domain.BondScreener{})
+	require.Equal(t, "No bonds match the filter\n", got)
+}
@@ -0,0 +1,41 @@
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ResponseMoexSecurities выводит справочные данные MOEX по акциям, фондам и валютам счета.
+// Без данных MOEX возвращает пустую строку, и отчет остается без этого раздела.
+func ResponseMoexSecurities(ctx context.Context, securities []domain.MoexSecurity) string {
+	if len(securities) == 0 {
+		return ""
+	}
+	table := newTextTable(tr(ctx, textColTicker), tr(ctx, textColName), tr(ctx, textColLot),
+		tr(ctx, textColSector), tr(ctx, textColLastPrice))
+	for _, security := range securities {
+		table.addRow(security.SecID,
+			security.ShortName,
+			strconv.Itoa(security.LotSize),
+			orDash(security.Sector),
+			formatMoexPrice(security))
+	}
+	return tr(ctx, textMoexSecuritiesTitle) + table.String()
+}
+
+// formatMoexPrice выводит цену без округления: у валют и дешевых акций значимы 4 знака и больше.
+func formatMoexPrice(security domain.MoexSecurity) string {
+	if !security.Price.IsHasValue() {
+		return "-"
+	}
+	return strconv.FormatFloat(security.Price.Value, 'f', -1, 64) + " " + strings.ToUpper(domain.NormalizeCurrency(security.Currency))
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
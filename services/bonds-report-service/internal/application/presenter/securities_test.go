@@ -0,0 +1,32 @@
+//go:build unit
+
+package presenter
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseMoexSecurities(t *testing.T) {
+	securities := []domain.MoexSecurity{
+		{SecID: "SBER", ShortName: "Сбербанк", LotSize: 10, Sector: "FNL", Currency: "SUR",
+			Price: domain.NewNullFloat64(307.55, true, false)},
+		{SecID: "CNYRUB_TOM", ShortName: "CNYRUB_TOM", LotSize: 1, Currency: "RUB",
+			Price: domain.NewNullFloat64(11.2345, true, false)},
+		{SecID: "TMOS", ShortName: "TMOS", LotSize: 1},
+	}
+
+	got := ResponseMoexSecurities(context.Background(), securities)
+	lines := strings.Split(got, "\n")
+	require.Equal(t, "Акции, фонды и валюты по данным MOEX", lines[1])
+	require.Contains(t, lines[4], "FNL")
+	require.True(t, strings.HasSuffix(lines[4], "307.55 RUB"))
+	require.True(t, strings.HasSuffix(lines[5], "11.2345 RUB"))
+	require.True(t, strings.HasSuffix(lines[6], "-"))
+
+	require.Empty(t, ResponseMoexSecurities(context.Background(), nil))
+}
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// textTable - таблица для моноширинного шрифта. Первая колонка выравнивается
+// по левому краю, остальные (числовые) - по правому.
+type textTable struct {
+	header []string
+	rows   [][]string
+}
+
+func newTextTable(header ...string) *textTable {
+	return &textTable{header: header}
+}
+
+func (t *textTable) addRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+func (t *textTable) String() string {
+	widths := make([]int, len(t.header))
+	for _, row := range append([][]string{t.header}, t.rows...) {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	var b strings.Builder
+	t.writeRow(&b, t.header, widths)
+	total := len(widths) - 1
+	for _, w := range widths {
+		total += w
+	}
+	b.WriteString(strings.Repeat("-", total))
+	b.WriteString("\n")
+	for _, row := range t.rows {
+		t.writeRow(&b, row, widths)
+	}
+	return b.String()
+}
+
+func (t *textTable) writeRow(b *strings.Builder, row []string, widths []int) {
+	for i, w := range widths {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		pad := strings.Repeat(" ", w-utf8.RuneCountInString(cell))
+		if i != 0 {
+			b.WriteString(" ")
+		}
+		switch {
+		case i == 0 && i == len(widths)-1:
+			b.WriteString(cell)
+		case i == 0:
+			b.WriteString(cell + pad)
+		default:
+			b.WriteString(pad + cell)
+		}
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,22 @@
+//go:build unit
+
+package presenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextTable(t *testing.T) {
+	table := newTextTable("Актив", "Стоимость", "Доля")
+	table.addRow("Портфель", "1000.00", "100.00%")
+	table.addRow("  RUB", "50.00", "5.00%")
+
+	want := "" +
+		"Актив    Стоимость    Доля\n" +
+		"--------------------------\n" +
+		"Портфель   1000.00 100.00%\n" +
+		"  RUB        50.00   5.00%\n"
+	require.Equal(t, want, table.String())
+}
@@ -155,9 +155,11 @@ func NewFuture() domain.Future {
 
 func NewBond() domain.Bond {
 	return domain.Bond{
-		AciValue: domain.NewMoneyValue("RUB", 15, 50_000_000), // 15.05
-		Currency: "RUB",
-		Nominal:  domain.NewMoneyValue("RUB", 1000, 0),
+		AciValue:  domain.NewMoneyValue("RUB", 15, 50_000_000), // 15.05
+		Currency:  "RUB",
+		Nominal:   domain.NewMoneyValue("RUB", 1000, 0),
+		Sector:    "financial",
+		RiskLevel: domain.RiskLevelLow,
 	}
 }
 
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+)
+
+// issuerCache хранит эмитентов по тикеру до перезапуска сервиса: эмитент, сектор и уровень
+// риска выпуска не меняются. Рейтинг в кэш не попадает, он берется из файла при каждом отчете.
+type issuerCache struct {
+	mu      sync.RWMutex
+	issuers map[string]domain.BondIssuer
+}
+
+func newIssuerCache() *issuerCache {
+	return &issuerCache{issuers: make(map[string]domain.BondIssuer)}
+}
+
+func (c *issuerCache) get(ticker string) (domain.BondIssuer, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	issuer, ok := c.issuers[ticker]
+	return issuer, ok
+}
+
+func (c *issuerCache) set(ticker string, issuer domain.BondIssuer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.issuers[ticker] = issuer
+}
+
+// bondIssuers собирает эмитентов облигаций по тикерам: название, ИНН и код эмитента
+// из MOEX, сектор и уровень риска из Т-Инвестиций, рейтинг из файла пользователя.
+// tickers - тикеры облигаций по instrument uid. В источники идут только тикеры, которых нет в кэше.
+// Эмитенты дополняют отчет, поэтому ошибки источников только пишутся в лог.
+func (s *Service) bondIssuers(ctx context.Context, tickers map[string]string) map[string]domain.BondIssuer {
+	const op = "service.bondIssuers"
+
+	res := make(map[string]domain.BondIssuer, len(tickers))
+	missing := make(map[string]string)
+	for uid, ticker := range tickers {
+		if issuer, ok := s.issuers.get(ticker); ok {
+			res[ticker] = issuer
+			continue
+		}
+		missing[uid] = ticker
+	}
+	if len(missing) != 0 {
+		uids := make([]string, 0, len(missing))
+		secIDs := make([]string, 0, len(missing))
+		for uid, ticker := range missing {
+			uids = append(uids, uid)
+			secIDs = append(secIDs, ticker)
+		}
+		sort.Strings(uids)
+		sort.Strings(secIDs)
+
+		bonds, err := s.Helpers.TinkoffHelper.TinkoffGetBondsByUids(ctx, uids)
+		if err != nil {
+			s.logger.WarnContext(ctx, "could not get bonds from tinkoff", slog.String("op", op), slog.Any("error", err))
+		}
+		moexIssuers, err := s.External.Moex.GetIssuers(ctx, secIDs)
+		if err != nil {
+			s.logger.WarnContext(ctx, "could not get issuers from moex", slog.String("op", op), slog.Any("error", err))
+		}
+		for uid, ticker := range missing {
+			issuer := newBondIssuer(bonds[uid], moexIssuers[ticker])
+			res[ticker] = issuer
+			// Неполные данные не кэшируются, чтобы при следующем отчете запросить их снова.
+			if _, ok := bonds[uid]; ok && issuer.Key() != "" {
+				s.issuers.set(ticker, issuer)
+			}
+		}
+	}
+	if len(res) == 0 {
+		return res
+	}
+
+	ratings, err := s.External.Ratings.GetRatings(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get ratings", slog.String("op", op), slog.Any("error", err))
+	}
+	for ticker, issuer := range res {
+		issuer.Rating = ratings.For(ticker, issuer)
+		res[ticker] = issuer
+	}
+	return res
+}
+
+// newBondIssuer сводит данные эмитента из MOEX и выпуска из Т-Инвестиций.
+func newBondIssuer(bond domain.Bond, moexIssuer domain.MoexIssuer) domain.BondIssuer {
+	return domain.BondIssuer{
+		ID:        moexIssuer.IssuerID,
+		Name:      moexIssuer.Name,
+		INN:       moexIssuer.INN,
+		Sector:    bond.Sector,
+		RiskLevel: bond.RiskLevel,
+	}
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/application/ports/mocks"
+	"bonds-report-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBondIssuers(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	instruments := s.Helpers.TinkoffHelper.Instruments.(*mocks.TinkoffInstrumentsClient)
+	moex := s.External.Moex.(*mocks.MoexClient)
+	ratings := s.External.Ratings.(*mocks.RatingSource)
+
+	tickers := map[string]string{
+		"uid-ofz":    "SU26238RMFS4",
+		"uid-sber":   "RU000A105A95",
+		"uid-nodata": "RU000A0JX0J2",
+	}
+	instruments.On("GetBondsByUids", mock.Anything, []string{"uid-nodata", "uid-ofz", "uid-sber"}).
+		Return(map[string]domain.Bond{
+			"uid-ofz":  {Sector: "government", RiskLevel: domain.RiskLevelLow},
+			"uid-sber": {Sector: "financial", RiskLevel: domain.RiskLevelModerate},
+		}, nil).Once()
+	moex.On("GetIssuers", mock.Anything, []string{"RU000A0JX0J2", "RU000A105A95", "SU26238RMFS4"}).
+		Return(map[string]domain.MoexIssuer{
+			"SU26238RMFS4": {SecID: "SU26238RMFS4", IssuerID: 1, Name: "Минфин России", INN: "7710168360"},
+			"RU000A105A95": {SecID: "RU000A105A95", IssuerID: 2, Name: "ПАО Сбербанк", INN: "7707083893"},
+		}, nil).Once()
+	ratings.On("GetRatings", mock.Anything).
+		Return(domain.Ratings{
+			"7710168360":   "AAA(RU)",
+			"7707083893":   "AAA(RU)",
+			"RU000A105A95": "AA+(RU)",
+		}, nil).Twice()
+
+	want := map[string]domain.BondIssuer{
+		"SU26238RMFS4": {ID: 1, Name: "Минфин России", INN: "7710168360", Sector: "government",
+			RiskLevel: domain.RiskLevelLow, Rating: "AAA(RU)"},
+		"RU000A105A95": {ID: 2, Name: "ПАО Сбербанк", INN: "7707083893", Sector: "financial",
+			RiskLevel: domain.RiskLevelModerate, Rating: "AA+(RU)"},
+		"RU000A0JX0J2": {},
+	}
+	require.Equal(t, want, s.bondIssuers(ctx, tickers))
+
+	// Найденные эмитенты берутся из кэша, в источники идет только выпуск без данных.
+	instruments.On("GetBondsByUids", mock.Anything, []string{"uid-nodata"}).
+		Return(nil, errors.New("tinkoff is down")).Once()
+	moex.On("GetIssuers", mock.Anything, []string{"RU000A0JX0J2"}).
+		Return(nil, errors.New("moex is down")).Once()
+	require.Equal(t, want, s.bondIssuers(ctx, tickers))
+
+	require.Empty(t, s.bondIssuers(ctx, nil))
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
+	"time"
+)
+
+// bulletSchedule строит по данным MOEX график с постоянным купоном до погашения, начиная
+// с купона, который идет первым после settlement. MOEX отдает только ближайший купон, поэтому
+// прошедшие купоны восстанавливаются шагом назад на длительность купона: для флоатеров
+// и бумаг со сменой купона такой график неточен.
+func bulletSchedule(bond domain.MoexBond, settlement time.Time) (bondmath.Schedule, bool) {
+	if !bond.CouponPeriod.IsHasValue() || bond.CouponPeriod.Value <= 0 || !bond.FaceValue.IsHasValue() {
+		return bondmath.Schedule{}, false
+	}
+	next, ok := parseMoexDate(bond.NextCoupon)
+	if !ok {
+		return bondmath.Schedule{}, false
+	}
+	maturity, ok := parseMoexDate(bond.MaturityDate)
+	if !ok {
+		return bondmath.Schedule{}, false
+	}
+	period := int(bond.CouponPeriod.Value)
+	for prev := next.AddDate(0, 0, -period); prev.After(settlement); prev = prev.AddDate(0, 0, -period) {
+		next = prev
+	}
+	schedule, err := bondmath.NewBulletSchedule(next, maturity, period, bond.CouponValue.Value, bond.FaceValue.Value)
+	if err != nil {
+		return bondmath.Schedule{}, false
+	}
+	return schedule, true
+}
@@ -0,0 +1,44 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulletSchedule(t *testing.T) {
+	bond := domain.MoexBond{
+		MaturityDate: domain.NewNullString("2029-05-30", true, false),
+		NextCoupon:   domain.NewNullString("2026-06-03", true, false),
+		CouponValue:  domain.NewNullFloat64(45.38, true, false),
+		CouponPeriod: domain.NewNullFloat64(182, true, false),
+		FaceValue:    domain.NewNullFloat64(1000, true, false),
+	}
+
+	t.Run("restores coupons after past settlement", func(t *testing.T) {
+		settlement := time.Date(2025, 3, 10, 11, 30, 0, 0, time.UTC)
+		schedule, ok := bulletSchedule(bond, settlement)
+		require.True(t, ok)
+		first := schedule.Flows[0].Date
+		require.True(t, first.After(settlement))
+		require.False(t, first.AddDate(0, 0, -182).After(settlement))
+		require.Equal(t, time.Date(2029, 5, 30, 0, 0, 0, 0, time.UTC), schedule.Flows[len(schedule.Flows)-1].Date)
+	})
+
+	t.Run("starts from next coupon for current date", func(t *testing.T) {
+		schedule, ok := bulletSchedule(bond, time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC))
+		require.True(t, ok)
+		require.Equal(t, time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), schedule.Flows[0].Date)
+	})
+
+	t.Run("no coupon period", func(t *testing.T) {
+		noPeriod := bond
+		noPeriod.CouponPeriod = domain.NewNullFloat64(0, true, true)
+		_, ok := bulletSchedule(noPeriod, time.Now())
+		require.False(t, ok)
+	})
+}
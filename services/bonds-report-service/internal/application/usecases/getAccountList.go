@@ -5,6 +5,7 @@ import (
 	"bonds-report-service/internal/application/presenter"
 	"bonds-report-service/internal/utils/logging"
 	"context"
+	"sort"
 
 	"github.com/gladinov/e"
 )
@@ -20,5 +21,13 @@ func (s *Service) GetAccountsList(ctx context.Context) (answ dto.AccountListResp
 	}
 
 	accountResponce := presenter.GetAccount(ctx, accs)
+	for _, account := range accs {
+		if isActiveAccounts(account) {
+			accountResponce.List = append(accountResponce.List, dto.Account{ID: account.ID, Name: account.Name})
+		}
+	}
+	sort.Slice(accountResponce.List, func(i, j int) bool {
+		return accountResponce.List[i].Name < accountResponce.List[j].Name
+	})
 	return accountResponce, nil
 }
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/application/dto"
+	"bonds-report-service/internal/application/presenter"
+	"bonds-report-service/internal/domain"
+	report_position "bonds-report-service/internal/domain/report_position"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+const (
+	// chartDefaultPeriod - период графика, если пользователь его не указал.
+	chartDefaultPeriod = "1y"
+	// chartBuyMarginMonths - сколько месяцев до первой покупки показывать без явного периода.
+	chartBuyMarginMonths = 1
+)
+
+var chartPeriods = map[string]struct{ years, months int }{
+	"1m": {months: 1},
+	"3m": {months: 3},
+	"6m": {months: 6},
+	"1y": {years: 1},
+	"3y": {years: 3},
+	"5y": {years: 5},
+}
+
+// ChartPeriodStart возвращает начало периода графика: 1m, 3m, 6m, 1y, 3y или 5y.
+func ChartPeriodStart(period string, now time.Time) (time.Time, error) {
+	p, ok := chartPeriods[strings.ToLower(period)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q", domain.ErrInvalidChartPeriod, period)
+	}
+	return now.AddDate(-p.years, -p.months, 0), nil
+}
+
+// GetBondChart строит PNG с ценой и доходностью облигации по дневным итогам MOEX
+// и отмечает на нем покупки из открытых позиций всех счетов. Без периода график
+// начинается за год до сегодня или за месяц до первой покупки, если она раньше.
+func (s *Service) GetBondChart(ctx context.Context, chatID int, ticker, period string) (_ dto.ImageData, err error) {
+	const op = "service.GetBondChart"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if ticker == "" {
+		return dto.ImageData{}, domain.ErrEmptyTicker
+	}
+
+	now := s.now()
+	auto := period == ""
+	if auto {
+		period = chartDefaultPeriod
+	}
+	from, err := ChartPeriodStart(period, now)
+	if err != nil {
+		return dto.ImageData{}, err
+	}
+
+	positions, err := s.openBondPositions(ctx, chatID, ticker)
+	if err != nil {
+		return dto.ImageData{}, e.WrapIfErr("failed to get open positions", err)
+	}
+	buyPoints := chartBuyPoints(positions)
+	if auto && len(buyPoints) > 0 {
+		if start := buyPoints[0].Date.AddDate(0, -chartBuyMarginMonths, 0); start.Before(from) {
+			from = start
+		}
+	}
+
+	history, err := s.External.Moex.GetHistory(ctx, ticker, from, now)
+	if err != nil {
+		return dto.ImageData{}, e.WrapIfErr("failed to get history from moex", err)
+	}
+
+	chart := domain.BondChart{
+		Ticker:    ticker,
+		Name:      chartName(history, ticker),
+		From:      from,
+		To:        now,
+		History:   history.Data,
+		BuyPoints: buyPointsSince(buyPoints, from),
+	}
+	pngData, err := presenter.GenerateBondChartPNG(ctx, s.logger, chart)
+	if err != nil {
+		return dto.ImageData{}, e.WrapIfErr("failed to generate chart", err)
+	}
+
+	imageData := dto.NewImageData()
+	imageData.Name = "chart_" + ticker
+	imageData.Data = pngData
+	imageData.Caption = presenter.BondChartCaption(ctx, chart)
+	return *imageData, nil
+}
+
+// openBondPositions собирает открытые FIFO-позиции по тикеру со всех активных счетов.
+func (s *Service) openBondPositions(ctx context.Context, chatID int, ticker string) ([]report_position.PositionByFIFO, error) {
+	accounts, err := s.Helpers.TinkoffHelper.TinkoffGetAccounts(ctx)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to get accounts from Tinkoff", err)
+	}
+
+	var res []report_position.PositionByFIFO
+	for _, account := range sortedAccounts(accounts) {
+		if !isActiveAccounts(account) {
+			continue
+		}
+		err = s.Helpers.OperationsUpdater.UpdateOperations(ctx, chatID, account.ID, account.OpenedDate)
+		if err != nil {
+			return nil, e.WrapIfErr("failed to update operations", err)
+		}
+		portfolio, err := s.Helpers.TinkoffHelper.TinkoffGetPortfolio(ctx, account)
+		if err != nil {
+			return nil, e.WrapIfErr("failed to get portfolio from tinkoff", err)
+		}
+		portfolioPositions, err := s.Helpers.PositionProcessor.ProcessPositionsToPositionsWithAssetUid(ctx, portfolio.Positions)
+		if err != nil {
+			return nil, e.WrapIfErr("failed to process position to postition with asset uid", err)
+		}
+		allOperations, err := s.Storage.GetAllOperations(ctx, chatID, account.ID)
+		if err != nil {
+			return nil, e.WrapIfErr("failed to get all operations from storage", err)
+		}
+		reportLines, err := s.Helpers.ReportLineBuilder.CreateNewReportLinesBatch(ctx,
+			filterBondPositions(portfolioPositions),
+			mapOperationsWithoutCustomTypesToMapByAssetUid(allOperations))
+		if err != nil {
+			return nil, e.WrapIfErr("failed to create new report lines", err)
+		}
+
+		for _, reportLine := range reportLines {
+			if reportLine == nil || !strings.EqualFold(reportLine.Bond.Ticker, ticker) {
+				continue
+			}
+			reportPositions, err := s.Helpers.ReportProcessor.ProcessOperations(ctx, reportLine)
+			if err != nil {
+				return nil, e.WrapIfErr("failed to process operation", err)
+			}
+			res = append(res, reportPositions.CurrentPositions...)
+		}
+	}
+	return res, nil
+}
+
+// chartBuyPoints переводит цену покупки в проценты от номинала, чтобы точки легли на цену MOEX.
+// Позиции без номинала пропускаются. Точки сортируются по дате покупки.
+func chartBuyPoints(positions []report_position.PositionByFIFO) []domain.ChartBuyPoint {
+	res := make([]domain.ChartBuyPoint, 0, len(positions))
+	for _, position := range positions {
+		if position.Nominal <= 0 || position.BuyDate.IsZero() {
+			continue
+		}
+		res = append(res, domain.ChartBuyPoint{
+			Date:     position.BuyDate,
+			Price:    position.BuyPrice / position.Nominal * 100,
+			Quantity: position.Quantity,
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Date.Before(res[j].Date)
+	})
+	return res
+}
+
+func buyPointsSince(points []domain.ChartBuyPoint, from time.Time) []domain.ChartBuyPoint {
+	res := make([]domain.ChartBuyPoint, 0, len(points))
+	for _, point := range points {
+		if !point.Date.Before(from) {
+			res = append(res, point)
+		}
+	}
+	return res
+}
+
+// chartName - короткое название бумаги из последнего дня истории, а без него - тикер.
+func chartName(history domain.MoexHistory, ticker string) string {
+	for i := len(history.Data) - 1; i >= 0; i-- {
+		if name := history.Data[i].ShortName; name.IsSet && !name.IsNull && name.Value != "" {
+			return name.Value
+		}
+	}
+	return ticker
+}
@@ -0,0 +1,57 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	report_position "bonds-report-service/internal/domain/report_position"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartPeriodStart(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	from, err := ChartPeriodStart("1Y", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC), from)
+
+	from, err = ChartPeriodStart("6m", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC), from, "AddDate normalizes 31 September")
+
+	_, err = ChartPeriodStart("2w", now)
+	require.ErrorIs(t, err, domain.ErrInvalidChartPeriod)
+}
+
+func TestChartBuyPoints(t *testing.T) {
+	positions := []report_position.PositionByFIFO{
+		{BuyDate: time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), BuyPrice: 985.5, Nominal: 1000, Quantity: 3},
+		{BuyDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), BuyPrice: 480, Nominal: 500, Quantity: 10},
+		// без номинала цену не перевести в проценты
+		{BuyDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), BuyPrice: 100},
+	}
+
+	got := chartBuyPoints(positions)
+	require.Len(t, got, 2)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), got[0].Date)
+	assert.InDelta(t, 96.0, got[0].Price, 1e-9)
+	assert.Equal(t, 10.0, got[0].Quantity)
+	assert.InDelta(t, 98.55, got[1].Price, 1e-9)
+
+	since := buyPointsSince(got, time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC))
+	require.Len(t, since, 1)
+	assert.Equal(t, 3.0, since[0].Quantity)
+}
+
+func TestChartName(t *testing.T) {
+	history := domain.MoexHistory{Data: []domain.ValuesMoex{
+		{ShortName: domain.NewNullString("ОФЗ 26238", true, false)},
+		{ShortName: domain.NewNullString("", true, true)},
+	}}
+	assert.Equal(t, "ОФЗ 26238", chartName(history, "SU26238RMFS4"))
+	assert.Equal(t, "SU26238RMFS4", chartName(domain.MoexHistory{}, "SU26238RMFS4"))
+}
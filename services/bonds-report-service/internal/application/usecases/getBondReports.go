@@ -8,7 +8,9 @@ import (
 	"bonds-report-service/internal/utils/logging"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
 	"sync"
 
 	"github.com/gladinov/e"
@@ -19,9 +21,17 @@ type reportJob struct {
 	AccountID         string
 }
 
+// renderedReport - таблицы счета и отчет, по которому они нарисованы.
+type renderedReport struct {
+	Media  []*dto.MediaGroup
+	Report generalbondreport.GeneralBondReports
+}
+
 var ErrEmptyBondPositions = errors.New("len of result bond positions is empty")
 
-func (s *Service) GetBondReports(ctx context.Context, chatID int) (_ dto.BondReportsResponce, err error) {
+// GetBondReports рисует отчеты по облигациям счетов. Пустой accountID - все счета,
+// иначе только указанный счет, для неизвестного счета возвращается domain.ErrUnknownAccount.
+func (s *Service) GetBondReports(ctx context.Context, chatID int, accountID string) (_ dto.BondReportsResponce, err error) {
 	const op = "service.GetBondReports"
 	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
 
@@ -31,12 +41,20 @@ func (s *Service) GetBondReports(ctx context.Context, chatID int) (_ dto.BondRep
 	if err != nil {
 		return dto.BondReportsResponce{}, e.WrapIfErr("failde to get accounts from Tinkoff", err)
 	}
+	if accountID != "" {
+		account, ok := accounts[accountID]
+		if !ok {
+			return dto.BondReportsResponce{}, fmt.Errorf("%w: %s", domain.ErrUnknownAccount, accountID)
+		}
+		accounts = map[string]domain.Account{accountID: account}
+	}
+	macro := s.getMacroIndicators(ctx)
 	ctxWorkers, cancel := context.WithCancel(ctx)
 	defer cancel()
 	bufSize := min(len(accounts), s.WorkersNumber)
 
 	reportsCh := make(chan reportJob, s.WorkersNumber)
-	mediaGroupsCh := make(chan []*dto.MediaGroup, s.WorkersNumber*2)
+	mediaGroupsCh := make(chan renderedReport, s.WorkersNumber*2)
 	errCh := make(chan error, 1)
 	var wgStage1 sync.WaitGroup
 
@@ -46,7 +64,7 @@ func (s *Service) GetBondReports(ctx context.Context, chatID int) (_ dto.BondRep
 		wgStage1.Add(1)
 		go func() {
 			defer wgStage1.Done()
-			s.fetchReportsWorker(ctxWorkers, accountsCh, errCh, reportsCh, chatID)
+			s.fetchReportsWorker(ctxWorkers, accountsCh, errCh, reportsCh, chatID, macro)
 		}()
 	}
 
@@ -69,24 +87,45 @@ func (s *Service) GetBondReports(ctx context.Context, chatID int) (_ dto.BondRep
 		close(mediaGroupsCh)
 	}()
 
+	var reports []generalbondreport.GeneralBondReports
 loop:
 	for {
 		select {
 		case er := <-errCh:
 			cancel()
 			return dto.BondReportsResponce{}, er
-		case reportsInByte, ok := <-mediaGroupsCh:
+		case rendered, ok := <-mediaGroupsCh:
 			if !ok {
 				break loop
 			}
-			reportsInByteByAccounts = append(reportsInByteByAccounts, reportsInByte)
+			reportsInByteByAccounts = append(reportsInByteByAccounts, rendered.Media)
+			reports = append(reports, rendered.Report)
 		}
 	}
 
+	issuersGroup, err := s.issuerConcentrationGroup(ctx, reports)
+	if err != nil {
+		return dto.BondReportsResponce{}, e.WrapIfErr("failed to generate issuer concentration", err)
+	}
+	reportsInByteByAccounts = append(reportsInByteByAccounts, []*dto.MediaGroup{issuersGroup})
+
 	getBondReportsResponce := dto.BondReportsResponce{Media: reportsInByteByAccounts}
 	return getBondReportsResponce, nil
 }
 
+// getMacroIndicators возвращает ключевую ставку и инфляцию для отчета. Без них отчет
+// остается верным, поэтому при ошибке колонки реальной доходности и спреда остаются пустыми.
+func (s *Service) getMacroIndicators(ctx context.Context) domain.MacroIndicators {
+	const op = "service.getMacroIndicators"
+
+	macro, err := s.Helpers.CbrGetter.GetMacroIndicators(ctx, s.now())
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get macro indicators", slog.String("op", op), slog.Any("error", err))
+		return domain.MacroIndicators{}
+	}
+	return macro
+}
+
 func (s *Service) produceAccounts(ctx context.Context, accounts map[string]domain.Account) <-chan domain.Account {
 	out := make(chan domain.Account, s.WorkersNumber*2)
 
@@ -115,6 +154,7 @@ func (s *Service) fetchReportsWorker(
 	errCh chan<- error,
 	generalBondReportsCh chan<- reportJob,
 	chatID int,
+	macro domain.MacroIndicators,
 ) {
 	for {
 		select {
@@ -125,7 +165,7 @@ func (s *Service) fetchReportsWorker(
 				return
 			}
 
-			generalBondReports, err := s.processAccount(ctx, chatID, account)
+			generalBondReports, err := s.processAccount(ctx, chatID, account, macro)
 			if err != nil {
 				select {
 				case errCh <- e.WrapIfErr("failed to procces account", err):
@@ -152,7 +192,7 @@ func (s *Service) renderReportsWorker(
 	ctx context.Context,
 	generalBondReportsCh <-chan reportJob,
 	errCh chan<- error,
-	reportCh chan<- []*dto.MediaGroup,
+	reportCh chan<- renderedReport,
 	chatID int,
 ) {
 	for {
@@ -176,7 +216,7 @@ func (s *Service) renderReportsWorker(
 				return
 			}
 			select {
-			case reportCh <- reportsInByte:
+			case reportCh <- renderedReport{Media: reportsInByte, Report: genralBondReportWithAccount.GeneralBondReport}:
 			case <-ctx.Done():
 				return
 			}
@@ -184,6 +224,36 @@ func (s *Service) renderReportsWorker(
 	}
 }
 
+// issuerConcentrationGroup дополняет отчеты эмитентами и рисует доли эмитентов и рейтинговых групп
+// во всем портфеле отдельным сообщением. Без облигаций сообщение пустое и не отправляется.
+// Эмитенты нужны только здесь, поэтому /screen и /scenario за них не платят.
+func (s *Service) issuerConcentrationGroup(ctx context.Context, reports []generalbondreport.GeneralBondReports) (*dto.MediaGroup, error) {
+	tickers := make(map[string]string)
+	for _, report := range reports {
+		maps.Copy(tickers, report.Tickers())
+	}
+	issuers := s.bondIssuers(ctx, tickers)
+	for i := range reports {
+		reports[i].ApplyIssuers(issuers)
+	}
+
+	mediaGroup := dto.NewMediaGroup()
+	concentration := generalbondreport.NewIssuerConcentration(s.IssuerShareLimit, reports)
+	if len(concentration.Issuers) == 0 {
+		return mediaGroup, nil
+	}
+	pngData, err := presenter.GenerateIssuerConcentrationPNG(ctx, s.logger, concentration)
+	if err != nil {
+		return nil, err
+	}
+	imageData := dto.NewImageData()
+	imageData.Name = "issuers"
+	imageData.Data = pngData
+	imageData.Caption = presenter.IssuerConcentrationCaption(ctx, concentration)
+	mediaGroup.Reports = append(mediaGroup.Reports, imageData)
+	return mediaGroup, nil
+}
+
 func isActiveAccounts(account domain.Account) bool {
 	if account.Status == 2 { // TODO: Magic Number
 		return true
@@ -191,7 +261,7 @@ func isActiveAccounts(account domain.Account) bool {
 	return false
 }
 
-func (s *Service) processAccount(ctx context.Context, chatID int, account domain.Account) (_ generalbondreport.GeneralBondReports, err error) {
+func (s *Service) processAccount(ctx context.Context, chatID int, account domain.Account, macro domain.MacroIndicators) (_ generalbondreport.GeneralBondReports, err error) {
 	const op = "service.processAccount"
 	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
 
@@ -223,7 +293,13 @@ func (s *Service) processAccount(ctx context.Context, chatID int, account domain
 
 		operationsByAssetUid := mapOperationsWithoutCustomTypesToMapByAssetUid(allOperations)
 
+		reportLines, err := s.Helpers.ReportLineBuilder.CreateNewReportLinesBatch(ctx, filterBondPositions(portfolioPositions), operationsByAssetUid)
+		if err != nil {
+			return generalbondreport.GeneralBondReports{}, e.WrapIfErr("failed to create new report lines", err)
+		}
+
 		generalBondReports := generalbondreport.NewGeneralBondReports()
+		generalBondReports.TotalAmount = totalAmount
 
 		workers := s.WorkersNumber
 		ctxWorkers, cancel := context.WithCancel(ctx)
@@ -238,7 +314,7 @@ func (s *Service) processAccount(ctx context.Context, chatID int, account domain
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				s.bondWorker(ctxWorkers, workList, bondReportCh, errCh, totalAmount, operationsByAssetUid)
+				s.bondWorker(ctxWorkers, workList, bondReportCh, errCh, totalAmount, reportLines)
 			}()
 
 		}
@@ -257,6 +333,7 @@ func (s *Service) processAccount(ctx context.Context, chatID int, account domain
 				if !ok {
 					break loop
 				}
+				bondReport.ApplyMacroIndicators(macro)
 				s.addBondReport(&generalBondReports, bondReport)
 			case er := <-errCh:
 				cancel()
@@ -275,13 +352,13 @@ func (s *Service) bondWorker(
 	resultCh chan<- generalbondreport.GeneralBondReportPosition,
 	errCh chan<- error,
 	totalAmount float64,
-	operationsByAssetUid map[string][]domain.OperationWithoutCustomTypes,
+	reportLines map[string]*domain.ReportLine,
 ) {
 	for position := range workList {
 		bondReport, er := s.processBondPosition(ctx,
 			position,
 			totalAmount,
-			operationsByAssetUid[position.AssetUid])
+			reportLines[position.InstrumentUid])
 		if er != nil {
 			if errors.Is(er, ErrEmptyBondPositions) {
 				continue
@@ -319,6 +396,16 @@ func (s *Service) producePositions(
 	}
 }
 
+func filterBondPositions(positions []domain.PortfolioPositionsWithAssetUid) []domain.PortfolioPositionsWithAssetUid {
+	bonds := make([]domain.PortfolioPositionsWithAssetUid, 0, len(positions))
+	for _, position := range positions {
+		if isBondType(position) {
+			bonds = append(bonds, position)
+		}
+	}
+	return bonds
+}
+
 func isBondType(position domain.PortfolioPositionsWithAssetUid) bool {
 	if position.InstrumentType != "bond" {
 		return false
@@ -329,7 +416,7 @@ func isBondType(position domain.PortfolioPositionsWithAssetUid) bool {
 func (s *Service) processBondPosition(ctx context.Context,
 	position domain.PortfolioPositionsWithAssetUid,
 	totalAmount float64,
-	operationsDb []domain.OperationWithoutCustomTypes,
+	reporLines *domain.ReportLine,
 ) (_ generalbondreport.GeneralBondReportPosition, err error) {
 	const op = "service.processBondPosition"
 	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
@@ -338,11 +425,6 @@ func (s *Service) processBondPosition(ctx context.Context,
 	case <-ctx.Done():
 		return generalbondreport.GeneralBondReportPosition{}, ctx.Err()
 	default:
-
-		reporLines, err := s.Helpers.ReportLineBuilder.CreateNewReportLines(ctx, position, operationsDb)
-		if err != nil {
-			return generalbondreport.GeneralBondReportPosition{}, e.WrapIfErr("failed to create new report lines", err)
-		}
 		// Обрабатываем операции и получаем открытые позиции по данной бумаге
 		resultBondPosition, err := s.Helpers.ReportProcessor.ProcessOperations(ctx, reporLines)
 		if err != nil {
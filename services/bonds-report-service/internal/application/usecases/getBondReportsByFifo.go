@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
 	"bonds-report-service/internal/domain/report"
 	"bonds-report-service/internal/utils/logging"
 	"context"
@@ -20,6 +21,9 @@ func (s *Service) GetBondReportsByFifo(ctx context.Context, chatID int) (err err
 		return e.WrapIfErr("get accounts error", err)
 	}
 
+	// графики купонов для доходности по цене покупки, без MOEX остается доходность на дату покупки
+	moexBonds := s.moexBondsBySecID(ctx)
+
 	ctxWorkers, cancel := context.WithCancel(ctx)
 	defer cancel()
 	workers := s.WorkersNumber
@@ -33,7 +37,7 @@ func (s *Service) GetBondReportsByFifo(ctx context.Context, chatID int) (err err
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			s.accountWorkerForBondReportByFifo(ctxWorkers, accountsCh, errCh, chatID)
+			s.accountWorkerForBondReportByFifo(ctxWorkers, accountsCh, errCh, chatID, moexBonds)
 		}()
 	}
 
@@ -54,7 +58,7 @@ func (s *Service) GetBondReportsByFifo(ctx context.Context, chatID int) (err err
 	}
 }
 
-func (s *Service) accountWorkerForBondReportByFifo(ctx context.Context, accountsCh <-chan domain.Account, errCh chan<- error, chatID int) {
+func (s *Service) accountWorkerForBondReportByFifo(ctx context.Context, accountsCh <-chan domain.Account, errCh chan<- error, chatID int, moexBonds map[string]domain.MoexBond) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -63,7 +67,7 @@ func (s *Service) accountWorkerForBondReportByFifo(ctx context.Context, accounts
 			if !ok {
 				return
 			}
-			err := s.processAccountForBondReportByFifo(ctx, chatID, account)
+			err := s.processAccountForBondReportByFifo(ctx, chatID, account, moexBonds)
 			if err != nil {
 				select {
 				case errCh <- e.WrapIfErr("failed to process account", err):
@@ -75,7 +79,7 @@ func (s *Service) accountWorkerForBondReportByFifo(ctx context.Context, accounts
 	}
 }
 
-func (s *Service) processAccountForBondReportByFifo(ctx context.Context, chatID int, account domain.Account) (err error) {
+func (s *Service) processAccountForBondReportByFifo(ctx context.Context, chatID int, account domain.Account, moexBonds map[string]domain.MoexBond) (err error) {
 	const op = "service.processAccountForBondReportByFifo"
 
 	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
@@ -109,6 +113,11 @@ func (s *Service) processAccountForBondReportByFifo(ctx context.Context, chatID
 		}
 		operationsByAssetUid := mapOperationsWithoutCustomTypesToMapByAssetUid(operationsDb)
 
+		reportLines, err := s.Helpers.ReportLineBuilder.CreateNewReportLinesBatch(ctx, filterBondPositions(portfolioPositions), operationsByAssetUid)
+		if err != nil {
+			return e.WrapIfErr("failed to create new report lines", err)
+		}
+
 		var wg sync.WaitGroup
 		ctxWorkers, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -122,7 +131,7 @@ func (s *Service) processAccountForBondReportByFifo(ctx context.Context, chatID
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				s.bondReportWorker(ctxWorkers, positionCh, bondReportCh, errCh, operationsByAssetUid)
+				s.bondReportWorker(ctxWorkers, positionCh, bondReportCh, errCh, reportLines, moexBonds)
 			}()
 		}
 
@@ -160,7 +169,7 @@ func (s *Service) processAccountForBondReportByFifo(ctx context.Context, chatID
 	}
 }
 
-func (s *Service) bondReportWorker(ctx context.Context, positionCh <-chan domain.PortfolioPositionsWithAssetUid, bondReportCh chan<- report.Report, errCh chan<- error, operationsByAssetUid map[string][]domain.OperationWithoutCustomTypes) {
+func (s *Service) bondReportWorker(ctx context.Context, positionCh <-chan domain.PortfolioPositionsWithAssetUid, bondReportCh chan<- report.Report, errCh chan<- error, reportLines map[string]*domain.ReportLine, moexBonds map[string]domain.MoexBond) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -169,8 +178,7 @@ func (s *Service) bondReportWorker(ctx context.Context, positionCh <-chan domain
 			if !ok {
 				return
 			}
-			operationsOfPosition := operationsByAssetUid[position.AssetUid]
-			bondReport, errWorkers := s.processPositionsForBondReportByFifo(ctx, position, operationsOfPosition)
+			bondReport, errWorkers := s.processPositionsForBondReportByFifo(ctx, reportLines[position.InstrumentUid], moexBonds)
 			if errWorkers != nil {
 				select {
 				case <-ctx.Done():
@@ -201,16 +209,11 @@ func (s *Service) producePositionsForBondReportByFifo(ctx context.Context, posit
 	}
 }
 
-func (s *Service) processPositionsForBondReportByFifo(ctx context.Context, position domain.PortfolioPositionsWithAssetUid, operationDbByAssetUid []domain.OperationWithoutCustomTypes) (report.Report, error) {
+func (s *Service) processPositionsForBondReportByFifo(ctx context.Context, reporLines *domain.ReportLine, moexBonds map[string]domain.MoexBond) (report.Report, error) {
 	select {
 	case <-ctx.Done():
 		return report.Report{}, ctx.Err()
 	default:
-		reporLines, err := s.Helpers.ReportLineBuilder.CreateNewReportLines(ctx, position, operationDbByAssetUid)
-		if err != nil {
-			return report.Report{}, e.WrapIfErr("failed to create new report lines", err)
-		}
-
 		resultBondPosition, err := s.Helpers.ReportProcessor.ProcessOperations(ctx, reporLines)
 		if err != nil {
 			return report.Report{}, e.WrapIfErr("failed to process operation", err)
@@ -227,11 +230,19 @@ func (s *Service) processPositionsForBondReportByFifo(ctx context.Context, posit
 			return report.Report{}, e.WrapIfErr("failed to get specifications from moex to buy now", err)
 		}
 
+		var schedule *bondmath.Schedule
+		if bond, ok := moexBonds[reporLines.Bond.Ticker]; ok {
+			if bondSchedule, ok := bulletSchedule(bond, firstBuyDate); ok {
+				schedule = &bondSchedule
+			}
+		}
+
 		bondReport, err := s.Helpers.BondReportProcessor.CreateBondReport(
 			ctx,
 			resultBondPosition.CurrentPositions,
 			moexBuyDateData,
 			moexNowData,
+			schedule,
 		)
 		if err != nil {
 			return report.Report{}, e.WrapIfErr("CreateBondReport error", err)
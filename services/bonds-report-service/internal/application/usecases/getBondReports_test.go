@@ -51,16 +51,18 @@ func newTestService(t *testing.T) *Service {
 		reportLineBuilderMock,
 		dividerByAssetTypeMock)
 
-	externalApis := NewExternalApis(moexMock, cbrMock, nil)
+	ratingsMock := mocks.NewRatingSource(t)
 
-	s := NewService(logger, workersNumber, externalApis, helpers, storage)
+	externalApis := NewExternalApis(moexMock, cbrMock, nil, ratingsMock)
+
+	s := NewService(logger, workersNumber, 10, externalApis, helpers, storage)
 	return s
 }
 
 // func TestService_GetBondReports(t *testing.T) {
 // 	t.Run("success", func(t *testing.T) {
 // 		logger := slog.New(slog.DiscardHandler)
-// 		s := NewService(logger, workersNumber, externalApis, helpers, storage)
+// 		s := NewService(logger, workersNumber, 10, externalApis, helpers, storage)
 // 		got, gotErr := s.GetBondReports(context.Background(), chatID)
 // 	})
 // }
@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/application/presenter"
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"bonds-report-service/internal/utils"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+const (
+	// screenerDefaultLimit - сколько бумаг показывать, если limit не задан.
+	screenerDefaultLimit = 20
+	// screenerMaxLimit - ограничение на размер таблицы, чтобы она помещалась в сообщение Telegram.
+	screenerMaxLimit = 50
+)
+
+// screenerBoards - режимы торгов облигациями, которые отдает moexApi.
+var screenerBoards = []string{"TQOB", "TQCB", "TQIR"}
+
+// ParseScreenerFilter разбирает параметры скринера:
+//
+//	ytm=8-12     доходность к погашению, % годовых
+//	dur=1-3      дюрация, годы
+//	mat=-5       лет до оферты или погашения
+//	coupon=fixed тип купона: fixed, float, zero
+//	cur=rub      валюта номинала
+//	level=1,2    уровень листинга
+//	board=TQOB   режимы торгов через запятую
+//	limit=20     размер таблицы, не больше 50
+//
+// У интервалов можно опустить любую границу: "8-" или "-12".
+func ParseScreenerFilter(params map[string]string) (domain.ScreenerFilter, error) {
+	filter := domain.ScreenerFilter{Limit: screenerDefaultLimit}
+	for key, value := range params {
+		value = strings.TrimSpace(value)
+		var err error
+		switch strings.ToLower(key) {
+		case "ytm":
+			filter.Yield, err = parseRange(value)
+		case "dur":
+			filter.Duration, err = parseRange(value)
+		case "mat":
+			filter.Maturity, err = parseRange(value)
+		case "coupon":
+			filter.CouponType, err = parseCouponType(value)
+		case "cur":
+			filter.Currency = domain.NormalizeCurrency(value)
+		case "level":
+			filter.ListLevels, err = parseListLevels(value)
+		case "board":
+			filter.Boards, err = parseBoards(value)
+		case "limit":
+			filter.Limit, err = strconv.Atoi(value)
+			if err == nil && (filter.Limit < 1 || filter.Limit > screenerMaxLimit) {
+				err = fmt.Errorf("limit must be from 1 to %d", screenerMaxLimit)
+			}
+		default:
+			err = fmt.Errorf("unknown parameter")
+		}
+		if err != nil {
+			return domain.ScreenerFilter{}, fmt.Errorf("%w: %s=%q: %v", domain.ErrInvalidScreenerFilter, key, value, err)
+		}
+	}
+	return filter, nil
+}
+
+// GetBondScreener отбирает торгуемые на MOEX облигации по фильтру, сортирует их по доходности
+// и сравнивает с облигациями пользователя: отмечает уже купленные и показывает разницу
+// доходности со средней по портфелю в той же валюте.
+func (s *Service) GetBondScreener(ctx context.Context, chatID int, params map[string]string) (_ domain.BondScreenerResponce, err error) {
+	const op = "service.GetBondScreener"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	filter, err := ParseScreenerFilter(params)
+	if err != nil {
+		return domain.BondScreenerResponce{}, err
+	}
+
+	bonds, err := s.External.Moex.GetBonds(ctx, filter.Boards)
+	if err != nil {
+		return domain.BondScreenerResponce{}, e.WrapIfErr("failed to get bonds from moex", err)
+	}
+
+	positions, err := s.bondPositions(ctx, chatID)
+	if err != nil {
+		return domain.BondScreenerResponce{}, e.WrapIfErr("failed to get holdings", err)
+	}
+	holdings := holdingsFromPositions(positions)
+
+	screener := screenBonds(bonds, filter, holdings, s.now())
+	return domain.BondScreenerResponce{Report: presenter.ResponseBondScreener(ctx, s.logger, screener)}, nil
+}
+
+// bondPositions собирает облигации со всех активных счетов. Макропоказатели
+// здесь не нужны, поэтому отчеты по счетам строятся без них.
+func (s *Service) bondPositions(ctx context.Context, chatID int) ([]generalbondreport.GeneralBondReportPosition, error) {
+	accounts, err := s.Helpers.TinkoffHelper.TinkoffGetAccounts(ctx)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to get accounts from Tinkoff", err)
+	}
+
+	var positions []generalbondreport.GeneralBondReportPosition
+	for _, account := range sortedAccounts(accounts) {
+		if !isActiveAccounts(account) {
+			continue
+		}
+		reports, err := s.processAccount(ctx, chatID, account, domain.MacroIndicators{})
+		if err != nil {
+			return nil, e.WrapIfErr("failed to process account", err)
+		}
+		for _, report := range []map[generalbondreport.TickerTimeKey]generalbondreport.GeneralBondReportPosition{
+			reports.RubBondsReport, reports.EuroBondsReport, reports.ReplacedBondsReport,
+		} {
+			for _, position := range report {
+				positions = append(positions, position)
+			}
+		}
+	}
+	return positions, nil
+}
+
+// holdingsFromPositions считает количество бумаг по тикеру и среднюю доходность
+// по валюте, взвешенную по текущей стоимости позиций.
+func holdingsFromPositions(positions []generalbondreport.GeneralBondReportPosition) domain.ScreenerHoldings {
+	holdings := domain.ScreenerHoldings{
+		Quantity: make(map[string]int64),
+		Yield:    make(map[string]float64),
+	}
+	weights := make(map[string]float64)
+	for _, position := range positions {
+		holdings.Quantity[position.Ticker] += position.Quantity
+		weight := position.CurrentPrice * float64(position.Quantity)
+		if weight <= 0 {
+			continue
+		}
+		holdings.Yield[position.Currencies] += position.YieldToMaturity * weight
+		weights[position.Currencies] += weight
+	}
+	for currency, weight := range weights {
+		holdings.Yield[currency] /= weight
+	}
+	return holdings
+}
+
+// screenBonds применяет фильтр, сортирует бумаги по убыванию доходности и обрезает до filter.Limit.
+// Бумаги без доходности (без сделок и без итогов прошлого дня) в рейтинг не попадают.
+func screenBonds(bonds []domain.MoexBond, filter domain.ScreenerFilter, holdings domain.ScreenerHoldings, now time.Time) domain.BondScreener {
+	matched := make([]domain.MoexBond, 0, len(bonds))
+	for _, bond := range bonds {
+		if bond.Yield.IsHasValue() && filter.Match(bond, now) {
+			matched = append(matched, bond)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Yield.Value != matched[j].Yield.Value {
+			return matched[i].Yield.Value > matched[j].Yield.Value
+		}
+		return matched[i].SecID < matched[j].SecID
+	})
+
+	screener := domain.BondScreener{Filter: filter, Matched: len(matched)}
+	for _, bond := range matched[:min(len(matched), filter.Limit)] {
+		row := domain.ScreenerRow{Bond: bond, Held: holdings.Quantity[bond.SecID]}
+		if avg, ok := holdings.Yield[bond.Currency()]; ok {
+			row.VsHeld = domain.NewNullFloat64(utils.RoundFloat(bond.Yield.Value-avg, 2), true, false)
+		}
+		screener.Rows = append(screener.Rows, row)
+	}
+	return screener
+}
+
+func parseRange(value string) (domain.Range, error) {
+	minValue, maxValue, ok := strings.Cut(value, "-")
+	if !ok {
+		return domain.Range{}, fmt.Errorf("expected range like 8-12, 8- or -12")
+	}
+	var r domain.Range
+	for _, bound := range []struct {
+		text string
+		dst  *domain.NullFloat64
+	}{{minValue, &r.Min}, {maxValue, &r.Max}} {
+		text := strings.ReplaceAll(strings.TrimSpace(bound.text), ",", ".")
+		if text == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return domain.Range{}, err
+		}
+		*bound.dst = domain.NewNullFloat64(v, true, false)
+	}
+	if !r.IsSet() {
+		return domain.Range{}, fmt.Errorf("range has no bounds")
+	}
+	if r.Min.IsHasValue() && r.Max.IsHasValue() && r.Min.Value > r.Max.Value {
+		return domain.Range{}, fmt.Errorf("min is greater than max")
+	}
+	return r, nil
+}
+
+func parseCouponType(value string) (domain.CouponType, error) {
+	couponType := domain.CouponType(strings.ToLower(value))
+	switch couponType {
+	case domain.CouponFixed, domain.CouponFloat, domain.CouponZero:
+		return couponType, nil
+	default:
+		return "", fmt.Errorf("expected fixed, float or zero")
+	}
+}
+
+func parseListLevels(value string) ([]int, error) {
+	var levels []int
+	for _, part := range strings.Split(value, ",") {
+		level, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || level < 1 || level > 3 {
+			return nil, fmt.Errorf("expected listing levels from 1 to 3")
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+func parseBoards(value string) ([]string, error) {
+	var boards []string
+	for _, part := range strings.Split(value, ",") {
+		board := strings.ToUpper(strings.TrimSpace(part))
+		if !slices.Contains(screenerBoards, board) {
+			return nil, fmt.Errorf("expected boards %s", strings.Join(screenerBoards, ", "))
+		}
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
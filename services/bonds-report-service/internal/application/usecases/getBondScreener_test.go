@@ -0,0 +1,148 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func screenerBond(secID, faceUnit string, yield float64, durationDays float64, matDate string) domain.MoexBond {
+	return domain.MoexBond{
+		SecID:         secID,
+		FaceUnit:      faceUnit,
+		ListLevel:     1,
+		MaturityDate:  domain.NewNullString(matDate, true, false),
+		OfferDate:     domain.NewNullString("", true, true),
+		CouponPercent: domain.NewNullFloat64(10, true, false),
+		CouponValue:   domain.NewNullFloat64(49.86, true, false),
+		Yield:         domain.NewNullFloat64(yield, true, false),
+		Duration:      domain.NewNullFloat64(durationDays, true, false),
+	}
+}
+
+func TestParseScreenerFilter(t *testing.T) {
+	filter, err := ParseScreenerFilter(map[string]string{
+		"ytm":    "12,5-",
+		"dur":    "-3",
+		"mat":    "1-5",
+		"coupon": "Fixed",
+		"cur":    "SUR",
+		"level":  "1,2",
+		"board":  "tqob,TQCB",
+		"limit":  "5",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.NewNullFloat64(12.5, true, false), filter.Yield.Min)
+	assert.False(t, filter.Yield.Max.IsHasValue())
+	assert.Equal(t, 3.0, filter.Duration.Max.Value)
+	assert.Equal(t, domain.CouponFixed, filter.CouponType)
+	assert.Equal(t, "rub", filter.Currency)
+	assert.Equal(t, []int{1, 2}, filter.ListLevels)
+	assert.Equal(t, []string{"TQOB", "TQCB"}, filter.Boards)
+	assert.Equal(t, 5, filter.Limit)
+
+	filter, err = ParseScreenerFilter(nil)
+	require.NoError(t, err)
+	assert.Equal(t, screenerDefaultLimit, filter.Limit)
+
+	for _, params := range []map[string]string{
+		{"ytm": "12"},
+		{"ytm": "-"},
+		{"ytm": "15-10"},
+		{"coupon": "step"},
+		{"level": "4"},
+		{"board": "TQBR"},
+		{"limit": "100"},
+		{"rating": "AAA"},
+	} {
+		_, err := ParseScreenerFilter(params)
+		require.ErrorIs(t, err, domain.ErrInvalidScreenerFilter, "%v", params)
+	}
+}
+
+func TestScreenerFilterMatch(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bond := screenerBond("RU000A10A1B2", "SUR", 14, 730, "2029-01-01")
+
+	cases := []struct {
+		name   string
+		filter domain.ScreenerFilter
+		want   bool
+	}{
+		{name: "Empty filter", want: true},
+		{name: "Yield in range", filter: domain.ScreenerFilter{Yield: rangeOf(12, 16)}, want: true},
+		{name: "Yield below", filter: domain.ScreenerFilter{Yield: rangeOf(15, 16)}},
+		{name: "Duration in years", filter: domain.ScreenerFilter{Duration: rangeOf(1.5, 2.5)}, want: true},
+		{name: "Maturity window", filter: domain.ScreenerFilter{Maturity: rangeOf(0, 2)}},
+		{name: "Coupon type", filter: domain.ScreenerFilter{CouponType: domain.CouponFloat}},
+		{name: "Currency", filter: domain.ScreenerFilter{Currency: "rub"}, want: true},
+		{name: "Listing level", filter: domain.ScreenerFilter{ListLevels: []int{2, 3}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.filter.Match(bond, now))
+		})
+	}
+
+	// оферта раньше погашения - срок считается до оферты
+	bond.OfferDate = domain.NewNullString("2027-01-01", true, false)
+	assert.True(t, domain.ScreenerFilter{Maturity: rangeOf(0, 2)}.Match(bond, now))
+}
+
+func TestMoexBondCouponType(t *testing.T) {
+	fixed := screenerBond("RU000A10A1B2", "SUR", 14, 730, "2029-01-01")
+	assert.Equal(t, domain.CouponFixed, fixed.CouponType())
+
+	floater := screenerBond("SU29014RMFS6", "SUR", 17, 10, "2026-03-25")
+	assert.Equal(t, domain.CouponFloat, floater.CouponType())
+
+	zero := screenerBond("RU000A10A1B3", "SUR", 15, 180, "2026-07-01")
+	zero.CouponPercent = domain.NewNullFloat64(0, true, true)
+	zero.CouponValue = domain.NewNullFloat64(0, true, false)
+	assert.Equal(t, domain.CouponZero, zero.CouponType())
+}
+
+func TestScreenBonds(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bonds := []domain.MoexBond{
+		screenerBond("LOW", "SUR", 12, 365, "2027-01-01"),
+		screenerBond("HIGH", "SUR", 18, 365, "2027-01-01"),
+		screenerBond("HELD", "SUR", 15, 365, "2027-01-01"),
+		screenerBond("CNY", "CNY", 7, 365, "2027-01-01"),
+		screenerBond("NOYIELD", "SUR", 0, 365, "2027-01-01"),
+	}
+	bonds[4].Yield = domain.NewNullFloat64(0, true, true)
+
+	holdings := holdingsFromPositions([]generalbondreport.GeneralBondReportPosition{
+		{Ticker: "HELD", Currencies: "rub", Quantity: 10, CurrentPrice: 1000, YieldToMaturity: 15},
+		{Ticker: "OTHER", Currencies: "rub", Quantity: 30, CurrentPrice: 1000, YieldToMaturity: 11},
+	})
+	assert.InDelta(t, 12.0, holdings.Yield["rub"], 1e-9)
+	assert.Equal(t, int64(10), holdings.Quantity["HELD"])
+
+	got := screenBonds(bonds, domain.ScreenerFilter{Limit: 3}, holdings, now)
+	assert.Equal(t, 4, got.Matched)
+	require.Len(t, got.Rows, 3)
+	assert.Equal(t, "HIGH", got.Rows[0].Bond.SecID)
+	assert.Equal(t, 6.0, got.Rows[0].VsHeld.Value)
+	assert.Equal(t, "HELD", got.Rows[1].Bond.SecID)
+	assert.Equal(t, int64(10), got.Rows[1].Held)
+	assert.Equal(t, "LOW", got.Rows[2].Bond.SecID)
+
+	got = screenBonds(bonds, domain.ScreenerFilter{Currency: "cny", Limit: 10}, holdings, now)
+	require.Len(t, got.Rows, 1)
+	assert.False(t, got.Rows[0].VsHeld.IsHasValue(), "no cny holdings to compare with")
+}
+
+func rangeOf(minValue, maxValue float64) domain.Range {
+	return domain.Range{
+		Min: domain.NewNullFloat64(minValue, true, false),
+		Max: domain.NewNullFloat64(maxValue, true, false),
+	}
+}
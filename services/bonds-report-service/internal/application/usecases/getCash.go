@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/application/presenter"
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/gladinov/e"
+)
+
+// GetCash возвращает отчет о денежных средствах и маржинальных показателях всех открытых счетов.
+func (s *Service) GetCash(ctx context.Context) (_ domain.CashResponce, err error) {
+	const op = "service.GetCash"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	accounts, err := s.Helpers.TinkoffHelper.TinkoffGetAccounts(ctx)
+	if err != nil {
+		return domain.CashResponce{}, e.WrapIfErr("cant' get accounts from tinkoff", err)
+	}
+
+	cash := make([]domain.AccountCash, 0, len(accounts))
+	for _, account := range sortedAccounts(accounts) {
+		if account.ValidateForPortfolio() != nil {
+			continue
+		}
+		accountCash, err := s.getAccountCash(ctx, account)
+		if err != nil {
+			return domain.CashResponce{}, e.WrapIfErr("cant' get account cash", err)
+		}
+		cash = append(cash, accountCash)
+	}
+
+	return domain.CashResponce{Report: presenter.ResponseCash(ctx, s.logger, cash)}, nil
+}
+
+func (s *Service) getAccountCash(ctx context.Context, account domain.Account) (_ domain.AccountCash, err error) {
+	const op = "service.getAccountCash"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	positions, err := s.Helpers.TinkoffHelper.TinkoffGetPositions(ctx, account.ID)
+	if err != nil {
+		return domain.AccountCash{}, e.WrapIfErr("cant' get positions", err)
+	}
+	limits, err := s.Helpers.TinkoffHelper.TinkoffGetWithdrawLimits(ctx, account.ID)
+	if err != nil {
+		return domain.AccountCash{}, e.WrapIfErr("cant' get withdraw limits", err)
+	}
+	margin, err := s.Helpers.TinkoffHelper.TinkoffGetMarginAttributes(ctx, account.ID)
+	if err != nil {
+		return domain.AccountCash{}, e.WrapIfErr("cant' get margin attributes", err)
+	}
+
+	return domain.AccountCash{
+		Account:    account,
+		Currencies: cashByCurrency(positions, limits),
+		Margin:     margin,
+	}, nil
+}
+
+// cashByCurrency сводит свободные, заблокированные и доступные к выводу средства по валютам.
+// К заблокированным относится и гарантийное обеспечение по фьючерсам.
+func cashByCurrency(positions domain.Positions, limits domain.WithdrawLimits) []domain.CurrencyCash {
+	byCurrency := make(map[string]*domain.CurrencyCash)
+	get := func(currency string) *domain.CurrencyCash {
+		cash, ok := byCurrency[currency]
+		if !ok {
+			cash = &domain.CurrencyCash{Currency: currency}
+			byCurrency[currency] = cash
+		}
+		return cash
+	}
+	for _, money := range positions.Money {
+		get(money.Currency).Available += money.ToFloat()
+	}
+	for _, money := range positions.Blocked {
+		get(money.Currency).Blocked += money.ToFloat()
+	}
+	for _, money := range limits.BlockedGuarantee {
+		get(money.Currency).Blocked += money.ToFloat()
+	}
+	for _, money := range limits.Money {
+		get(money.Currency).Withdrawable += money.ToFloat()
+	}
+
+	res := make([]domain.CurrencyCash, 0, len(byCurrency))
+	for _, cash := range byCurrency {
+		if cash.Available == 0 && cash.Blocked == 0 && cash.Withdrawable == 0 {
+			continue
+		}
+		res = append(res, *cash)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		// рубли первыми, остальные валюты по алфавиту
+		if (res[i].Currency == rub) != (res[j].Currency == rub) {
+			return res[i].Currency == rub
+		}
+		return res[i].Currency < res[j].Currency
+	})
+	return res
+}
+
+// addBlockedCash дополняет валютную часть структуры портфеля заблокированными средствами счета в рублях.
+// Структура портфеля без них остается верной, поэтому ошибки только логируются.
+func (s *Service) addBlockedCash(ctx context.Context, accountID string, portfolio *domain.PortfolioByTypeAndCurrency) {
+	const op = "service.addBlockedCash"
+
+	positions, err := s.Helpers.TinkoffHelper.TinkoffGetPositions(ctx, accountID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get blocked cash", slog.String("op", op), slog.Any("error", err))
+		return
+	}
+	limits, err := s.Helpers.TinkoffHelper.TinkoffGetWithdrawLimits(ctx, accountID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get blocked cash", slog.String("op", op), slog.Any("error", err))
+		return
+	}
+
+	var blocked float64
+	for _, cash := range cashByCurrency(positions, limits) {
+		if cash.Blocked == 0 {
+			continue
+		}
+		rate := 1.0
+		if cash.Currency != rub {
+			rate, err = s.Helpers.CbrGetter.GetCurrencyFromCB(ctx, cash.Currency, s.now())
+			if err != nil {
+				s.logger.WarnContext(ctx, "could not get currency rate", slog.String("op", op), slog.Any("error", err))
+				return
+			}
+		}
+		blocked += cash.Blocked * rate
+	}
+	portfolio.CurrenciesAssets.Blocked += blocked
+}
+
+func sortedAccounts(accounts map[string]domain.Account) []domain.Account {
+	res := make([]domain.Account, 0, len(accounts))
+	for _, account := range accounts {
+		res = append(res, account)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
@@ -0,0 +1,40 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashByCurrency(t *testing.T) {
+	positions := domain.Positions{
+		Money: []domain.MoneyValue{
+			domain.NewMoneyValue("usd", 10, 500000000),
+			domain.NewMoneyValue(rub, 1000, 0),
+			domain.NewMoneyValue("cny", 0, 0),
+		},
+		Blocked: []domain.MoneyValue{
+			domain.NewMoneyValue(rub, 100, 0),
+		},
+	}
+	limits := domain.WithdrawLimits{
+		Money: []domain.MoneyValue{
+			domain.NewMoneyValue(rub, 800, 0),
+			domain.NewMoneyValue("usd", 10, 0),
+		},
+		BlockedGuarantee: []domain.MoneyValue{
+			domain.NewMoneyValue(rub, 50, 0),
+		},
+	}
+
+	got := cashByCurrency(positions, limits)
+
+	want := []domain.CurrencyCash{
+		{Currency: rub, Available: 1000, Blocked: 150, Withdrawable: 800},
+		{Currency: "usd", Available: 10.5, Withdrawable: 10},
+	}
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/application/presenter"
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+const (
+	// dividendTaxRate - НДФЛ, который брокер удерживает с рублевых дивидендов российских эмитентов.
+	dividendTaxRate = 0.13
+	// dividendsHorizonYears - на сколько лет вперед показываются дивиденды.
+	dividendsHorizonYears = 1
+)
+
+// GetDividends возвращает таблицу ближайших дивидендов по акциям каждого открытого счета,
+// а под ней - график отчетностей эмитентов, если Invest API его знает.
+func (s *Service) GetDividends(ctx context.Context) (_ domain.DividendsResponce, err error) {
+	const op = "service.GetDividends"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	accounts, err := s.Helpers.TinkoffHelper.TinkoffGetAccounts(ctx)
+	if err != nil {
+		return domain.DividendsResponce{}, e.WrapIfErr("cant' get accounts from tinkoff", err)
+	}
+
+	now := s.now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(dividendsHorizonYears, 0, 0)
+
+	// одна акция часто лежит на нескольких счетах, дивиденды по ней запрашиваются один раз
+	dividendsByFigi := make(map[string][]domain.Dividend)
+	reportsByUid := make(map[string][]domain.AssetReport)
+	var sharePositions []domain.PortfolioPosition
+	res := make([]domain.AccountDividends, 0, len(accounts))
+	for _, account := range sortedAccounts(accounts) {
+		if account.ValidateForPortfolio() != nil {
+			continue
+		}
+		portfolio, err := s.Helpers.TinkoffHelper.TinkoffGetPortfolio(ctx, account)
+		if err != nil {
+			return domain.DividendsResponce{}, e.WrapIfErr("can't get portfolio from Tinkoff", err)
+		}
+
+		accountDividends := domain.AccountDividends{Account: account}
+		for _, position := range portfolio.Positions {
+			if position.InstrumentType != share {
+				continue
+			}
+			sharePositions = append(sharePositions, position)
+			dividends, ok := dividendsByFigi[position.Figi]
+			if !ok {
+				dividends, err = s.Helpers.TinkoffHelper.TinkoffGetDividends(ctx, position.Figi, from, to)
+				if err != nil {
+					return domain.DividendsResponce{}, e.WrapIfErr("can't get dividends from Tinkoff", err)
+				}
+				dividendsByFigi[position.Figi] = dividends
+			}
+			accountDividends.Dividends = append(accountDividends.Dividends, upcomingDividends(position, dividends, from)...)
+
+			reports, ok := reportsByUid[position.InstrumentUid]
+			if !ok {
+				reports = s.assetReports(ctx, position, from, to)
+				reportsByUid[position.InstrumentUid] = reports
+			}
+			accountDividends.Reports = append(accountDividends.Reports, upcomingReports(position, reports, from)...)
+		}
+		sortUpcomingDividends(accountDividends.Dividends)
+		sortUpcomingReports(accountDividends.Reports)
+		res = append(res, accountDividends)
+	}
+
+	addMoexNames(res, s.moexSecurities(ctx, sharePositions))
+
+	return domain.DividendsResponce{Report: presenter.ResponseDividends(ctx, s.logger, res)}, nil
+}
+
+// assetReports загружает график отчетностей эмитента. Раздел необязательный:
+// при ошибке Invest API отчет о дивидендах строится без него.
+func (s *Service) assetReports(ctx context.Context, position domain.PortfolioPosition, from, to time.Time) []domain.AssetReport {
+	reports, err := s.Helpers.TinkoffHelper.TinkoffGetAssetReports(ctx, position.InstrumentUid, from, to)
+	if err != nil {
+		s.logger.WarnContext(ctx, "can't get asset reports from Tinkoff",
+			slog.String("ticker", position.Ticker),
+			slog.Any("error", err))
+		return nil
+	}
+	return reports
+}
+
+// addMoexNames подписывает дивиденды и отчетности коротким названием акции на MOEX.
+func addMoexNames(accounts []domain.AccountDividends, securities domain.MoexSecurities) {
+	for i := range accounts {
+		for j := range accounts[i].Dividends {
+			dividend := &accounts[i].Dividends[j]
+			dividend.Name = securities[dividend.Ticker].ShortName
+		}
+		for j := range accounts[i].Reports {
+			report := &accounts[i].Reports[j]
+			report.Name = securities[report.Ticker].ShortName
+		}
+	}
+}
+
+// upcomingDividends считает ожидаемые выплаты по позиции. Дивиденд учитывается,
+// если дата фиксации реестра еще не прошла, а без нее - если не прошла дата выплаты.
+func upcomingDividends(position domain.PortfolioPosition, dividends []domain.Dividend, from time.Time) []domain.UpcomingDividend {
+	quantity := position.Quantity.ToFloat()
+	res := make([]domain.UpcomingDividend, 0, len(dividends))
+	for _, dividend := range dividends {
+		if dividendDate(dividend.RecordDate, dividend.PaymentDate).Before(from) {
+			continue
+		}
+		amount := quantity * dividend.DividendNet.ToFloat()
+		upcoming := domain.UpcomingDividend{
+			Ticker:      position.Ticker,
+			RecordDate:  dividend.RecordDate,
+			PaymentDate: dividend.PaymentDate,
+			Quantity:    quantity,
+			DividendNet: dividend.DividendNet,
+			Amount:      amount,
+		}
+		// в рублях платят российские эмитенты, с них брокер удерживает 13%;
+		// с валютных выплат налог зависит от страны эмитента, они показываются до налога
+		if strings.EqualFold(dividend.DividendNet.Currency, rub) {
+			upcoming.AfterTax = amount * (1 - dividendTaxRate)
+			upcoming.TaxKnown = true
+		}
+		res = append(res, upcoming)
+	}
+	return res
+}
+
+// upcomingReports оставляет отчетности эмитента, которые еще не опубликованы.
+func upcomingReports(position domain.PortfolioPosition, reports []domain.AssetReport, from time.Time) []domain.UpcomingReport {
+	res := make([]domain.UpcomingReport, 0, len(reports))
+	for _, report := range reports {
+		if report.ReportDate.Before(from) {
+			continue
+		}
+		res = append(res, domain.UpcomingReport{Ticker: position.Ticker, AssetReport: report})
+	}
+	return res
+}
+
+func sortUpcomingReports(reports []domain.UpcomingReport) {
+	sort.Slice(reports, func(i, j int) bool {
+		if !reports[i].ReportDate.Equal(reports[j].ReportDate) {
+			return reports[i].ReportDate.Before(reports[j].ReportDate)
+		}
+		return reports[i].Ticker < reports[j].Ticker
+	})
+}
+
+func sortUpcomingDividends(dividends []domain.UpcomingDividend) {
+	sort.Slice(dividends, func(i, j int) bool {
+		dateI := dividendDate(dividends[i].RecordDate, dividends[i].PaymentDate)
+		dateJ := dividendDate(dividends[j].RecordDate, dividends[j].PaymentDate)
+		if !dateI.Equal(dateJ) {
+			return dateI.Before(dateJ)
+		}
+		return dividends[i].Ticker < dividends[j].Ticker
+	})
+}
+
+// dividendDate - дата, по которой дивиденд попадает в отчет: фиксация реестра, а без нее - выплата.
+func dividendDate(recordDate, paymentDate time.Time) time.Time {
+	if recordDate.IsZero() {
+		return paymentDate
+	}
+	return recordDate
+}
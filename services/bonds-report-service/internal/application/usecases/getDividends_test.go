@@ -0,0 +1,80 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpcomingDividends(t *testing.T) {
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	position := domain.PortfolioPosition{
+		Figi:           "FIGI1",
+		Ticker:         "SBER",
+		InstrumentType: share,
+		Quantity:       domain.Quotation{Units: 10},
+	}
+	dividends := []domain.Dividend{
+		{
+			DividendNet: domain.NewMoneyValue(rub, 33, 300000000),
+			RecordDate:  time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC),
+			PaymentDate: time.Date(2026, 7, 24, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// реестр уже закрыт
+			DividendNet: domain.NewMoneyValue(rub, 10, 0),
+			RecordDate:  time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// дата реестра еще не известна
+			DividendNet: domain.NewMoneyValue(rub, 5, 0),
+			PaymentDate: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// налог с валютного дивиденда зависит от страны эмитента
+			DividendNet: domain.NewMoneyValue("usd", 1, 500000000),
+			RecordDate:  time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	got := upcomingDividends(position, dividends, from)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "SBER", got[0].Ticker)
+	assert.InDelta(t, 333.0, got[0].Amount, 1e-9)
+	assert.InDelta(t, 289.71, got[0].AfterTax, 1e-9)
+	assert.True(t, got[0].TaxKnown)
+	assert.InDelta(t, 43.5, got[1].AfterTax, 1e-9)
+	assert.InDelta(t, 15.0, got[2].Amount, 1e-9)
+	assert.False(t, got[2].TaxKnown)
+	assert.Zero(t, got[2].AfterTax)
+	got = got[:2]
+
+	got = append(got, domain.UpcomingDividend{Ticker: "GAZP", RecordDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)})
+	sortUpcomingDividends(got)
+	assert.Equal(t, "SBER", got[0].Ticker)
+	assert.Equal(t, "GAZP", got[1].Ticker)
+	assert.True(t, got[2].RecordDate.IsZero(), "dividends without record date are sorted by payment date")
+}
+
+func TestUpcomingReports(t *testing.T) {
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	reports := []domain.AssetReport{
+		{ReportDate: time.Date(2026, 4, 28, 0, 0, 0, 0, time.UTC), PeriodYear: 2026, PeriodNum: 1},
+		{ReportDate: time.Date(2026, 7, 30, 0, 0, 0, 0, time.UTC), PeriodYear: 2026, PeriodNum: 2},
+	}
+
+	got := upcomingReports(domain.PortfolioPosition{Ticker: "SBER"}, reports, from)
+	require.Len(t, got, 1, "published reports are skipped")
+	assert.Equal(t, "SBER", got[0].Ticker)
+	assert.Equal(t, int32(2), got[0].PeriodNum)
+
+	got = append(got, domain.UpcomingReport{Ticker: "GAZP", AssetReport: domain.AssetReport{ReportDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)}})
+	sortUpcomingReports(got)
+	assert.Equal(t, "GAZP", got[0].Ticker)
+}
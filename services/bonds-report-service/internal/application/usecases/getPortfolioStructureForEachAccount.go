@@ -120,7 +120,9 @@ func (s *Service) getPortfolioStructure(ctx context.Context, account domain.Acco
 		if err != nil {
 			return "", e.WrapIfErr("couldnot divide by type", err)
 		}
+		s.addBlockedCash(ctx, account.ID, potfolioStructure)
 		response := presenter.ResponsePortfolioStructure(ctx, s.logger, potfolioStructure, dto.EachPortf, account.Name)
+		response += presenter.ResponseMoexSecurities(ctx, moexPositions(positions, s.moexSecurities(ctx, positions)))
 
 		return response, nil
 	}
@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/application/dto"
+	"bonds-report-service/internal/application/presenter"
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"bonds-report-service/internal/utils/logging"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+const (
+	// scenarioDefaultShiftBP - сдвиг ставок, если пользователь его не указал.
+	scenarioDefaultShiftBP = 100
+	// scenarioMaxShiftBP - ограничение сдвига: при больших сдвигах оценка по дюрации
+	// и выпуклости перестает быть точной.
+	scenarioMaxShiftBP = 1000
+
+	moexDateLayout = "2006-01-02"
+	hoursInDay     = 24
+	daysInYear     = 365
+)
+
+// ParseRateShift разбирает сдвиг ставок: +100bp, -50бп, 75 (базисные пункты) или +1.5% (проценты).
+// Пустая строка - +100 б.п.
+func ParseRateShift(text string) (int, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return scenarioDefaultShiftBP, nil
+	}
+
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(text, "%"):
+		text, scale = strings.TrimSuffix(text, "%"), 100
+	case strings.HasSuffix(text, "bp"):
+		text = strings.TrimSuffix(text, "bp")
+	case strings.HasSuffix(text, "бп"):
+		text = strings.TrimSuffix(text, "бп")
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", "."), 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, fmt.Errorf("%w: %q", domain.ErrInvalidRateShift, text)
+	}
+	bp := int(math.Round(value * scale))
+	if bp == 0 || bp > scenarioMaxShiftBP || bp < -scenarioMaxShiftBP {
+		return 0, fmt.Errorf("%w: shift must be from 1 to %d bp", domain.ErrInvalidRateShift, scenarioMaxShiftBP)
+	}
+	return bp, nil
+}
+
+// GetRateScenario оценивает изменение стоимости облигаций со всех счетов при сдвиге ставок
+// и возвращает таблицу PNG по группам: рублевые, юаневые, замещающие и еврооблигации.
+//
+// Параллельный сдвиг меняет доходность всех облигаций на shift. Изменение ключевой ставки
+// меняет доходность только рублевых облигаций. Флоатеры в обоих случаях переоцениваются лишь
+// до ближайшего купона, после которого купон подстраивается под новую ставку.
+func (s *Service) GetRateScenario(ctx context.Context, chatID int, shift string) (_ dto.ImageData, err error) {
+	const op = "service.GetRateScenario"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	shiftBP, err := ParseRateShift(shift)
+	if err != nil {
+		return dto.ImageData{}, err
+	}
+
+	positions, err := s.bondPositions(ctx, chatID)
+	if err != nil {
+		return dto.ImageData{}, e.WrapIfErr("failed to get bond positions", err)
+	}
+
+	scenario := domain.RateScenario{
+		ShiftBP:   shiftBP,
+		Positions: scenarioPositions(positions, s.moexBondsBySecID(ctx), s.now()),
+	}
+	pngData, err := presenter.GenerateRateScenarioPNG(ctx, s.logger, scenario)
+	if err != nil {
+		return dto.ImageData{}, e.WrapIfErr("failed to generate scenario table", err)
+	}
+
+	imageData := dto.NewImageData()
+	imageData.Name = "scenario"
+	imageData.Data = pngData
+	imageData.Caption = presenter.RateScenarioCaption(ctx, scenario)
+	return *imageData, nil
+}
+
+// moexBondsBySecID загружает торгуемые облигации MOEX для графиков купонов и дат пересмотра купона флоатеров.
+// Без них дюрация берется из отчета, поэтому ошибка MOEX только пишется в лог.
+func (s *Service) moexBondsBySecID(ctx context.Context) map[string]domain.MoexBond {
+	const op = "service.moexBondsBySecID"
+
+	bonds, err := s.External.Moex.GetBonds(ctx, nil)
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get bonds from moex", slog.String("op", op), slog.Any("error", err))
+		return map[string]domain.MoexBond{}
+	}
+	res := make(map[string]domain.MoexBond, len(bonds))
+	for _, bond := range bonds {
+		res[bond.SecID] = bond
+	}
+	return res
+}
+
+// scenarioPositions складывает одну бумагу с разных счетов и считает ее чувствительность к ставкам.
+func scenarioPositions(
+	positions []generalbondreport.GeneralBondReportPosition,
+	bonds map[string]domain.MoexBond,
+	now time.Time,
+) []domain.ScenarioPosition {
+	index := make(map[string]int)
+	var res []domain.ScenarioPosition
+	for _, position := range positions {
+		value := position.CurrentPrice * float64(position.Quantity)
+		if i, ok := index[position.Ticker]; ok {
+			res[i].Value += value
+			continue
+		}
+		index[position.Ticker] = len(res)
+		bond, ok := bonds[position.Ticker]
+		res = append(res, scenarioPosition(position, bond, ok, now, value))
+	}
+	return res
+}
+
+func scenarioPosition(
+	position generalbondreport.GeneralBondReportPosition,
+	bond domain.MoexBond,
+	hasBond bool,
+	now time.Time,
+	value float64,
+) domain.ScenarioPosition {
+	res := domain.ScenarioPosition{
+		Ticker:   position.Ticker,
+		Name:     position.Name,
+		Bucket:   scenarioBucket(position),
+		Currency: position.Currencies,
+		Floater:  position.FloatingCoupon || hasBond && bond.CouponType() == domain.CouponFloat,
+		Value:    value,
+	}
+
+	y := position.YieldToMaturity / 100
+	if y == 0 && hasBond && bond.Yield.IsHasValue() {
+		y = bond.Yield.Value / 100
+	}
+	switch {
+	case res.Floater:
+		// Купон флоатера подстраивается под ставку на ближайшей дате купона,
+		// поэтому переоценивается только поток до нее.
+		if next, ok := nextReset(bond, hasBond, now); ok {
+			res.Parallel = domain.RateSensitivity{Duration: next.Sub(now).Hours() / hoursInDay / daysInYear / (1 + y)}
+		} else {
+			res.Parallel = reportSensitivity(position, y)
+			res.Estimated = true
+		}
+	default:
+		if analytics, ok := scheduleAnalytics(bond, hasBond, now, y); ok {
+			res.Parallel = domain.RateSensitivity{Duration: analytics.ModifiedDuration, Convexity: analytics.Convexity}
+		} else {
+			res.Parallel = reportSensitivity(position, y)
+			res.Estimated = true
+		}
+	}
+
+	if res.Bucket == domain.ScenarioRUB {
+		res.KeyRate = res.Parallel
+	}
+	return res
+}
+
+// reportSensitivity считает облигацию бескупонной с дюрацией MOEX из отчета, если графика платежей нет:
+// выпуклость такой бумаги - верхняя оценка для купонной с той же дюрацией.
+// Для флоатеров MOEX считает дюрацию до ближайшей даты пересмотра купона.
+func reportSensitivity(position generalbondreport.GeneralBondReportPosition, y float64) domain.RateSensitivity {
+	duration := float64(position.Duration) / daysInYear
+	return domain.RateSensitivity{
+		Duration:  duration / (1 + y),
+		Convexity: duration * (duration + 1) / ((1 + y) * (1 + y)),
+	}
+}
+
+// nextReset - ближайшая дата купона флоатера, на которую пересматривается ставка.
+func nextReset(bond domain.MoexBond, hasBond bool, now time.Time) (time.Time, bool) {
+	if !hasBond {
+		return time.Time{}, false
+	}
+	next, ok := parseMoexDate(bond.NextCoupon)
+	if !ok || !next.After(now) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// scheduleAnalytics строит по данным MOEX график с постоянным купоном до оферты или погашения
+// и считает дюрацию и выпуклость при текущей доходности. Амортизации MOEX в режиме торгов
+// не отдает, поэтому для амортизируемых бумаг дюрация получается немного завышенной.
+func scheduleAnalytics(bond domain.MoexBond, hasBond bool, now time.Time, y float64) (bondmath.Analytics, bool) {
+	if !hasBond {
+		return bondmath.Analytics{}, false
+	}
+	schedule, ok := bulletSchedule(bond, now)
+	if !ok {
+		return bondmath.Analytics{}, false
+	}
+	maturity := schedule.Flows[len(schedule.Flows)-1].Date
+	if offer, ok := parseMoexDate(bond.OfferDate); ok && offer.Before(maturity) {
+		var err error
+		if schedule, err = schedule.ToOffer(offer); err != nil {
+			return bondmath.Analytics{}, false
+		}
+	}
+	analytics, _, err := bondmath.AnalyzeAtYield(schedule, now, y)
+	if err != nil {
+		return bondmath.Analytics{}, false
+	}
+	return analytics, true
+}
+
+// scenarioBucket раскладывает облигации так же, как отчет по облигациям, но отделяет юаневые.
+func scenarioBucket(position generalbondreport.GeneralBondReportPosition) domain.ScenarioBucket {
+	switch {
+	case position.Replaced:
+		return domain.ScenarioReplaced
+	case position.Currencies == rub:
+		return domain.ScenarioRUB
+	case position.Currencies == cny:
+		return domain.ScenarioCNY
+	default:
+		return domain.ScenarioEuro
+	}
+}
+
+func parseMoexDate(date domain.NullString) (time.Time, bool) {
+	if !date.IsHasValue() {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(moexDateLayout, date.Value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
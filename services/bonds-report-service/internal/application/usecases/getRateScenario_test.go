@@ -0,0 +1,105 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
+	"bonds-report-service/internal/domain/generalbondreport"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateShift(t *testing.T) {
+	for text, want := range map[string]int{
+		"":       100,
+		"+100bp": 100,
+		"-50BP":  -50,
+		"75":     75,
+		"+25бп":  25,
+		"-1,5%":  -150,
+		"0.25%":  25,
+	} {
+		got, err := ParseRateShift(text)
+		require.NoError(t, err, text)
+		require.Equal(t, want, got, text)
+	}
+
+	for _, text := range []string{"abc", "0bp", "+1001bp", "-11%", "bp"} {
+		_, err := ParseRateShift(text)
+		require.ErrorIs(t, err, domain.ErrInvalidRateShift, text)
+	}
+}
+
+func TestScenarioPositions(t *testing.T) {
+	now := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	fixed := domain.MoexBond{
+		SecID:        "SU26238RMFS4",
+		MaturityDate: domain.NewNullString("2036-05-14", true, false),
+		OfferDate:    domain.NewNullString("", true, true),
+		NextCoupon:   domain.NewNullString("2026-05-20", true, false),
+		CouponValue:  domain.NewNullFloat64(35.4, true, false),
+		CouponPeriod: domain.NewNullFloat64(182, true, false),
+		FaceValue:    domain.NewNullFloat64(1000, true, false),
+	}
+	floater := domain.MoexBond{
+		SecID:        "SU29006RMFS2",
+		MaturityDate: domain.NewNullString("2029-01-29", true, false),
+		NextCoupon:   domain.NewNullString("2026-03-05", true, false),
+		CouponValue:  domain.NewNullFloat64(40.86, true, false),
+		CouponPeriod: domain.NewNullFloat64(182, true, false),
+		FaceValue:    domain.NewNullFloat64(1000, true, false),
+	}
+	positions := []generalbondreport.GeneralBondReportPosition{
+		{Ticker: "SU26238RMFS4", Currencies: "rub", Quantity: 10, CurrentPrice: 587, YieldToMaturity: 14.5},
+		{Ticker: "SU29006RMFS2", Currencies: "rub", Quantity: 5, CurrentPrice: 999, YieldToMaturity: 17},
+		{Ticker: "RU000A105A95", Currencies: "cny", Quantity: 3, CurrentPrice: 100, YieldToMaturity: 10, Duration: 365},
+		{Ticker: "XS0191754729", Currencies: "usd", Replaced: true, Quantity: 1, CurrentPrice: 1000, Duration: 730},
+		{Ticker: "RU000A1068X9", Currencies: "rub", FloatingCoupon: true, Quantity: 4, CurrentPrice: 1010, YieldToMaturity: 20, Duration: 73},
+		// та же бумага на втором счете
+		{Ticker: "SU26238RMFS4", Currencies: "rub", Quantity: 2, CurrentPrice: 587, YieldToMaturity: 14.5},
+	}
+	bonds := map[string]domain.MoexBond{fixed.SecID: fixed, floater.SecID: floater}
+
+	got := scenarioPositions(positions, bonds, now)
+	require.Len(t, got, 5)
+
+	ofz := got[0]
+	require.Equal(t, domain.ScenarioRUB, ofz.Bucket)
+	require.InDelta(t, 587.0*12, ofz.Value, 1e-9)
+	require.False(t, ofz.Floater)
+	require.False(t, ofz.Estimated)
+	schedule, err := bondmath.NewBulletSchedule(time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2036, 5, 14, 0, 0, 0, 0, time.UTC), 182, 35.4, 1000)
+	require.NoError(t, err)
+	analytics, _, err := bondmath.AnalyzeAtYield(schedule, now, 0.145)
+	require.NoError(t, err)
+	require.InDelta(t, analytics.ModifiedDuration, ofz.Parallel.Duration, 1e-9)
+	require.InDelta(t, analytics.Convexity, ofz.Parallel.Convexity, 1e-9)
+	require.Equal(t, ofz.Parallel, ofz.KeyRate, "fixed RUB bond follows the key rate")
+
+	frn := got[1]
+	require.True(t, frn.Floater, "SU29 series is a floater")
+	require.InDelta(t, 30.0/365/1.17, frn.KeyRate.Duration, 1e-9, "floater reprices until the next coupon")
+	require.Zero(t, frn.KeyRate.Convexity)
+	require.Equal(t, frn.KeyRate, frn.Parallel, "parallel shift is capped at the next coupon too")
+	require.False(t, frn.Estimated)
+
+	cnyBond := got[2]
+	require.Equal(t, domain.ScenarioCNY, cnyBond.Bucket)
+	require.True(t, cnyBond.Estimated, "no MOEX data")
+	require.InDelta(t, 1/1.1, cnyBond.Parallel.Duration, 1e-9)
+	require.InDelta(t, 2/(1.1*1.1), cnyBond.Parallel.Convexity, 1e-9)
+	require.Zero(t, cnyBond.KeyRate)
+
+	require.Equal(t, domain.ScenarioReplaced, got[3].Bucket)
+	require.Zero(t, got[3].KeyRate)
+
+	noSchedule := got[4]
+	require.True(t, noSchedule.Floater, "Tinkoff floating coupon flag")
+	require.True(t, noSchedule.Estimated, "no MOEX next coupon date")
+	require.InDelta(t, 0.2/1.2, noSchedule.KeyRate.Duration, 1e-9)
+	require.Equal(t, noSchedule.Parallel, noSchedule.KeyRate)
+}
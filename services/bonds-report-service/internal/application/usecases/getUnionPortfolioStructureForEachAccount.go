@@ -105,6 +105,7 @@ func (s *Service) portfolioWorkers(p *pipeline, in <-chan domain.Account, out ch
 			p.sendErr(e.WrapIfErr("can't get portfolio from Tinkoff", err))
 			return
 		}
+		portfolio.AccountID = account.ID
 
 		select {
 		case <-p.ctx.Done():
@@ -122,6 +123,7 @@ func (s *Service) portfolioStructureWorkers(p *pipeline, in <-chan domain.Portfo
 			p.sendErr(e.WrapIfErr("couldnot divide by type", err))
 			return
 		}
+		s.addBlockedCash(p.ctx, portfolio.AccountID, portfolioStructure)
 		select {
 		case <-p.ctx.Done():
 			return
@@ -113,6 +113,7 @@ func (s *Service) worker(p *pipeline, in <-chan domain.Account, out chan<- *doma
 			p.sendErr(e.WrapIfErr("couldnot divide by type", err))
 			return
 		}
+		s.addBlockedCash(p.ctx, account.ID, portfolioStructure)
 		select {
 		case <-p.ctx.Done():
 			return
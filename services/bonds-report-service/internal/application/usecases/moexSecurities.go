@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// moexSecurities запрашивает у MOEX названия, лоты, секторы и цены акций, фондов и валют
+// из позиций. Это дополнение к отчету, поэтому при ошибке MOEX отчет строится без него.
+func (s *Service) moexSecurities(ctx context.Context, positions []domain.PortfolioPosition) domain.MoexSecurities {
+	const op = "service.moexSecurities"
+
+	shares, etfs, currencies := tickersByMarket(positions)
+	if len(shares) == 0 && len(etfs) == 0 && len(currencies) == 0 {
+		return domain.MoexSecurities{}
+	}
+	securities, err := s.External.Moex.GetSecurities(ctx, shares, etfs, currencies)
+	if err != nil {
+		s.logger.WarnContext(ctx, "could not get securities from moex", slog.String("op", op), slog.Any("error", err))
+		return domain.MoexSecurities{}
+	}
+	return securities
+}
+
+// tickersByMarket раскладывает тикеры позиций по рынкам MOEX. Облигации и фьючерсы пропускаются.
+func tickersByMarket(positions []domain.PortfolioPosition) (shares, etfs, currencies []string) {
+	add := func(list []string, ticker string) []string {
+		if ticker == "" || slices.Contains(list, ticker) {
+			return list
+		}
+		return append(list, ticker)
+	}
+	for _, position := range positions {
+		switch position.InstrumentType {
+		case share:
+			shares = add(shares, position.Ticker)
+		case etf:
+			etfs = add(etfs, position.Ticker)
+		case currency:
+			currencies = add(currencies, position.Ticker)
+		}
+	}
+	return shares, etfs, currencies
+}
+
+// moexPositions возвращает данные MOEX по позициям в порядке портфеля: акции, фонды, валюты.
+func moexPositions(positions []domain.PortfolioPosition, securities domain.MoexSecurities) []domain.MoexSecurity {
+	shares, etfs, currencies := tickersByMarket(positions)
+	res := make([]domain.MoexSecurity, 0, len(securities))
+	for _, ticker := range slices.Concat(shares, etfs, currencies) {
+		if security, ok := securities[ticker]; ok {
+			res = append(res, security)
+		}
+	}
+	return res
+}
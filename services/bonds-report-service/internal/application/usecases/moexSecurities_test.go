@@ -0,0 +1,58 @@
+//go:build unit
+
+package usecases
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickersByMarket(t *testing.T) {
+	positions := []domain.PortfolioPosition{
+		{Ticker: "SBER", InstrumentType: share},
+		{Ticker: "SU26238RMFS4", InstrumentType: bond},
+		{Ticker: "TMOS", InstrumentType: etf},
+		{Ticker: "SBER", InstrumentType: share},
+		{Ticker: "CNYRUB_TOM", InstrumentType: currency},
+		{Ticker: "", InstrumentType: share},
+	}
+
+	shares, etfs, currencies := tickersByMarket(positions)
+	require.Equal(t, []string{"SBER"}, shares)
+	require.Equal(t, []string{"TMOS"}, etfs)
+	require.Equal(t, []string{"CNYRUB_TOM"}, currencies)
+}
+
+func TestMoexPositions(t *testing.T) {
+	positions := []domain.PortfolioPosition{
+		{Ticker: "CNYRUB_TOM", InstrumentType: currency},
+		{Ticker: "TMOS", InstrumentType: etf},
+		{Ticker: "GAZP", InstrumentType: share},
+		{Ticker: "SBER", InstrumentType: share},
+	}
+	securities := domain.MoexSecurities{
+		"SBER":       {SecID: "SBER"},
+		"TMOS":       {SecID: "TMOS"},
+		"CNYRUB_TOM": {SecID: "CNYRUB_TOM"},
+	}
+
+	got := moexPositions(positions, securities)
+	ids := make([]string, 0, len(got))
+	for _, security := range got {
+		ids = append(ids, security.SecID)
+	}
+	// GAZP нет в ответе MOEX, поэтому его строка пропускается
+	require.Equal(t, []string{"SBER", "TMOS", "CNYRUB_TOM"}, ids)
+}
+
+func TestAddMoexNames(t *testing.T) {
+	accounts := []domain.AccountDividends{
+		{Dividends: []domain.UpcomingDividend{{Ticker: "SBER"}, {Ticker: "GAZP"}}},
+	}
+
+	addMoexNames(accounts, domain.MoexSecurities{"SBER": {SecID: "SBER", ShortName: "Сбербанк"}})
+	require.Equal(t, "Сбербанк", accounts[0].Dividends[0].Name)
+	require.Empty(t, accounts[0].Dividends[1].Name)
+}
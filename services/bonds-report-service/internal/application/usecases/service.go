@@ -37,20 +37,23 @@ const (
 )
 
 type ExternalApis struct {
-	Moex ports.MoexClient
-	Cbr  ports.CbrClient
-	Sber *sber.Client
+	Moex    ports.MoexClient
+	Cbr     ports.CbrClient
+	Sber    *sber.Client
+	Ratings ports.RatingSource
 }
 
 func NewExternalApis(
 	moex ports.MoexClient,
 	cbr ports.CbrClient,
 	sber *sber.Client,
+	ratings ports.RatingSource,
 ) *ExternalApis {
 	return &ExternalApis{
-		Moex: moex,
-		Cbr:  cbr,
-		Sber: sber,
+		Moex:    moex,
+		Cbr:     cbr,
+		Sber:    sber,
+		Ratings: ratings,
 	}
 }
 
@@ -94,27 +97,32 @@ func NewHelpers(
 }
 
 type Service struct {
-	logger        *slog.Logger
-	WorkersNumber int
-	External      *ExternalApis
-	Helpers       *Helpers
-	Storage       ports.Storage
-	now           func() time.Time
+	logger           *slog.Logger
+	WorkersNumber    int
+	IssuerShareLimit float64 // допустимая доля одного эмитента в портфеле, %
+	External         *ExternalApis
+	Helpers          *Helpers
+	Storage          ports.Storage
+	issuers          *issuerCache
+	now              func() time.Time
 }
 
 func NewService(
 	logger *slog.Logger,
 	workersNumber int,
+	issuerShareLimit float64,
 	externalApis *ExternalApis,
 	helpers *Helpers,
 	storage ports.Storage,
 ) *Service {
 	return &Service{
-		logger:        logger,
-		WorkersNumber: workersNumber,
-		External:      externalApis,
-		Helpers:       helpers,
-		Storage:       storage,
-		now:           time.Now,
+		logger:           logger,
+		WorkersNumber:    workersNumber,
+		IssuerShareLimit: issuerShareLimit,
+		External:         externalApis,
+		Helpers:          helpers,
+		Storage:          storage,
+		issuers:          newIssuerCache(),
+		now:              time.Now,
 	}
 }
@@ -18,6 +18,8 @@ type Config struct {
 	DbType                    string       `yaml:"dbType"`
 	ServiceStorageSQLLitePath string       `yaml:"serviceStorageSQLLitePath"`
 	WorkersNubmer             int          `yaml:"workersNumber"`
+	RatingsPath               string       `yaml:"ratingsPath" env:"RATINGS_PATH"`
+	IssuerShareLimit          float64      `yaml:"issuerShareLimit" env-default:"10"` // допустимая доля одного эмитента в портфеле, %
 	Clients                   Clients      `yaml:"clients"`
 	PostgresHost              PostgresHost `yaml:"postgresHost"`
 }
@@ -0,0 +1,140 @@
+package bondmath
+
+import (
+	"math"
+	"time"
+)
+
+// Analytics - доходность и чувствительность цены облигации к доходности.
+// Доходность эффективная годовая, как YIELD и YIELDCLOSE на MOEX, но в долях: 0.12 - это 12%.
+type Analytics struct {
+	Yield            float64
+	MacaulayDuration float64 // годы
+	ModifiedDuration float64 // годы
+	Convexity        float64 // годы в квадрате
+}
+
+// YieldPercent - доходность в процентах годовых, как ее публикует MOEX.
+func (a Analytics) YieldPercent() float64 {
+	return a.Yield * 100
+}
+
+// DurationDays - дюрация Маколея в днях, как поле DURATION на MOEX.
+func (a Analytics) DurationDays() float64 {
+	return a.MacaulayDuration * daysInYear
+}
+
+// Analyze считает доходность, дюрацию и выпуклость по полной цене одной бумаги
+// (чистая цена плюс НКД) на дату расчетов. Платежи дисконтируются по методике MOEX:
+// PV = CF / (1 + y)^(t/365), где t - календарные дни от даты расчетов до платежа.
+// Для доходности к оферте график нужно сначала обрезать через Schedule.ToOffer.
+func Analyze(s Schedule, settlement time.Time, dirtyPrice float64) (Analytics, error) {
+	y, err := Yield(s, settlement, dirtyPrice)
+	if err != nil {
+		return Analytics{}, err
+	}
+	return analyticsAtYield(s.futureFlows(settlement), settlement, y), nil
+}
+
+// AnalyzeAtYield считает цену и чувствительность по известной доходности y (в долях).
+// Нужна, чтобы проверить бумагу по доходности MOEX или пересчитать цену после сдвига ставок.
+func AnalyzeAtYield(s Schedule, settlement time.Time, y float64) (Analytics, float64, error) {
+	flows := s.futureFlows(settlement)
+	if len(flows) == 0 {
+		return Analytics{}, 0, ErrNoFutureFlows
+	}
+	return analyticsAtYield(flows, settlement, y), presentValue(flows, settlement, y), nil
+}
+
+// Price - полная цена одной бумаги при доходности y (в долях).
+func Price(s Schedule, settlement time.Time, y float64) float64 {
+	return presentValue(s.futureFlows(settlement), settlement, y)
+}
+
+// Yield находит доходность, при которой дисконтированные платежи равны полной цене.
+// Цена убывает по доходности, поэтому корень единственный: метод Ньютона с защитой
+// бисекцией не выходит за интервал, где цена меняет знак.
+func Yield(s Schedule, settlement time.Time, dirtyPrice float64) (float64, error) {
+	if dirtyPrice <= 0 || math.IsNaN(dirtyPrice) || math.IsInf(dirtyPrice, 0) {
+		return 0, ErrInvalidPrice
+	}
+	flows := s.futureFlows(settlement)
+	if len(flows) == 0 {
+		return 0, ErrNoFutureFlows
+	}
+
+	diff := func(y float64) float64 {
+		return presentValue(flows, settlement, y) - dirtyPrice
+	}
+	lo, hi := yieldMin, yieldMax
+	if diff(lo) < 0 || diff(hi) > 0 {
+		return 0, ErrYieldNotFound
+	}
+
+	y := 0.1
+	for i := 0; i < yieldMaxIter; i++ {
+		f := diff(y)
+		if math.Abs(f) < yieldTolerance*dirtyPrice {
+			return y, nil
+		}
+		if f > 0 {
+			lo = y
+		} else {
+			hi = y
+		}
+		next := y - f/priceDerivative(flows, settlement, y)
+		if next <= lo || next >= hi || math.IsNaN(next) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-y) < yieldTolerance {
+			return next, nil
+		}
+		y = next
+	}
+	return y, nil
+}
+
+func analyticsAtYield(flows []CashFlow, settlement time.Time, y float64) Analytics {
+	var price, weighted, convexity float64
+	for _, flow := range flows {
+		t := yearFraction(settlement, flow.Date)
+		pv := flow.Amount() * discount(y, t)
+		price += pv
+		weighted += t * pv
+		convexity += t * (t + 1) * pv
+	}
+	res := Analytics{Yield: y}
+	if price == 0 {
+		return res
+	}
+	res.MacaulayDuration = weighted / price
+	res.ModifiedDuration = res.MacaulayDuration / (1 + y)
+	res.Convexity = convexity / price / ((1 + y) * (1 + y))
+	return res
+}
+
+func presentValue(flows []CashFlow, settlement time.Time, y float64) float64 {
+	var res float64
+	for _, flow := range flows {
+		res += flow.Amount() * discount(y, yearFraction(settlement, flow.Date))
+	}
+	return res
+}
+
+// priceDerivative - производная цены по доходности: -sum(t * CF / (1 + y)^(t+1)).
+func priceDerivative(flows []CashFlow, settlement time.Time, y float64) float64 {
+	var res float64
+	for _, flow := range flows {
+		t := yearFraction(settlement, flow.Date)
+		res -= t * flow.Amount() * discount(y, t) / (1 + y)
+	}
+	return res
+}
+
+func discount(y, t float64) float64 {
+	return math.Pow(1+y, -t)
+}
+
+func yearFraction(from, to time.Time) float64 {
+	return days(from, to) / daysInYear
+}
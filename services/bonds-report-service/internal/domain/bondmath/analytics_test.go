@@ -0,0 +1,87 @@
+//go:build unit
+
+package bondmath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_ZeroCoupon(t *testing.T) {
+	s, err := NewSchedule(date(2025, 1, 15), []CashFlow{{Date: date(2026, 1, 15), Amortization: 1000}})
+	require.NoError(t, err)
+
+	got, err := Analyze(s, date(2025, 1, 15), 900)
+	require.NoError(t, err)
+	require.InDelta(t, 1000.0/900-1, got.Yield, 1e-9)
+	require.InDelta(t, 365, got.DurationDays(), 1e-6)
+	require.InDelta(t, 0.9, got.ModifiedDuration, 1e-9)
+	require.InDelta(t, 2*0.81, got.Convexity, 1e-9)
+}
+
+func TestAnalyze_ParBond(t *testing.T) {
+	// купон 10% раз в год, цена на дату купона равна номиналу: доходность равна купону,
+	// дюрация Маколея - (1+y)/y * (1 - (1+y)^-n)
+	s, err := NewSchedule(date(2025, 1, 15), []CashFlow{
+		{Date: date(2026, 1, 15), Coupon: 100},
+		{Date: date(2027, 1, 15), Coupon: 100},
+		{Date: date(2028, 1, 15), Coupon: 100, Amortization: 1000},
+	})
+	require.NoError(t, err)
+
+	got, err := Analyze(s, date(2025, 1, 15), 1000)
+	require.NoError(t, err)
+	require.InDelta(t, 10, got.YieldPercent(), 1e-7)
+	require.InDelta(t, 11*(1-1/1.331), got.MacaulayDuration, 1e-7)
+}
+
+func TestAnalyze_Amortizing(t *testing.T) {
+	// Ожидаемые значения посчитаны отдельно по методике MOEX: эффективная доходность,
+	// дисконтирование по календарным дням Act/365, дюрация Маколея.
+	s := amortizingSchedule(t)
+	settlement := date(2025, 6, 10)
+	dirty := s.DirtyPrice(98.5, settlement)
+
+	got, err := Analyze(s, settlement, dirty)
+	require.NoError(t, err)
+	require.InDelta(t, 9.3025, got.YieldPercent(), 1e-4)
+	require.InDelta(t, 1.389380, got.MacaulayDuration, 1e-6)
+	require.InDelta(t, 1.271133, got.ModifiedDuration, 1e-6)
+	require.InDelta(t, 2.888462, got.Convexity, 1e-6)
+	require.InDelta(t, 507, got.DurationDays(), 1)
+
+	toOffer, err := s.ToOffer(date(2026, 3, 1))
+	require.NoError(t, err)
+	got, err = Analyze(toOffer, settlement, dirty)
+	require.NoError(t, err)
+	require.InDelta(t, 10.4513, got.YieldPercent(), 1e-4)
+	require.InDelta(t, 0.704029, got.MacaulayDuration, 1e-6)
+}
+
+func TestAnalyze_SensitivityMatchesPrice(t *testing.T) {
+	// модифицированная дюрация и выпуклость - первая и вторая производные цены по доходности
+	s, err := NewBulletSchedule(date(2026, 5, 20), date(2036, 5, 14), 182, 35.4, 1000)
+	require.NoError(t, err)
+	settlement := date(2026, 2, 3)
+	const y, h = 0.145, 1e-4
+
+	got, price, err := AnalyzeAtYield(s, settlement, y)
+	require.NoError(t, err)
+	up, down := Price(s, settlement, y+h), Price(s, settlement, y-h)
+	require.InDelta(t, (down-up)/(2*h)/price, got.ModifiedDuration, 1e-4)
+	require.InDelta(t, (up+down-2*price)/(h*h)/price, got.Convexity, 1e-2)
+}
+
+func TestYield_Errors(t *testing.T) {
+	s := amortizingSchedule(t)
+
+	_, err := Yield(s, date(2025, 6, 10), 0)
+	require.ErrorIs(t, err, ErrInvalidPrice)
+
+	_, err = Yield(s, date(2027, 3, 1), 1000)
+	require.ErrorIs(t, err, ErrNoFutureFlows)
+
+	_, err = Yield(s, date(2025, 6, 10), 1e9)
+	require.ErrorIs(t, err, ErrYieldNotFound)
+}
@@ -0,0 +1,13 @@
+package bondmath
+
+const (
+	hoursInDay = 24
+	daysInYear = 365 // MOEX считает доходность и дюрацию по базису Act/365
+)
+
+const (
+	yieldMin       = -0.99 // нижняя граница поиска доходности: цена почти в сто раз выше суммы платежей
+	yieldMax       = 100.0 // верхняя граница: 10000% годовых
+	yieldTolerance = 1e-10
+	yieldMaxIter   = 200
+)
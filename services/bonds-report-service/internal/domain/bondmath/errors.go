@@ -0,0 +1,14 @@
+package bondmath
+
+import "errors"
+
+var (
+	ErrEmptySchedule      = errors.New("schedule has no cash flows")
+	ErrNegativeFlow       = errors.New("cash flow couldn't be negative")
+	ErrNoRedemption       = errors.New("schedule has no face value redemption")
+	ErrFlowBeforeStart    = errors.New("cash flow is before the first coupon period")
+	ErrOfferOutOfSchedule = errors.New("offer date is outside the schedule")
+	ErrNoFutureFlows      = errors.New("no cash flows after settlement date")
+	ErrInvalidPrice       = errors.New("price must be positive")
+	ErrYieldNotFound      = errors.New("yield not found for the price")
+)
@@ -0,0 +1,145 @@
+//go:build unit
+
+package bondmath
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// issBlock - таблица ISS в формате columns/data.
+type issBlock struct {
+	Columns []string `json:"columns"`
+	Data    [][]any  `json:"data"`
+}
+
+func (b issBlock) rows() []map[string]any {
+	res := make([]map[string]any, 0, len(b.Data))
+	for _, values := range b.Data {
+		row := make(map[string]any, len(b.Columns))
+		for i, column := range b.Columns {
+			row[column] = values[i]
+		}
+		res = append(res, row)
+	}
+	return res
+}
+
+// issBond - график платежей и итоги торгов облигации из фикстур testdata/iss.
+type issBond struct {
+	Coupons       issBlock `json:"coupons"`
+	Amortizations issBlock `json:"amortizations"`
+	Offers        issBlock `json:"offers"`
+	History       issBlock `json:"history"`
+}
+
+func loadISSBond(t *testing.T, secID string) issBond {
+	t.Helper()
+	var bond issBond
+	for _, name := range []string{secID + ".bondization.json", secID + ".history.json"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "iss", name))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &bond))
+	}
+	require.Len(t, bond.History.Data, 1, "fixture must hold one trading day")
+	return bond
+}
+
+func issDate(t *testing.T, v any) time.Time {
+	t.Helper()
+	s, ok := v.(string)
+	require.True(t, ok, "date %v", v)
+	d, err := time.Parse(time.DateOnly, s)
+	require.NoError(t, err)
+	return d
+}
+
+func issFloat(t *testing.T, v any) float64 {
+	t.Helper()
+	f, ok := v.(float64)
+	require.True(t, ok, "number %v", v)
+	return f
+}
+
+// schedule собирает график из купонов и амортизаций ISS; погашение в дату оферты
+// подставляется, если оферта еще впереди, потому что YIELDCLOSE по таким бумагам - к оферте.
+func (b issBond) schedule(t *testing.T, settlement time.Time) Schedule {
+	t.Helper()
+	flows := make(map[time.Time]CashFlow)
+	var start time.Time
+	for _, row := range b.Coupons.rows() {
+		date := issDate(t, row["coupondate"])
+		if rowStart := issDate(t, row["startdate"]); start.IsZero() || rowStart.Before(start) {
+			start = rowStart
+		}
+		flow := flows[date]
+		flow.Date = date
+		flow.Coupon = issFloat(t, row["value"])
+		flows[date] = flow
+	}
+	for _, row := range b.Amortizations.rows() {
+		date := issDate(t, row["amortdate"])
+		flow := flows[date]
+		flow.Date = date
+		flow.Amortization += issFloat(t, row["value"])
+		flows[date] = flow
+	}
+	list := make([]CashFlow, 0, len(flows))
+	for _, flow := range flows {
+		list = append(list, flow)
+	}
+	s, err := NewSchedule(start, list)
+	require.NoError(t, err)
+
+	for _, row := range b.Offers.rows() {
+		if offer := issDate(t, row["offerdate"]); offer.After(settlement) {
+			s, err = s.ToOffer(offer)
+			require.NoError(t, err)
+			break
+		}
+	}
+	return s
+}
+
+// nextWorkday - дата расчетов T+1, на которую MOEX считает доходность по итогам дня.
+func nextWorkday(date time.Time) time.Time {
+	date = date.AddDate(0, 0, 1)
+	for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// TestAnalyze_MatchesMoex сверяет доходность и дюрацию с YIELDCLOSE и DURATION, которые
+// MOEX публикует по цене закрытия. Фикстуры обновляет testdata/iss/fetch.sh.
+func TestAnalyze_MatchesMoex(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		secID string
+	}{
+		{name: "ofz-pd", secID: "SU26238RMFS4"},
+		{name: "amortizing ofz-ad", secID: "SU46020RMFS2"},
+		{name: "offer", secID: "RU000A105ZS4"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bond := loadISSBond(t, tc.secID)
+			day := bond.History.rows()[0]
+			settlement := nextWorkday(issDate(t, day["TRADEDATE"]))
+			s := bond.schedule(t, settlement)
+
+			require.InDelta(t, issFloat(t, day["FACEVALUE"]), s.Outstanding(settlement), 0.01)
+			require.InDelta(t, issFloat(t, day["ACCINT"]), s.AccruedInterest(settlement), 0.01)
+
+			dirtyPrice := issFloat(t, day["CLOSE"])/100*issFloat(t, day["FACEVALUE"]) + issFloat(t, day["ACCINT"])
+			got, err := Analyze(s, settlement, dirtyPrice)
+			require.NoError(t, err)
+			require.InDelta(t, issFloat(t, day["YIELDCLOSE"]), got.YieldPercent(), 0.05)
+			require.InDelta(t, issFloat(t, day["DURATION"]), got.DurationDays(), 2)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package bondmath
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// CashFlow - платеж по одной облигации в валюте номинала.
+type CashFlow struct {
+	Date         time.Time
+	Coupon       float64
+	Amortization float64 // погашение части номинала, в последнем платеже - весь остаток
+}
+
+func (c CashFlow) Amount() float64 {
+	return c.Coupon + c.Amortization
+}
+
+// Schedule - график купонов и амортизаций облигации.
+type Schedule struct {
+	Start time.Time  // начало первого купонного периода, обычно дата размещения
+	Flows []CashFlow // по возрастанию дат
+}
+
+// NewSchedule сортирует платежи по датам и проверяет, что в графике есть погашение номинала.
+func NewSchedule(start time.Time, flows []CashFlow) (Schedule, error) {
+	if len(flows) == 0 {
+		return Schedule{}, ErrEmptySchedule
+	}
+	sorted := make([]CashFlow, len(flows))
+	copy(sorted, flows)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	var face float64
+	for _, flow := range sorted {
+		if flow.Coupon < 0 || flow.Amortization < 0 {
+			return Schedule{}, ErrNegativeFlow
+		}
+		face += flow.Amortization
+	}
+	if face == 0 {
+		return Schedule{}, ErrNoRedemption
+	}
+	if !start.IsZero() && sorted[0].Date.Before(start) {
+		return Schedule{}, ErrFlowBeforeStart
+	}
+	return Schedule{Start: start, Flows: sorted}, nil
+}
+
+// NewBulletSchedule строит график облигации с постоянным купоном и погашением номинала
+// в конце срока по полям MOEX: дате ближайшего купона, длительности купона в днях, размеру
+// купона и номиналу. Купоны идут с шагом periodDays, последний совпадает с погашением.
+// Для бумаг с амортизацией или переменным купоном график нужно собирать из платежей.
+func NewBulletSchedule(nextCoupon, maturity time.Time, periodDays int, coupon, face float64) (Schedule, error) {
+	if periodDays <= 0 || days(nextCoupon, maturity) < 0 {
+		return Schedule{}, ErrEmptySchedule
+	}
+	var flows []CashFlow
+	for date := nextCoupon; days(date, maturity) > 0; date = date.AddDate(0, 0, periodDays) {
+		flows = append(flows, CashFlow{Date: date, Coupon: coupon})
+	}
+	flows = append(flows, CashFlow{Date: maturity, Coupon: coupon, Amortization: face})
+	return NewSchedule(nextCoupon.AddDate(0, 0, -periodDays), flows)
+}
+
+// Outstanding - непогашенный номинал на дату: амортизации, выплаченные в этот день, уже вычтены.
+func (s Schedule) Outstanding(date time.Time) float64 {
+	var res float64
+	for _, flow := range s.futureFlows(date) {
+		res += flow.Amortization
+	}
+	return res
+}
+
+// AccruedInterest - НКД на дату: купон текущего периода пропорционально прошедшим дням.
+// Начало первого периода берется из Start, без него НКД по первому купону не считается.
+func (s Schedule) AccruedInterest(date time.Time) float64 {
+	periodStart := s.Start
+	for _, flow := range s.Flows {
+		if days(date, flow.Date) > 0 {
+			if periodStart.IsZero() || days(periodStart, date) < 0 {
+				return 0
+			}
+			periodDays := days(periodStart, flow.Date)
+			if periodDays <= 0 {
+				return 0
+			}
+			return flow.Coupon * days(periodStart, date) / periodDays
+		}
+		periodStart = flow.Date
+	}
+	return 0
+}
+
+// DirtyPrice переводит чистую цену в процентах от номинала, как ее показывает биржа,
+// в полную цену одной бумаги: с учетом амортизаций и НКД на дату расчетов.
+func (s Schedule) DirtyPrice(cleanPercent float64, settlement time.Time) float64 {
+	return cleanPercent/100*s.Outstanding(settlement) + s.AccruedInterest(settlement)
+}
+
+// ToOffer обрезает график датой оферты: весь непогашенный номинал выплачивается в этот день.
+// Купон, выплачиваемый в день оферты, сохраняется.
+func (s Schedule) ToOffer(offer time.Time) (Schedule, error) {
+	if len(s.Flows) == 0 {
+		return Schedule{}, ErrEmptySchedule
+	}
+	last := s.Flows[len(s.Flows)-1].Date
+	if days(offer, last) < 0 || (!s.Start.IsZero() && days(s.Start, offer) <= 0) {
+		return Schedule{}, ErrOfferOutOfSchedule
+	}
+
+	res := Schedule{Start: s.Start}
+	for _, flow := range s.Flows {
+		if days(offer, flow.Date) > 0 {
+			break
+		}
+		res.Flows = append(res.Flows, flow)
+	}
+	redemption := s.Outstanding(offer)
+	if n := len(res.Flows); n > 0 && days(res.Flows[n-1].Date, offer) == 0 {
+		res.Flows[n-1].Amortization += redemption
+	} else {
+		res.Flows = append(res.Flows, CashFlow{Date: offer, Amortization: redemption})
+	}
+	return res, nil
+}
+
+// futureFlows - платежи строго после даты расчетов: платеж в день сделки получает продавец.
+func (s Schedule) futureFlows(settlement time.Time) []CashFlow {
+	i := sort.Search(len(s.Flows), func(i int) bool {
+		return days(settlement, s.Flows[i].Date) > 0
+	})
+	return s.Flows[i:]
+}
+
+// days - число календарных дней между датами без учета времени суток.
+func days(from, to time.Time) float64 {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	return math.Round(time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Sub(time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)).Hours() / hoursInDay)
+}
@@ -0,0 +1,94 @@
+//go:build unit
+
+package bondmath
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// amortizingSchedule - полугодовой купон 8% годовых, половина номинала гасится
+// вместе с третьим купоном, вторая половина - с четвертым.
+func amortizingSchedule(t *testing.T) Schedule {
+	t.Helper()
+	s, err := NewSchedule(date(2025, 3, 1), []CashFlow{
+		{Date: date(2026, 9, 1), Coupon: 40, Amortization: 500},
+		{Date: date(2025, 9, 1), Coupon: 40},
+		{Date: date(2027, 3, 1), Coupon: 20, Amortization: 500},
+		{Date: date(2026, 3, 1), Coupon: 40},
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewSchedule(t *testing.T) {
+	s := amortizingSchedule(t)
+	require.Equal(t, date(2025, 9, 1), s.Flows[0].Date, "flows are sorted")
+	require.Equal(t, date(2027, 3, 1), s.Flows[3].Date)
+
+	_, err := NewSchedule(time.Time{}, nil)
+	require.ErrorIs(t, err, ErrEmptySchedule)
+
+	_, err = NewSchedule(time.Time{}, []CashFlow{{Date: date(2025, 9, 1), Coupon: 40}})
+	require.ErrorIs(t, err, ErrNoRedemption)
+
+	_, err = NewSchedule(time.Time{}, []CashFlow{{Date: date(2025, 9, 1), Coupon: -1, Amortization: 1000}})
+	require.ErrorIs(t, err, ErrNegativeFlow)
+
+	_, err = NewSchedule(date(2026, 1, 1), []CashFlow{{Date: date(2025, 9, 1), Amortization: 1000}})
+	require.ErrorIs(t, err, ErrFlowBeforeStart)
+}
+
+func TestSchedule_OutstandingAndAccruedInterest(t *testing.T) {
+	s := amortizingSchedule(t)
+
+	require.Equal(t, 1000.0, s.Outstanding(date(2025, 6, 10)))
+	require.Equal(t, 500.0, s.Outstanding(date(2026, 9, 1)), "amortization on the date is already paid")
+	require.Equal(t, 0.0, s.Outstanding(date(2027, 3, 1)))
+
+	// 101 день из 184 в первом купонном периоде
+	require.InDelta(t, 40.0*101/184, s.AccruedInterest(date(2025, 6, 10)), 1e-9)
+	require.Equal(t, 0.0, s.AccruedInterest(date(2025, 9, 1)), "coupon date")
+	require.Equal(t, 0.0, s.AccruedInterest(date(2025, 1, 1)), "before placement")
+	require.Equal(t, 0.0, s.AccruedInterest(date(2027, 3, 2)), "after maturity")
+
+	require.InDelta(t, 985+40.0*101/184, s.DirtyPrice(98.5, date(2025, 6, 10)), 1e-9)
+	require.InDelta(t, 490.0, s.DirtyPrice(98, date(2026, 9, 1)), 1e-9)
+}
+
+func TestSchedule_ToOffer(t *testing.T) {
+	s := amortizingSchedule(t)
+
+	got, err := s.ToOffer(date(2026, 3, 1))
+	require.NoError(t, err)
+	require.Equal(t, []CashFlow{
+		{Date: date(2025, 9, 1), Coupon: 40},
+		{Date: date(2026, 3, 1), Coupon: 40, Amortization: 1000},
+	}, got.Flows)
+
+	got, err = s.ToOffer(date(2026, 6, 1))
+	require.NoError(t, err)
+	require.Equal(t, CashFlow{Date: date(2026, 6, 1), Amortization: 1000}, got.Flows[2])
+	require.Len(t, s.Flows, 4, "source schedule is not changed")
+
+	_, err = s.ToOffer(date(2028, 1, 1))
+	require.ErrorIs(t, err, ErrOfferOutOfSchedule)
+}
+
+func TestNewBulletSchedule(t *testing.T) {
+	s, err := NewBulletSchedule(date(2026, 5, 20), date(2027, 11, 16), 182, 35.4, 1000)
+	require.NoError(t, err)
+	require.Equal(t, date(2025, 11, 19), s.Start)
+	require.Len(t, s.Flows, 4)
+	require.Equal(t, date(2026, 11, 18), s.Flows[1].Date)
+	require.Equal(t, CashFlow{Date: date(2027, 11, 16), Coupon: 35.4, Amortization: 1000}, s.Flows[3])
+
+	_, err = NewBulletSchedule(date(2026, 5, 20), date(2025, 1, 1), 182, 35.4, 1000)
+	require.ErrorIs(t, err, ErrEmptySchedule)
+}
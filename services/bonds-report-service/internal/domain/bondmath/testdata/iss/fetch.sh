@@ -0,0 +1,20 @@
+#!/bin/sh
+# Обновляет фикстуры ISS для TestAnalyze_MatchesMoex: график платежей облигации
+# и строку итогов торгов с YIELDCLOSE и DURATION за дату DATE.
+#
+#   ./fetch.sh 2025-10-16
+set -eu
+
+DATE=${1:?usage: fetch.sh YYYY-MM-DD}
+ISS=https://iss.moex.com/iss
+cd "$(dirname "$0")"
+
+# SECID:режим торгов - ОФЗ-ПД, амортизируемая ОФЗ-АД и облигация с офертой
+for bond in SU26238RMFS4:TQOB SU46020RMFS2:TQOB RU000A105ZS4:TQCB; do
+	secid=${bond%%:*}
+	board=${bond##*:}
+	curl -sf "$ISS/securities/$secid/bondization.json?iss.meta=off&iss.only=coupons,amortizations,offers&limit=unlimited" \
+		-o "$secid.bondization.json"
+	curl -sf "$ISS/history/engines/stock/markets/bonds/boards/$board/securities/$secid.json?iss.meta=off&iss.only=history&from=$DATE&till=$DATE&history.columns=TRADEDATE,BOARDID,SECID,CLOSE,ACCINT,YIELDCLOSE,DURATION,FACEVALUE,FACEUNIT" \
+		-o "$secid.history.json"
+done
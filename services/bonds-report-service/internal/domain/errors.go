@@ -6,6 +6,9 @@ var (
 	ErrEmptyUids     = errors.New("no uids")
 	ErrNoCurrency    = errors.New("no currency")
 	ErrNoOpperations = errors.New("no operations")
+	// ErrNoOperationsSyncState - по счету еще не было ни одной выгрузки операций постранично
+	ErrNoOperationsSyncState = errors.New("no operations sync state")
+	ErrOperationsCursorStuck = errors.New("operations cursor did not advance")
 )
 
 var ErrEmptyUidAfterUpdate = errors.New("asset uid by this instrument uid is not exist")
@@ -29,4 +32,11 @@ var (
 	ErrEmptyInstrumentShortResponce = errors.New("instrument short responce is empty")
 	ErrInstrumentNotShare           = errors.New("instrument is not share")
 	ErrEmptyTicker                  = errors.New("ticker is empty")
+	ErrInvalidChartPeriod           = errors.New("invalid chart period")
 )
+
+var ErrInvalidScreenerFilter = errors.New("invalid screener filter")
+
+var ErrInvalidRateShift = errors.New("invalid rate shift")
+
+var ErrUnknownAccount = errors.New("unknown account")
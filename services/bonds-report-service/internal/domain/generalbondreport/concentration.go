@@ -0,0 +1,128 @@
+package generalbondreport
+
+import (
+	"bonds-report-service/internal/domain"
+	"slices"
+	"sort"
+)
+
+// IssuerShare - доля выпусков одного эмитента в портфеле.
+type IssuerShare struct {
+	Issuer  domain.BondIssuer
+	Name    string   // название эмитента, а если он не найден - название облигации
+	Tickers []string // выпуски эмитента в отчете
+	Percent float64  // доля в портфеле, %
+}
+
+// RatingShare - доля облигаций рейтинговой группы в портфеле.
+type RatingShare struct {
+	Bucket  domain.RatingBucket
+	Percent float64
+}
+
+// IssuerConcentration - распределение облигаций портфеля по эмитентам и рейтингам.
+// Limit - допустимая доля одного эмитента в процентах.
+type IssuerConcentration struct {
+	Limit   float64
+	Issuers []IssuerShare // по убыванию доли
+	Ratings []RatingShare // в порядке domain.RatingBuckets, пустые группы пропущены
+}
+
+// OverLimit возвращает эмитентов, доля которых больше лимита.
+func (c IssuerConcentration) OverLimit() []IssuerShare {
+	var res []IssuerShare
+	for _, issuer := range c.Issuers {
+		if c.Limit > 0 && issuer.Percent > c.Limit {
+			res = append(res, issuer)
+		}
+	}
+	return res
+}
+
+// Tickers возвращает тикеры облигаций отчета по instrument uid.
+func (r *GeneralBondReports) Tickers() map[string]string {
+	res := make(map[string]string)
+	for _, report := range r.bondReports() {
+		for _, position := range report {
+			if position.InstrumentUid != "" && position.Ticker != "" {
+				res[position.InstrumentUid] = position.Ticker
+			}
+		}
+	}
+	return res
+}
+
+// ApplyIssuers проставляет позициям отчета эмитентов по тикеру.
+func (r *GeneralBondReports) ApplyIssuers(issuers map[string]domain.BondIssuer) {
+	for _, report := range r.bondReports() {
+		for key, position := range report {
+			position.Issuer = issuers[position.Ticker]
+			report[key] = position
+		}
+	}
+}
+
+func (r *GeneralBondReports) bondReports() []map[TickerTimeKey]GeneralBondReportPosition {
+	return []map[TickerTimeKey]GeneralBondReportPosition{r.RubBondsReport, r.ReplacedBondsReport, r.EuroBondsReport}
+}
+
+// NewIssuerConcentration складывает доли облигаций всех счетов по эмитентам и рейтинговым группам.
+// Доли позиций посчитаны от стоимости своего счета, поэтому в портфеле они взвешиваются
+// стоимостью счетов. Выпуски без найденного эмитента считаются отдельными эмитентами,
+// чтобы не занижать концентрацию.
+func NewIssuerConcentration(limit float64, reports []GeneralBondReports) IssuerConcentration {
+	var total float64
+	for _, r := range reports {
+		total += r.TotalAmount
+	}
+	index := make(map[string]int)
+	ratings := make(map[domain.RatingBucket]float64)
+	res := IssuerConcentration{Limit: limit}
+	for _, r := range reports {
+		weight := 1 / float64(len(reports))
+		if total > 0 {
+			weight = r.TotalAmount / total
+		}
+		for _, report := range r.bondReports() {
+			for _, position := range report {
+				key := position.Issuer.Key()
+				if key == "" {
+					key = "ticker:" + position.Ticker
+				}
+				i, ok := index[key]
+				if !ok {
+					i = len(res.Issuers)
+					index[key] = i
+					name := position.Issuer.Name
+					if name == "" {
+						name = position.Name
+					}
+					res.Issuers = append(res.Issuers, IssuerShare{Issuer: position.Issuer, Name: name})
+				}
+				percent := position.PercentOfPortfolio * weight
+				issuer := &res.Issuers[i]
+				issuer.Percent += percent
+				if !slices.Contains(issuer.Tickers, position.Ticker) {
+					issuer.Tickers = append(issuer.Tickers, position.Ticker)
+				}
+				ratings[domain.NewRatingBucket(position.Issuer.Rating)] += percent
+			}
+		}
+	}
+
+	for i := range res.Issuers {
+		sort.Strings(res.Issuers[i].Tickers)
+	}
+	sort.SliceStable(res.Issuers, func(i, j int) bool {
+		if res.Issuers[i].Percent != res.Issuers[j].Percent {
+			return res.Issuers[i].Percent > res.Issuers[j].Percent
+		}
+		return res.Issuers[i].Name < res.Issuers[j].Name
+	})
+	for _, bucket := range domain.RatingBuckets {
+		if percent, ok := ratings[bucket]; ok {
+			res.Ratings = append(res.Ratings, RatingShare{Bucket: bucket, Percent: percent})
+		}
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+package generalbondreport
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuerConcentration(t *testing.T) {
+	sber := domain.BondIssuer{ID: 1, Name: "Сбербанк", Rating: "AAA(RU)"}
+	samolet := domain.BondIssuer{INN: "9731004688", Name: "Самолет", Rating: "ruA-"}
+	first := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	reports := NewGeneralBondReports()
+	reports.RubBondsReport[NewTickerTimeKey("SBER1", first)] = GeneralBondReportPosition{Ticker: "SBER1", PercentOfPortfolio: 6, Issuer: sber}
+	reports.RubBondsReport[NewTickerTimeKey("SBER1", second)] = GeneralBondReportPosition{Ticker: "SBER1", PercentOfPortfolio: 3, Issuer: sber}
+	reports.RubBondsReport[NewTickerTimeKey("SMLT1", first)] = GeneralBondReportPosition{Ticker: "SMLT1", PercentOfPortfolio: 4, Issuer: samolet}
+	reports.EuroBondsReport[NewTickerTimeKey("SBER2", first)] = GeneralBondReportPosition{Ticker: "SBER2", PercentOfPortfolio: 2, Issuer: sber}
+	reports.ReplacedBondsReport[NewTickerTimeKey("UNKN1", first)] = GeneralBondReportPosition{Ticker: "UNKN1", Name: "Неизвестный 01", PercentOfPortfolio: 5}
+
+	got := NewIssuerConcentration(10, []GeneralBondReports{reports})
+
+	require.Len(t, got.Issuers, 3)
+	require.Equal(t, "Сбербанк", got.Issuers[0].Name)
+	require.Equal(t, []string{"SBER1", "SBER2"}, got.Issuers[0].Tickers)
+	require.InDelta(t, 11, got.Issuers[0].Percent, 1e-9)
+	require.Equal(t, "Неизвестный 01", got.Issuers[1].Name, "issuer not found: bond is its own issuer")
+	require.Equal(t, "Самолет", got.Issuers[2].Name)
+
+	require.Equal(t, []RatingShare{
+		{Bucket: domain.RatingAAA, Percent: 11},
+		{Bucket: domain.RatingA, Percent: 4},
+		{Bucket: domain.RatingNone, Percent: 5},
+	}, got.Ratings)
+
+	over := got.OverLimit()
+	require.Len(t, over, 1)
+	require.Equal(t, "Сбербанк", over[0].Name)
+	require.Empty(t, NewIssuerConcentration(0, []GeneralBondReports{reports}).OverLimit(), "no limit")
+}
+
+func TestIssuerConcentrationAcrossAccounts(t *testing.T) {
+	sber := domain.BondIssuer{ID: 1, Name: "Сбербанк", Rating: "AAA(RU)"}
+	date := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	small := NewGeneralBondReports()
+	small.TotalAmount = 100_000
+	small.RubBondsReport[NewTickerTimeKey("SBER1", date)] = GeneralBondReportPosition{InstrumentUid: "uid-sber1", Ticker: "SBER1",
+		PercentOfPortfolio: 20}
+	small.RubBondsReport[NewTickerTimeKey("SMLT1", date)] = GeneralBondReportPosition{InstrumentUid: "uid-smlt1", Ticker: "SMLT1",
+		Name: "Самолет БО-П13", PercentOfPortfolio: 40}
+	large := NewGeneralBondReports()
+	large.TotalAmount = 300_000
+	large.EuroBondsReport[NewTickerTimeKey("SBER2", date)] = GeneralBondReportPosition{InstrumentUid: "uid-sber2", Ticker: "SBER2",
+		PercentOfPortfolio: 10}
+
+	require.Equal(t, map[string]string{"uid-sber1": "SBER1", "uid-smlt1": "SMLT1"}, small.Tickers())
+	small.ApplyIssuers(map[string]domain.BondIssuer{"SBER1": sber})
+	large.ApplyIssuers(map[string]domain.BondIssuer{"SBER2": sber})
+	require.Equal(t, sber, small.RubBondsReport[NewTickerTimeKey("SBER1", date)].Issuer)
+
+	got := NewIssuerConcentration(10, []GeneralBondReports{small, large})
+
+	require.Len(t, got.Issuers, 2)
+	require.Equal(t, "Сбербанк", got.Issuers[0].Name)
+	require.InDelta(t, 12.5, got.Issuers[0].Percent, 1e-9, "(20%*100k + 10%*300k) / 400k")
+	require.InDelta(t, 10, got.Issuers[1].Percent, 1e-9, "40% of the small account")
+	require.Equal(t, []RatingShare{
+		{Bucket: domain.RatingAAA, Percent: 12.5},
+		{Bucket: domain.RatingNone, Percent: 10},
+	}, got.Ratings)
+}
+
+func TestNewRatingBucket(t *testing.T) {
+	for rating, want := range map[string]domain.RatingBucket{
+		"AAA(RU)": domain.RatingAAA,
+		"ruAA+":   domain.RatingAA,
+		"A-.ru":   domain.RatingA,
+		"BBB|ru|": domain.RatingBBB,
+		"ВВ+":     domain.RatingBB, // кириллица
+		"B-":      domain.RatingB,
+		"RD":      domain.RatingCCC,
+		"ruCCC":   domain.RatingCCC,
+		"":        domain.RatingNone,
+		"отозван": domain.RatingNone,
+	} {
+		require.Equal(t, want, domain.NewRatingBucket(rating), rating)
+	}
+}
+
+func TestRatingsFor(t *testing.T) {
+	ratings := domain.Ratings{"RU000A10BW96": "A+(RU)", "7707083893": "AAA(RU)", "42": "ruBB"}
+
+	require.Equal(t, "A+(RU)", ratings.For("ru000a10bw96", domain.BondIssuer{INN: "7707083893"}), "issue rating first")
+	require.Equal(t, "AAA(RU)", ratings.For("RU000A0ZZ000", domain.BondIssuer{INN: "7707083893"}))
+	require.Equal(t, "ruBB", ratings.For("RU000A0ZZ000", domain.BondIssuer{ID: 42}))
+	require.Empty(t, ratings.For("RU000A0ZZ000", domain.BondIssuer{}))
+}
@@ -23,7 +23,9 @@ const (
 type GeneralBondReportPosition struct {
 	Name                      string
 	Ticker                    string
+	InstrumentUid             string
 	Replaced                  bool
+	FloatingCoupon            bool // флоатер по данным Т-Инвестиций
 	Currencies                string
 	Quantity                  int64
 	PercentOfPortfolio        float64
@@ -37,6 +39,9 @@ type GeneralBondReportPosition struct {
 	Nominal                   float64
 	Profit                    float64 // Результат инвестиции
 	ProfitInPercentage        float64
+	RealYield                 domain.NullFloat64 // Текущая доходность к погашению за вычетом инфляции
+	KeyRateSpread             domain.NullFloat64 // Спред текущей доходности к ключевой ставке ЦБ
+	Issuer                    domain.BondIssuer  // Эмитент, сектор, уровень риска и рейтинг
 }
 
 func (p *GeneralBondReportPosition) CreateGeneralBondReportPosition(
@@ -59,10 +64,12 @@ func (p *GeneralBondReportPosition) CreateGeneralBondReportPosition(
 	}
 
 	ticker := currentPositions[0].Ticker
+	instrumentUid := currentPositions[0].InstrumentUid
 	currency := currentPositions[0].Currency
 	nominal := currentPositions[0].Nominal
 	sellPrice := currentPositions[0].SellPrice
 	replaced := currentPositions[0].Replaced
+	floatingCoupon := currentPositions[0].FloatingCoupon
 
 	sumOfPositionsRes := getSumOfPositions(currentPositions)
 
@@ -96,10 +103,12 @@ func (p *GeneralBondReportPosition) CreateGeneralBondReportPosition(
 	}
 
 	p.Ticker = ticker
+	p.InstrumentUid = instrumentUid
 	p.Currencies = currency
 	p.Nominal = nominal
 	p.CurrentPrice = sellPrice
 	p.Replaced = replaced
+	p.FloatingCoupon = floatingCoupon
 	p.PositionPrice = positionsPrice
 	p.BuyDate = firstBondsBuyDate
 	p.Quantity = quantity
@@ -123,3 +132,19 @@ func (p *GeneralBondReportPosition) CreateGeneralBondReportPosition(
 
 	return nil
 }
+
+// ApplyMacroIndicators считает реальную доходность и спред к ключевой ставке.
+// Ставка и инфляция ЦБ относятся к рублю, поэтому показатели считаются только для рублевых облигаций.
+// Реальная доходность считается по формуле Фишера: (1 + YTM) / (1 + инфляция) - 1.
+func (p *GeneralBondReportPosition) ApplyMacroIndicators(macro domain.MacroIndicators) {
+	if p.Replaced || p.Currencies != "rub" {
+		return
+	}
+	if macro.Inflation.IsHasValue() {
+		realYield := ((1+p.YieldToMaturity/100)/(1+macro.Inflation.Value/100) - 1) * 100
+		p.RealYield = domain.NewNullFloat64(utils.RoundFloat(realYield, 2), true, false)
+	}
+	if macro.KeyRate.IsHasValue() {
+		p.KeyRateSpread = domain.NewNullFloat64(utils.RoundFloat(p.YieldToMaturity-macro.KeyRate.Value, 2), true, false)
+	}
+}
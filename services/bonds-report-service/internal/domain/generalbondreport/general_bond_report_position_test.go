@@ -0,0 +1,44 @@
+//go:build unit
+
+package generalbondreport
+
+import (
+	"bonds-report-service/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMacroIndicators(t *testing.T) {
+	macro := domain.MacroIndicators{
+		KeyRate:   domain.NewNullFloat64(17, true, false),
+		Inflation: domain.NewNullFloat64(8, true, false),
+	}
+
+	t.Run("Rub bond", func(t *testing.T) {
+		position := GeneralBondReportPosition{Currencies: "rub", YieldToMaturity: 18.8}
+		position.ApplyMacroIndicators(macro)
+		// (1 + 0.188) / (1 + 0.08) - 1 = 0.1
+		require.Equal(t, domain.NewNullFloat64(10, true, false), position.RealYield)
+		require.Equal(t, domain.NewNullFloat64(1.8, true, false), position.KeyRateSpread)
+	})
+
+	t.Run("Only key rate known", func(t *testing.T) {
+		position := GeneralBondReportPosition{Currencies: "rub", YieldToMaturity: 15}
+		position.ApplyMacroIndicators(domain.MacroIndicators{KeyRate: macro.KeyRate})
+		require.False(t, position.RealYield.IsHasValue())
+		require.Equal(t, domain.NewNullFloat64(-2, true, false), position.KeyRateSpread)
+	})
+
+	t.Run("Currency and replaced bonds are skipped", func(t *testing.T) {
+		euro := GeneralBondReportPosition{Currencies: "usd", YieldToMaturity: 7}
+		euro.ApplyMacroIndicators(macro)
+		require.False(t, euro.RealYield.IsHasValue())
+		require.False(t, euro.KeyRateSpread.IsHasValue())
+
+		replaced := GeneralBondReportPosition{Currencies: "rub", Replaced: true, YieldToMaturity: 7}
+		replaced.ApplyMacroIndicators(macro)
+		require.False(t, replaced.RealYield.IsHasValue())
+		require.False(t, replaced.KeyRateSpread.IsHasValue())
+	})
+}
@@ -6,6 +6,7 @@ type GeneralBondReports struct {
 	RubBondsReport      map[TickerTimeKey]GeneralBondReportPosition
 	EuroBondsReport     map[TickerTimeKey]GeneralBondReportPosition
 	ReplacedBondsReport map[TickerTimeKey]GeneralBondReportPosition
+	TotalAmount         float64 // стоимость счета, от которой считаются доли позиций
 }
 
 func NewGeneralBondReports() GeneralBondReports {
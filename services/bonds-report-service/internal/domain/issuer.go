@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RiskLevel - уровень риска облигации по оценке Т-Инвестиций.
+type RiskLevel string
+
+const (
+	RiskLevelUnknown  RiskLevel = ""
+	RiskLevelLow      RiskLevel = "low"
+	RiskLevelModerate RiskLevel = "moderate"
+	RiskLevelHigh     RiskLevel = "high"
+)
+
+// NewRiskLevel переводит уровень риска из API Т-Инвестиций: RISK_LEVEL_LOW, RISK_LEVEL_MODERATE,
+// RISK_LEVEL_HIGH. RISK_LEVEL_UNSPECIFIED и незнакомые значения - RiskLevelUnknown.
+func NewRiskLevel(level string) RiskLevel {
+	switch strings.TrimPrefix(strings.ToUpper(level), "RISK_LEVEL_") {
+	case "LOW":
+		return RiskLevelLow
+	case "MODERATE":
+		return RiskLevelModerate
+	case "HIGH":
+		return RiskLevelHigh
+	default:
+		return RiskLevelUnknown
+	}
+}
+
+// MoexIssuer - эмитент облигации по справочнику ISS.
+type MoexIssuer struct {
+	SecID    string
+	IssuerID int64 // код эмитента MOEX, общий для всех его выпусков
+	Name     string
+	INN      string
+	TypeName string // вид бумаги: государственная, корпоративная, муниципальная облигация
+}
+
+// BondIssuer - эмитент облигации: данные MOEX, сектор и уровень риска Т-Инвестиций
+// и рейтинг из файла пользователя.
+type BondIssuer struct {
+	ID        int64 // код эмитента MOEX
+	Name      string
+	INN       string
+	Sector    string
+	RiskLevel RiskLevel
+	Rating    string // рейтинг, как он записан в файле рейтингов
+}
+
+// Key - ключ эмитента для группировки выпусков: код MOEX, а без него ИНН.
+// Пустой ключ значит, что эмитент не найден.
+func (i BondIssuer) Key() string {
+	switch {
+	case i.ID != 0:
+		return "moex:" + strconv.FormatInt(i.ID, 10)
+	case i.INN != "":
+		return "inn:" + i.INN
+	default:
+		return ""
+	}
+}
+
+// Ratings - кредитные рейтинги, которые ведет пользователь. Ключ - тикер выпуска (SECID),
+// ИНН эмитента или код эмитента MOEX, записанные в верхнем регистре.
+type Ratings map[string]string
+
+// For возвращает рейтинг выпуска, а если его нет - рейтинг эмитента по ИНН или коду MOEX.
+func (r Ratings) For(ticker string, issuer BondIssuer) string {
+	keys := []string{strings.ToUpper(ticker), issuer.INN}
+	if issuer.ID != 0 {
+		keys = append(keys, strconv.FormatInt(issuer.ID, 10))
+	}
+	for _, key := range keys {
+		if rating, ok := r[key]; ok && key != "" {
+			return rating
+		}
+	}
+	return ""
+}
+
+// RatingBucket - рейтинговая группа без модификаторов "+" и "-".
+type RatingBucket string
+
+const (
+	RatingAAA  RatingBucket = "AAA"
+	RatingAA   RatingBucket = "AA"
+	RatingA    RatingBucket = "A"
+	RatingBBB  RatingBucket = "BBB"
+	RatingBB   RatingBucket = "BB"
+	RatingB    RatingBucket = "B"
+	RatingCCC  RatingBucket = "CCC" // CCC и ниже, включая дефолт
+	RatingNone RatingBucket = ""
+)
+
+// RatingBuckets - порядок групп в отчете: от надежных к рискованным.
+var RatingBuckets = []RatingBucket{RatingAAA, RatingAA, RatingA, RatingBBB, RatingBB, RatingB, RatingCCC, RatingNone}
+
+// ratingScales - обозначения национальных шкал: АКРА - AA(RU), Эксперт РА - ruAA,
+// НКР - AA.ru, НРА - AA|ru|.
+var ratingScales = strings.NewReplacer("(RU)", "", ".RU", "", "|RU|", "", " ", "")
+
+// cyrillicRating - кириллические буквы, похожие на латинские: рейтинг могут записать по-русски.
+var cyrillicRating = strings.NewReplacer("А", "A", "В", "B", "С", "C", "Д", "D")
+
+// NewRatingBucket относит рейтинг любой шкалы к группе. Нераспознанный рейтинг - RatingNone.
+func NewRatingBucket(rating string) RatingBucket {
+	r := ratingScales.Replace(cyrillicRating.Replace(strings.ToUpper(strings.TrimSpace(rating))))
+	r = strings.TrimRight(strings.TrimPrefix(r, "RU"), "+-")
+	switch RatingBucket(r) {
+	case RatingAAA, RatingAA, RatingA, RatingBBB, RatingBB, RatingB:
+		return RatingBucket(r)
+	}
+	switch r {
+	case "CCC", "CC", "C", "RD", "SD", "D":
+		return RatingCCC
+	default:
+		return RatingNone
+	}
+}
@@ -183,10 +183,13 @@ func NewFuturesType() FuturesType {
 type CurrenciesAssets struct {
 	SumOfAssets      float64
 	AssetsByCurrency map[string]*AssetByParam
+	// Blocked - часть денежных средств в рублях, заблокированная под заявки и гарантийное обеспечение.
+	Blocked float64
 }
 
 func (first *CurrenciesAssets) SumWithCurrenciesAssets(second CurrenciesAssets) {
 	first.SumOfAssets += second.SumOfAssets
+	first.Blocked += second.Blocked
 	first.AssetsByCurrency = SumOfAssetsByCurrency(first.AssetsByCurrency, second.AssetsByCurrency)
 }
 
@@ -222,6 +225,35 @@ func (r OperationsRequest) Validate(now time.Time) error {
 	return nil
 }
 
+// OperationsPageRequest - запрос одной страницы операций: первая страница по периоду, следующие по курсору.
+type OperationsPageRequest struct {
+	AccountID string
+	From      time.Time
+	To        time.Time
+	Cursor    string
+}
+
+type OperationsPage struct {
+	Operations []Operation
+	NextCursor string
+	HasNext    bool
+	From       time.Time
+	To         time.Time
+}
+
+// OperationsSyncState - прогресс постраничной выгрузки операций по счету.
+// Сохраняется вместе с каждой страницей, поэтому после сбоя выгрузка продолжается с последнего курсора.
+type OperationsSyncState struct {
+	AccountID       string
+	From            time.Time
+	To              time.Time
+	Cursor          string
+	Pages           int
+	OperationsSaved int
+	Completed       bool
+	UpdatedAt       time.Time
+}
+
 type ReportLine struct {
 	Operation  []OperationWithoutCustomTypes
 	Bond       BondIdentIdentifiers
@@ -263,6 +295,24 @@ func NewCurrencies(mapCurr map[string]CurrencyCBR) *CurrenciesCBR {
 	}
 }
 
+// KeyRate - ключевая ставка ЦБ в % годовых, действующая с Date.
+type KeyRate struct {
+	Date time.Time
+	Rate float64
+}
+
+// Inflation - годовая инфляция (ИПЦ, % г/г) за месяц, который начинается с Date.
+type Inflation struct {
+	Date      time.Time
+	Inflation float64
+}
+
+// MacroIndicators - последние известные ключевая ставка и инфляция в % годовых.
+type MacroIndicators struct {
+	KeyRate   NullFloat64
+	Inflation NullFloat64
+}
+
 type ValuesMoex struct {
 	ShortName       NullString
 	TradeDate       NullString
@@ -274,8 +324,43 @@ type ValuesMoex struct {
 	FaceValue       NullFloat64
 	FaceUnit        NullString
 	Duration        NullFloat64
+	Close           NullFloat64 // цена закрытия в процентах от номинала, есть только в дневном ряде
+	Volume          NullFloat64 // объем торгов в штуках, есть только в дневном ряде
+}
+
+// MoexHistory - дневной ряд торгов облигацией на MOEX в порядке возрастания даты.
+type MoexHistory struct {
+	Ticker string
+	Data   []ValuesMoex
 }
 
+// MoexSecurityKind - рынок MOEX, к которому относится бумага из справочника.
+type MoexSecurityKind string
+
+const (
+	MoexShare    MoexSecurityKind = "share"
+	MoexETF      MoexSecurityKind = "etf"
+	MoexCurrency MoexSecurityKind = "currency"
+)
+
+// MoexSecurity - справочные данные MOEX по акции, фонду или валютной паре.
+// Sector есть только у акций, ISIN - у акций и фондов.
+type MoexSecurity struct {
+	Kind      MoexSecurityKind
+	SecID     string
+	BoardID   string
+	ShortName string
+	Name      string
+	ISIN      string
+	LotSize   int
+	Currency  string // валюта цены
+	Sector    string
+	Price     NullFloat64 // последняя цена, а без сделок - цена закрытия прошлого дня
+}
+
+// MoexSecurities - найденные на MOEX бумаги по тикеру (SECID).
+type MoexSecurities map[string]MoexSecurity
+
 type NullString struct {
 	Value  string
 	IsSet  bool
@@ -349,6 +434,7 @@ type BondIdentIdentifiers struct {
 	Nominal         MoneyValue
 	NominalCurrency string
 	Replaced        bool
+	FloatingCoupon  bool // купон привязан к ставке (флоатер)
 }
 
 type LastPrice struct {
@@ -441,10 +527,126 @@ type Future struct {
 }
 
 type Portfolio struct {
+	AccountID   string
 	Positions   []PortfolioPosition
 	TotalAmount MoneyValue
 }
 
+// Positions - свободные и заблокированные денежные средства счета.
+type Positions struct {
+	Money                   []MoneyValue
+	Blocked                 []MoneyValue
+	LimitsLoadingInProgress bool
+}
+
+// WithdrawLimits - средства, доступные к выводу, и заблокированные суммы по валютам.
+type WithdrawLimits struct {
+	Money            []MoneyValue
+	Blocked          []MoneyValue
+	BlockedGuarantee []MoneyValue
+}
+
+// MarginAttributes - маржинальные показатели счета.
+type MarginAttributes struct {
+	LiquidPortfolio       MoneyValue
+	StartingMargin        MoneyValue
+	MinimalMargin         MoneyValue
+	FundsSufficiencyLevel Quotation
+	AmountOfMissingFunds  MoneyValue
+}
+
+// CurrencyCash - денежные средства счета в одной валюте.
+type CurrencyCash struct {
+	Currency     string
+	Available    float64
+	Blocked      float64
+	Withdrawable float64
+}
+
+// AccountCash - денежные средства счета по валютам и маржинальные показатели.
+// Margin = nil, если маржинальная торговля на счете не подключена.
+type AccountCash struct {
+	Account    Account
+	Currencies []CurrencyCash
+	Margin     *MarginAttributes
+}
+
+type CashResponce struct {
+	Report string
+}
+
+// Dividend - выплата дивиденда на одну акцию.
+type Dividend struct {
+	DividendNet  MoneyValue
+	PaymentDate  time.Time
+	DeclaredDate time.Time
+	LastBuyDate  time.Time
+	RecordDate   time.Time
+	DividendType string
+	Regularity   string
+	ClosePrice   MoneyValue
+	YieldValue   Quotation
+}
+
+// UpcomingDividend - ожидаемая выплата по позиции счета.
+type UpcomingDividend struct {
+	Ticker      string
+	Name        string // короткое название на MOEX, пустое, если MOEX недоступен
+	RecordDate  time.Time
+	PaymentDate time.Time
+	Quantity    float64
+	DividendNet MoneyValue
+	// Amount - выплата до удержания налога, AfterTax - после. Налог с валютных дивидендов
+	// зависит от страны эмитента и договора об избежании двойного налогообложения,
+	// поэтому для них AfterTax не считается и TaxKnown = false.
+	Amount   float64
+	AfterTax float64
+	TaxKnown bool
+}
+
+// AssetReport - публикация отчетности эмитента по графику.
+type AssetReport struct {
+	ReportDate time.Time
+	PeriodYear int32
+	PeriodNum  int32
+	PeriodType string
+}
+
+// UpcomingReport - ближайшая отчетность эмитента акции со счета.
+type UpcomingReport struct {
+	Ticker string
+	Name   string
+	AssetReport
+}
+
+type AccountDividends struct {
+	Account   Account
+	Dividends []UpcomingDividend
+	// Reports - график отчетностей эмитентов; пустой, если Invest API его не отдал.
+	Reports []UpcomingReport
+}
+
+type DividendsResponce struct {
+	Report string
+}
+
+// BondChart - данные для графика цены и доходности облигации с точками покупок пользователя.
+type BondChart struct {
+	Ticker    string
+	Name      string
+	From      time.Time
+	To        time.Time
+	History   []ValuesMoex
+	BuyPoints []ChartBuyPoint
+}
+
+// ChartBuyPoint - покупка из открытой позиции. Цена в процентах от номинала, как CLOSE на MOEX.
+type ChartBuyPoint struct {
+	Date     time.Time
+	Price    float64
+	Quantity float64
+}
+
 type PortfolioPosition struct {
 	Figi                     string
 	InstrumentType           string
@@ -471,9 +673,11 @@ type PortfolioPositionsWithAssetUid struct {
 }
 
 type Bond struct {
-	AciValue MoneyValue
-	Currency string
-	Nominal  MoneyValue
+	AciValue  MoneyValue
+	Currency  string
+	Nominal   MoneyValue
+	Sector    string // сектор экономики эмитента по классификации Т-Инвестиций
+	RiskLevel RiskLevel
 }
 
 type Currency struct {
@@ -2,6 +2,7 @@ package report
 
 import (
 	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
 	report_position "bonds-report-service/internal/domain/report_position"
 	"bonds-report-service/internal/utils"
 	"errors"
@@ -14,6 +15,7 @@ func (r *Report) Add(
 	position *report_position.PositionByFIFO,
 	moexBuyDateData domain.ValuesMoex,
 	moexNowData domain.ValuesMoex,
+	schedule *bondmath.Schedule,
 	now time.Time,
 ) (err error) {
 	const op = "service.CreateBondReport"
@@ -22,13 +24,13 @@ func (r *Report) Add(
 
 	switch position.Currency {
 	case "rub":
-		err := bondReportPosition.createBondReportPosition(position, moexBuyDateData, moexNowData, now)
+		err := bondReportPosition.createBondReportPosition(position, moexBuyDateData, moexNowData, schedule, now)
 		if err != nil {
 			return e.WrapIfErr("failed to create Bond report position", err)
 		}
 		r.BondsInRUB = append(r.BondsInRUB, bondReportPosition)
 	case "cny":
-		err := bondReportPosition.createBondReportPosition(position, moexBuyDateData, moexNowData, now)
+		err := bondReportPosition.createBondReportPosition(position, moexBuyDateData, moexNowData, schedule, now)
 		if err != nil {
 			return e.WrapIfErr("failed to create Bond report position", err)
 		}
@@ -42,6 +44,7 @@ func (r *BondReport) createBondReportPosition(
 	position *report_position.PositionByFIFO,
 	moexBuyDateData domain.ValuesMoex,
 	moexNowData domain.ValuesMoex,
+	schedule *bondmath.Schedule,
 	now time.Time,
 ) (err error) {
 	const op = "service.createBondReportByCurrency"
@@ -88,6 +91,20 @@ func (r *BondReport) createBondReportPosition(
 	if moexBuyDateData.YieldToOffer.IsHasValue() {
 		yieldToOfferOnPurchase = moexBuyDateData.YieldToOffer.Value
 	}
+
+	// доходность MOEX на дату покупки - по цене закрытия дня, а не по цене сделки,
+	// поэтому она остается только на случай, когда графика платежей нет
+	if y, ok := purchaseYield(position, schedule); ok {
+		yieldToMaturityOnPurchase = y
+	}
+	if offer, err := time.Parse(layout, offerDate); err == nil && schedule != nil {
+		if toOffer, err := schedule.ToOffer(offer); err == nil {
+			if y, ok := purchaseYield(position, &toOffer); ok {
+				yieldToOfferOnPurchase = y
+			}
+		}
+	}
+
 	profitWithoutTax := position.GetProfitBeforeTax()
 	totalTax := position.GetTotalTaxFromPosition(profitWithoutTax)
 	profit := getNetProfit(profitWithoutTax, totalTax)
@@ -119,3 +136,18 @@ func (r *BondReport) createBondReportPosition(
 
 	return nil
 }
+
+// purchaseYield - доходность в процентах годовых по фактической полной цене покупки одной
+// бумаги: цена сделки плюс НКД, уплаченный за бумагу. Для флоатеров будущие купоны
+// неизвестны, поэтому доходность по графику не считается.
+func purchaseYield(position *report_position.PositionByFIFO, schedule *bondmath.Schedule) (float64, bool) {
+	if schedule == nil || position.FloatingCoupon || position.Quantity <= 0 {
+		return 0, false
+	}
+	dirtyPrice := position.BuyPrice + position.BuyAccruedInt/position.Quantity
+	y, err := bondmath.Yield(*schedule, position.BuyDate, dirtyPrice)
+	if err != nil {
+		return 0, false
+	}
+	return y * 100, true
+}
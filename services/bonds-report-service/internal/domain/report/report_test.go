@@ -0,0 +1,69 @@
+//go:build unit
+
+package report
+
+import (
+	"bonds-report-service/internal/domain"
+	"bonds-report-service/internal/domain/bondmath"
+	report_position "bonds-report-service/internal/domain/report_position"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBondReportPosition_YieldOnPurchase(t *testing.T) {
+	buyDate := time.Date(2025, 3, 10, 11, 30, 0, 0, time.UTC)
+	now := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	schedule, err := bondmath.NewBulletSchedule(
+		time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2029, 5, 30, 0, 0, 0, 0, time.UTC),
+		182, 45.38, 1000,
+	)
+	require.NoError(t, err)
+
+	// цена сделки соответствует 15% годовых, MOEX на дату покупки показывает 20%
+	const quantity = 10
+	dirtyPrice := bondmath.Price(schedule, buyDate, 0.15)
+	accrued := schedule.AccruedInterest(buyDate)
+	newPosition := func() *report_position.PositionByFIFO {
+		return &report_position.PositionByFIFO{
+			Ticker:        "RU000A1TEST0",
+			Currency:      "rub",
+			BuyDate:       buyDate,
+			Quantity:      quantity,
+			BuyPrice:      dirtyPrice - accrued,
+			BuyAccruedInt: accrued * quantity,
+			SellPrice:     1000,
+		}
+	}
+	moexBuyDate := domain.ValuesMoex{
+		YieldToMaturity: domain.NewNullFloat64(20, true, false),
+		YieldToOffer:    domain.NewNullFloat64(21, true, false),
+	}
+	moexNow := domain.ValuesMoex{OfferDate: domain.NewNullString("2027-06-02", true, false)}
+
+	t.Run("computed from purchase price", func(t *testing.T) {
+		var r BondReport
+		require.NoError(t, r.createBondReportPosition(newPosition(), moexBuyDate, moexNow, &schedule, now))
+		require.InDelta(t, 15.0, r.YieldToMaturityOnPurchase, 0.01)
+		require.NotEqual(t, 21.0, r.YieldToOfferOnPurchase)
+		require.Greater(t, r.YieldToOfferOnPurchase, 0.0)
+	})
+
+	t.Run("moex fallback without schedule", func(t *testing.T) {
+		var r BondReport
+		require.NoError(t, r.createBondReportPosition(newPosition(), moexBuyDate, moexNow, nil, now))
+		require.Equal(t, 20.0, r.YieldToMaturityOnPurchase)
+		require.Equal(t, 21.0, r.YieldToOfferOnPurchase)
+	})
+
+	t.Run("moex fallback for floater", func(t *testing.T) {
+		position := newPosition()
+		position.FloatingCoupon = true
+		var r BondReport
+		require.NoError(t, r.createBondReportPosition(position, moexBuyDate, moexNow, &schedule, now))
+		require.Equal(t, 20.0, r.YieldToMaturityOnPurchase)
+		require.Equal(t, 21.0, r.YieldToOfferOnPurchase)
+	})
+}
@@ -11,6 +11,7 @@ type PositionByFIFO struct {
 	Name                  string
 	Replaced              bool
 	CurrencyIfReplaced    string
+	FloatingCoupon        bool
 	BuyDate               time.Time
 	SellDate              time.Time
 	Quantity              float64
@@ -59,6 +60,7 @@ func (p *PositionByFIFO) ApplyBondMetadata(
 	p.ClassCode = bond.ClassCode
 	p.Replaced = bond.Replaced
 	p.CurrencyIfReplaced = bond.NominalCurrency
+	p.FloatingCoupon = bond.FloatingCoupon
 }
 
 func (p *PositionByFIFO) isCurrentQuantityGreaterThanSellQuantity(
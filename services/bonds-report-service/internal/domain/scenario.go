@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"sort"
+)
+
+// ScenarioBucket - группа облигаций в сценарном анализе, как в отчетах по облигациям.
+type ScenarioBucket string
+
+const (
+	ScenarioRUB      ScenarioBucket = "rub"
+	ScenarioCNY      ScenarioBucket = "cny"
+	ScenarioReplaced ScenarioBucket = "replaced"
+	ScenarioEuro     ScenarioBucket = "euro"
+)
+
+// ScenarioBuckets - порядок групп в отчете.
+var ScenarioBuckets = []ScenarioBucket{ScenarioRUB, ScenarioCNY, ScenarioReplaced, ScenarioEuro}
+
+// RateSensitivity - чувствительность цены к доходности в годах.
+type RateSensitivity struct {
+	Duration  float64 // модифицированная дюрация
+	Convexity float64
+}
+
+// Change - изменение стоимости value при сдвиге доходности shift в долях:
+// value * (-D * dy + C * dy^2 / 2).
+func (s RateSensitivity) Change(value, shift float64) float64 {
+	return value * (-s.Duration*shift + s.Convexity*shift*shift/2)
+}
+
+// ScenarioPosition - облигация со всех счетов с оценкой чувствительности к ставкам.
+type ScenarioPosition struct {
+	Ticker    string
+	Name      string
+	Bucket    ScenarioBucket
+	Currency  string
+	Floater   bool
+	Value     float64         // текущая стоимость позиции в валюте облигации
+	Parallel  RateSensitivity // к сдвигу доходности самой бумаги
+	KeyRate   RateSensitivity // к изменению ключевой ставки ЦБ
+	Estimated bool            // дюрация MOEX без графика платежей, выпуклость оценена
+}
+
+// RateScenario - сдвиг ставок в базисных пунктах и облигации портфеля.
+type RateScenario struct {
+	ShiftBP   int
+	Positions []ScenarioPosition
+}
+
+// Shift - сдвиг в долях: 100 б.п. - это 0.01.
+func (s RateScenario) Shift() float64 {
+	return float64(s.ShiftBP) / 10000
+}
+
+// ScenarioGroup - итог по группе облигаций в одной валюте.
+type ScenarioGroup struct {
+	Bucket    ScenarioBucket
+	Currency  string
+	Value     float64
+	Duration  float64 // модифицированная дюрация, взвешенная по стоимости
+	Convexity float64 // взвешенная по стоимости
+	Parallel  float64 // изменение стоимости при параллельном сдвиге
+	KeyRate   float64 // изменение стоимости при изменении ключевой ставки
+	Positions []ScenarioPosition
+}
+
+// Groups складывает позиции по группам и валютам. Группы идут в порядке ScenarioBuckets,
+// внутри группы - по валюте, бумаги - по убыванию стоимости.
+func (s RateScenario) Groups() []ScenarioGroup {
+	type key struct {
+		bucket   ScenarioBucket
+		currency string
+	}
+	index := make(map[key]int)
+	var groups []ScenarioGroup
+	shift := s.Shift()
+	for _, position := range s.Positions {
+		k := key{position.Bucket, position.Currency}
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, ScenarioGroup{Bucket: position.Bucket, Currency: position.Currency})
+		}
+		group := &groups[i]
+		group.Value += position.Value
+		group.Duration += position.Parallel.Duration * position.Value
+		group.Convexity += position.Parallel.Convexity * position.Value
+		group.Parallel += position.Parallel.Change(position.Value, shift)
+		group.KeyRate += position.KeyRate.Change(position.Value, shift)
+		group.Positions = append(group.Positions, position)
+	}
+
+	order := make(map[ScenarioBucket]int, len(ScenarioBuckets))
+	for i, bucket := range ScenarioBuckets {
+		order[bucket] = i
+	}
+	for i := range groups {
+		group := &groups[i]
+		if group.Value != 0 {
+			group.Duration /= group.Value
+			group.Convexity /= group.Value
+		}
+		sort.SliceStable(group.Positions, func(a, b int) bool {
+			return group.Positions[a].Value > group.Positions[b].Value
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if order[groups[i].Bucket] != order[groups[j].Bucket] {
+			return order[groups[i].Bucket] < order[groups[j].Bucket]
+		}
+		return groups[i].Currency < groups[j].Currency
+	})
+	return groups
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	screenerDateLayout = "2006-01-02"
+	daysInYear         = 365
+)
+
+// CouponType - тип купона для фильтра скринера.
+type CouponType string
+
+const (
+	CouponFixed CouponType = "fixed"
+	CouponFloat CouponType = "float"
+	CouponZero  CouponType = "zero"
+)
+
+// MoexBond - облигация из режима торгов MOEX с текущими ценой, доходностью и дюрацией.
+type MoexBond struct {
+	SecID         string
+	BoardID       string
+	ShortName     string
+	ISIN          string
+	MaturityDate  NullString
+	OfferDate     NullString
+	CouponPercent NullFloat64
+	CouponValue   NullFloat64
+	CouponPeriod  NullFloat64 // длительность купона в днях
+	NextCoupon    NullString  // дата ближайшего купона
+	FaceValue     NullFloat64
+	FaceUnit      string // валюта номинала: SUR, CNY, USD...
+	CurrencyID    string // валюта расчетов
+	ListLevel     int
+	BondType      string
+	BondSubType   string
+	Price         NullFloat64 // в процентах от номинала
+	Yield         NullFloat64 // % годовых
+	Duration      NullFloat64 // дни
+}
+
+// Currency - валюта номинала в том же виде, что и в позициях Тинькофф: rub, cny, usd.
+func (b MoexBond) Currency() string {
+	return NormalizeCurrency(b.FaceUnit)
+}
+
+// NormalizeCurrency приводит код валюты MOEX к виду Тинькофф: SUR и RUB - rub.
+func NormalizeCurrency(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "sur" {
+		return "rub"
+	}
+	return code
+}
+
+// CouponType определяет тип купона. В ISS нет отдельного признака флоатера, поэтому
+// он распознается по типу бумаги (ОФЗ-ПК - серия SU29) и по подтипу из справочника.
+func (b MoexBond) CouponType() CouponType {
+	kind := strings.ToLower(b.BondType + " " + b.BondSubType)
+	switch {
+	case strings.HasPrefix(b.SecID, "SU29"),
+		strings.Contains(kind, "float"),
+		strings.Contains(kind, "флоат"),
+		strings.Contains(kind, "перем"):
+		return CouponFloat
+	case !b.CouponPercent.IsHasValue() && !b.CouponValue.IsHasValue(),
+		b.CouponPercent.Value == 0 && b.CouponValue.Value == 0:
+		return CouponZero
+	default:
+		return CouponFixed
+	}
+}
+
+// RedemptionDate - ближайшая из дат оферты и погашения, к которой MOEX считает доходность.
+func (b MoexBond) RedemptionDate() (time.Time, bool) {
+	var res time.Time
+	for _, date := range []NullString{b.OfferDate, b.MaturityDate} {
+		if !date.IsHasValue() {
+			continue
+		}
+		t, err := time.Parse(screenerDateLayout, date.Value)
+		if err != nil {
+			continue
+		}
+		if res.IsZero() || t.Before(res) {
+			res = t
+		}
+	}
+	return res, !res.IsZero()
+}
+
+// Range - числовой интервал фильтра. Незаданная граница не ограничивает.
+type Range struct {
+	Min NullFloat64
+	Max NullFloat64
+}
+
+func (r Range) IsSet() bool {
+	return r.Min.IsHasValue() || r.Max.IsHasValue()
+}
+
+func (r Range) Contains(v float64) bool {
+	if r.Min.IsHasValue() && v < r.Min.Value {
+		return false
+	}
+	if r.Max.IsHasValue() && v > r.Max.Value {
+		return false
+	}
+	return true
+}
+
+// ScreenerFilter - условия отбора облигаций. Пустые поля не ограничивают выборку.
+type ScreenerFilter struct {
+	Boards     []string
+	Yield      Range // % годовых
+	Duration   Range // годы
+	Maturity   Range // годы до оферты или погашения
+	CouponType CouponType
+	Currency   string // rub, cny, usd
+	ListLevels []int
+	Limit      int
+}
+
+// Match проверяет облигацию по всем условиям фильтра на дату now. Если условие задано,
+// а у бумаги нет нужного значения (нет сделок, нет даты погашения), она отбрасывается.
+func (f ScreenerFilter) Match(b MoexBond, now time.Time) bool {
+	if f.Yield.IsSet() && (!b.Yield.IsHasValue() || !f.Yield.Contains(b.Yield.Value)) {
+		return false
+	}
+	if f.Duration.IsSet() && (!b.Duration.IsHasValue() || !f.Duration.Contains(b.Duration.Value/daysInYear)) {
+		return false
+	}
+	if f.Maturity.IsSet() {
+		date, ok := b.RedemptionDate()
+		if !ok || !f.Maturity.Contains(date.Sub(now).Hours()/24/daysInYear) {
+			return false
+		}
+	}
+	if f.CouponType != "" && b.CouponType() != f.CouponType {
+		return false
+	}
+	if f.Currency != "" && b.Currency() != f.Currency {
+		return false
+	}
+	if len(f.ListLevels) > 0 && !slices.Contains(f.ListLevels, b.ListLevel) {
+		return false
+	}
+	return true
+}
+
+// ScreenerHoldings - облигации пользователя для сравнения с результатами скринера.
+type ScreenerHoldings struct {
+	Quantity map[string]int64   // количество по тикеру со всех счетов
+	Yield    map[string]float64 // средняя доходность к погашению по валюте, взвешенная по стоимости
+}
+
+// ScreenerRow - строка результата скринера.
+type ScreenerRow struct {
+	Bond   MoexBond
+	Held   int64       // сколько бумаг уже в портфеле
+	VsHeld NullFloat64 // разница доходности со средней по портфелю в той же валюте, п.п.
+}
+
+// BondScreener - результат скринера: отобранные бумаги и сколько всего подошло под фильтр.
+type BondScreener struct {
+	Filter  ScreenerFilter
+	Matched int
+	Rows    []ScreenerRow
+}
+
+type BondScreenerResponce struct {
+	Report string
+}
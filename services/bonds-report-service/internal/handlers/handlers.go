@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"bonds-report-service/internal/application/usecases"
+	"bonds-report-service/internal/domain"
+	httperrors "bonds-report-service/internal/infrastructure/http"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -34,7 +37,7 @@ func (h *Handler) GetAccountsList(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not get accounts"})
+		abortWithError(c, err, "could not get accounts")
 		return
 	}
 
@@ -54,7 +57,7 @@ func (h *Handler) GetBondReportsByFifo(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header"})
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header", "code": httperrors.CodeInvalidArgument})
 		return
 	}
 	err = h.service.GetBondReportsByFifo(ctx, chatID)
@@ -64,7 +67,7 @@ func (h *Handler) GetBondReportsByFifo(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not save bond reports")
 		return
 	}
 
@@ -83,7 +86,7 @@ func (h *Handler) GetUSD(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not get usd")
 		return
 	}
 
@@ -105,15 +108,20 @@ func (h *Handler) GetBondReports(c *gin.Context) {
 		logg.Warn(
 			"incorrect X-ChatId header",
 			slog.Any("error", err))
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header"})
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header", "code": httperrors.CodeInvalidArgument})
 		return
 	}
-	getBondReportsResponse, err := h.service.GetBondReports(ctx, chatID)
-	if err != nil {
+	getBondReportsResponse, err := h.service.GetBondReports(ctx, chatID, c.Query("account"))
+	switch {
+	case errors.Is(err, domain.ErrUnknownAccount):
+		logg.Warn("unknown account", slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error(), "code": httperrors.CodeNotFound})
+		return
+	case err != nil:
 		logg.Error("GetBondReports err",
 			slog.Any("error", err),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not get bond reports")
 		return
 	}
 
@@ -134,7 +142,7 @@ func (h *Handler) GetPortfolioStructure(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not get portfolio structure")
 		return
 	}
 
@@ -154,7 +162,7 @@ func (h *Handler) GetUnionPortfolioStructure(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not get union portfolio structure")
 		return
 	}
 
@@ -175,10 +183,163 @@ func (h *Handler) GetUnionPortfolioStructureWithSber(c *gin.Context) {
 			slog.Any("error", err),
 			slog.String("path", c.Request.URL.Path),
 		)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		abortWithError(c, err, "could not get union portfolio structure")
 		return
 	}
 
 	portfolioHTTP := MapUnionPortfolioStructureWithSberToHTTP(&portfolioStructure)
 	c.JSON(http.StatusOK, portfolioHTTP)
 }
+
+func (h *Handler) GetCash(c *gin.Context) {
+	const op = "handlers.GetCash"
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	cash, err := h.service.GetCash(ctx)
+	if err != nil {
+		h.logger.Error("internal server error",
+			slog.String("op", op),
+			slog.Any("error", err),
+			slog.String("path", c.Request.URL.Path),
+		)
+		abortWithError(c, err, "could not get cash")
+		return
+	}
+
+	cashHTTP := MapCashToHTTP(&cash)
+	c.JSON(http.StatusOK, cashHTTP)
+}
+
+func (h *Handler) GetDividends(c *gin.Context) {
+	const op = "handlers.GetDividends"
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	dividends, err := h.service.GetDividends(ctx)
+	if err != nil {
+		h.logger.Error("internal server error",
+			slog.String("op", op),
+			slog.Any("error", err),
+			slog.String("path", c.Request.URL.Path),
+		)
+		abortWithError(c, err, "could not get dividends")
+		return
+	}
+
+	dividendsHTTP := MapDividendsToHTTP(&dividends)
+	c.JSON(http.StatusOK, dividendsHTTP)
+}
+
+func (h *Handler) GetBondChart(c *gin.Context) {
+	const op = "handlers.GetBondChart"
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+	logg := h.logger.With(
+		slog.String("op", op),
+		slog.String("path", c.Request.URL.Path))
+
+	chatID, err := valuefromcontext.GetChatIDFromCtxInt(ctx)
+	if err != nil {
+		logg.Warn(
+			"incorrect X-ChatId header",
+			slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header", "code": httperrors.CodeInvalidArgument})
+		return
+	}
+
+	chart, err := h.service.GetBondChart(ctx, chatID, c.Query("ticker"), c.Query("period"))
+	switch {
+	case errors.Is(err, domain.ErrEmptyTicker), errors.Is(err, domain.ErrInvalidChartPeriod):
+		logg.Warn("invalid chart request", slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": httperrors.CodeInvalidArgument})
+		return
+	case err != nil:
+		logg.Error("GetBondChart err",
+			slog.Any("error", err),
+		)
+		abortWithError(c, err, "could not get bond chart")
+		return
+	}
+
+	c.JSON(http.StatusOK, MapImageDataToHTTP(&chart))
+}
+
+func (h *Handler) GetBondScreener(c *gin.Context) {
+	const op = "handlers.GetBondScreener"
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+	logg := h.logger.With(
+		slog.String("op", op),
+		slog.String("path", c.Request.URL.Path))
+
+	chatID, err := valuefromcontext.GetChatIDFromCtxInt(ctx)
+	if err != nil {
+		logg.Warn(
+			"incorrect X-ChatId header",
+			slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header", "code": httperrors.CodeInvalidArgument})
+		return
+	}
+
+	params := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		params[key] = values[0]
+	}
+
+	screener, err := h.service.GetBondScreener(ctx, chatID, params)
+	switch {
+	case errors.Is(err, domain.ErrInvalidScreenerFilter):
+		logg.Warn("invalid screener filter", slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": httperrors.CodeInvalidArgument})
+		return
+	case err != nil:
+		logg.Error("GetBondScreener err",
+			slog.Any("error", err),
+		)
+		abortWithError(c, err, "could not get bond screener")
+		return
+	}
+
+	c.JSON(http.StatusOK, MapBondScreenerToHTTP(&screener))
+}
+
+func (h *Handler) GetRateScenario(c *gin.Context) {
+	const op = "handlers.GetRateScenario"
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+	logg := h.logger.With(
+		slog.String("op", op),
+		slog.String("path", c.Request.URL.Path))
+
+	chatID, err := valuefromcontext.GetChatIDFromCtxInt(ctx)
+	if err != nil {
+		logg.Warn(
+			"incorrect X-ChatId header",
+			slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "incorrect X-ChatId header", "code": httperrors.CodeInvalidArgument})
+		return
+	}
+
+	scenario, err := h.service.GetRateScenario(ctx, chatID, c.Query("shift"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidRateShift):
+		logg.Warn("invalid rate shift", slog.Any("error", err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": httperrors.CodeInvalidArgument})
+		return
+	case err != nil:
+		logg.Error("GetRateScenario err",
+			slog.Any("error", err),
+		)
+		abortWithError(c, err, "could not get rate scenario")
+		return
+	}
+
+	c.JSON(http.StatusOK, MapImageDataToHTTP(&scenario))
+}
+
+// abortWithError отвечает статусом и машиночитаемым кодом ошибки,
+// чтобы бот мог показать пользователю понятное сообщение.
+func abortWithError(c *gin.Context, err error, message string) {
+	c.AbortWithStatusJSON(httperrors.Status(err), gin.H{"error": message, "code": httperrors.Code(err)})
+}
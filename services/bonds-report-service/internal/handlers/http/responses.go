@@ -21,7 +21,13 @@ func NewImageData() *ImageData {
 }
 
 type AccountListResponce struct {
-	Accounts string `json:"accounts,omitempty"`
+	Accounts string    `json:"accounts,omitempty"`
+	List     []Account `json:"list,omitempty"`
+}
+
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type BondReportsResponce struct {
@@ -40,6 +46,18 @@ type UnionPortfolioStructureWithSberResponce struct {
 	Report string `json:"report"`
 }
 
+type CashResponce struct {
+	Report string `json:"report"`
+}
+
+type DividendsResponce struct {
+	Report string `json:"report"`
+}
+
+type BondScreenerResponce struct {
+	Report string `json:"report"`
+}
+
 type UsdResponce struct {
 	Usd float64 `json:"usd,omitempty"`
 }
@@ -33,8 +33,13 @@ func MapAccountListToHTTP(acc *dto.AccountListResponce) *httpmodels.AccountListR
 	if acc == nil {
 		return nil
 	}
+	list := make([]httpmodels.Account, 0, len(acc.List))
+	for _, account := range acc.List {
+		list = append(list, httpmodels.Account{ID: account.ID, Name: account.Name})
+	}
 	return &httpmodels.AccountListResponce{
 		Accounts: acc.Accounts,
+		List:     list,
 	}
 }
 
@@ -84,6 +89,33 @@ func MapUnionPortfolioStructureWithSberToHTTP(u *dto.UnionPortfolioStructureWith
 	}
 }
 
+func MapCashToHTTP(u *domain.CashResponce) *httpmodels.CashResponce {
+	if u == nil {
+		return nil
+	}
+	return &httpmodels.CashResponce{
+		Report: u.Report,
+	}
+}
+
+func MapDividendsToHTTP(u *domain.DividendsResponce) *httpmodels.DividendsResponce {
+	if u == nil {
+		return nil
+	}
+	return &httpmodels.DividendsResponce{
+		Report: u.Report,
+	}
+}
+
+func MapBondScreenerToHTTP(u *domain.BondScreenerResponce) *httpmodels.BondScreenerResponce {
+	if u == nil {
+		return nil
+	}
+	return &httpmodels.BondScreenerResponce{
+		Report: u.Report,
+	}
+}
+
 func MapUsdToHTTP(u *domain.UsdResponce) *httpmodels.UsdResponce {
 	if u == nil {
 		return nil
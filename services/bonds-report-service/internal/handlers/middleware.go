@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bonds-report-service/internal/application/presenter"
 	"context"
 	"log/slog"
 	"net/http"
@@ -53,6 +54,17 @@ func (h *Handler) AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// LanguageMiddleware кладет в контекст язык из Accept-Language,
+// на нем presenter формирует таблицы и подписи.
+func (h *Handler) LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := presenter.ParseLang(c.GetHeader("Accept-Language"))
+		ctx := presenter.WithLang(c.Request.Context(), lang)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 func (h *Handler) LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		logg := h.logger.With(
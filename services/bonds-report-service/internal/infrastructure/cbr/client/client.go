@@ -71,3 +71,75 @@ func (c *Client) GetAllCurrencies(ctx context.Context, date time.Time) (_ domain
 
 	return domainRes, nil
 }
+
+func (c *Client) GetKeyRate(ctx context.Context, from, to time.Time) (_ []domain.KeyRate, err error) {
+	const op = "cbr.GetKeyRate"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewPeriodRequest(from, to)
+	Path := path.Join("cbr", "keyrate")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.KeyRateResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapKeyRateResponseToDomain(res), nil
+}
+
+func (c *Client) GetInflation(ctx context.Context, from, to time.Time) (_ []domain.Inflation, err error) {
+	const op = "cbr.GetInflation"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewPeriodRequest(from, to)
+	Path := path.Join("cbr", "inflation")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.InflationResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapInflationResponseToDomain(res), nil
+}
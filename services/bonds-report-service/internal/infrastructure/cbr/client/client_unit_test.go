@@ -3,11 +3,13 @@
 package cbr
 
 import (
+	"bonds-report-service/internal/infrastructure/cbr/dto"
 	"bonds-report-service/internal/infrastructure/cbr/models"
 	factories "bonds-report-service/internal/infrastructure/cbr/testing"
 	"bonds-report-service/internal/infrastructure/cbr/transport/mocks"
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -199,3 +201,87 @@ func TestClient_GetAllCurrencies(t *testing.T) {
 		transportMock.AssertExpectations(t)
 	})
 }
+
+func TestClient_GetKeyRate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		keyRateResp := dto.KeyRateResponse{Rates: []dto.KeyRate{
+			{Date: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC), Rate: 20},
+			{Date: time.Date(2025, 7, 28, 0, 0, 0, 0, time.UTC), Rate: 18},
+		}}
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On(
+			"DoRequest",
+			ctx,
+			path.Join("cbr", "keyrate"),
+			url.Values{},
+			mock.AnythingOfType("*bytes.Buffer"),
+		).Return(factories.NewHTTPResponse(http.StatusOK, keyRateResp), nil).Once()
+
+		got, err := NewCbrClient(logger, transportMock).GetKeyRate(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, 18.0, got[1].Rate)
+		assert.Equal(t, keyRateResp.Rates[1].Date, got[1].Date)
+	})
+
+	t.Run("HTTP 500 Internal Server Error", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On(
+			"DoRequest",
+			ctx,
+			path.Join("cbr", "keyrate"),
+			url.Values{},
+			mock.AnythingOfType("*bytes.Buffer"),
+		).Return(models.NewHTTPResponse(http.StatusInternalServerError, []byte(`{}`)), nil).Once()
+
+		_, err := NewCbrClient(logger, transportMock).GetKeyRate(ctx, from, to)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "internal server error")
+	})
+}
+
+func TestClient_GetInflation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		inflationResp := dto.InflationResponse{Inflation: []dto.Inflation{
+			{Date: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Inflation: 8.14, KeyRate: 18, Target: 4},
+		}}
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On(
+			"DoRequest",
+			ctx,
+			path.Join("cbr", "inflation"),
+			url.Values{},
+			mock.AnythingOfType("*bytes.Buffer"),
+		).Return(factories.NewHTTPResponse(http.StatusOK, inflationResp), nil).Once()
+
+		got, err := NewCbrClient(logger, transportMock).GetInflation(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, 8.14, got[0].Inflation)
+	})
+
+	t.Run("Invalid JSON response", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On(
+			"DoRequest",
+			ctx,
+			path.Join("cbr", "inflation"),
+			url.Values{},
+			mock.AnythingOfType("*bytes.Buffer"),
+		).Return(models.NewHTTPResponse(http.StatusOK, []byte(`invalid json`)), nil).Once()
+
+		_, err := NewCbrClient(logger, transportMock).GetInflation(ctx, from, to)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unmarshal response")
+	})
+}
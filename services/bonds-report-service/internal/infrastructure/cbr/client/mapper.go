@@ -72,3 +72,25 @@ func parseFloat(s string) (float64, error) {
 func normalizeCharCode(code string) string {
 	return strings.ToLower(code)
 }
+
+func MapKeyRateResponseToDomain(dtoResp dto.KeyRateResponse) []domain.KeyRate {
+	out := make([]domain.KeyRate, 0, len(dtoResp.Rates))
+	for _, rate := range dtoResp.Rates {
+		out = append(out, domain.KeyRate{
+			Date: rate.Date,
+			Rate: rate.Rate,
+		})
+	}
+	return out
+}
+
+func MapInflationResponseToDomain(dtoResp dto.InflationResponse) []domain.Inflation {
+	out := make([]domain.Inflation, 0, len(dtoResp.Inflation))
+	for _, inflation := range dtoResp.Inflation {
+		out = append(out, domain.Inflation{
+			Date:      inflation.Date,
+			Inflation: inflation.Inflation,
+		})
+	}
+	return out
+}
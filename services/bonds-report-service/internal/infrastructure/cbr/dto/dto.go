@@ -30,3 +30,33 @@ func NewCurrenciesResponce(date string, currs []Currency) *CurrenciesResponse {
 		Currencies: currs,
 	}
 }
+
+// PeriodRequest - период для ставок и инфляции. Пустые даты - последний год.
+type PeriodRequest struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+func NewPeriodRequest(from, to time.Time) *PeriodRequest {
+	return &PeriodRequest{From: from, To: to}
+}
+
+type KeyRate struct {
+	Date time.Time `json:"date"`
+	Rate float64   `json:"rate"`
+}
+
+type KeyRateResponse struct {
+	Rates []KeyRate `json:"rates"`
+}
+
+type Inflation struct {
+	Date      time.Time `json:"date"`
+	Inflation float64   `json:"inflation"`
+	KeyRate   float64   `json:"keyRate"`
+	Target    float64   `json:"target"`
+}
+
+type InflationResponse struct {
+	Inflation []Inflation `json:"inflation"`
+}
@@ -1,6 +1,7 @@
 package httperrors
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,7 +17,138 @@ var (
 	ErrServiceUnavailable  = errors.New("service unavailable")
 )
 
+// Коды ошибок из тела ответа tinkoffApi.
+const (
+	CodeUnauthorized    = "unauthorized"
+	CodeNotFound        = "not_found"
+	CodeRateLimited     = "rate_limited"
+	CodeInvalidArgument = "invalid_argument"
+	CodeUnavailable     = "unavailable"
+	CodeInternal        = "internal"
+)
+
+// APIError - ошибка сервиса с кодом из тела ответа.
+// Для проверки типа ошибки используются sentinel ошибки: errors.Is(err, ErrTooManyRequests).
+type APIError struct {
+	Status int
+	Code   string
+	// TinkoffCode - код ошибки Invest API, например 30070 или 80002.
+	TinkoffCode string
+	Message     string
+	kind        error
+}
+
+func (e *APIError) Error() string {
+	msg := e.kind.Error()
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.TinkoffCode != "" {
+		msg += " (tinkoff code " + e.TinkoffCode + ")"
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+type errorResponse struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	TinkoffCode string `json:"tinkoffCode"`
+}
+
+// MapHTTPError превращает ответ с ошибкой в sentinel ошибку.
+// Если в теле есть код ошибки, тип определяется по нему, иначе по HTTP статусу.
 func MapHTTPError(status int, body []byte) error {
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Code == "" {
+		return statusError(status, body)
+	}
+
+	kind := codeError(resp.Code)
+	if kind == nil {
+		kind = statusError(status, body)
+	}
+	return &APIError{
+		Status:      status,
+		Code:        resp.Code,
+		TinkoffCode: resp.TinkoffCode,
+		Message:     resp.Message,
+		kind:        kind,
+	}
+}
+
+// TinkoffCode возвращает код ошибки Invest API из цепочки ошибок или пустую строку.
+func TinkoffCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.TinkoffCode
+	}
+	return ""
+}
+
+// Code возвращает машиночитаемый код ошибки для ответа клиенту.
+func Code(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && codeError(apiErr.Code) != nil {
+		return apiErr.Code
+	}
+	switch {
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrForbidden):
+		return CodeUnauthorized
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrTooManyRequests):
+		return CodeRateLimited
+	case errors.Is(err, ErrBadRequest):
+		return CodeInvalidArgument
+	case errors.Is(err, ErrServiceUnavailable):
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+// Status возвращает HTTP статус для ошибки по ее коду.
+func Status(err error) int {
+	switch Code(err) {
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeRateLimited:
+		return http.StatusTooManyRequests
+	case CodeInvalidArgument:
+		return http.StatusBadRequest
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func codeError(code string) error {
+	switch code {
+	case CodeUnauthorized:
+		return ErrUnauthorized
+	case CodeNotFound:
+		return ErrNotFound
+	case CodeRateLimited:
+		return ErrTooManyRequests
+	case CodeInvalidArgument:
+		return ErrBadRequest
+	case CodeUnavailable:
+		return ErrServiceUnavailable
+	case CodeInternal:
+		return ErrInternalServerError
+	default:
+		return nil
+	}
+}
+
+func statusError(status int, body []byte) error {
 	switch status {
 	case http.StatusBadRequest:
 		return ErrBadRequest
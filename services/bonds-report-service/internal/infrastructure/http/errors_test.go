@@ -0,0 +1,84 @@
+//go:build unit
+
+package httperrors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapHTTPError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  error
+		wantCode string
+	}{
+		{
+			name:     "rate limited with tinkoff code",
+			status:   http.StatusTooManyRequests,
+			body:     `{"code":"rate_limited","message":"could not get data","tinkoffCode":"80002"}`,
+			wantErr:  ErrTooManyRequests,
+			wantCode: CodeRateLimited,
+		},
+		{
+			name:     "code wins over status",
+			status:   http.StatusBadRequest,
+			body:     `{"code":"unauthorized","message":"incorrect token"}`,
+			wantErr:  ErrUnauthorized,
+			wantCode: CodeUnauthorized,
+		},
+		{
+			name:     "unknown code falls back to status",
+			status:   http.StatusNotFound,
+			body:     `{"code":"something_new","message":"no price data"}`,
+			wantErr:  ErrNotFound,
+			wantCode: CodeNotFound,
+		},
+		{
+			name:     "plain echo body",
+			status:   http.StatusBadRequest,
+			body:     `{"message":"Bad Request"}`,
+			wantErr:  ErrBadRequest,
+			wantCode: CodeInvalidArgument,
+		},
+		{
+			name:     "not json",
+			status:   http.StatusBadGateway,
+			body:     `<html>bad gateway</html>`,
+			wantErr:  ErrServiceUnavailable,
+			wantCode: CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("op: %w", MapHTTPError(tt.status, []byte(tt.body)))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCode, Code(err))
+		})
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	err := MapHTTPError(http.StatusBadRequest, []byte(`{"code":"invalid_argument","message":"from can't be more than the current date","tinkoffCode":"30070"}`))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "30070", apiErr.TinkoffCode)
+	assert.Equal(t, "30070", TinkoffCode(fmt.Errorf("op: %w", err)))
+	assert.Empty(t, TinkoffCode(ErrBadRequest))
+	assert.Equal(t, "bad request: from can't be more than the current date (tinkoff code 30070)", err.Error())
+	assert.Equal(t, http.StatusBadRequest, Status(err))
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, Status(ErrForbidden))
+	assert.Equal(t, http.StatusServiceUnavailable, Status(fmt.Errorf("op: %w", ErrServiceUnavailable)))
+	assert.Equal(t, http.StatusInternalServerError, Status(fmt.Errorf("boom")))
+	assert.Equal(t, CodeInternal, Code(MapHTTPError(http.StatusTeapot, nil)))
+}
@@ -67,3 +67,154 @@ func (c *Client) GetSpecifications(ctx context.Context, ticker string, date time
 
 	return domainRes, nil
 }
+
+// GetHistory возвращает дневной ряд торгов облигацией за период для построения графиков.
+func (c *Client) GetHistory(ctx context.Context, ticker string, from, to time.Time) (data domain.MoexHistory, err error) {
+	const op = "moex.GetHistory"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewHistoryRequest(ticker, from, to)
+	Path := path.Join("moex", "history")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return domain.MoexHistory{}, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return domain.MoexHistory{}, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return domain.MoexHistory{}, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.HistoryResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return domain.MoexHistory{}, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapHistoryFromDTOToDomain(res), nil
+}
+
+// GetBonds возвращает облигации из режимов торгов boards с текущими ценой и доходностью.
+// Пустой boards - все режимы, которые поддерживает moexApi.
+func (c *Client) GetBonds(ctx context.Context, boards []string) (data []domain.MoexBond, err error) {
+	const op = "moex.GetBonds"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewBondsRequest(boards)
+	Path := path.Join("moex", "bonds")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.BondsResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapBondsFromDTOToDomain(res), nil
+}
+
+// GetSecurities возвращает справочные данные MOEX по акциям, фондам и валютным парам.
+// Тикеры, которых нет на MOEX, в ответ не попадают.
+func (c *Client) GetSecurities(ctx context.Context, shares, etfs, currencies []string) (data domain.MoexSecurities, err error) {
+	const op = "moex.GetSecurities"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewSecuritiesRequest(shares, etfs, currencies)
+	Path := path.Join("moex", "securities")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.SecuritiesResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapSecuritiesFromDTOToDomain(res), nil
+}
+
+// GetIssuers возвращает эмитентов облигаций по тикерам (SECID). Бумаги, которых нет на MOEX,
+// в ответ не попадают.
+func (c *Client) GetIssuers(ctx context.Context, secIDs []string) (data map[string]domain.MoexIssuer, err error) {
+	const op = "moex.GetIssuers"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	request := dto.NewIssuersRequest(secIDs)
+	Path := path.Join("moex", "issuers")
+	params := url.Values{}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(requestBody)
+
+	httpResponse, err := c.transport.DoRequest(ctx, Path, params, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var res dto.IssuersResponse
+	err = json.Unmarshal(httpResponse.Body, &res)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapIssuersFromDTOToDomain(res), nil
+}
@@ -3,9 +3,11 @@
 package moex
 
 import (
+	"bonds-report-service/internal/domain"
 	"bonds-report-service/internal/infrastructure/moex/dto"
 	"bonds-report-service/internal/infrastructure/moex/models"
 	"bonds-report-service/internal/infrastructure/moex/transport/mocks"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -107,3 +109,229 @@ func TestClient_GetSpecifications(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to unmarshal")
 	})
 }
+
+func TestClient_GetHistory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(nil, nil))
+	ctx := context.Background()
+	ticker := "TEST"
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		wantHistory := dto.HistoryResponse{
+			Ticker: ticker,
+			Data: []dto.Values{
+				{
+					TradeDate: dto.NullString{Value: "2025-11-13", IsSet: true},
+					Close:     dto.NullFloat64{Value: 61.2, IsSet: true},
+				},
+				{
+					TradeDate: dto.NullString{Value: "2025-11-14", IsSet: true},
+					Close:     dto.NullFloat64{Value: 61.5, IsSet: true},
+					Volume:    dto.NullFloat64{Value: 15000, IsSet: true},
+				},
+			},
+		}
+		body, _ := json.Marshal(wantHistory)
+
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest",
+			ctx,
+			"moex/history",
+			mock.AnythingOfType("url.Values"),
+			mock.MatchedBy(func(body any) bool {
+				var req dto.HistoryRequest
+				buf, ok := body.(*bytes.Buffer)
+				return ok && json.Unmarshal(buf.Bytes(), &req) == nil &&
+					req.Ticker == ticker && req.From.Equal(from) && req.To.Equal(to)
+			}),
+		).Return(models.NewHTTPResponse(200, body), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		got, err := client.GetHistory(ctx, ticker, from, to)
+
+		require.NoError(t, err)
+		require.Equal(t, ticker, got.Ticker)
+		require.Len(t, got.Data, 2)
+		require.Equal(t, "2025-11-14", got.Data[1].TradeDate.Value)
+		require.Equal(t, 61.5, got.Data[1].Close.Value)
+		require.Equal(t, 15000.0, got.Data[1].Volume.Value)
+	})
+
+	t.Run("HTTP 404 Not Found", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(404, []byte(`{"error":"could not find data in MOEX"}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetHistory(ctx, ticker, from, to)
+
+		require.Error(t, err)
+	})
+
+	t.Run("JSON unmarshal error", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(200, []byte(`{invalid json}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetHistory(ctx, ticker, from, to)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal")
+	})
+}
+
+func TestClient_GetBonds(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(nil, nil))
+	ctx := context.Background()
+	boards := []string{"TQOB", "TQCB"}
+
+	t.Run("Success", func(t *testing.T) {
+		body := []byte(`{"bonds":[{"SECID":"SU26238RMFS4","BOARDID":"TQOB","FACEUNIT":"SUR","LISTLEVEL":1,` +
+			`"MATDATE":{"value":"2041-05-15","isSet":true,"isNull":false},` +
+			`"YIELD":{"value":14.55,"isSet":true,"isNull":false},` +
+			`"DURATION":{"value":3650,"isSet":true,"isNull":false}}]}`)
+
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest",
+			ctx,
+			"moex/bonds",
+			mock.AnythingOfType("url.Values"),
+			mock.MatchedBy(func(body any) bool {
+				var req dto.BondsRequest
+				buf, ok := body.(*bytes.Buffer)
+				return ok && json.Unmarshal(buf.Bytes(), &req) == nil && len(req.Boards) == 2
+			}),
+		).Return(models.NewHTTPResponse(200, body), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		got, err := client.GetBonds(ctx, boards)
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "SU26238RMFS4", got[0].SecID)
+		require.Equal(t, "rub", got[0].Currency())
+		require.Equal(t, 1, got[0].ListLevel)
+		require.Equal(t, "2041-05-15", got[0].MaturityDate.Value)
+		require.Equal(t, 14.55, got[0].Yield.Value)
+	})
+
+	t.Run("HTTP 400 Bad Request", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(400, []byte(`{"error":"invalid request body"}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetBonds(ctx, []string{"TQBR"})
+
+		require.Error(t, err)
+	})
+
+	t.Run("JSON unmarshal error", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(200, []byte(`{invalid json}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetBonds(ctx, boards)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal")
+	})
+}
+
+func TestClient_GetSecurities(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(nil, nil))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		body := []byte(`{"shares":[{"SECID":"SBER","BOARDID":"TQBR","SHORTNAME":"Сбербанк","LOTSIZE":10,` +
+			`"CURRENCYID":"SUR","SECTORID":"FNL","PRICE":{"value":307.5,"isSet":true,"isNull":false}}],` +
+			`"etfs":[{"SECID":"TMOS","BOARDID":"TQTF","LOTSIZE":1,"PRICE":{"isSet":true,"isNull":true}}],` +
+			`"currencies":[{"SECID":"CNYRUB_TOM","BOARDID":"CETS","LOTSIZE":1,"CURRENCYID":"RUB"}]}`)
+
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest",
+			ctx,
+			"moex/securities",
+			mock.AnythingOfType("url.Values"),
+			mock.MatchedBy(func(body any) bool {
+				var req dto.SecuritiesRequest
+				buf, ok := body.(*bytes.Buffer)
+				return ok && json.Unmarshal(buf.Bytes(), &req) == nil &&
+					len(req.Shares) == 1 && len(req.ETFs) == 1 && len(req.Currencies) == 1
+			}),
+		).Return(models.NewHTTPResponse(200, body), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		got, err := client.GetSecurities(ctx, []string{"SBER"}, []string{"TMOS"}, []string{"CNYRUB_TOM"})
+
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		require.Equal(t, domain.MoexShare, got["SBER"].Kind)
+		require.Equal(t, 10, got["SBER"].LotSize)
+		require.Equal(t, "FNL", got["SBER"].Sector)
+		require.Equal(t, 307.5, got["SBER"].Price.Value)
+		require.Equal(t, domain.MoexETF, got["TMOS"].Kind)
+		require.False(t, got["TMOS"].Price.IsHasValue())
+		require.Equal(t, domain.MoexCurrency, got["CNYRUB_TOM"].Kind)
+	})
+
+	t.Run("HTTP 400 Bad Request", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(400, []byte(`{"error":"empty request"}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetSecurities(ctx, nil, nil, nil)
+
+		require.Error(t, err)
+	})
+}
+
+func TestClient_GetIssuers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(nil, nil))
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		body := []byte(`{"issuers":[{"SECID":"SU26238RMFS4","EMITTER_ID":1,` +
+			`"EMITENT_TITLE":"Министерство Финансов Российской Федерации","EMITENT_INN":"7710168360",` +
+			`"TYPENAME":"Государственная облигация"}]}`)
+
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest",
+			ctx,
+			"moex/issuers",
+			mock.AnythingOfType("url.Values"),
+			mock.MatchedBy(func(body any) bool {
+				var req dto.IssuersRequest
+				buf, ok := body.(*bytes.Buffer)
+				return ok && json.Unmarshal(buf.Bytes(), &req) == nil && len(req.SecIDs) == 2
+			}),
+		).Return(models.NewHTTPResponse(200, body), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		got, err := client.GetIssuers(ctx, []string{"SU26238RMFS4", "UNKNOWN"})
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]domain.MoexIssuer{"SU26238RMFS4": {
+			SecID:    "SU26238RMFS4",
+			IssuerID: 1,
+			Name:     "Министерство Финансов Российской Федерации",
+			INN:      "7710168360",
+			TypeName: "Государственная облигация",
+		}}, got)
+	})
+
+	t.Run("HTTP 500", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(models.NewHTTPResponse(500, []byte(`{"error":"could not get data"}`)), nil)
+
+		client := NewMoexClient(logger, transportMock)
+		_, err := client.GetIssuers(ctx, []string{"SU26238RMFS4"})
+
+		require.Error(t, err)
+	})
+}
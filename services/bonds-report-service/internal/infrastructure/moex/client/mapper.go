@@ -17,9 +17,94 @@ func MapValueFromDTOToDomain(dtoValues dto.Values) domain.ValuesMoex {
 		FaceValue:       MapNullFloat64FromDTOToDomain(dtoValues.FaceValue),
 		FaceUnit:        MapNullStringFromDTOToDomain(dtoValues.FaceUnit),
 		Duration:        MapNullFloat64FromDTOToDomain(dtoValues.Duration),
+		Close:           MapNullFloat64FromDTOToDomain(dtoValues.Close),
+		Volume:          MapNullFloat64FromDTOToDomain(dtoValues.Volume),
 	}
 }
 
+func MapHistoryFromDTOToDomain(dtoHistory dto.HistoryResponse) domain.MoexHistory {
+	data := make([]domain.ValuesMoex, 0, len(dtoHistory.Data))
+	for _, values := range dtoHistory.Data {
+		data = append(data, MapValueFromDTOToDomain(values))
+	}
+	return domain.MoexHistory{
+		Ticker: dtoHistory.Ticker,
+		Data:   data,
+	}
+}
+
+func MapBondsFromDTOToDomain(dtoBonds dto.BondsResponse) []domain.MoexBond {
+	bonds := make([]domain.MoexBond, 0, len(dtoBonds.Bonds))
+	for _, bond := range dtoBonds.Bonds {
+		bonds = append(bonds, domain.MoexBond{
+			SecID:         bond.SecID,
+			BoardID:       bond.BoardID,
+			ShortName:     bond.ShortName,
+			ISIN:          bond.ISIN,
+			MaturityDate:  MapNullStringFromDTOToDomain(bond.MaturityDate),
+			OfferDate:     MapNullStringFromDTOToDomain(bond.OfferDate),
+			CouponPercent: MapNullFloat64FromDTOToDomain(bond.CouponPercent),
+			CouponValue:   MapNullFloat64FromDTOToDomain(bond.CouponValue),
+			CouponPeriod:  MapNullFloat64FromDTOToDomain(bond.CouponPeriod),
+			NextCoupon:    MapNullStringFromDTOToDomain(bond.NextCoupon),
+			FaceValue:     MapNullFloat64FromDTOToDomain(bond.FaceValue),
+			FaceUnit:      bond.FaceUnit,
+			CurrencyID:    bond.CurrencyID,
+			ListLevel:     bond.ListLevel,
+			BondType:      bond.BondType,
+			BondSubType:   bond.BondSubType,
+			Price:         MapNullFloat64FromDTOToDomain(bond.Price),
+			Yield:         MapNullFloat64FromDTOToDomain(bond.Yield),
+			Duration:      MapNullFloat64FromDTOToDomain(bond.Duration),
+		})
+	}
+	return bonds
+}
+
+func MapSecuritiesFromDTOToDomain(dtoSecurities dto.SecuritiesResponse) domain.MoexSecurities {
+	res := make(domain.MoexSecurities, len(dtoSecurities.Shares)+len(dtoSecurities.ETFs)+len(dtoSecurities.Currencies))
+	for _, share := range dtoSecurities.Shares {
+		res[share.SecID] = domain.MoexSecurity{
+			Kind:      domain.MoexShare,
+			SecID:     share.SecID,
+			BoardID:   share.BoardID,
+			ShortName: share.ShortName,
+			Name:      share.Name,
+			ISIN:      share.ISIN,
+			LotSize:   share.LotSize,
+			Currency:  share.Currency,
+			Sector:    share.Sector,
+			Price:     MapNullFloat64FromDTOToDomain(share.Price),
+		}
+	}
+	for _, etf := range dtoSecurities.ETFs {
+		res[etf.SecID] = domain.MoexSecurity{
+			Kind:      domain.MoexETF,
+			SecID:     etf.SecID,
+			BoardID:   etf.BoardID,
+			ShortName: etf.ShortName,
+			Name:      etf.Name,
+			ISIN:      etf.ISIN,
+			LotSize:   etf.LotSize,
+			Currency:  etf.Currency,
+			Price:     MapNullFloat64FromDTOToDomain(etf.Price),
+		}
+	}
+	for _, currency := range dtoSecurities.Currencies {
+		res[currency.SecID] = domain.MoexSecurity{
+			Kind:      domain.MoexCurrency,
+			SecID:     currency.SecID,
+			BoardID:   currency.BoardID,
+			ShortName: currency.ShortName,
+			Name:      currency.Name,
+			LotSize:   currency.LotSize,
+			Currency:  currency.Currency,
+			Price:     MapNullFloat64FromDTOToDomain(currency.Price),
+		}
+	}
+	return res
+}
+
 func MapNullStringFromDTOToDomain(dtoNullString dto.NullString) domain.NullString {
 	return domain.NewNullString(dtoNullString.Value, dtoNullString.IsSet, dtoNullString.IsNull)
 }
@@ -27,3 +112,17 @@ func MapNullStringFromDTOToDomain(dtoNullString dto.NullString) domain.NullStrin
 func MapNullFloat64FromDTOToDomain(dtoNullFLoat64 dto.NullFloat64) domain.NullFloat64 {
 	return domain.NewNullFloat64(dtoNullFLoat64.Value, dtoNullFLoat64.IsSet, dtoNullFLoat64.IsNull)
 }
+
+func MapIssuersFromDTOToDomain(dtoIssuers dto.IssuersResponse) map[string]domain.MoexIssuer {
+	res := make(map[string]domain.MoexIssuer, len(dtoIssuers.Issuers))
+	for _, issuer := range dtoIssuers.Issuers {
+		res[issuer.SecID] = domain.MoexIssuer{
+			SecID:    issuer.SecID,
+			IssuerID: issuer.IssuerID,
+			Name:     issuer.Name,
+			INN:      issuer.INN,
+			TypeName: issuer.TypeName,
+		}
+	}
+	return res
+}
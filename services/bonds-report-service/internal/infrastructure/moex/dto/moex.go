@@ -14,6 +14,137 @@ func NewSpecificationsRequest(ticker string, date time.Time) *SpecificationsRequ
 	}
 }
 
+type HistoryRequest struct {
+	Ticker string    `json:"ticker"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+func NewHistoryRequest(ticker string, from, to time.Time) *HistoryRequest {
+	return &HistoryRequest{
+		Ticker: ticker,
+		From:   from,
+		To:     to,
+	}
+}
+
+type HistoryResponse struct {
+	Ticker string   `json:"ticker"`
+	Data   []Values `json:"data"`
+}
+
+type BondsRequest struct {
+	Boards []string `json:"boards,omitempty"`
+}
+
+func NewBondsRequest(boards []string) *BondsRequest {
+	return &BondsRequest{
+		Boards: boards,
+	}
+}
+
+type BondsResponse struct {
+	Bonds []Bond `json:"bonds"`
+}
+
+type Bond struct {
+	SecID         string      `json:"SECID"`
+	BoardID       string      `json:"BOARDID"`
+	ShortName     string      `json:"SHORTNAME"`
+	ISIN          string      `json:"ISIN"`
+	MaturityDate  NullString  `json:"MATDATE"`
+	OfferDate     NullString  `json:"OFFERDATE"`
+	CouponPercent NullFloat64 `json:"COUPONPERCENT"`
+	CouponValue   NullFloat64 `json:"COUPONVALUE"`
+	CouponPeriod  NullFloat64 `json:"COUPONPERIOD"`
+	NextCoupon    NullString  `json:"NEXTCOUPON"`
+	FaceValue     NullFloat64 `json:"FACEVALUE"`
+	FaceUnit      string      `json:"FACEUNIT"`
+	CurrencyID    string      `json:"CURRENCYID"`
+	ListLevel     int         `json:"LISTLEVEL"`
+	BondType      string      `json:"BONDTYPE"`
+	BondSubType   string      `json:"BONDSUBTYPE"`
+	Price         NullFloat64 `json:"PRICE"`
+	Yield         NullFloat64 `json:"YIELD"`
+	Duration      NullFloat64 `json:"DURATION"`
+}
+
+type SecuritiesRequest struct {
+	Shares     []string `json:"shares,omitempty"`
+	ETFs       []string `json:"etfs,omitempty"`
+	Currencies []string `json:"currencies,omitempty"`
+}
+
+func NewSecuritiesRequest(shares, etfs, currencies []string) *SecuritiesRequest {
+	return &SecuritiesRequest{
+		Shares:     shares,
+		ETFs:       etfs,
+		Currencies: currencies,
+	}
+}
+
+type SecuritiesResponse struct {
+	Shares     []Share    `json:"shares"`
+	ETFs       []ETF      `json:"etfs"`
+	Currencies []Currency `json:"currencies"`
+}
+
+type Share struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	ISIN      string      `json:"ISIN"`
+	LotSize   int         `json:"LOTSIZE"`
+	Currency  string      `json:"CURRENCYID"`
+	Sector    string      `json:"SECTORID"`
+	ListLevel int         `json:"LISTLEVEL"`
+	Price     NullFloat64 `json:"PRICE"`
+}
+
+type ETF struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	ISIN      string      `json:"ISIN"`
+	LotSize   int         `json:"LOTSIZE"`
+	Currency  string      `json:"CURRENCYID"`
+	ListLevel int         `json:"LISTLEVEL"`
+	Price     NullFloat64 `json:"PRICE"`
+}
+
+type Currency struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	LotSize   int         `json:"LOTSIZE"`
+	FaceUnit  string      `json:"FACEUNIT"`
+	Currency  string      `json:"CURRENCYID"`
+	Price     NullFloat64 `json:"PRICE"`
+}
+
+type IssuersRequest struct {
+	SecIDs []string `json:"secIds"`
+}
+
+func NewIssuersRequest(secIDs []string) *IssuersRequest {
+	return &IssuersRequest{SecIDs: secIDs}
+}
+
+type IssuersResponse struct {
+	Issuers []Issuer `json:"issuers"`
+}
+
+type Issuer struct {
+	SecID    string `json:"SECID"`
+	IssuerID int64  `json:"EMITTER_ID"`
+	Name     string `json:"EMITENT_TITLE"`
+	INN      string `json:"EMITENT_INN"`
+	TypeName string `json:"TYPENAME"`
+}
+
 type Values struct {
 	ShortName       NullString  `json:"SHORTNAME"`
 	TradeDate       NullString  `json:"TRADEDATE"`    // Торговая дата(на момент которой рассчитаны остальные данные)
@@ -25,6 +156,8 @@ type Values struct {
 	FaceValue       NullFloat64 `json:"FACEVALUE"`
 	FaceUnit        NullString  `json:"FACEUNIT"` // номинальная стоимость облигации
 	Duration        NullFloat64 `json:"DURATION"` // дюрация (средневзвешенный срок платежей)
+	Close           NullFloat64 `json:"CLOSE"`    // цена закрытия в процентах от номинала
+	Volume          NullFloat64 `json:"VOLUME"`   // объем торгов в штуках
 }
 
 type NullString struct {
@@ -0,0 +1,63 @@
+package ratings
+
+import (
+	"bonds-report-service/internal/domain"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gladinov/e"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigRatings - файл рейтингов: ключ - тикер выпуска, ИНН эмитента или код эмитента MOEX,
+// значение - рейтинг в любой национальной шкале.
+type ConfigRatings struct {
+	Ratings map[string]string `yaml:"Ratings"`
+}
+
+// File читает рейтинги из файла, который ведет пользователь. Файл читается при каждом
+// запросе, поэтому правки применяются без перезапуска сервиса.
+type File struct {
+	filename string
+}
+
+// NewFile создает источник рейтингов. Пустой ratingsPath значит, что рейтингов нет.
+func NewFile(rootPath, ratingsPath string) *File {
+	if ratingsPath == "" {
+		return &File{}
+	}
+	return &File{filename: filepath.Join(rootPath, ratingsPath)}
+}
+
+func (f *File) GetRatings(ctx context.Context) (_ domain.Ratings, err error) {
+	defer func() { err = e.WrapIfErr("load ratings error", err) }()
+	if f.filename == "" {
+		return domain.Ratings{}, nil
+	}
+	input, err := os.ReadFile(f.filename)
+	if err != nil {
+		return nil, err
+	}
+	var c ConfigRatings
+	err = yaml.Unmarshal(input, &c)
+	if err != nil {
+		return nil, err
+	}
+	return ProcessConfigRatings(c), nil
+}
+
+// ProcessConfigRatings приводит ключи к верхнему регистру и убирает пустые рейтинги.
+func ProcessConfigRatings(config ConfigRatings) domain.Ratings {
+	res := make(domain.Ratings, len(config.Ratings))
+	for key, rating := range config.Ratings {
+		key, rating = strings.ToUpper(strings.TrimSpace(key)), strings.TrimSpace(rating)
+		if key == "" || rating == "" {
+			continue
+		}
+		res[key] = rating
+	}
+	return res
+}
@@ -54,6 +54,7 @@ func (s *Storage) InitDB(ctx context.Context) (err error) {
 
 	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
 
+	// operations и operations_sync создает мигратор из migrations/bonds-report/postgres
 	err = s.createBondReportsTable(ctx)
 	if err != nil {
 		return err
@@ -66,10 +67,6 @@ func (s *Storage) InitDB(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	err = s.createOperationsTable(ctx)
-	if err != nil {
-		return err
-	}
 	err = s.createCurrenciesTable(ctx)
 	if err != nil {
 		return err
@@ -102,14 +99,6 @@ func (s *Storage) createUidsTable(ctx context.Context) error {
 	return nil
 }
 
-func (s *Storage) createOperationsTable(ctx context.Context) error {
-	_, err := s.db.Exec(ctx, queryCreateOperationsTable)
-	if err != nil {
-		return e.WrapIfErr("could not create operations table", err)
-	}
-	return nil
-}
-
 func (s *Storage) createCurrenciesTable(ctx context.Context) error {
 	_, err := s.db.Exec(ctx, queryCreateCurrenciesTable)
 	if err != nil {
@@ -147,6 +136,23 @@ func (s *Storage) SaveOperations(ctx context.Context, chatID int, accountId stri
 		return fmt.Errorf("begin tx failed: %w", err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := insertOperations(ctx, tx, chatID, accountId, operations); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// insertOperations пропускает уже сохраненные операции: при возобновлении выгрузки с курсора
+// страница может прийти повторно. Дубли отсекает уникальный индекс
+// (chatId, broker_account_id, operation_id) из migrations/bonds-report/postgres; без него
+// вставка падает с ошибкой, а не пишет повторные строки.
+func insertOperations(ctx context.Context, tx pgx.Tx, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes) error {
 	batch := &pgx.Batch{}
 	for _, op := range operations {
 		batch.Queue(`
@@ -177,7 +183,8 @@ func (s *Storage) SaveOperations(ctx context.Context, chatID int, accountId stri
             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 
             $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, 
             $21, $22
-        )`,
+        )
+        ON CONFLICT (chatId, broker_account_id, operation_id) DO NOTHING`,
 			chatID,
 			accountId,
 			op.Currency,
@@ -209,10 +216,91 @@ func (s *Storage) SaveOperations(ctx context.Context, chatID int, accountId stri
 			return fmt.Errorf("batch insert operation error: %w", err)
 		}
 	}
-	err = br.Close()
+	err := br.Close()
 	if err != nil {
 		return fmt.Errorf("could not close batch results: %w", err)
 	}
+	return nil
+}
+
+// GetOperationsSyncState возвращает прогресс постраничной выгрузки операций по счету.
+func (s *Storage) GetOperationsSyncState(ctx context.Context, chatID int, accountId string) (_ domain.OperationsSyncState, err error) {
+	const op = "postgreSQL.GetOperationsSyncState"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	q := `SELECT date_from, date_to, cursor, pages, operations_saved, completed, updated_at
+		FROM operations_sync WHERE chatId = $1 AND broker_account_id = $2`
+
+	state := domain.OperationsSyncState{AccountID: accountId}
+	err = s.db.QueryRow(ctx, q, chatID, accountId).Scan(
+		&state.From,
+		&state.To,
+		&state.Cursor,
+		&state.Pages,
+		&state.OperationsSaved,
+		&state.Completed,
+		&state.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.OperationsSyncState{}, domain.ErrNoOperationsSyncState
+		}
+		return domain.OperationsSyncState{}, err
+	}
+	return state, nil
+}
+
+// SaveOperationsPage сохраняет страницу операций и прогресс выгрузки в одной транзакции,
+// чтобы после сбоя страница не записалась повторно и не потерялась.
+func (s *Storage) SaveOperationsPage(ctx context.Context, chatID int, accountId string, operations []domain.OperationWithoutCustomTypes, state domain.OperationsSyncState) (err error) {
+	const op = "postgreSQL.SaveOperationsPage"
+
+	defer logging.LogOperation_Debug(ctx, s.logger, op, &err)()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx failed: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := insertOperations(ctx, tx, chatID, accountId, operations); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO operations_sync (
+            chatId,
+            broker_account_id,
+            date_from,
+            date_to,
+            cursor,
+            pages,
+            operations_saved,
+            completed,
+            updated_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (chatId, broker_account_id) DO UPDATE SET
+            date_from = EXCLUDED.date_from,
+            date_to = EXCLUDED.date_to,
+            cursor = EXCLUDED.cursor,
+            pages = EXCLUDED.pages,
+            operations_saved = EXCLUDED.operations_saved,
+            completed = EXCLUDED.completed,
+            updated_at = EXCLUDED.updated_at`,
+		chatID,
+		accountId,
+		state.From,
+		state.To,
+		state.Cursor,
+		state.Pages,
+		state.OperationsSaved,
+		state.Completed,
+		state.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert operations sync state: %w", err)
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("commit tx: %w", err)
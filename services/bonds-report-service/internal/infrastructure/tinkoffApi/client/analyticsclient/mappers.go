@@ -13,6 +13,7 @@ func MapBondIdentIdentifiers(dtoBondId dto.BondIdentIdentifiers) domain.BondIden
 		Nominal:         MapMoneyValue(dtoBondId.Nominal),
 		NominalCurrency: dtoBondId.NominalCurrency,
 		Replaced:        dtoBondId.Replaced,
+		FloatingCoupon:  dtoBondId.FloatingCoupon,
 	}
 }
 
@@ -36,3 +37,19 @@ func MapQuotationToDomain(dtoQuat dto.Quotation) domain.Quotation {
 		Nano:  dtoQuat.Nano,
 	}
 }
+
+func MapBondsActionsBatchResponseToDomain(dtoResp dto.BondsActionsBatchResponse) map[string]domain.BondIdentIdentifiers {
+	out := make(map[string]domain.BondIdentIdentifiers, len(dtoResp.BondsActions))
+	for uid, v := range dtoResp.BondsActions {
+		out[uid] = MapBondIdentIdentifiers(v)
+	}
+	return out
+}
+
+func MapLastPricesResponseToDomain(dtoResp dto.LastPricesResponse) map[string]domain.LastPrice {
+	out := make(map[string]domain.LastPrice, len(dtoResp.LastPrices))
+	for uid, v := range dtoResp.LastPrices {
+		out[uid] = domain.LastPrice{LastPrice: MapQuotationToDomain(v)}
+	}
+	return out
+}
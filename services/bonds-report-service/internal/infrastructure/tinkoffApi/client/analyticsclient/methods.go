@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
@@ -125,3 +126,91 @@ func (c *AnalyticsTinkoffClient) GetLastPriceInPersentageToNominal(ctx context.C
 
 	return domainData, nil
 }
+
+func (c *AnalyticsTinkoffClient) GetBondsActionsBatch(ctx context.Context, instrumentUids []string) (_ map[string]domain.BondIdentIdentifiers, err error) {
+	const op = "tinkoffApi.GetBondsActionsBatch"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "bondsactions")
+	query := url.Values{}
+
+	requestBody := dto.NewBondsActionsBatchReq(instrumentUids)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.BondsActionsBatchResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+	if len(data.NotFound) != 0 {
+		logg.WarnContext(ctx, "instruments not found", slog.Any("instrumentUids", data.NotFound))
+	}
+
+	domainData := MapBondsActionsBatchResponseToDomain(data)
+
+	return domainData, nil
+}
+
+func (c *AnalyticsTinkoffClient) GetLastPricesInPersentageToNominal(ctx context.Context, instrumentUids []string) (_ map[string]domain.LastPrice, err error) {
+	const op = "tinkoffApi.GetLastPricesInPersentageToNominal"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "lastprices")
+	query := url.Values{}
+
+	requestBody := dto.NewLastPricesReq(instrumentUids)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.LastPricesResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+	if len(data.NotFound) != 0 {
+		logg.WarnContext(ctx, "instruments not found", slog.Any("instrumentUids", data.NotFound))
+	}
+
+	domainData := MapLastPricesResponseToDomain(data)
+
+	return domainData, nil
+}
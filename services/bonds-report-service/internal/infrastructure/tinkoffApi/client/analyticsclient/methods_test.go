@@ -3,6 +3,7 @@
 package analyticsclient
 
 import (
+	httperrors "bonds-report-service/internal/infrastructure/http"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/dto"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/models"
 	factories "bonds-report-service/internal/infrastructure/tinkoffApi/testing"
@@ -98,6 +99,7 @@ func TestGetBondsActions(t *testing.T) {
 			},
 			NominalCurrency: "RUB",
 			Replaced:        false,
+			FloatingCoupon:  true,
 		}
 
 		jsonData, _ := json.Marshal(dto)
@@ -122,6 +124,7 @@ func TestGetBondsActions(t *testing.T) {
 		assert.Equal(t, dto.Name, result.Name)
 		assert.Equal(t, dto.NominalCurrency, result.NominalCurrency)
 		assert.Equal(t, dto.Replaced, result.Replaced)
+		assert.True(t, result.FloatingCoupon)
 	})
 
 	t.Run("HTTP_Error", func(t *testing.T) {
@@ -248,3 +251,147 @@ func TestGetLastPriceInPersentageToNominal(t *testing.T) {
 		assert.ErrorContains(t, err, want)
 	})
 }
+
+func TestGetLastPricesInPersentageToNominal(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "lastprices")
+	instrumentUids := []string{"uid-1", "uid-2"}
+
+	t.Run("Success", func(t *testing.T) {
+		respBody := dto.LastPricesResponse{
+			LastPrices: map[string]dto.Quotation{
+				"uid-1": factories.QuotationDtoFromFloat(102.45),
+				"uid-2": factories.QuotationDtoFromFloat(98.1),
+			},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		result, err := client.GetLastPricesInPersentageToNominal(ctx, instrumentUids)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, factories.NewTestLastPrice(102.45), result["uid-1"])
+		assert.Equal(t, factories.NewTestLastPrice(98.1), result["uid-2"])
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("batch is too large"),
+			}, nil)
+
+		_, err := client.GetLastPricesInPersentageToNominal(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetLastPricesInPersentageToNominal(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetLastPricesInPersentageToNominal(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
+
+func TestGetBondsActionsBatch(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "bondsactions")
+	instrumentUids := []string{"bond-uid-1"}
+
+	t.Run("Success", func(t *testing.T) {
+		respBody := dto.BondsActionsBatchResponse{
+			BondsActions: map[string]dto.BondIdentIdentifiers{
+				"bond-uid-1": {
+					Ticker:          "TICK",
+					ClassCode:       "TQCB",
+					Name:            "Bond Name",
+					NominalCurrency: "usd",
+					Replaced:        true,
+				},
+			},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		result, err := client.GetBondsActionsBatch(ctx, instrumentUids)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "TICK", result["bond-uid-1"].Ticker)
+		assert.Equal(t, "usd", result["bond-uid-1"].NominalCurrency)
+		assert.True(t, result["bond-uid-1"].Replaced)
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("batch is too large"),
+			}, nil)
+
+		_, err := client.GetBondsActionsBatch(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetBondsActionsBatch(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetBondsActionsBatch(ctx, instrumentUids)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
@@ -32,9 +32,11 @@ func MapMoneyValueToDomain(dto dto.MoneyValue) domain.MoneyValue {
 
 func MapBondToDomain(dto dto.Bond) domain.Bond {
 	return domain.Bond{
-		AciValue: MapMoneyValueToDomain(dto.AciValue),
-		Currency: dto.Currency,
-		Nominal:  MapMoneyValueToDomain(dto.Nominal),
+		AciValue:  MapMoneyValueToDomain(dto.AciValue),
+		Currency:  dto.Currency,
+		Nominal:   MapMoneyValueToDomain(dto.Nominal),
+		Sector:    dto.Sector,
+		RiskLevel: domain.NewRiskLevel(dto.RiskLevel),
 	}
 }
 
@@ -66,3 +68,66 @@ func MapShareCurrencyByResponseToDomain(dto dto.ShareCurrencyByResponse) domain.
 		Currency: dto.Currency,
 	}
 }
+
+func MapBondsResponseToDomain(dto dto.BondsResponse) map[string]domain.Bond {
+	out := make(map[string]domain.Bond, len(dto.Bonds))
+	for uid, v := range dto.Bonds {
+		out[uid] = MapBondToDomain(v)
+	}
+	return out
+}
+
+func MapFuturesResponseToDomain(dto dto.FuturesResponse) map[string]domain.Future {
+	out := make(map[string]domain.Future, len(dto.Futures))
+	for figi, v := range dto.Futures {
+		out[figi] = MapFutureToDomain(v)
+	}
+	return out
+}
+
+func MapCurrenciesResponseToDomain(dto dto.CurrenciesResponse) map[string]domain.Currency {
+	out := make(map[string]domain.Currency, len(dto.Currencies))
+	for figi, v := range dto.Currencies {
+		out[figi] = MapCurrencyToDomain(v)
+	}
+	return out
+}
+
+func MapDividendToDomain(dto dto.Dividend) domain.Dividend {
+	return domain.Dividend{
+		DividendNet:  MapMoneyValueToDomain(dto.DividendNet),
+		PaymentDate:  dto.PaymentDate,
+		DeclaredDate: dto.DeclaredDate,
+		LastBuyDate:  dto.LastBuyDate,
+		RecordDate:   dto.RecordDate,
+		DividendType: dto.DividendType,
+		Regularity:   dto.Regularity,
+		ClosePrice:   MapMoneyValueToDomain(dto.ClosePrice),
+		YieldValue:   MapQuotationToDomain(dto.YieldValue),
+	}
+}
+
+func MapDividendsResponseToDomain(dto dto.DividendsResponse) []domain.Dividend {
+	res := make([]domain.Dividend, 0, len(dto.Dividends))
+	for _, v := range dto.Dividends {
+		res = append(res, MapDividendToDomain(v))
+	}
+	return res
+}
+
+func MapAssetReportToDomain(dto dto.AssetReport) domain.AssetReport {
+	return domain.AssetReport{
+		ReportDate: dto.ReportDate,
+		PeriodYear: dto.PeriodYear,
+		PeriodNum:  dto.PeriodNum,
+		PeriodType: dto.PeriodType,
+	}
+}
+
+func MapAssetReportsResponseToDomain(dto dto.AssetReportsResponse) []domain.AssetReport {
+	res := make([]domain.AssetReport, 0, len(dto.Reports))
+	for _, v := range dto.Reports {
+		res = append(res, MapAssetReportToDomain(v))
+	}
+	return res
+}
@@ -3,7 +3,9 @@
 package instrumentsclient
 
 import (
+	"bonds-report-service/internal/domain"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/dto"
+	factories "bonds-report-service/internal/infrastructure/tinkoffApi/testing"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,3 +23,14 @@ func TestMapSliceInstrumentShortToDomain(t *testing.T) {
 	require.Equal(t, "1", out[0].Uid)
 	require.Equal(t, "2", out[1].Uid)
 }
+
+func TestMapBondToDomain(t *testing.T) {
+	out := MapBondToDomain(factories.NewBond())
+
+	require.Equal(t, "financial", out.Sector)
+	require.Equal(t, domain.RiskLevelLow, out.RiskLevel)
+	require.Equal(t, int64(1000), out.Nominal.Units)
+
+	out = MapBondToDomain(dto.Bond{RiskLevel: "RISK_LEVEL_UNSPECIFIED"})
+	require.Equal(t, domain.RiskLevelUnknown, out.RiskLevel)
+}
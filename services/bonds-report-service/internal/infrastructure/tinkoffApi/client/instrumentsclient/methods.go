@@ -8,9 +8,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/gladinov/e"
 )
@@ -212,3 +214,215 @@ func (c *InstrumentsTinkoffClient) GetShareCurrencyBy(ctx context.Context, figi
 	domainData := MapShareCurrencyByResponseToDomain(data)
 	return domainData, nil
 }
+
+func (c *InstrumentsTinkoffClient) GetBondsByUids(ctx context.Context, uids []string) (_ map[string]domain.Bond, err error) {
+	const op = "tinkoffApi.GetBondsByUids"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "bonds")
+	query := url.Values{}
+
+	requestBody := dto.NewBondsReq(uids)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.BondsResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+	if len(data.NotFound) != 0 {
+		logg.WarnContext(ctx, "instruments not found", slog.Any("uids", data.NotFound))
+	}
+
+	domainData := MapBondsResponseToDomain(data)
+
+	return domainData, nil
+}
+
+func (c *InstrumentsTinkoffClient) GetFuturesBy(ctx context.Context, figis []string) (_ map[string]domain.Future, err error) {
+	const op = "tinkoffApi.GetFuturesBy"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "futures")
+	query := url.Values{}
+
+	requestBody := dto.NewFuturesReq(figis)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.FuturesResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+	if len(data.NotFound) != 0 {
+		logg.WarnContext(ctx, "instruments not found", slog.Any("figis", data.NotFound))
+	}
+
+	domainData := MapFuturesResponseToDomain(data)
+
+	return domainData, nil
+}
+
+func (c *InstrumentsTinkoffClient) GetCurrenciesBy(ctx context.Context, figis []string) (_ map[string]domain.Currency, err error) {
+	const op = "tinkoffApi.GetCurrenciesBy"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "currencies")
+	query := url.Values{}
+
+	requestBody := dto.NewCurrenciesReq(figis)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.CurrenciesResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+	if len(data.NotFound) != 0 {
+		logg.WarnContext(ctx, "instruments not found", slog.Any("figis", data.NotFound))
+	}
+
+	domainData := MapCurrenciesResponseToDomain(data)
+
+	return domainData, nil
+}
+
+func (c *InstrumentsTinkoffClient) GetDividends(ctx context.Context, figi string, from, to time.Time) (_ []domain.Dividend, err error) {
+	const op = "tinkoffApi.GetDividends"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "dividends")
+	query := url.Values{}
+
+	requestBody := dto.NewDividendsReq(figi, from, to)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.DividendsResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	domainData := MapDividendsResponseToDomain(data)
+	return domainData, nil
+}
+
+func (c *InstrumentsTinkoffClient) GetAssetReports(ctx context.Context, instrumentID string, from, to time.Time) (_ []domain.AssetReport, err error) {
+	const op = "tinkoffApi.GetAssetReports"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "assetreports")
+	query := url.Values{}
+
+	requestBody := dto.NewAssetReportsReq(instrumentID, from, to)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return nil, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.AssetReportsResponse
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return nil, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	domainData := MapAssetReportsResponseToDomain(data)
+	return domainData, nil
+}
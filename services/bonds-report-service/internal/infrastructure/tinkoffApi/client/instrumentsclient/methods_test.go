@@ -3,6 +3,7 @@
 package instrumentsclient
 
 import (
+	httperrors "bonds-report-service/internal/infrastructure/http"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/dto"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/models"
 	factories "bonds-report-service/internal/infrastructure/tinkoffApi/testing"
@@ -14,6 +15,7 @@ import (
 	"net/http"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -342,3 +344,255 @@ func TestGetShareCurrencyBy(t *testing.T) {
 		assert.Contains(t, err.Error(), "DoRequest")
 	})
 }
+
+func TestGetBondsByUids(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "bonds")
+	uids := []string{"bond-uid-1"}
+
+	t.Run("Success", func(t *testing.T) {
+		respBody := dto.BondsResponse{
+			Bonds: map[string]dto.Bond{"bond-uid-1": factories.NewBond()},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		res, err := client.GetBondsByUids(ctx, uids)
+		assert.NoError(t, err)
+		assert.Equal(t, MapBondToDomain(factories.NewBond()), res["bond-uid-1"])
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("batch is too large"),
+			}, nil)
+
+		_, err := client.GetBondsByUids(ctx, uids)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetBondsByUids(ctx, uids)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetBondsByUids(ctx, uids)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
+
+func TestGetFuturesBy(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "futures")
+	figis := []string{"FIGI1", "FIGI2"}
+
+	t.Run("Success", func(t *testing.T) {
+		respBody := dto.FuturesResponse{
+			Futures: map[string]dto.Future{
+				"FIGI1": factories.NewFutureDTO(),
+				"FIGI2": factories.NewFutureDTO(),
+			},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		res, err := client.GetFuturesBy(ctx, figis)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "Test Future", res["FIGI2"].Name)
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("batch is too large"),
+			}, nil)
+
+		_, err := client.GetFuturesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetFuturesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetFuturesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
+
+func TestGetCurrenciesBy(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "currencies")
+	figis := []string{"BBG0013HGFT4"}
+
+	t.Run("Success", func(t *testing.T) {
+		respBody := dto.CurrenciesResponse{
+			Currencies: map[string]dto.Currency{"BBG0013HGFT4": {Isin: "usd"}},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		res, err := client.GetCurrenciesBy(ctx, figis)
+		assert.NoError(t, err)
+		assert.Equal(t, "usd", res["BBG0013HGFT4"].Isin)
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("batch is too large"),
+			}, nil)
+
+		_, err := client.GetCurrenciesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetCurrenciesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetCurrenciesBy(ctx, figis)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
+
+func TestGetDividends(t *testing.T) {
+	ctx := context.Background()
+	path := path.Join("tinkoff", "dividends")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	t.Run("Success", func(t *testing.T) {
+		recordDate := time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC)
+		respBody := dto.DividendsResponse{
+			Figi: "FIGI1",
+			Dividends: []dto.Dividend{{
+				DividendNet: dto.MoneyValue{Currency: "rub", Units: 33, Nano: 300000000},
+				RecordDate:  recordDate,
+			}},
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		res, err := client.GetDividends(ctx, "FIGI1", from, to)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 33.3, res[0].DividendNet.ToFloat())
+		assert.True(t, recordDate.Equal(res[0].RecordDate))
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte("figi could not be empty string"),
+			}, nil)
+
+		_, err := client.GetDividends(ctx, "", from, to)
+		assert.ErrorIs(t, err, httperrors.ErrBadRequest)
+	})
+
+	t.Run("DoRequest_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.
+			On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(nil, errors.New("network error"))
+
+		_, err := client.GetDividends(ctx, "FIGI1", from, to)
+		assert.ErrorContains(t, err, "failed transport DoRequest")
+	})
+}
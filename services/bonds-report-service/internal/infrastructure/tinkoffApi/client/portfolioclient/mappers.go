@@ -109,3 +109,47 @@ func MapOperationsToDomain(ops []dto.Operation) []domain.Operation {
 
 	return out
 }
+
+func MapOperationsPageToDomain(page dto.OperationsPage) domain.OperationsPage {
+	return domain.OperationsPage{
+		Operations: MapOperationsToDomain(page.Operations),
+		NextCursor: page.NextCursor,
+		HasNext:    page.HasNext,
+		From:       page.From,
+		To:         page.To,
+	}
+}
+
+func MapMoneyValuesToDomain(values []dto.MoneyValue) []domain.MoneyValue {
+	res := make([]domain.MoneyValue, 0, len(values))
+	for _, v := range values {
+		res = append(res, MapMoneyValueToDomain(v))
+	}
+	return res
+}
+
+func MapPositionsToDomain(p dto.Positions) domain.Positions {
+	return domain.Positions{
+		Money:                   MapMoneyValuesToDomain(p.Money),
+		Blocked:                 MapMoneyValuesToDomain(p.Blocked),
+		LimitsLoadingInProgress: p.LimitsLoadingInProgress,
+	}
+}
+
+func MapWithdrawLimitsToDomain(l dto.WithdrawLimits) domain.WithdrawLimits {
+	return domain.WithdrawLimits{
+		Money:            MapMoneyValuesToDomain(l.Money),
+		Blocked:          MapMoneyValuesToDomain(l.Blocked),
+		BlockedGuarantee: MapMoneyValuesToDomain(l.BlockedGuarantee),
+	}
+}
+
+func MapMarginAttributesToDomain(m dto.MarginAttributes) domain.MarginAttributes {
+	return domain.MarginAttributes{
+		LiquidPortfolio:       MapMoneyValueToDomain(m.LiquidPortfolio),
+		StartingMargin:        MapMoneyValueToDomain(m.StartingMargin),
+		MinimalMargin:         MapMoneyValueToDomain(m.MinimalMargin),
+		FundsSufficiencyLevel: MapQuotationToDomain(m.FundsSufficiencyLevel),
+		AmountOfMissingFunds:  MapMoneyValueToDomain(m.AmountOfMissingFunds),
+	}
+}
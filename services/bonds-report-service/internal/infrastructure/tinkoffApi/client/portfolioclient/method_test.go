@@ -3,6 +3,7 @@
 package portfolioclient
 
 import (
+	"bonds-report-service/internal/domain"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/dto"
 	"bonds-report-service/internal/infrastructure/tinkoffApi/models"
 	factories "bonds-report-service/internal/infrastructure/tinkoffApi/testing"
@@ -10,6 +11,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"path"
@@ -240,3 +242,67 @@ func TestGetOperations(t *testing.T) {
 		assert.Contains(t, err.Error(), "DoRequest")
 	})
 }
+
+func TestGetOperationsPage(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	req := domain.OperationsPageRequest{AccountID: "ACC123", From: from, To: to, Cursor: "c1"}
+	path := path.Join("tinkoff", "operations", "page")
+
+	t.Run("Success", func(t *testing.T) {
+		want := factories.NewOperationDTO()
+		respBody := dto.OperationsPage{
+			Operations: []dto.Operation{want},
+			NextCursor: "c2",
+			HasNext:    true,
+			From:       from,
+			To:         to,
+		}
+		jsonData, _ := json.Marshal(respBody)
+
+		client, transportMock := setupClient(t)
+		transportMock.On("DoRequest", mock.Anything, path, mock.Anything, mock.MatchedBy(func(body io.Reader) bool {
+			var got dto.OperationsPageRequest
+			return json.NewDecoder(body).Decode(&got) == nil && got.Cursor == "c1" && got.AccountID == "ACC123"
+		})).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       jsonData,
+			}, nil)
+
+		result, err := client.GetOperationsPage(ctx, req)
+		assert.NoError(t, err)
+		assert.Len(t, result.Operations, 1)
+		assert.Equal(t, want.OperationID, result.Operations[0].OperationID)
+		assert.Equal(t, "c2", result.NextCursor)
+		assert.True(t, result.HasNext)
+		assert.True(t, to.Equal(result.To))
+	})
+
+	t.Run("HTTP_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusUnauthorized,
+				Body:       []byte("unauthorized"),
+			}, nil)
+
+		_, err := client.GetOperationsPage(ctx, req)
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "unauthorized")
+	})
+
+	t.Run("JSON_Error", func(t *testing.T) {
+		client, transportMock := setupClient(t)
+		transportMock.On("DoRequest", mock.Anything, path, mock.Anything, mock.Anything).
+			Return(&models.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte("invalid json"),
+			}, nil)
+
+		_, err := client.GetOperationsPage(ctx, req)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshal")
+	})
+}
@@ -127,3 +127,105 @@ func (c *PortfolioTinkoffClient) GetOperations(ctx context.Context, accountId st
 	domainData := MapOperationsToDomain(data)
 	return domainData, nil
 }
+
+func (c *PortfolioTinkoffClient) GetOperationsPage(ctx context.Context, req domain.OperationsPageRequest) (_ domain.OperationsPage, err error) {
+	const op = "tinkoffApi.GetOperationsPage"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("tinkoff", "operations", "page")
+	query := url.Values{}
+
+	requestBody := dto.NewOperationsPageRequest(req.AccountID, req.From, req.To, req.Cursor)
+
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return domain.OperationsPage{}, e.WrapIfErr("failed json.Marshal", err)
+	}
+	formatRequestBody := bytes.NewBuffer(jsonData)
+
+	httpResponse, err := c.transport.DoRequest(ctx, path, query, formatRequestBody)
+	if err != nil {
+		return domain.OperationsPage{}, e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return domain.OperationsPage{}, httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	var data dto.OperationsPage
+	err = json.Unmarshal(httpResponse.Body, &data)
+	if err != nil {
+		return domain.OperationsPage{}, e.WrapIfErr("failed to unmarshal response", err)
+	}
+
+	return MapOperationsPageToDomain(data), nil
+}
+
+func (c *PortfolioTinkoffClient) GetPositions(ctx context.Context, accountID string) (_ domain.Positions, err error) {
+	const op = "tinkoffApi.GetPositions"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	var data dto.Positions
+	if err := c.postAccountRequest(ctx, path.Join("tinkoff", "positions"), accountID, &data); err != nil {
+		return domain.Positions{}, err
+	}
+	return MapPositionsToDomain(data), nil
+}
+
+func (c *PortfolioTinkoffClient) GetWithdrawLimits(ctx context.Context, accountID string) (_ domain.WithdrawLimits, err error) {
+	const op = "tinkoffApi.GetWithdrawLimits"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	var data dto.WithdrawLimits
+	if err := c.postAccountRequest(ctx, path.Join("tinkoff", "withdrawlimits"), accountID, &data); err != nil {
+		return domain.WithdrawLimits{}, err
+	}
+	return MapWithdrawLimitsToDomain(data), nil
+}
+
+func (c *PortfolioTinkoffClient) GetMarginAttributes(ctx context.Context, accountID string) (_ domain.MarginAttributes, err error) {
+	const op = "tinkoffApi.GetMarginAttributes"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	var data dto.MarginAttributes
+	if err := c.postAccountRequest(ctx, path.Join("tinkoff", "marginattributes"), accountID, &data); err != nil {
+		return domain.MarginAttributes{}, err
+	}
+	return MapMarginAttributesToDomain(data), nil
+}
+
+// postAccountRequest отправляет запрос с номером счета и разбирает ответ в out.
+func (c *PortfolioTinkoffClient) postAccountRequest(ctx context.Context, endpoint, accountID string, out any) error {
+	jsonData, err := json.Marshal(dto.NewAccountReq(accountID))
+	if err != nil {
+		return e.WrapIfErr("failed json.Marshal", err)
+	}
+
+	httpResponse, err := c.transport.DoRequest(ctx, endpoint, url.Values{}, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return e.WrapIfErr("failed transport DoRequest", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return httperrors.MapHTTPError(
+			httpResponse.StatusCode,
+			httpResponse.Body,
+		)
+	}
+
+	if err := json.Unmarshal(httpResponse.Body, out); err != nil {
+		return e.WrapIfErr("failed to unmarshal response", err)
+	}
+	return nil
+}
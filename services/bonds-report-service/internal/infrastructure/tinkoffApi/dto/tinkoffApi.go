@@ -100,9 +100,11 @@ type Future struct {
 }
 
 type Bond struct {
-	AciValue MoneyValue `json:"aciValue,omitempty"`
-	Currency string     `json:"currency,omitempty"`
-	Nominal  MoneyValue `json:"nominal,omitempty"`
+	AciValue  MoneyValue `json:"aciValue,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Nominal   MoneyValue `json:"nominal,omitempty"`
+	Sector    string     `json:"sector,omitempty"`
+	RiskLevel string     `json:"riskLevel,omitempty"`
 }
 
 type Currency struct {
@@ -156,6 +158,7 @@ type BondIdentIdentifiers struct {
 	Nominal         MoneyValue `json:"nominal,omitempty"`
 	NominalCurrency string     `json:"nominalCurrency,omitempty"`
 	Replaced        bool       `json:"replaced,omitempty"`
+	FloatingCoupon  bool       `json:"floatingCoupon,omitempty"`
 }
 
 type PortfolioRequest struct {
@@ -182,6 +185,58 @@ func NewOperationsRequest(accountID string, date time.Time) OperationsRequest {
 	}
 }
 
+type OperationsPageRequest struct {
+	AccountID string    `json:"accountID,omitempty"`
+	From      time.Time `json:"from,omitempty"`
+	To        time.Time `json:"to,omitempty"`
+	Cursor    string    `json:"cursor,omitempty"`
+}
+
+func NewOperationsPageRequest(accountID string, from, to time.Time, cursor string) OperationsPageRequest {
+	return OperationsPageRequest{
+		AccountID: accountID,
+		From:      from,
+		To:        to,
+		Cursor:    cursor,
+	}
+}
+
+type OperationsPage struct {
+	Operations []Operation `json:"operations"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	HasNext    bool        `json:"hasNext"`
+	From       time.Time   `json:"from"`
+	To         time.Time   `json:"to"`
+}
+
+type AccountReq struct {
+	AccountID string `json:"accountId"`
+}
+
+func NewAccountReq(accountID string) AccountReq {
+	return AccountReq{AccountID: accountID}
+}
+
+type Positions struct {
+	Money                   []MoneyValue `json:"money"`
+	Blocked                 []MoneyValue `json:"blocked"`
+	LimitsLoadingInProgress bool         `json:"limitsLoadingInProgress,omitempty"`
+}
+
+type WithdrawLimits struct {
+	Money            []MoneyValue `json:"money"`
+	Blocked          []MoneyValue `json:"blocked"`
+	BlockedGuarantee []MoneyValue `json:"blockedGuarantee"`
+}
+
+type MarginAttributes struct {
+	LiquidPortfolio       MoneyValue `json:"liquidPortfolio"`
+	StartingMargin        MoneyValue `json:"startingMargin"`
+	MinimalMargin         MoneyValue `json:"minimalMargin"`
+	FundsSufficiencyLevel Quotation  `json:"fundsSufficiencyLevel"`
+	AmountOfMissingFunds  MoneyValue `json:"amountOfMissingFunds"`
+}
+
 type FutureReq struct {
 	Figi string `json:"figi,omitempty"`
 }
@@ -257,3 +312,117 @@ type ShareCurrencyByReq struct {
 func NewShareCurrencyByReq(figi string) *ShareCurrencyByReq {
 	return &ShareCurrencyByReq{Figi: figi}
 }
+
+type LastPricesReq struct {
+	InstrumentUids []string `json:"instrumentUids,omitempty"`
+}
+
+func NewLastPricesReq(instrumentUids []string) LastPricesReq {
+	return LastPricesReq{InstrumentUids: instrumentUids}
+}
+
+type LastPricesResponse struct {
+	LastPrices map[string]Quotation `json:"lastPrices"`
+	NotFound   []string             `json:"notFound,omitempty"`
+}
+
+type BondsReq struct {
+	Uids []string `json:"uids,omitempty"`
+}
+
+func NewBondsReq(uids []string) BondsReq {
+	return BondsReq{Uids: uids}
+}
+
+type BondsResponse struct {
+	Bonds    map[string]Bond `json:"bonds"`
+	NotFound []string        `json:"notFound,omitempty"`
+}
+
+type BondsActionsBatchReq struct {
+	InstrumentUids []string `json:"instrumentUids,omitempty"`
+}
+
+func NewBondsActionsBatchReq(instrumentUids []string) BondsActionsBatchReq {
+	return BondsActionsBatchReq{InstrumentUids: instrumentUids}
+}
+
+type BondsActionsBatchResponse struct {
+	BondsActions map[string]BondIdentIdentifiers `json:"bondsActions"`
+	NotFound     []string                        `json:"notFound,omitempty"`
+}
+
+type FuturesReq struct {
+	Figis []string `json:"figis,omitempty"`
+}
+
+func NewFuturesReq(figis []string) FuturesReq {
+	return FuturesReq{Figis: figis}
+}
+
+type FuturesResponse struct {
+	Futures  map[string]Future `json:"futures"`
+	NotFound []string          `json:"notFound,omitempty"`
+}
+
+type CurrenciesReq struct {
+	Figis []string `json:"figis,omitempty"`
+}
+
+func NewCurrenciesReq(figis []string) CurrenciesReq {
+	return CurrenciesReq{Figis: figis}
+}
+
+type CurrenciesResponse struct {
+	Currencies map[string]Currency `json:"currencies"`
+	NotFound   []string            `json:"notFound,omitempty"`
+}
+
+type DividendsReq struct {
+	Figi string    `json:"figi,omitempty"`
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+func NewDividendsReq(figi string, from, to time.Time) DividendsReq {
+	return DividendsReq{Figi: figi, From: from, To: to}
+}
+
+type DividendsResponse struct {
+	Figi      string     `json:"figi"`
+	Dividends []Dividend `json:"dividends"`
+}
+
+type Dividend struct {
+	DividendNet  MoneyValue `json:"dividendNet"`
+	PaymentDate  time.Time  `json:"paymentDate,omitempty"`
+	DeclaredDate time.Time  `json:"declaredDate,omitempty"`
+	LastBuyDate  time.Time  `json:"lastBuyDate,omitempty"`
+	RecordDate   time.Time  `json:"recordDate,omitempty"`
+	DividendType string     `json:"dividendType,omitempty"`
+	Regularity   string     `json:"regularity,omitempty"`
+	ClosePrice   MoneyValue `json:"closePrice"`
+	YieldValue   Quotation  `json:"yieldValue"`
+}
+
+type AssetReportsReq struct {
+	InstrumentID string    `json:"instrumentId,omitempty"`
+	From         time.Time `json:"from,omitempty"`
+	To           time.Time `json:"to,omitempty"`
+}
+
+func NewAssetReportsReq(instrumentID string, from, to time.Time) AssetReportsReq {
+	return AssetReportsReq{InstrumentID: instrumentID, From: from, To: to}
+}
+
+type AssetReportsResponse struct {
+	InstrumentID string        `json:"instrumentId"`
+	Reports      []AssetReport `json:"reports"`
+}
+
+type AssetReport struct {
+	ReportDate time.Time `json:"reportDate"`
+	PeriodYear int32     `json:"periodYear,omitempty"`
+	PeriodNum  int32     `json:"periodNum,omitempty"`
+	PeriodType string    `json:"periodType,omitempty"`
+}
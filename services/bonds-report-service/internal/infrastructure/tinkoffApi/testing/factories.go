@@ -86,6 +86,8 @@ func NewBond() dto.Bond {
 			Units:    1000,
 			Nano:     0,
 		},
+		Sector:    "financial",
+		RiskLevel: "RISK_LEVEL_LOW",
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"cbr/internal/clients/cbr"
 	"cbr/internal/configs"
 	"cbr/internal/handlers"
+	"cbr/internal/ratestore"
+	redisClient "cbr/internal/repository/redis"
 	"cbr/internal/service"
 	"cbr/internal/utils"
 	"context"
@@ -21,9 +23,6 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-// TODO: реализовать паттерн: “Cache-as-a-service”. Убрать хэндлеры и просто добавлять информацию
-// по валюте раз в 12 часов в redis. И никаких запросов от других сервисов
-
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL)
 	defer cancel()
@@ -46,10 +45,23 @@ func main() {
 	transport := cbr.NewTransport(logg, conf.CbrHost)
 	logg.Info("initialize cbr client")
 	client := cbr.NewClient(logg, transport)
+	logg.Info("initialize redis client")
+	// Без Redis сервис работает как раньше: каждый запрос курсов уходит на cbr.ru
+	var rates service.RateStore
+	redis, err := redisClient.NewClient(ctx, conf.Redis)
+	if err != nil {
+		logg.Warn("failed to connect to redis, start without rate store", slog.Any("error", err))
+	} else {
+		defer redis.Close()
+		logg.Info("initialize rate store", slog.Int("prefetch_hour", conf.RateStore.PrefetchHour))
+		store := ratestore.New(logg, redisClient.NewRateStore(redis), client, conf.RateStore, timeLocation)
+		go store.Run(ctx)
+		rates = store
+	}
 	logg.Info("initialize service")
-	service := service.NewService(logg, client, timeLocation)
+	service := service.NewService(logg, client, rates, timeLocation)
 	logg.Info("initialize handlers")
-	handler := handlers.NewHandlers(logg, service)
+	handler := handlers.NewHandlers(logg, service, service, service)
 
 	logg.Info("initialize router echo")
 	router := echo.New()
@@ -59,6 +71,11 @@ func main() {
 	router.HTTPErrorHandler = handlers.HTTPErrorHandler(logg)
 
 	router.POST("/cbr/currencies", handler.GetAllCurrencies)
+	router.POST("/cbr/keyrate", handler.GetKeyRate)
+	router.POST("/cbr/ruonia", handler.GetRuonia)
+	router.POST("/cbr/inflation", handler.GetInflation)
+	router.GET("/cbr/rates", handler.GetRates)
+	router.GET("/cbr/convert", handler.Convert)
 	address := conf.Clients.CbrAppApiClient.GetCbrAppServer()
 
 	httpSrv := &http.Server{
@@ -4,17 +4,20 @@ go 1.24.11
 
 require (
 	github.com/gladinov/contracts v0.1.5
+	github.com/gladinov/e v0.2.0
 	github.com/gladinov/mylogger v0.3.3
 	github.com/gladinov/traceidgenerator v0.1.0
+	github.com/gladinov/valuefromcontext v0.2.0
 	github.com/labstack/echo/v4 v4.13.4
+	github.com/redis/go-redis/v9 v9.17.1
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/text v0.30.0
 )
 
 require (
 	github.com/BurntSushi/toml v1.2.1 // indirect
-	github.com/gladinov/e v0.2.0 // indirect
-	github.com/gladinov/valuefromcontext v0.2.0 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/gladinov/e"
 )
@@ -14,8 +15,16 @@ import (
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=CbrClient
 type CbrClient interface {
 	GetAllCurrencies(ctx context.Context, formatDate string) (models.CurrenciesResponce, error)
+	GetKeyRate(ctx context.Context, from, to time.Time) (models.KeyRateResponce, error)
+	GetRuonia(ctx context.Context, from, to time.Time) (models.RuoniaResponce, error)
+	GetInflation(ctx context.Context, from, to time.Time) (models.InflationResponce, error)
 }
 
+const (
+	dailyInfoPath       = "DailyInfoWebServ/DailyInfo.asmx"
+	dailyInfoDateLayout = "2006-01-02"
+)
+
 type Client struct {
 	transport TransportClient
 	logger    *slog.Logger
@@ -50,3 +59,69 @@ func (c *Client) GetAllCurrencies(ctx context.Context, formatDate string) (_ mod
 
 	return currResp, nil
 }
+
+func (c *Client) GetKeyRate(ctx context.Context, from, to time.Time) (_ models.KeyRateResponce, err error) {
+	const op = "cbr.GetKeyRate"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	body, err := c.transport.DoRequest(ctx, path.Join(dailyInfoPath, "KeyRateXML"), dailyInfoPeriod(from, to))
+	if err != nil {
+		return models.KeyRateResponce{}, e.WrapIfErr("could not do request", err)
+	}
+
+	keyRate, err := parseKeyRate(ctx, logg, body)
+	if err != nil {
+		return models.KeyRateResponce{}, e.WrapIfErr("could not parse key rate", err)
+	}
+
+	return keyRate, nil
+}
+
+func (c *Client) GetRuonia(ctx context.Context, from, to time.Time) (_ models.RuoniaResponce, err error) {
+	const op = "cbr.GetRuonia"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	body, err := c.transport.DoRequest(ctx, path.Join(dailyInfoPath, "RuoniaXML"), dailyInfoPeriod(from, to))
+	if err != nil {
+		return models.RuoniaResponce{}, e.WrapIfErr("could not do request", err)
+	}
+
+	ruonia, err := parseRuonia(ctx, logg, body)
+	if err != nil {
+		return models.RuoniaResponce{}, e.WrapIfErr("could not parse ruonia", err)
+	}
+
+	return ruonia, nil
+}
+
+func (c *Client) GetInflation(ctx context.Context, from, to time.Time) (_ models.InflationResponce, err error) {
+	const op = "cbr.GetInflation"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	params := url.Values{}
+	params.Add("UniDbQuery.Posted", "True")
+	params.Add("UniDbQuery.From", from.Format(inflationMonthLayout))
+	params.Add("UniDbQuery.To", to.Format(inflationMonthLayout))
+
+	body, err := c.transport.DoRequest(ctx, path.Join("hd_base", "infl"), params)
+	if err != nil {
+		return models.InflationResponce{}, e.WrapIfErr("could not do request", err)
+	}
+
+	inflation, err := parseInflation(ctx, logg, body)
+	if err != nil {
+		return models.InflationResponce{}, e.WrapIfErr("could not parse inflation", err)
+	}
+
+	return inflation, nil
+}
+
+func dailyInfoPeriod(from, to time.Time) url.Values {
+	params := url.Values{}
+	params.Add("fromDate", from.Format(dailyInfoDateLayout))
+	params.Add("ToDate", to.Format(dailyInfoDateLayout))
+	return params
+}
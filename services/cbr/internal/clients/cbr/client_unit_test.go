@@ -83,3 +83,56 @@ func TestGetAllCurrencies(t *testing.T) {
 		transportMock.AssertExpectations(t)
 	})
 }
+
+func TestGetKeyRate(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("sucsess", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On(
+			"DoRequest",
+			ctx,
+			"DailyInfoWebServ/DailyInfo.asmx/KeyRateXML",
+			mock.MatchedBy(func(v url.Values) bool {
+				return v.Get("fromDate") == "2025-06-01" && v.Get("ToDate") == "2025-08-01"
+			}),
+		).Return(keyRateXMLInBytes, nil).Once()
+		client := NewClient(logg, transportMock)
+		keyRate, err := client.GetKeyRate(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, keyRate.Rates, 2)
+		require.Equal(t, 18.0, keyRate.Rates[1].Rate)
+	})
+	t.Run("DoRequest failed", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", ctx, "DailyInfoWebServ/DailyInfo.asmx/KeyRateXML", mock.Anything).
+			Return(nil, errors.New("could not do request")).Once()
+		client := NewClient(logg, transportMock)
+		_, err := client.GetKeyRate(ctx, from, to)
+		require.Error(t, err)
+	})
+}
+
+func TestGetInflation(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	transportMock := mocks.NewTransportClient(t)
+	transportMock.On(
+		"DoRequest",
+		ctx,
+		"hd_base/infl",
+		mock.MatchedBy(func(v url.Values) bool {
+			return v.Get("UniDbQuery.From") == "06.2025" && v.Get("UniDbQuery.To") == "07.2025"
+		}),
+	).Return(inflationHTMLInBytes, nil).Once()
+	client := NewClient(logg, transportMock)
+	inflation, err := client.GetInflation(ctx, from, to)
+	require.NoError(t, err)
+	require.Equal(t, inflationHTMLData, inflation)
+}
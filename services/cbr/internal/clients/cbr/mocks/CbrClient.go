@@ -7,6 +7,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // CbrClient is an autogenerated mock type for the CbrClient type
@@ -42,6 +44,90 @@ func (_m *CbrClient) GetAllCurrencies(ctx context.Context, formatDate string) (m
 	return r0, r1
 }
 
+// GetInflation provides a mock function with given fields: ctx, from, to
+func (_m *CbrClient) GetInflation(ctx context.Context, from time.Time, to time.Time) (models.InflationResponce, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInflation")
+	}
+
+	var r0 models.InflationResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (models.InflationResponce, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) models.InflationResponce); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(models.InflationResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyRate provides a mock function with given fields: ctx, from, to
+func (_m *CbrClient) GetKeyRate(ctx context.Context, from time.Time, to time.Time) (models.KeyRateResponce, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetKeyRate")
+	}
+
+	var r0 models.KeyRateResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (models.KeyRateResponce, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) models.KeyRateResponce); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(models.KeyRateResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuonia provides a mock function with given fields: ctx, from, to
+func (_m *CbrClient) GetRuonia(ctx context.Context, from time.Time, to time.Time) (models.RuoniaResponce, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRuonia")
+	}
+
+	var r0 models.RuoniaResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (models.RuoniaResponce, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) models.RuoniaResponce); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(models.RuoniaResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCbrClient creates a new instance of CbrClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCbrClient(t interface {
@@ -3,21 +3,41 @@ package cbr
 import (
 	"bytes"
 	"cbr/internal/models"
+	"cbr/internal/utils"
 	"cbr/internal/utils/logging"
 	"context"
 	"encoding/xml"
+	"errors"
 	"io"
 	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gladinov/e"
 	"golang.org/x/text/encoding/charmap"
 )
 
+const inflationMonthLayout = "01.2006"
+
+var ErrNoTableData = errors.New("no data in cbr table")
+
 func parseCurrencies(ctx context.Context, logger *slog.Logger, data []byte) (_ models.CurrenciesResponce, err error) {
 	const op = "cbr.parseCurrencies"
 	logg := logger.With()
 	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
 
+	var curr models.CurrenciesResponce
+	err = newDecoder(data).Decode(&curr)
+	if err != nil {
+		return models.CurrenciesResponce{}, e.WrapIfErr("could not decode Xml file", err)
+	}
+
+	return curr, nil
+}
+
+func newDecoder(data []byte) *xml.Decoder {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
 		if label == "windows-1251" {
@@ -25,11 +45,111 @@ func parseCurrencies(ctx context.Context, logger *slog.Logger, data []byte) (_ m
 		}
 		return input, nil
 	}
-	var curr models.CurrenciesResponce
-	err = decoder.Decode(&curr)
+	return decoder
+}
+
+// parseKeyRate разбирает ответ KeyRateXML сервиса DailyInfoWebServ.
+// ЦБ отдает ставки от новых к старым, возвращаются от старых к новым.
+func parseKeyRate(ctx context.Context, logger *slog.Logger, data []byte) (_ models.KeyRateResponce, err error) {
+	const op = "cbr.parseKeyRate"
+	logg := logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	var keyRate models.KeyRateResponce
+	err = newDecoder(data).Decode(&keyRate)
 	if err != nil {
-		return models.CurrenciesResponce{}, e.WrapIfErr("could not decode Xml file", err)
+		return models.KeyRateResponce{}, e.WrapIfErr("could not decode Xml file", err)
 	}
+	sort.Slice(keyRate.Rates, func(i, j int) bool {
+		return keyRate.Rates[i].Date.Before(keyRate.Rates[j].Date)
+	})
 
-	return curr, nil
+	return keyRate, nil
+}
+
+// parseRuonia разбирает ответ RuoniaXML сервиса DailyInfoWebServ.
+func parseRuonia(ctx context.Context, logger *slog.Logger, data []byte) (_ models.RuoniaResponce, err error) {
+	const op = "cbr.parseRuonia"
+	logg := logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	var ruonia models.RuoniaResponce
+	err = newDecoder(data).Decode(&ruonia)
+	if err != nil {
+		return models.RuoniaResponce{}, e.WrapIfErr("could not decode Xml file", err)
+	}
+	sort.Slice(ruonia.Rates, func(i, j int) bool {
+		return ruonia.Rates[i].Date.Before(ruonia.Rates[j].Date)
+	})
+
+	return ruonia, nil
+}
+
+var (
+	dataTableRe = regexp.MustCompile(`(?s)<table[^>]*class="data"[^>]*>(.*?)</table>`)
+	tableRowRe  = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+	tableCellRe = regexp.MustCompile(`(?s)<td[^>]*>(.*?)</td>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// parseInflation разбирает таблицу "Инфляция и ключевая ставка Банка России" (hd_base/infl).
+// В DailyInfoWebServ инфляции нет, поэтому данные берутся из html-таблицы class="data" с колонками:
+// месяц (ММ.ГГГГ), ключевая ставка, инфляция % г/г, цель по инфляции. Остальная разметка страницы
+// не просматривается, а строки, которые не разбираются (сноски, пустые месяцы), пропускаются.
+func parseInflation(ctx context.Context, logger *slog.Logger, data []byte) (_ models.InflationResponce, err error) {
+	const op = "cbr.parseInflation"
+	logg := logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	table := dataTableRe.FindSubmatch(data)
+	if table == nil {
+		return models.InflationResponce{}, ErrNoTableData
+	}
+
+	inflation := models.InflationResponce{Inflation: make([]models.Inflation, 0)}
+	for _, row := range tableRowRe.FindAllSubmatch(table[1], -1) {
+		cells := tableCellRe.FindAllSubmatch(row[1], -1)
+		// строка заголовка состоит из th
+		if len(cells) < 4 {
+			continue
+		}
+		values := make([]string, len(cells))
+		for i, cell := range cells {
+			values[i] = strings.TrimSpace(htmlTagRe.ReplaceAllString(string(cell[1]), ""))
+		}
+
+		month, err := parseInflationRow(values)
+		if err != nil {
+			logg.DebugContext(ctx, "skip inflation table row", slog.String("op", op), slog.Any("row", values), slog.Any("error", err))
+			continue
+		}
+		inflation.Inflation = append(inflation.Inflation, month)
+	}
+	if len(inflation.Inflation) == 0 {
+		return models.InflationResponce{}, ErrNoTableData
+	}
+	sort.Slice(inflation.Inflation, func(i, j int) bool {
+		return inflation.Inflation[i].Date.Before(inflation.Inflation[j].Date)
+	})
+
+	return inflation, nil
+}
+
+func parseInflationRow(values []string) (models.Inflation, error) {
+	date, err := time.Parse(inflationMonthLayout, values[0])
+	if err != nil {
+		return models.Inflation{}, e.WrapIfErr("could not parse month", err)
+	}
+	month := models.Inflation{Date: date}
+	for i, v := range []*float64{&month.KeyRate, &month.Inflation, &month.Target} {
+		// ParseRuFloat читает прочерк как ноль, а в таблице он значит, что данных за месяц еще нет
+		if value := values[i+1]; value == "" || value == "—" || value == "-" {
+			return models.Inflation{}, ErrNoTableData
+		}
+		*v, err = utils.ParseRuFloat(values[i+1])
+		if err != nil {
+			return models.Inflation{}, e.WrapIfErr("could not parse inflation table value", err)
+		}
+	}
+	return month, nil
 }
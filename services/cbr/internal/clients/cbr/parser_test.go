@@ -9,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -68,3 +69,63 @@ func TestParseCurrencies_UTF8CharsetReader(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "Австралийский доллар", got.Currencies[0].Name)
 }
+
+func TestParseKeyRate(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	got, err := parseKeyRate(ctx, logg, keyRateXMLInBytes)
+	require.NoError(t, err)
+	require.Len(t, got.Rates, len(keyRateXMLData.Rates))
+	for i, rate := range keyRateXMLData.Rates {
+		require.True(t, rate.Date.Equal(got.Rates[i].Date), "rates are sorted from old to new")
+		require.Equal(t, rate.Rate, got.Rates[i].Rate)
+	}
+
+	_, err = parseKeyRate(ctx, logg, xmlDataInBytesErr)
+	require.Error(t, err)
+}
+
+func TestParseRuonia(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	got, err := parseRuonia(ctx, logg, ruoniaXMLInBytes)
+	require.NoError(t, err)
+	require.Len(t, got.Rates, len(ruoniaXMLData.Rates))
+	for i, rate := range ruoniaXMLData.Rates {
+		require.True(t, rate.Date.Equal(got.Rates[i].Date))
+		require.Equal(t, rate.Rate, got.Rates[i].Rate)
+		require.Equal(t, rate.Volume, got.Rates[i].Volume)
+	}
+
+	_, err = parseRuonia(ctx, logg, xmlDataInBytesErr)
+	require.Error(t, err)
+}
+
+func TestParseInflation(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	got, err := parseInflation(ctx, logg, inflationHTMLInBytes)
+	require.NoError(t, err)
+	require.Equal(t, inflationHTMLData, got)
+
+	_, err = parseInflation(ctx, logg, []byte(`<table class="data"><tr><th>Дата</th></tr></table>`))
+	require.ErrorIs(t, err, ErrNoTableData)
+
+	// таблицы вне class="data" (меню, сноски) не разбираются
+	_, err = parseInflation(ctx, logg, []byte(`<table><tr><td>07.2025</td><td>18,00</td><td>8,79</td><td>4,00</td></tr></table>`))
+	require.ErrorIs(t, err, ErrNoTableData)
+
+	got, err = parseInflation(ctx, logg, []byte(`<table class="data">
+<tr><td>07.2025</td><td>18,00</td><td>8,79</td><td>4,00</td></tr>
+<tr><td>08.2025</td><td>18,00</td><td>—</td><td>4,00</td></tr>
+<tr><td colspan="3">* данные уточняются</td><td></td></tr>
+</table>`))
+	require.NoError(t, err)
+	require.Equal(t, []models.Inflation{{Date: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), KeyRate: 18, Inflation: 8.79, Target: 4}}, got.Inflation)
+
+	_, err = parseInflation(ctx, logg, []byte(`<table class="data"><tr><td>2025-07</td><td>18,00</td><td>8,79</td><td>4,00</td></tr></table>`))
+	require.ErrorIs(t, err, ErrNoTableData)
+}
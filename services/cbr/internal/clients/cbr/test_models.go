@@ -1,6 +1,9 @@
 package cbr
 
-import "cbr/internal/models"
+import (
+	"cbr/internal/models"
+	"time"
+)
 
 var xmlDataInBytes = []byte(`
 <ValCurs Date="03.03.1995" name="Foreign Currency Market">
@@ -28,3 +31,79 @@ var xmlData = models.CurrenciesResponce{
 }
 
 var xmlDataInBytesErr = []byte(``)
+
+var keyRateXMLInBytes = []byte(`<?xml version="1.0" encoding="utf-8"?>
+<KeyRate xmlns="">
+  <KR>
+    <DT>2025-07-28T00:00:00+03:00</DT>
+    <Rate>18.00</Rate>
+  </KR>
+  <KR>
+    <DT>2025-06-09T00:00:00+03:00</DT>
+    <Rate>20.00</Rate>
+  </KR>
+</KeyRate>`)
+
+var keyRateXMLData = models.KeyRateResponce{
+	Rates: []models.KeyRate{
+		{Date: time.Date(2025, 6, 9, 0, 0, 0, 0, moscowOffset), Rate: 20},
+		{Date: time.Date(2025, 7, 28, 0, 0, 0, 0, moscowOffset), Rate: 18},
+	},
+}
+
+var ruoniaXMLInBytes = []byte(`<?xml version="1.0" encoding="utf-8"?>
+<Ruonia xmlns="">
+  <ro>
+    <D0>2025-08-01T00:00:00+03:00</D0>
+    <ruo>17.8500</ruo>
+    <vol>568.1200</vol>
+    <DateUpdate>2025-08-04T14:00:00+03:00</DateUpdate>
+  </ro>
+  <ro>
+    <D0>2025-07-31T00:00:00+03:00</D0>
+    <ruo>17.9100</ruo>
+    <vol>601.4400</vol>
+    <DateUpdate>2025-08-01T14:00:00+03:00</DateUpdate>
+  </ro>
+</Ruonia>`)
+
+var ruoniaXMLData = models.RuoniaResponce{
+	Rates: []models.Ruonia{
+		{Date: time.Date(2025, 7, 31, 0, 0, 0, 0, moscowOffset), Rate: 17.91, Volume: 601.44},
+		{Date: time.Date(2025, 8, 1, 0, 0, 0, 0, moscowOffset), Rate: 17.85, Volume: 568.12},
+	},
+}
+
+var inflationHTMLInBytes = []byte(`<div class="table-wrapper">
+<div class="table">
+<table class="data">
+<tr>
+<th>Дата</th>
+<th>Ключевая ставка, % годовых</th>
+<th>Инфляция, % г/г</th>
+<th>Цель по инфляции, %</th>
+</tr>
+<tr>
+<td>07.2025</td>
+<td>18,00</td>
+<td>8,79</td>
+<td>4,00</td>
+</tr>
+<tr>
+<td>06.2025</td>
+<td>20,00</td>
+<td>9,40</td>
+<td>4,00</td>
+</tr>
+</table>
+</div>
+</div>`)
+
+var inflationHTMLData = models.InflationResponce{
+	Inflation: []models.Inflation{
+		{Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), KeyRate: 20, Inflation: 9.4, Target: 4},
+		{Date: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), KeyRate: 18, Inflation: 8.79, Target: 4},
+	},
+}
+
+var moscowOffset = time.FixedZone("", 3*60*60)
@@ -1,19 +1,37 @@
 package configs
 
 import (
+	"cbr/internal/ratestore"
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Env        string  `env:"ENV" env-required:"true"`
-	RootPath   string  `env:"ROOT_PATH" env-required:"true"`
-	ConfigPath string  `env:"CONFIG_PATH" env-required:"true"`
-	CbrHost    string  `yaml:"cbrHost"`
-	Clients    Clients `yaml:"clients"`
+	Env        string           `env:"ENV" env-required:"true"`
+	RootPath   string           `env:"ROOT_PATH" env-required:"true"`
+	ConfigPath string           `env:"CONFIG_PATH" env-required:"true"`
+	CbrHost    string           `yaml:"cbrHost"`
+	Clients    Clients          `yaml:"clients"`
+	Redis      RedisHTTPServer  `yaml:"redisHTTP"`
+	RateStore  ratestore.Config `yaml:"rateStore"`
+}
+
+type RedisHTTPServer struct {
+	Host        string        `env:"REDIS_HOST" env-required:"true"`
+	Port        string        `env:"REDIS_PORT" env-required:"true"`
+	Password    string        `env:"REDIS_PASSWORD" env-required:"true"`
+	DB          int           `yaml:"db"`
+	MaxRetries  int           `yaml:"max_retries"`
+	DialTimeout time.Duration `yaml:"dial_timeout"`
+	Timeout     time.Duration `yaml:"timeout"`
+}
+
+func (r *RedisHTTPServer) GetAddress() string {
+	return getAddress(r.Host, r.Port)
 }
 
 type Clients struct {
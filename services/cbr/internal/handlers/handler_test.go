@@ -5,6 +5,7 @@ package handlers
 import (
 	"bytes"
 	"cbr/internal/models"
+	"cbr/internal/service"
 	"cbr/internal/service/mocks"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -22,7 +24,7 @@ import (
 func TestGetAllCurrencies(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	mockService := mocks.NewCurrencyService(t)
-	h := NewHandlers(logger, mockService)
+	h := NewHandlers(logger, mockService, nil, nil)
 	e := echo.New()
 	e.HTTPErrorHandler = HTTPErrorHandler(logger)
 
@@ -95,3 +97,134 @@ func TestGetAllCurrencies(t *testing.T) {
 		assert.Equal(t, expectedBody, respBody["error"])
 	})
 }
+
+func TestGetKeyRate(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockMacro := mocks.NewMacroService(t)
+	h := NewHandlers(logger, nil, mockMacro, nil)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.POST("/cbr/keyrate", h.GetKeyRate)
+
+	doRequest := func(inputBody map[string]any) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(inputBody)
+		req := httptest.NewRequest(http.MethodPost, "/cbr/keyrate", bytes.NewReader(bodyBytes))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("sucsess", func(t *testing.T) {
+		expectedBody := models.KeyRateResponce{Rates: []models.KeyRate{{Rate: 18}}}
+		mockMacro.On("GetKeyRate", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(expectedBody, nil).Once()
+
+		rec := doRequest(map[string]any{"from": "2025-06-01T00:00:00+03:00"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var respBody models.KeyRateResponce
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBody))
+		assert.Equal(t, expectedBody, respBody)
+	})
+	t.Run("invalid period", func(t *testing.T) {
+		mockMacro.On("GetKeyRate", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(models.KeyRateResponce{}, service.ErrInvalidPeriod).Once()
+
+		rec := doRequest(map[string]any{"from": "2025-08-01T00:00:00+03:00", "to": "2025-06-01T00:00:00+03:00"})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("GetKeyRate err", func(t *testing.T) {
+		mockMacro.On("GetKeyRate", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(models.KeyRateResponce{}, errors.New("failed to get key rate from client")).Once()
+
+		rec := doRequest(map[string]any{})
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var respBody map[string]string
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBody))
+		assert.Equal(t, errGetData.Error(), respBody["error"])
+	})
+}
+
+func TestConvert(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockConvert := mocks.NewConvertService(t)
+	h := NewHandlers(logger, nil, nil, mockConvert)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.GET("/cbr/convert", h.Convert)
+
+	doRequest := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/cbr/convert?"+query, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("sucsess", func(t *testing.T) {
+		expectedBody := models.ConvertResponce{Date: "06.11.2025", From: "CNY", To: "USD", Amount: 100, Rate: 0.14, Result: 14}
+		mockConvert.On("Convert", mock.Anything, "CNY", "USD", 100.0, time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC)).
+			Return(expectedBody, nil).Once()
+
+		rec := doRequest("from=CNY&to=USD&amount=100&date=2025-11-06")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var respBody models.ConvertResponce
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBody))
+		assert.Equal(t, expectedBody, respBody)
+	})
+	t.Run("invalid date", func(t *testing.T) {
+		rec := doRequest("from=CNY&to=USD&amount=100&date=06.11.2025")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid amount", func(t *testing.T) {
+		rec := doRequest("from=CNY&to=USD&amount=abc")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("unknown currency", func(t *testing.T) {
+		mockConvert.On("Convert", mock.Anything, "XXX", "USD", 1.0, mock.AnythingOfType("time.Time")).
+			Return(models.ConvertResponce{}, service.ErrUnknownCurrency).Once()
+
+		rec := doRequest("from=XXX&to=USD&amount=1")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetRates(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockConvert := mocks.NewConvertService(t)
+	h := NewHandlers(logger, nil, nil, mockConvert)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.GET("/cbr/rates", h.GetRates)
+
+	t.Run("sucsess", func(t *testing.T) {
+		expectedBody := models.RatesResponce{Date: "06.11.2025", Rates: []models.Rate{{CharCode: "USD", Nominal: 1, Value: 81, VunitRate: 81}}}
+		mockConvert.On("GetRates", mock.Anything, []string{"USD", "EUR"}, mock.AnythingOfType("time.Time")).
+			Return(expectedBody, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/cbr/rates?codes=USD,%20EUR,", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var respBody models.RatesResponce
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &respBody))
+		assert.Equal(t, expectedBody, respBody)
+	})
+	t.Run("GetRates err", func(t *testing.T) {
+		mockConvert.On("GetRates", mock.Anything, []string{"USD"}, mock.AnythingOfType("time.Time")).
+			Return(models.RatesResponce{}, errors.New("failed to get currencies")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/cbr/rates?codes=USD", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
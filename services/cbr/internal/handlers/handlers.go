@@ -6,6 +6,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -14,17 +15,24 @@ import (
 var (
 	errInvalidRequestBody error = errors.New("invalid request body")
 	errGetData            error = errors.New("could not get data")
+	errInvalidDate        error = errors.New("invalid date, want format 2006-01-02")
 )
 
+const queryDateLayout = "2006-01-02"
+
 type Handlers struct {
 	logger  *slog.Logger
 	service service.CurrencyService
+	macro   service.MacroService
+	convert service.ConvertService
 }
 
-func NewHandlers(logger *slog.Logger, srvc service.CurrencyService) *Handlers {
+func NewHandlers(logger *slog.Logger, srvc service.CurrencyService, macro service.MacroService, convert service.ConvertService) *Handlers {
 	return &Handlers{
 		logger:  logger,
 		service: srvc,
+		macro:   macro,
+		convert: convert,
 	}
 }
 
@@ -49,3 +57,154 @@ func (h *Handlers) GetAllCurrencies(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, currencies)
 }
+
+func (h *Handlers) GetKeyRate(c echo.Context) error {
+	const op = "handlers.GetKeyRate"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var periodRequest PeriodRequest
+
+	err := c.Bind(&periodRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+	}
+
+	keyRate, err := h.macro.GetKeyRate(ctx, periodRequest.From, periodRequest.To)
+	switch {
+	case errors.Is(err, service.ErrInvalidPeriod):
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+	}
+
+	return c.JSON(http.StatusOK, keyRate)
+}
+
+func (h *Handlers) GetRuonia(c echo.Context) error {
+	const op = "handlers.GetRuonia"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var periodRequest PeriodRequest
+
+	err := c.Bind(&periodRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+	}
+
+	ruonia, err := h.macro.GetRuonia(ctx, periodRequest.From, periodRequest.To)
+	switch {
+	case errors.Is(err, service.ErrInvalidPeriod):
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+	}
+
+	return c.JSON(http.StatusOK, ruonia)
+}
+
+func (h *Handlers) GetInflation(c echo.Context) error {
+	const op = "handlers.GetInflation"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var periodRequest PeriodRequest
+
+	err := c.Bind(&periodRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+	}
+
+	inflation, err := h.macro.GetInflation(ctx, periodRequest.From, periodRequest.To)
+	switch {
+	case errors.Is(err, service.ErrInvalidPeriod):
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+	}
+
+	return c.JSON(http.StatusOK, inflation)
+}
+
+func (h *Handlers) GetRates(c echo.Context) error {
+	const op = "handlers.GetRates"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var ratesRequest RatesRequest
+
+	err := c.Bind(&ratesRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+	}
+	date, err := parseQueryDate(ratesRequest.Date)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidDate)
+	}
+
+	rates, err := h.convert.GetRates(ctx, splitCodes(ratesRequest.Codes), date)
+	switch {
+	case errors.Is(err, service.ErrEmptyCodes), errors.Is(err, service.ErrUnknownCurrency):
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+func (h *Handlers) Convert(c echo.Context) error {
+	const op = "handlers.Convert"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var convertRequest ConvertRequest
+
+	err := c.Bind(&convertRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+	}
+	date, err := parseQueryDate(convertRequest.Date)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidDate)
+	}
+
+	conversion, err := h.convert.Convert(ctx, convertRequest.From, convertRequest.To, convertRequest.Amount, date)
+	switch {
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrUnknownCurrency):
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+	}
+
+	return c.JSON(http.StatusOK, conversion)
+}
+
+// parseQueryDate разбирает дату из query. Пустая дата - текущий момент.
+func parseQueryDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(queryDateLayout, value)
+}
+
+func splitCodes(value string) []string {
+	codes := make([]string, 0)
+	for _, code := range strings.Split(value, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
@@ -21,7 +21,7 @@ func TestContextHeaderTraceIdMiddleware_FromHeader(t *testing.T) {
 
 	srvc := mocks.NewCurrencyService(t)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	h := NewHandlers(logger, srvc)
+	h := NewHandlers(logger, srvc, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set(httpheaders.HeaderTraceID, "trace-123")
@@ -52,7 +52,7 @@ func TestContextHeaderTraceIdMiddleware_EmptyTraceID(t *testing.T) {
 
 	srvc := mocks.NewCurrencyService(t)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	h := NewHandlers(logger, srvc)
+	h := NewHandlers(logger, srvc, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set(httpheaders.HeaderTraceID, "")
@@ -83,7 +83,7 @@ func TestLoggerMiddleware_PassesThrough(t *testing.T) {
 
 	srvc := mocks.NewCurrencyService(t)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	h := NewHandlers(logger, srvc)
+	h := NewHandlers(logger, srvc, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
 	rec := httptest.NewRecorder()
@@ -108,7 +108,7 @@ func TestLoggerMiddleware_Error(t *testing.T) {
 
 	srvc := mocks.NewCurrencyService(t)
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
-	h := NewHandlers(logger, srvc)
+	h := NewHandlers(logger, srvc, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/err", nil)
 	rec := httptest.NewRecorder()
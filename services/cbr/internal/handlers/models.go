@@ -6,6 +6,26 @@ type CurrencyRequest struct {
 	Date time.Time `json:"date,omitempty"`
 }
 
+// PeriodRequest - период для ставок и инфляции. Пустые даты - последний год.
+type PeriodRequest struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// RatesRequest - параметры /cbr/rates: коды валют через запятую и дата в формате 2006-01-02.
+type RatesRequest struct {
+	Codes string `query:"codes"`
+	Date  string `query:"date"`
+}
+
+// ConvertRequest - параметры /cbr/convert. Пустая дата - сегодняшний курс.
+type ConvertRequest struct {
+	From   string  `query:"from"`
+	To     string  `query:"to"`
+	Amount float64 `query:"amount"`
+	Date   string  `query:"date"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
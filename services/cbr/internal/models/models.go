@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 const RequestIDHeader = "X-request_id"
 
 type Currency struct {
@@ -549,3 +551,59 @@ var xmlData = CurrenciesResponce{
 }
 
 var xmlDataInBytesErr = []byte(``)
+
+// KeyRate - ключевая ставка ЦБ, действующая с Date, в % годовых.
+type KeyRate struct {
+	Date time.Time `xml:"DT" json:"date"`
+	Rate float64   `xml:"Rate" json:"rate"`
+}
+
+type KeyRateResponce struct {
+	Rates []KeyRate `xml:"KR" json:"rates"`
+}
+
+// Ruonia - ставка RUONIA за день в % годовых и объем сделок в млрд руб.
+type Ruonia struct {
+	Date   time.Time `xml:"D0" json:"date"`
+	Rate   float64   `xml:"ruo" json:"rate"`
+	Volume float64   `xml:"vol" json:"volume"`
+}
+
+type RuoniaResponce struct {
+	Rates []Ruonia `xml:"ro" json:"rates"`
+}
+
+// Inflation - годовая инфляция (ИПЦ, % г/г) за месяц. Date - первое число месяца.
+type Inflation struct {
+	Date      time.Time `json:"date"`
+	Inflation float64   `json:"inflation"`
+	KeyRate   float64   `json:"keyRate"`
+	Target    float64   `json:"target"`
+}
+
+type InflationResponce struct {
+	Inflation []Inflation `json:"inflation"`
+}
+
+// Rate - курс валюты ЦБ: Value рублей за Nominal единиц, VunitRate - рублей за одну единицу.
+type Rate struct {
+	CharCode  string  `json:"charCode"`
+	Nominal   int     `json:"nominal"`
+	Value     float64 `json:"value"`
+	VunitRate float64 `json:"vunitRate"`
+}
+
+type RatesResponce struct {
+	Date  string `json:"date"`
+	Rates []Rate `json:"rates"`
+}
+
+// ConvertResponce - пересчет Amount из From в To по кросс-курсу ЦБ: Result = Amount * Rate.
+type ConvertResponce struct {
+	Date   string  `json:"date"`
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
+	Rate   float64 `json:"rate"`
+	Result float64 `json:"result"`
+}
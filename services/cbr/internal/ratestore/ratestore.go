@@ -0,0 +1,213 @@
+package ratestore
+
+import (
+	"cbr/internal/clients/cbr"
+	"cbr/internal/models"
+	"cbr/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// rateDateLayout - формат даты, с которой действует курс, в ответе ЦБ.
+	rateDateLayout = "02.01.2006"
+
+	defaultBackfillYears = 5
+	defaultPrefetchHour  = 1
+	defaultRequestDelay  = 200 * time.Millisecond
+	defaultTimeout       = 500 * time.Millisecond
+)
+
+var ErrNoRates = errors.New("no rates in store")
+
+// Store - постоянное хранилище курсов (Redis). Курсы лежат по дате, с которой они действуют.
+type Store interface {
+	SaveRates(ctx context.Context, date time.Time, rates []byte) error
+	// LastRates возвращает последние курсы, действующие с даты не позже date, или ErrNoRates.
+	LastRates(ctx context.Context, date time.Time) ([]byte, error)
+	// SyncedTo возвращает день, до которого хранилище заполнено полностью, или нулевое время.
+	SyncedTo(ctx context.Context) (time.Time, error)
+	SetSyncedTo(ctx context.Context, date time.Time) error
+}
+
+type Config struct {
+	// BackfillYears - за сколько лет загружается история при первом запуске.
+	BackfillYears int `yaml:"backfillYears" env-default:"5"`
+	// PrefetchHour - час по Москве, в который курсы загружаются каждую ночь.
+	PrefetchHour int `yaml:"prefetchHour" env-default:"1"`
+	// RequestDelay - пауза между запросами к cbr.ru при загрузке истории.
+	RequestDelay time.Duration `yaml:"requestDelay" env-default:"200ms"`
+	// Timeout - ограничение на операцию с хранилищем, чтобы медленный Redis не тормозил запросы.
+	Timeout time.Duration `yaml:"timeout" env-default:"500ms"`
+}
+
+// Rates хранит курсы ЦБ у себя, чтобы отвечать без запроса к cbr.ru.
+// Запрос на выходной или праздник всегда получает последний опубликованный до него курс.
+type Rates struct {
+	logger   *slog.Logger
+	store    Store
+	client   cbr.CbrClient
+	cfg      Config
+	location *time.Location
+	now      func() time.Time
+}
+
+func New(logger *slog.Logger, store Store, client cbr.CbrClient, cfg Config, location *time.Location) *Rates {
+	if cfg.BackfillYears <= 0 {
+		cfg.BackfillYears = defaultBackfillYears
+	}
+	if cfg.PrefetchHour < 0 || cfg.PrefetchHour > 23 {
+		cfg.PrefetchHour = defaultPrefetchHour
+	}
+	if cfg.RequestDelay <= 0 {
+		cfg.RequestDelay = defaultRequestDelay
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return &Rates{
+		logger:   logger,
+		store:    store,
+		client:   client,
+		cfg:      cfg,
+		location: location,
+		now:      time.Now,
+	}
+}
+
+// Get возвращает курсы на дату из хранилища. Если день еще не загружен
+// (например, сегодняшний курс до ночной загрузки), возвращается ErrNoRates.
+func (r *Rates) Get(ctx context.Context, date time.Time) (models.CurrenciesResponce, error) {
+	const op = "ratestore.Get"
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	day := utils.Day(date, r.location)
+	syncedTo, err := r.store.SyncedTo(ctx)
+	if err != nil {
+		return models.CurrenciesResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if syncedTo.IsZero() || day.After(syncedTo) {
+		return models.CurrenciesResponce{}, fmt.Errorf("%s: %w", op, ErrNoRates)
+	}
+	return r.last(ctx, op, day)
+}
+
+// GetLast возвращает последние сохраненные курсы на дату, даже если день не загружен.
+// Нужен, когда cbr.ru недоступен: устаревший курс лучше ошибки.
+func (r *Rates) GetLast(ctx context.Context, date time.Time) (models.CurrenciesResponce, error) {
+	const op = "ratestore.GetLast"
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	return r.last(ctx, op, utils.Day(date, r.location))
+}
+
+func (r *Rates) last(ctx context.Context, op string, day time.Time) (models.CurrenciesResponce, error) {
+	raw, err := r.store.LastRates(ctx, day)
+	if err != nil {
+		return models.CurrenciesResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+	var rates models.CurrenciesResponce
+	if err := json.Unmarshal(raw, &rates); err != nil {
+		return models.CurrenciesResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rates, nil
+}
+
+// Save сохраняет ответ ЦБ по дате, с которой действует курс.
+func (r *Rates) Save(ctx context.Context, rates models.CurrenciesResponce) error {
+	const op = "ratestore.Save"
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+
+	if err := r.save(ctx, rates); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *Rates) save(ctx context.Context, rates models.CurrenciesResponce) error {
+	date, err := time.Parse(rateDateLayout, rates.Date)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	return r.store.SaveRates(ctx, date, raw)
+}
+
+// Sync загружает курсы за дни после последней загрузки по сегодняшний включительно.
+// При первом запуске загружается история за BackfillYears лет. Курсы на сегодня
+// ЦБ публикует накануне, поэтому после загрузки они уже не меняются.
+// Прогресс сохраняется после каждого дня, прерванная загрузка продолжается с того же места.
+func (r *Rates) Sync(ctx context.Context) error {
+	const op = "ratestore.Sync"
+	now := r.now().In(r.location)
+	today := utils.Day(now, r.location)
+	startDate := utils.GetStartSingleExchangeRateRubble(r.location)
+
+	syncedTo, err := r.store.SyncedTo(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	from := syncedTo.AddDate(0, 0, 1)
+	if syncedTo.IsZero() {
+		from = today.AddDate(-r.cfg.BackfillYears, 0, 0)
+	}
+
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, r.location)
+		rates, err := r.client.GetAllCurrencies(ctx, utils.NormalizeDate(date, now, startDate))
+		if err != nil {
+			return fmt.Errorf("%s: could not get rates on %s: %w", op, day.Format(time.DateOnly), err)
+		}
+		if err := r.save(ctx, rates); err != nil {
+			return fmt.Errorf("%s: could not save rates on %s: %w", op, day.Format(time.DateOnly), err)
+		}
+		if err := r.store.SetSyncedTo(ctx, day); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.cfg.RequestDelay):
+		}
+	}
+	return nil
+}
+
+// Run загружает недостающие курсы при запуске, а затем каждую ночь в PrefetchHour.
+// Ошибка загрузки не останавливает сервис: непокрытые дни запрашиваются у cbr.ru напрямую.
+func (r *Rates) Run(ctx context.Context) {
+	const op = "ratestore.Run"
+	for {
+		if err := r.Sync(ctx); err != nil && ctx.Err() == nil {
+			r.logger.WarnContext(ctx, "failed to prefetch cbr rates", slog.String("op", op), slog.Any("error", err))
+		}
+
+		timer := time.NewTimer(r.untilNextPrefetch())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Rates) untilNextPrefetch() time.Duration {
+	now := r.now().In(r.location)
+	next := time.Date(now.Year(), now.Month(), now.Day(), r.cfg.PrefetchHour, 0, 0, 0, r.location)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(now)
+}
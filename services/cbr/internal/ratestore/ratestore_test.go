@@ -0,0 +1,199 @@
+//go:build unit
+
+package ratestore
+
+import (
+	"cbr/internal/clients/cbr/mocks"
+	"cbr/internal/models"
+	"cbr/internal/utils"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu       sync.Mutex
+	rates    map[time.Time][]byte
+	syncedTo time.Time
+	err      error
+}
+
+func newMemStore() *memStore {
+	return &memStore{rates: make(map[time.Time][]byte)}
+}
+
+func (s *memStore) SaveRates(ctx context.Context, date time.Time, rates []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.rates[date] = rates
+	return nil
+}
+
+func (s *memStore) LastRates(ctx context.Context, date time.Time) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	var last time.Time
+	for d := range s.rates {
+		if !d.After(date) && d.After(last) {
+			last = d
+		}
+	}
+	if last.IsZero() {
+		return nil, ErrNoRates
+	}
+	return s.rates[last], nil
+}
+
+func (s *memStore) SyncedTo(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.syncedTo, s.err
+}
+
+func (s *memStore) SetSyncedTo(ctx context.Context, date time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.syncedTo = date
+	return nil
+}
+
+func newTestRates(t *testing.T, store Store, client *mocks.CbrClient, now time.Time) *Rates {
+	t.Helper()
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+	r := New(slog.New(slog.NewTextHandler(io.Discard, nil)), store, client, Config{
+		BackfillYears: 1,
+		PrefetchHour:  1,
+		RequestDelay:  time.Nanosecond,
+	}, location)
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 11, 12, 10, 0, 0, 0, time.UTC)
+
+	store := newMemStore()
+	store.syncedTo = day(2025, 11, 10)
+	r := newTestRates(t, store, nil, now)
+	// курс, установленный в пятницу, действует с субботы по понедельник
+	require.NoError(t, r.Save(ctx, models.CurrenciesResponce{Date: "07.11.2025"}))
+	require.NoError(t, r.Save(ctx, models.CurrenciesResponce{Date: "08.11.2025"}))
+
+	t.Run("Weekend resolves to last published rate", func(t *testing.T) {
+		got, err := r.Get(ctx, time.Date(2025, 11, 9, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, "08.11.2025", got.Date)
+	})
+
+	t.Run("Day after sync is not in store", func(t *testing.T) {
+		_, err := r.Get(ctx, time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, ErrNoRates)
+	})
+
+	t.Run("GetLast ignores sync", func(t *testing.T) {
+		got, err := r.GetLast(ctx, time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, "08.11.2025", got.Date)
+	})
+
+	t.Run("Date before history", func(t *testing.T) {
+		_, err := r.GetLast(ctx, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		require.ErrorIs(t, err, ErrNoRates)
+	})
+
+	t.Run("Empty store", func(t *testing.T) {
+		_, err := newTestRates(t, newMemStore(), nil, now).Get(ctx, now)
+		require.ErrorIs(t, err, ErrNoRates)
+	})
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 11, 12, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Continue after last synced day", func(t *testing.T) {
+		store := newMemStore()
+		store.syncedTo = day(2025, 11, 9)
+		client := mocks.NewCbrClient(t)
+		for _, date := range []string{"10/11/2025", "11/11/2025", "12/11/2025"} {
+			client.On("GetAllCurrencies", mock.Anything, date).
+				Return(models.CurrenciesResponce{Date: date[:2] + "." + date[3:5] + "." + date[6:]}, nil).Once()
+		}
+
+		r := newTestRates(t, store, client, now)
+		require.NoError(t, r.Sync(ctx))
+		require.Equal(t, day(2025, 11, 12), store.syncedTo)
+		require.Len(t, store.rates, 3)
+
+		got, err := r.Get(ctx, now)
+		require.NoError(t, err)
+		require.Equal(t, "12.11.2025", got.Date)
+	})
+
+	t.Run("Backfill on first start", func(t *testing.T) {
+		store := newMemStore()
+		client := mocks.NewCbrClient(t)
+		client.On("GetAllCurrencies", mock.Anything, mock.AnythingOfType("string")).
+			Return(models.CurrenciesResponce{Date: "12.11.2025"}, nil)
+
+		r := newTestRates(t, store, client, now)
+		require.NoError(t, r.Sync(ctx))
+		require.Equal(t, day(2025, 11, 12), store.syncedTo)
+		client.AssertNumberOfCalls(t, "GetAllCurrencies", 366)
+		client.AssertCalled(t, "GetAllCurrencies", mock.Anything, "12/11/2024")
+	})
+
+	t.Run("Client error keeps progress", func(t *testing.T) {
+		store := newMemStore()
+		store.syncedTo = day(2025, 11, 10)
+		client := mocks.NewCbrClient(t)
+		client.On("GetAllCurrencies", mock.Anything, "11/11/2025").
+			Return(models.CurrenciesResponce{Date: "11.11.2025"}, nil).Once()
+		client.On("GetAllCurrencies", mock.Anything, "12/11/2025").
+			Return(models.CurrenciesResponce{}, errors.New("cbr is down")).Once()
+
+		r := newTestRates(t, store, client, now)
+		err := r.Sync(ctx)
+		require.ErrorContains(t, err, "cbr is down")
+		require.Equal(t, day(2025, 11, 11), store.syncedTo)
+	})
+
+	t.Run("Already synced", func(t *testing.T) {
+		store := newMemStore()
+		store.syncedTo = day(2025, 11, 12)
+		r := newTestRates(t, store, mocks.NewCbrClient(t), now)
+		require.NoError(t, r.Sync(ctx))
+	})
+}
+
+func TestUntilNextPrefetch(t *testing.T) {
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+	r := newTestRates(t, newMemStore(), nil, time.Date(2025, 11, 12, 0, 30, 0, 0, location))
+	require.Equal(t, 30*time.Minute, r.untilNextPrefetch())
+
+	r.now = func() time.Time { return time.Date(2025, 11, 12, 1, 0, 0, 0, location) }
+	require.Equal(t, 24*time.Hour, r.untilNextPrefetch())
+}
@@ -0,0 +1,87 @@
+package redisClient
+
+import (
+	"cbr/internal/ratestore"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ratesPrefix   = "cbr:rates:"
+	ratesDatesKey = "cbr:rates:dates"
+	syncedToKey   = "cbr:rates:synced_to"
+)
+
+// RateStore хранит курсы ЦБ в Redis без срока жизни. Ключ cbr:rates:<дата> - ответ ЦБ,
+// сортированное множество cbr:rates:dates - даты курсов, чтобы найти последний курс до даты.
+type RateStore struct {
+	client *redis.Client
+}
+
+func NewRateStore(client *redis.Client) *RateStore {
+	return &RateStore{client: client}
+}
+
+func (s *RateStore) SaveRates(ctx context.Context, date time.Time, rates []byte) error {
+	const op = "redis.RateStore.SaveRates"
+	day := date.Format(time.DateOnly)
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, ratesPrefix+day, rates, 0)
+	pipe.ZAdd(ctx, ratesDatesKey, redis.Z{Score: float64(date.Unix()), Member: day})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *RateStore) LastRates(ctx context.Context, date time.Time) ([]byte, error) {
+	const op = "redis.RateStore.LastRates"
+	days, err := s.client.ZRevRangeByScore(ctx, ratesDatesKey, &redis.ZRangeBy{
+		Max:   strconv.FormatInt(date.Unix(), 10),
+		Min:   "-inf",
+		Count: 1,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ratestore.ErrNoRates)
+	}
+	rates, err := s.client.Get(ctx, ratesPrefix+days[0]).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("%s: %w", op, ratestore.ErrNoRates)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return rates, nil
+}
+
+func (s *RateStore) SyncedTo(ctx context.Context) (time.Time, error) {
+	const op = "redis.RateStore.SyncedTo"
+	day, err := s.client.Get(ctx, syncedToKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	date, err := time.Parse(time.DateOnly, day)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return date, nil
+}
+
+func (s *RateStore) SetSyncedTo(ctx context.Context, date time.Time) error {
+	const op = "redis.RateStore.SetSyncedTo"
+	if err := s.client.Set(ctx, syncedToKey, date.Format(time.DateOnly), 0).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
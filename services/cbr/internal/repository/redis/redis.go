@@ -0,0 +1,25 @@
+package redisClient
+
+import (
+	"cbr/internal/configs"
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func NewClient(ctx context.Context, cfg configs.RedisHTTPServer) (*redis.Client, error) {
+	db := redis.NewClient(&redis.Options{
+		Addr:         cfg.GetAddress(),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		MaxRetries:   cfg.MaxRetries,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
+	})
+
+	if err := db.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"cbr/internal/models"
+	"cbr/internal/utils"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+const rub = "RUB"
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrInvalidAmount   = errors.New("amount must be a finite non-negative number")
+	ErrEmptyCodes      = errors.New("currency codes are required")
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ConvertService
+type ConvertService interface {
+	GetRates(ctx context.Context, codes []string, date time.Time) (models.RatesResponce, error)
+	Convert(ctx context.Context, from, to string, amount float64, date time.Time) (models.ConvertResponce, error)
+}
+
+// GetRates возвращает курсы перечисленных валют к рублю на дату в порядке запроса.
+func (s *Service) GetRates(ctx context.Context, codes []string, date time.Time) (models.RatesResponce, error) {
+	if len(codes) == 0 {
+		return models.RatesResponce{}, ErrEmptyCodes
+	}
+
+	currencies, err := s.GetAllCurrencies(ctx, date)
+	if err != nil {
+		return models.RatesResponce{}, e.WrapIfErr("failed to get currencies", err)
+	}
+	rates, err := ratesByCode(currencies)
+	if err != nil {
+		return models.RatesResponce{}, e.WrapIfErr("failed to parse currencies", err)
+	}
+
+	res := models.RatesResponce{Date: currencies.Date, Rates: make([]models.Rate, 0, len(codes))}
+	for _, code := range codes {
+		rate, ok := rates[normalizeCode(code)]
+		if !ok {
+			return models.RatesResponce{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+		}
+		res.Rates = append(res.Rates, rate)
+	}
+	return res, nil
+}
+
+// Convert пересчитывает сумму из одной валюты в другую через рублевые курсы ЦБ.
+func (s *Service) Convert(ctx context.Context, from, to string, amount float64, date time.Time) (models.ConvertResponce, error) {
+	if amount < 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return models.ConvertResponce{}, ErrInvalidAmount
+	}
+
+	currencies, err := s.GetAllCurrencies(ctx, date)
+	if err != nil {
+		return models.ConvertResponce{}, e.WrapIfErr("failed to get currencies", err)
+	}
+	rates, err := ratesByCode(currencies)
+	if err != nil {
+		return models.ConvertResponce{}, e.WrapIfErr("failed to parse currencies", err)
+	}
+
+	fromRate, ok := rates[normalizeCode(from)]
+	if !ok {
+		return models.ConvertResponce{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, from)
+	}
+	toRate, ok := rates[normalizeCode(to)]
+	if !ok {
+		return models.ConvertResponce{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, to)
+	}
+
+	crossRate := fromRate.VunitRate / toRate.VunitRate
+	return models.ConvertResponce{
+		Date:   currencies.Date,
+		From:   fromRate.CharCode,
+		To:     toRate.CharCode,
+		Amount: amount,
+		Rate:   crossRate,
+		Result: amount * crossRate,
+	}, nil
+}
+
+// ratesByCode разбирает ответ ЦБ в курсы по коду валюты. Курс за единицу считается
+// из Value и Nominal, а не берется из VunitRate: ЦБ округляет его до 4 знаков.
+// Рубль добавляется с курсом 1, чтобы пересчет в рубли и из рублей шел по общим правилам.
+func ratesByCode(currencies models.CurrenciesResponce) (map[string]models.Rate, error) {
+	rates := make(map[string]models.Rate, len(currencies.Currencies)+1)
+	rates[rub] = models.Rate{CharCode: rub, Nominal: 1, Value: 1, VunitRate: 1}
+	for _, currency := range currencies.Currencies {
+		nominal, err := strconv.Atoi(strings.TrimSpace(currency.Nominal))
+		if err != nil || nominal <= 0 {
+			return nil, fmt.Errorf("invalid nominal %q of %s", currency.Nominal, currency.CharCode)
+		}
+		value, err := utils.ParseRuFloat(currency.Value)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid value %q of %s", currency.Value, currency.CharCode)
+		}
+		code := normalizeCode(currency.CharCode)
+		rates[code] = models.Rate{
+			CharCode:  code,
+			Nominal:   nominal,
+			Value:     value,
+			VunitRate: value / float64(nominal),
+		}
+	}
+	return rates, nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
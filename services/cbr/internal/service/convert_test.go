@@ -0,0 +1,167 @@
+//go:build unit
+
+package service
+
+import (
+	"cbr/internal/clients/cbr"
+	"cbr/internal/clients/cbr/mocks"
+	"cbr/internal/utils"
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// rawRate - курс за единицу валюты, посчитанный прямо из строк ответа ЦБ, независимо от сервиса.
+type rawRate struct {
+	code      string
+	perUnit   float64
+	vunitRate float64
+}
+
+var rawValuteRe = regexp.MustCompile(`<CharCode>(\w+)</CharCode><Nominal>(\d+)</Nominal><Name>[^<]*</Name><Value>([\d,]+)</Value><VunitRate>([\d,]+)</VunitRate>`)
+
+// rawRates достает курсы из XML ЦБ регулярным выражением, чтобы не проверять парсер им же самим.
+func rawRates(t *testing.T, xmlDaily []byte) []rawRate {
+	t.Helper()
+	parse := func(s string) float64 {
+		v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+		require.NoError(t, err, s)
+		return v
+	}
+	res := []rawRate{{code: rub, perUnit: 1, vunitRate: 1}}
+	for _, m := range rawValuteRe.FindAllSubmatch(xmlDaily, -1) {
+		nominal, err := strconv.Atoi(string(m[2]))
+		require.NoError(t, err)
+		res = append(res, rawRate{
+			code:      string(m[1]),
+			perUnit:   parse(string(m[3])) / float64(nominal),
+			vunitRate: parse(string(m[4])),
+		})
+	}
+	require.Greater(t, len(res), 1, "no currencies in XML_daily.xml")
+	return res
+}
+
+// newConvertTestService отдает сервису сырой ответ XML_daily.asp в windows-1251,
+// так что курсы проходят через настоящий клиент и парсер ЦБ.
+func newConvertTestService(t *testing.T) (*Service, []byte) {
+	t.Helper()
+	xmlDaily, err := os.ReadFile(filepath.Join("testdata", "XML_daily.xml"))
+	require.NoError(t, err)
+
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	transportMock := mocks.NewTransportClient(t)
+	transportMock.On("DoRequest", mock.Anything, "scripts/XML_daily.asp", mock.Anything).Return(xmlDaily, nil).Maybe()
+	return NewService(logger, cbr.NewClient(logger, transportMock), nil, location), xmlDaily
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestConvertProperties(t *testing.T) {
+	ctx := context.Background()
+	srv, xmlDaily := newConvertTestService(t)
+	rates := rawRates(t, xmlDaily)
+	date := time.Now()
+
+	pick := func(i uint16) rawRate { return rates[int(i)%len(rates)] }
+	amountOf := func(cents uint32) float64 { return float64(cents) / 100 }
+
+	t.Run("to rubles equals raw value divided by nominal", func(t *testing.T) {
+		property := func(i uint16, cents uint32) bool {
+			from, amount := pick(i), amountOf(cents)
+			got, err := srv.Convert(ctx, from.code, rub, amount, date)
+			return err == nil && almostEqual(got.Result, amount*from.perUnit)
+		}
+		require.NoError(t, quick.Check(property, nil))
+	})
+
+	t.Run("rate per unit matches rounded VunitRate", func(t *testing.T) {
+		property := func(i uint16) bool {
+			from := pick(i)
+			got, err := srv.Convert(ctx, from.code, rub, 1, date)
+			// ЦБ округляет VunitRate до 4 знаков после запятой
+			return err == nil && math.Abs(got.Rate-from.vunitRate) <= 5e-5*math.Max(1, from.vunitRate)
+		}
+		require.NoError(t, quick.Check(property, nil))
+	})
+
+	t.Run("cross rate goes through rubles", func(t *testing.T) {
+		property := func(i, j uint16, cents uint32) bool {
+			from, to, amount := pick(i), pick(j), amountOf(cents)
+			got, err := srv.Convert(ctx, from.code, to.code, amount, date)
+			return err == nil && almostEqual(got.Result, amount*from.perUnit/to.perUnit)
+		}
+		require.NoError(t, quick.Check(property, nil))
+	})
+
+	t.Run("round trip returns amount", func(t *testing.T) {
+		property := func(i, j uint16, cents uint32) bool {
+			from, to, amount := pick(i), pick(j), amountOf(cents)
+			there, err := srv.Convert(ctx, from.code, to.code, amount, date)
+			if err != nil {
+				return false
+			}
+			back, err := srv.Convert(ctx, to.code, from.code, there.Result, date)
+			return err == nil && almostEqual(back.Result, amount)
+		}
+		require.NoError(t, quick.Check(property, nil))
+	})
+}
+
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := newConvertTestService(t)
+
+	t.Run("nominal scaling", func(t *testing.T) {
+		// 100 алжирских динаров = 62,1249 руб.
+		got, err := srv.Convert(ctx, "dzd", "RUB", 100, time.Now())
+		require.NoError(t, err)
+		require.Equal(t, "DZD", got.From)
+		require.InDelta(t, 62.1249, got.Result, 1e-9)
+	})
+	t.Run("unknown currency", func(t *testing.T) {
+		_, err := srv.Convert(ctx, "XXX", "RUB", 1, time.Now())
+		require.ErrorIs(t, err, ErrUnknownCurrency)
+	})
+	t.Run("invalid amount", func(t *testing.T) {
+		_, err := srv.Convert(ctx, "USD", "RUB", -1, time.Now())
+		require.ErrorIs(t, err, ErrInvalidAmount)
+		_, err = srv.Convert(ctx, "USD", "RUB", math.NaN(), time.Now())
+		require.ErrorIs(t, err, ErrInvalidAmount)
+	})
+}
+
+func TestGetRates(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := newConvertTestService(t)
+
+	got, err := srv.GetRates(ctx, []string{"usd", "AMD", "RUB"}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "06.11.2025", got.Date)
+	require.Len(t, got.Rates, 3)
+	require.Equal(t, "USD", got.Rates[0].CharCode)
+	require.Equal(t, 100, got.Rates[1].Nominal)
+	require.InDelta(t, 0.212219, got.Rates[1].VunitRate, 1e-12)
+	require.Equal(t, 1.0, got.Rates[2].VunitRate)
+
+	_, err = srv.GetRates(ctx, nil, time.Now())
+	require.ErrorIs(t, err, ErrEmptyCodes)
+	_, err = srv.GetRates(ctx, []string{"USD", "XXX"}, time.Now())
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
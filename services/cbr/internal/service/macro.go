@@ -0,0 +1,77 @@
+package service
+
+import (
+	"cbr/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gladinov/e"
+)
+
+var ErrInvalidPeriod = errors.New("from can't be after to")
+
+// defaultMacroPeriodYears - за сколько лет отдаются ставки и инфляция, если from не задан.
+const defaultMacroPeriodYears = -1
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=MacroService
+type MacroService interface {
+	GetKeyRate(ctx context.Context, from, to time.Time) (models.KeyRateResponce, error)
+	GetRuonia(ctx context.Context, from, to time.Time) (models.RuoniaResponce, error)
+	GetInflation(ctx context.Context, from, to time.Time) (models.InflationResponce, error)
+}
+
+func (s *Service) GetKeyRate(ctx context.Context, from, to time.Time) (models.KeyRateResponce, error) {
+	from, to, err := s.normalizePeriod(from, to)
+	if err != nil {
+		return models.KeyRateResponce{}, err
+	}
+
+	keyRate, err := s.Client.GetKeyRate(ctx, from, to)
+	if err != nil {
+		return models.KeyRateResponce{}, e.WrapIfErr("failed to get key rate from client", err)
+	}
+	return keyRate, nil
+}
+
+func (s *Service) GetRuonia(ctx context.Context, from, to time.Time) (models.RuoniaResponce, error) {
+	from, to, err := s.normalizePeriod(from, to)
+	if err != nil {
+		return models.RuoniaResponce{}, err
+	}
+
+	ruonia, err := s.Client.GetRuonia(ctx, from, to)
+	if err != nil {
+		return models.RuoniaResponce{}, e.WrapIfErr("failed to get ruonia from client", err)
+	}
+	return ruonia, nil
+}
+
+func (s *Service) GetInflation(ctx context.Context, from, to time.Time) (models.InflationResponce, error) {
+	from, to, err := s.normalizePeriod(from, to)
+	if err != nil {
+		return models.InflationResponce{}, err
+	}
+
+	inflation, err := s.Client.GetInflation(ctx, from, to)
+	if err != nil {
+		return models.InflationResponce{}, e.WrapIfErr("failed to get inflation from client", err)
+	}
+	return inflation, nil
+}
+
+// normalizePeriod подставляет период по умолчанию: год до текущей даты.
+// Будущие даты обрезаются текущей датой.
+func (s *Service) normalizePeriod(from, to time.Time) (time.Time, time.Time, error) {
+	now := time.Now().In(s.TimeLocation)
+	if to.IsZero() || to.After(now) {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.AddDate(defaultMacroPeriodYears, 0, 0)
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return from.In(s.TimeLocation), to.In(s.TimeLocation), nil
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"cbr/internal/clients/cbr"
 	"cbr/internal/models"
+	"cbr/internal/ratestore"
 	"cbr/internal/utils"
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -16,32 +18,77 @@ type CurrencyService interface {
 	GetAllCurrencies(ctx context.Context, date time.Time) (models.CurrenciesResponce, error)
 }
 
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=RateStore
+type RateStore interface {
+	Get(ctx context.Context, date time.Time) (models.CurrenciesResponce, error)
+	GetLast(ctx context.Context, date time.Time) (models.CurrenciesResponce, error)
+	Save(ctx context.Context, rates models.CurrenciesResponce) error
+}
+
 type Service struct {
 	Logger       *slog.Logger
 	Client       cbr.CbrClient
+	Rates        RateStore
 	TimeLocation *time.Location
 }
 
-func NewService(logger *slog.Logger, client cbr.CbrClient, timeLocation *time.Location) *Service {
+func NewService(logger *slog.Logger, client cbr.CbrClient, rates RateStore, timeLocation *time.Location) *Service {
 	return &Service{
 		Logger:       logger,
 		Client:       client,
+		Rates:        rates,
 		TimeLocation: timeLocation,
 	}
 }
 
+// GetAllCurrencies отдает курсы из своего хранилища, а незагруженные дни запрашивает у cbr.ru.
+// Если cbr.ru недоступен, отдается последний сохраненный курс.
 func (s *Service) GetAllCurrencies(ctx context.Context, date time.Time) (models.CurrenciesResponce, error) {
 	const op = "service.GetAllCurrencies"
 	location := s.TimeLocation
 	now := time.Now().In(location)
 	startDate := utils.GetStartSingleExchangeRateRubble(location)
 
-	formatDate := utils.NormalizeDate(date, now, startDate)
+	date = utils.ClampDate(date.In(location), now, startDate)
+
+	if s.Rates != nil {
+		rates, err := s.Rates.Get(ctx, date)
+		if err == nil {
+			return rates, nil
+		}
+		if !errors.Is(err, ratestore.ErrNoRates) {
+			s.Logger.WarnContext(ctx, "could not get rates from store", slog.String("op", op), slog.Any("error", err))
+		}
+	}
 
-	currResp, err := s.Client.GetAllCurrencies(ctx, formatDate)
+	currResp, err := s.Client.GetAllCurrencies(ctx, utils.NormalizeDate(date, now, startDate))
 	if err != nil {
+		if rates, ok := s.lastStoredRates(ctx, date); ok {
+			s.Logger.WarnContext(ctx, "cbr is unavailable, use last stored rates",
+				slog.String("op", op),
+				slog.String("date", rates.Date),
+				slog.Any("error", err))
+			return rates, nil
+		}
 		return models.CurrenciesResponce{}, e.WrapIfErr("failed to get all currencies from client", err)
 	}
 
+	if s.Rates != nil {
+		if err := s.Rates.Save(ctx, currResp); err != nil {
+			s.Logger.WarnContext(ctx, "could not save rates to store", slog.String("op", op), slog.Any("error", err))
+		}
+	}
+
 	return currResp, nil
 }
+
+func (s *Service) lastStoredRates(ctx context.Context, date time.Time) (models.CurrenciesResponce, bool) {
+	if s.Rates == nil {
+		return models.CurrenciesResponce{}, false
+	}
+	rates, err := s.Rates.GetLast(ctx, date)
+	if err != nil {
+		return models.CurrenciesResponce{}, false
+	}
+	return rates, true
+}
@@ -5,6 +5,8 @@ package service
 import (
 	"cbr/internal/clients/cbr/mocks"
 	"cbr/internal/models"
+	"cbr/internal/ratestore"
+	servicemocks "cbr/internal/service/mocks"
 	"cbr/internal/utils"
 	"context"
 	"errors"
@@ -34,7 +36,7 @@ func TestGetAllCurrencies(t *testing.T) {
 		cbrClientMock := mocks.NewCbrClient(t)
 		cbrClientMock.On("GetAllCurrencies", ctx, mock.AnythingOfType("string")).
 			Return(wantMock, nil).Once()
-		srv := NewService(logg, cbrClientMock, location)
+		srv := NewService(logg, cbrClientMock, nil, location)
 		currResp, err := srv.GetAllCurrencies(ctx, now)
 		require.NoError(t, err)
 		require.Equal(t, wantMock, currResp)
@@ -44,10 +46,118 @@ func TestGetAllCurrencies(t *testing.T) {
 		cbrClientMock := mocks.NewCbrClient(t)
 		cbrClientMock.On("GetAllCurrencies", ctx, mock.AnythingOfType("string")).
 			Return(models.CurrenciesResponce{}, errors.New("could not do request")).Once()
-		srv := NewService(logg, cbrClientMock, location)
+		srv := NewService(logg, cbrClientMock, nil, location)
 		currResp, err := srv.GetAllCurrencies(ctx, now)
 		require.Error(t, err)
 		require.ErrorContains(t, err, errContains)
 		require.Empty(t, currResp)
 	})
 }
+
+func TestNormalizePeriod(t *testing.T) {
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+	srv := NewService(slog.New(slog.NewTextHandler(io.Discard, nil)), nil, nil, location)
+	now := time.Now()
+
+	from, to, err := srv.normalizePeriod(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.WithinDuration(t, now, to, time.Minute)
+	require.WithinDuration(t, now.AddDate(-1, 0, 0), from, time.Minute)
+
+	_, to, err = srv.normalizePeriod(now.AddDate(0, -1, 0), now.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.WithinDuration(t, now, to, time.Minute, "future dates are cut by now")
+
+	_, _, err = srv.normalizePeriod(now, now.AddDate(0, -1, 0))
+	require.ErrorIs(t, err, ErrInvalidPeriod)
+}
+
+func TestGetKeyRate(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, location)
+	to := time.Date(2025, 8, 1, 0, 0, 0, 0, location)
+
+	t.Run("success", func(t *testing.T) {
+		wantMock := models.KeyRateResponce{Rates: []models.KeyRate{{Date: from, Rate: 20}}}
+		cbrClientMock := mocks.NewCbrClient(t)
+		cbrClientMock.On("GetKeyRate", ctx, from, to).Return(wantMock, nil).Once()
+		srv := NewService(logg, cbrClientMock, nil, location)
+		keyRate, err := srv.GetKeyRate(ctx, from, to)
+		require.NoError(t, err)
+		require.Equal(t, wantMock, keyRate)
+	})
+	t.Run("GetKeyRate error", func(t *testing.T) {
+		cbrClientMock := mocks.NewCbrClient(t)
+		cbrClientMock.On("GetKeyRate", ctx, from, to).
+			Return(models.KeyRateResponce{}, errors.New("could not do request")).Once()
+		srv := NewService(logg, cbrClientMock, nil, location)
+		_, err := srv.GetKeyRate(ctx, from, to)
+		require.ErrorContains(t, err, "failed to get key rate from client")
+	})
+}
+
+func TestGetAllCurrenciesWithRateStore(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	date := time.Date(2025, 11, 9, 12, 0, 0, 0, time.UTC)
+	location, err := utils.GetMoscowLocation()
+	require.NoError(t, err)
+
+	t.Run("from store", func(t *testing.T) {
+		want := models.CurrenciesResponce{Date: "08.11.2025"}
+		ratesMock := servicemocks.NewRateStore(t)
+		ratesMock.On("Get", ctx, mock.AnythingOfType("time.Time")).Return(want, nil).Once()
+		srv := NewService(logg, mocks.NewCbrClient(t), ratesMock, location)
+
+		got, err := srv.GetAllCurrencies(ctx, date)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+	t.Run("not in store, save from cbr", func(t *testing.T) {
+		want := models.CurrenciesResponce{Date: "08.11.2025"}
+		ratesMock := servicemocks.NewRateStore(t)
+		ratesMock.On("Get", ctx, mock.AnythingOfType("time.Time")).
+			Return(models.CurrenciesResponce{}, ratestore.ErrNoRates).Once()
+		ratesMock.On("Save", ctx, want).Return(nil).Once()
+		cbrClientMock := mocks.NewCbrClient(t)
+		cbrClientMock.On("GetAllCurrencies", ctx, "09/11/2025").Return(want, nil).Once()
+		srv := NewService(logg, cbrClientMock, ratesMock, location)
+
+		got, err := srv.GetAllCurrencies(ctx, date)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+	t.Run("cbr is down, use last stored rates", func(t *testing.T) {
+		want := models.CurrenciesResponce{Date: "07.11.2025"}
+		ratesMock := servicemocks.NewRateStore(t)
+		ratesMock.On("Get", ctx, mock.AnythingOfType("time.Time")).
+			Return(models.CurrenciesResponce{}, ratestore.ErrNoRates).Once()
+		ratesMock.On("GetLast", ctx, mock.AnythingOfType("time.Time")).Return(want, nil).Once()
+		cbrClientMock := mocks.NewCbrClient(t)
+		cbrClientMock.On("GetAllCurrencies", ctx, mock.AnythingOfType("string")).
+			Return(models.CurrenciesResponce{}, errors.New("could not do request")).Once()
+		srv := NewService(logg, cbrClientMock, ratesMock, location)
+
+		got, err := srv.GetAllCurrencies(ctx, date)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+	t.Run("cbr is down and store is empty", func(t *testing.T) {
+		ratesMock := servicemocks.NewRateStore(t)
+		ratesMock.On("Get", ctx, mock.AnythingOfType("time.Time")).
+			Return(models.CurrenciesResponce{}, ratestore.ErrNoRates).Once()
+		ratesMock.On("GetLast", ctx, mock.AnythingOfType("time.Time")).
+			Return(models.CurrenciesResponce{}, ratestore.ErrNoRates).Once()
+		cbrClientMock := mocks.NewCbrClient(t)
+		cbrClientMock.On("GetAllCurrencies", ctx, mock.AnythingOfType("string")).
+			Return(models.CurrenciesResponce{}, errors.New("could not do request")).Once()
+		srv := NewService(logg, cbrClientMock, ratesMock, location)
+
+		_, err := srv.GetAllCurrencies(ctx, date)
+		require.ErrorContains(t, err, "failed to get all currencies from client")
+	})
+}
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseRuFloat разбирает число в русской записи: "21,00", "1 234,5".
+func ParseRuFloat(value string) (float64, error) {
+	value = strings.NewReplacer(",", ".", " ", "", "\u00a0", "").Replace(value)
+	if value == "" || value == "—" || value == "-" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRuFloat(t *testing.T) {
+	cases := map[string]float64{
+		"21,00":        21,
+		"1 234,5":      1234.5,
+		"1\u00a0234,5": 1234.5,
+		"":             0,
+		"—":            0,
+	}
+	for in, want := range cases {
+		got, err := ParseRuFloat(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+	_, err := ParseRuFloat("abc")
+	require.Error(t, err)
+}
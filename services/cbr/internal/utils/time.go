@@ -43,12 +43,24 @@ func MustGetMoscowLocation() *time.Location {
 }
 
 func NormalizeDate(date, now, startDate time.Time) string {
+	return ClampDate(date, now, startDate).Format(layout)
+}
+
+// ClampDate ограничивает дату курса периодом от начала единого курса рубля до текущего момента.
+func ClampDate(date, now, startDate time.Time) time.Time {
 	switch {
 	case date.After(now):
-		return now.Format(layout)
+		return now
 	case date.Before(startDate):
-		return startDate.Format(layout)
+		return startDate
 	default:
-		return date.Format(layout)
+		return date
 	}
 }
+
+// Day возвращает календарный день даты в location как полночь по UTC.
+// Так курсы на один и тот же день ЦБ совпадают независимо от часового пояса запроса.
+func Day(date time.Time, location *time.Location) time.Time {
+	y, m, d := date.In(location).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
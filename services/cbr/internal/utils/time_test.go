@@ -49,3 +49,12 @@ func TestNormalizeDate(t *testing.T) {
 		})
 	}
 }
+
+func TestDay(t *testing.T) {
+	location, err := GetMoscowLocation()
+	require.NoError(t, err)
+
+	// 22:30 UTC - уже следующие сутки по Москве
+	got := Day(time.Date(2025, 11, 7, 22, 30, 0, 0, time.UTC), location)
+	require.Equal(t, time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC), got)
+}
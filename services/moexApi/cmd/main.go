@@ -51,6 +51,10 @@ func main() {
 	router.HTTPErrorHandler = handlers.HTTPErrorHandler(logg)
 
 	router.POST("/moex/specifications", handler.GetSpecifications)
+	router.POST("/moex/history", handler.GetHistory)
+	router.POST("/moex/bonds", handler.GetBonds)
+	router.POST("/moex/securities", handler.GetSecurities)
+	router.POST("/moex/issuers", handler.GetIssuers)
 
 	address := conf.Clients.MoexApiAppClient.GetMoexApiAppClientAddress()
 
@@ -3,11 +3,14 @@ package moex
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"moex/internal/models"
 	"moex/internal/utils/logging"
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gladinov/e"
@@ -15,11 +18,51 @@ import (
 
 const (
 	layout = "2006-01-02"
+	// historyPageSize - максимальный размер страницы истории в ISS.
+	historyPageSize = 100
+
+	boardMarketDataColumns = "SECID,LAST,YIELD,DURATION"
+	marketDataColumns      = "SECID,LAST"
+	searchColumns          = "secid,isin,emitent_id,emitent_title,emitent_inn"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=MoexClient
 type MoexClient interface {
-	GetSpecifications(ctx context.Context, ticker string, date time.Time) (_ models.SpecificationsResponce, err error)
+	GetHistory(ctx context.Context, ticker string, from, till time.Time, start int) (_ models.SpecificationsResponce, err error)
+	GetBoardSecurities(ctx context.Context, board string) (_ models.BoardSecuritiesResponce, err error)
+	GetMarketSecurities(ctx context.Context, market models.Market, secIDs []string) (_ models.BoardSecuritiesResponce, err error)
+	GetSecurityDescription(ctx context.Context, secID string) (_ models.DescriptionResponce, err error)
+	SearchSecurities(ctx context.Context, query string) (_ models.SecuritiesSearchResponce, err error)
+}
+
+// marketBoard - рынок ISS, его основной режим торгов и колонки справочника бумаг и истории.
+type marketBoard struct {
+	engine            string
+	market            string
+	board             string
+	securitiesColumns string
+	historyColumns    string
+}
+
+var marketBoards = map[models.Market]marketBoard{
+	models.MarketBonds: {
+		engine: "stock", market: "bonds", board: "TQOB",
+		securitiesColumns: "SECID,BOARDID,SHORTNAME,ISIN,MATDATE,OFFERDATE,COUPONPERCENT,COUPONVALUE,COUPONPERIOD,NEXTCOUPON," +
+			"FACEVALUE,FACEUNIT,CURRENCYID,LISTLEVEL,BONDTYPE,BONDSUBTYPE,PREVPRICE,YIELDATPREVWAPRICE",
+		historyColumns: "TRADEDATE,MATDATE,OFFERDATE,BUYBACKDATE,YIELDCLOSE,YIELDTOOFFER,FACEVALUE,FACEUNIT,DURATION,SHORTNAME,CLOSE,VOLUME",
+	},
+	models.MarketShares: {
+		engine: "stock", market: "shares", board: "TQBR",
+		securitiesColumns: "SECID,BOARDID,SHORTNAME,SECNAME,ISIN,LOTSIZE,CURRENCYID,SECTORID,LISTLEVEL,PREVPRICE",
+	},
+	models.MarketETF: {
+		engine: "stock", market: "shares", board: "TQTF",
+		securitiesColumns: "SECID,BOARDID,SHORTNAME,SECNAME,ISIN,LOTSIZE,CURRENCYID,LISTLEVEL,PREVPRICE",
+	},
+	models.MarketCurrency: {
+		engine: "currency", market: "selt", board: "CETS",
+		securitiesColumns: "SECID,BOARDID,SHORTNAME,SECNAME,LOTSIZE,FACEUNIT,CURRENCYID,PREVPRICE",
+	},
 }
 
 type Client struct {
@@ -34,20 +77,24 @@ func NewMoexClient(logger *slog.Logger, transport TransportClient) *Client {
 	}
 }
 
-func (c *Client) GetSpecifications(ctx context.Context, ticker string, date time.Time) (_ models.SpecificationsResponce, err error) {
-	const op = "cbr.GetSpecifications"
+// GetHistory запрашивает итоги торгов по бумаге за период [from, till] одним запросом.
+// ISS отдает историю страницами по historyPageSize строк, start - смещение страницы.
+func (c *Client) GetHistory(ctx context.Context, ticker string, from, till time.Time, start int) (_ models.SpecificationsResponce, err error) {
+	const op = "moex.GetHistory"
 	logg := c.logger.With()
 	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
 
-	formatDate := date.Format(layout)
-	path := path.Join("iss", "history", "engines", "stock", "markets", "bonds", "sessions", "3", "securities", ticker+".json")
+	mb := marketBoards[models.MarketBonds]
+	// сессия 3 - итоги всего торгового дня
+	path := path.Join("iss", "history", "engines", mb.engine, "markets", mb.market, "sessions", "3", "securities", ticker+".json")
 	params := url.Values{}
-	params.Add("limit", "1")
 	params.Add("iss.meta", "off")
-	params.Add("history.columns", "TRADEDATE,MATDATE,OFFERDATE,BUYBACKDATE,YIELDCLOSE,YIELDTOOFFER,FACEVALUE,FACEUNIT,DURATION, SHORTNAME")
-	params.Add("limit", "1")
-	params.Add("from", formatDate)
-	params.Add("to", formatDate)
+	params.Add("iss.only", "history,history.cursor")
+	params.Add("history.columns", mb.historyColumns)
+	params.Add("limit", strconv.Itoa(historyPageSize))
+	params.Add("start", strconv.Itoa(start))
+	params.Add("from", from.Format(layout))
+	params.Add("till", till.Format(layout))
 
 	body, err := c.transport.DoRequest(ctx, path, params)
 	if err != nil {
@@ -60,3 +107,111 @@ func (c *Client) GetSpecifications(ctx context.Context, ticker string, date time
 	}
 	return data, nil
 }
+
+// GetBoardSecurities запрашивает все облигации режима торгов board вместе с рыночными данными.
+// ISS отдает список режима целиком, без страниц.
+func (c *Client) GetBoardSecurities(ctx context.Context, board string) (_ models.BoardSecuritiesResponce, err error) {
+	const op = "moex.GetBoardSecurities"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	mb := marketBoards[models.MarketBonds]
+	path := path.Join("iss", "engines", mb.engine, "markets", mb.market, "boards", board, "securities.json")
+	params := url.Values{}
+	params.Add("iss.meta", "off")
+	params.Add("iss.only", "securities,marketdata")
+	params.Add("securities.columns", mb.securitiesColumns)
+	params.Add("marketdata.columns", boardMarketDataColumns)
+
+	body, err := c.transport.DoRequest(ctx, path, params)
+	if err != nil {
+		return models.BoardSecuritiesResponce{}, e.WrapIfErr("failed DoRequest", err)
+	}
+	var data models.BoardSecuritiesResponce
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return models.BoardSecuritiesResponce{}, e.WrapIfErr("failed unmarshall json", err)
+	}
+	return data, nil
+}
+
+// GetMarketSecurities запрашивает справочник и рыночные данные бумаг secIDs в основном режиме рынка market.
+func (c *Client) GetMarketSecurities(ctx context.Context, market models.Market, secIDs []string) (_ models.BoardSecuritiesResponce, err error) {
+	const op = "moex.GetMarketSecurities"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	mb, ok := marketBoards[market]
+	if !ok {
+		return models.BoardSecuritiesResponce{}, fmt.Errorf("unknown market %q", market)
+	}
+
+	path := path.Join("iss", "engines", mb.engine, "markets", mb.market, "boards", mb.board, "securities.json")
+	params := url.Values{}
+	params.Add("iss.meta", "off")
+	params.Add("iss.only", "securities,marketdata")
+	params.Add("securities", strings.Join(secIDs, ","))
+	params.Add("securities.columns", mb.securitiesColumns)
+	params.Add("marketdata.columns", marketDataColumns)
+
+	body, err := c.transport.DoRequest(ctx, path, params)
+	if err != nil {
+		return models.BoardSecuritiesResponce{}, e.WrapIfErr("failed DoRequest", err)
+	}
+	var data models.BoardSecuritiesResponce
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return models.BoardSecuritiesResponce{}, e.WrapIfErr("failed unmarshall json", err)
+	}
+	return data, nil
+}
+
+// GetSecurityDescription запрашивает описание бумаги: код эмитента, вид бумаги, даты и параметры выпуска.
+func (c *Client) GetSecurityDescription(ctx context.Context, secID string) (_ models.DescriptionResponce, err error) {
+	const op = "moex.GetSecurityDescription"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("iss", "securities", secID+".json")
+	params := url.Values{}
+	params.Add("iss.meta", "off")
+	params.Add("iss.only", "description")
+	params.Add("description.columns", "name,value")
+
+	body, err := c.transport.DoRequest(ctx, path, params)
+	if err != nil {
+		return models.DescriptionResponce{}, e.WrapIfErr("failed DoRequest", err)
+	}
+	var data models.DescriptionResponce
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return models.DescriptionResponce{}, e.WrapIfErr("failed unmarshall json", err)
+	}
+	return data, nil
+}
+
+// SearchSecurities ищет бумаги по тикеру, ISIN или названию. В отличие от описания бумаги
+// поиск отдает название и ИНН эмитента.
+func (c *Client) SearchSecurities(ctx context.Context, query string) (_ models.SecuritiesSearchResponce, err error) {
+	const op = "moex.SearchSecurities"
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	path := path.Join("iss", "securities.json")
+	params := url.Values{}
+	params.Add("iss.meta", "off")
+	params.Add("iss.only", "securities")
+	params.Add("q", query)
+	params.Add("securities.columns", searchColumns)
+
+	body, err := c.transport.DoRequest(ctx, path, params)
+	if err != nil {
+		return models.SecuritiesSearchResponce{}, e.WrapIfErr("failed DoRequest", err)
+	}
+	var data models.SecuritiesSearchResponce
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return models.SecuritiesSearchResponce{}, e.WrapIfErr("failed unmarshall json", err)
+	}
+	return data, nil
+}
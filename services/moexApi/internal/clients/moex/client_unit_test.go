@@ -19,10 +19,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestGetSpecification(t *testing.T) {
+func TestGetHistory(t *testing.T) {
 	ctx := context.Background()
 	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
-	date := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC)
+	till := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
 	ticker := "OFZ26238"
 
 	t.Run("Success", func(t *testing.T) {
@@ -37,12 +38,15 @@ func TestGetSpecification(t *testing.T) {
 					return strings.HasSuffix(p, ticker+".json")
 				}),
 				mock.MatchedBy(func(v url.Values) bool {
-					return v.Get("from") == date.Format(layout) &&
-						v.Get("to") == date.Format(layout)
+					return v.Get("from") == "2025-11-02" &&
+						v.Get("till") == "2025-11-16" &&
+						v.Get("start") == "200" &&
+						v.Get("limit") == "100" &&
+						strings.HasSuffix(v.Get("history.columns"), "CLOSE,VOLUME")
 				}),
 			).Return(body, nil).Once()
 		moexClient := NewMoexClient(logg, transportMock)
-		got, err := moexClient.GetSpecifications(ctx, ticker, date)
+		got, err := moexClient.GetHistory(ctx, ticker, from, till, 200)
 		require.NoError(t, err)
 		require.Equal(t, want, got)
 
@@ -56,7 +60,7 @@ func TestGetSpecification(t *testing.T) {
 		transportMock.On("DoRequest", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("url.Values")).
 			Return(nil, errors.New("could not do request")).Once()
 		moexClient := NewMoexClient(logg, transportMock)
-		_, err := moexClient.GetSpecifications(ctx, "ticker", date)
+		_, err := moexClient.GetHistory(ctx, "ticker", from, till, 0)
 		require.Error(t, err)
 		require.ErrorContains(t, err, errContains)
 
@@ -69,7 +73,7 @@ func TestGetSpecification(t *testing.T) {
 			Return([]byte(`invalid json`), nil).Once()
 
 		client := NewMoexClient(logg, transportMock)
-		_, err := client.GetSpecifications(ctx, ticker, date)
+		_, err := client.GetHistory(ctx, ticker, from, till, 0)
 		require.Error(t, err)
 		require.ErrorContains(t, err, "failed unmarshall json")
 
@@ -87,10 +91,173 @@ func TestGetSpecification(t *testing.T) {
 			Return(emptyJSON, nil).Once()
 
 		client := NewMoexClient(logg, transportMock)
-		got, err := client.GetSpecifications(ctx, ticker, date)
+		got, err := client.GetHistory(ctx, ticker, from, till, 0)
 		require.NoError(t, err)
 		require.Equal(t, want, got)
 
 		transportMock.AssertExpectations(t)
 	})
+	t.Run("Cursor and daily columns", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+
+		body := []byte(`{
+			"history": {"columns": [], "data": [["2025-11-14", "2035-01-15", null, null, 14.1, null, 1000, "SUR", 2400, "ОФЗ 26238", 61.2, 15000]]},
+			"history.cursor": {"columns": ["INDEX", "TOTAL", "PAGESIZE"], "data": [[0, 250, 100]]}
+		}`)
+
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return(body, nil).Once()
+
+		client := NewMoexClient(logg, transportMock)
+		got, err := client.GetHistory(ctx, ticker, from, till, 0)
+		require.NoError(t, err)
+		require.Len(t, got.History.Data, 1)
+		require.Equal(t, factories.NF(61.2), got.History.Data[0].Close)
+		require.Equal(t, factories.NF(15000), got.History.Data[0].Volume)
+
+		next, ok := got.NextStart()
+		require.True(t, ok)
+		require.Equal(t, 100, next)
+	})
+}
+
+func TestGetBoardSecurities(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+
+		transportMock.
+			On("DoRequest", ctx,
+				"iss/engines/stock/markets/bonds/boards/TQOB/securities.json",
+				mock.MatchedBy(func(v url.Values) bool {
+					return v.Get("iss.only") == "securities,marketdata" &&
+						v.Get("securities.columns") == marketBoards[models.MarketBonds].securitiesColumns &&
+						v.Get("marketdata.columns") == boardMarketDataColumns
+				}),
+			).Return(factories.NewBoardSecuritiesJSON(), nil).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		got, err := moexClient.GetBoardSecurities(ctx, "TQOB")
+		require.NoError(t, err)
+		require.Len(t, got.Securities.Rows(), 2)
+		require.Len(t, got.MarketData.Rows(), 2)
+	})
+	t.Run("DoRequest err", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return(nil, errors.New("could not do request")).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		_, err := moexClient.GetBoardSecurities(ctx, "TQOB")
+		require.ErrorContains(t, err, "failed DoRequest")
+	})
+	t.Run("Invalid JSON", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return([]byte(`invalid json`), nil).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		_, err := moexClient.GetBoardSecurities(ctx, "TQOB")
+		require.ErrorContains(t, err, "failed unmarshall json")
+	})
+}
+
+func TestGetMarketSecurities(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cases := []struct {
+		market models.Market
+		path   string
+	}{
+		{market: models.MarketShares, path: "iss/engines/stock/markets/shares/boards/TQBR/securities.json"},
+		{market: models.MarketETF, path: "iss/engines/stock/markets/shares/boards/TQTF/securities.json"},
+		{market: models.MarketCurrency, path: "iss/engines/currency/markets/selt/boards/CETS/securities.json"},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.market), func(t *testing.T) {
+			transportMock := mocks.NewTransportClient(t)
+			transportMock.
+				On("DoRequest", ctx, tc.path,
+					mock.MatchedBy(func(v url.Values) bool {
+						return v.Get("securities") == "SBER,GAZP" &&
+							v.Get("securities.columns") == marketBoards[tc.market].securitiesColumns &&
+							v.Get("marketdata.columns") == marketDataColumns
+					}),
+				).Return(factories.NewSharesJSON(), nil).Once()
+			moexClient := NewMoexClient(logg, transportMock)
+			got, err := moexClient.GetMarketSecurities(ctx, tc.market, []string{"SBER", "GAZP"})
+			require.NoError(t, err)
+			require.Len(t, got.Securities.Rows(), 2)
+		})
+	}
+	t.Run("Unknown market", func(t *testing.T) {
+		moexClient := NewMoexClient(logg, mocks.NewTransportClient(t))
+		_, err := moexClient.GetMarketSecurities(ctx, models.Market("futures"), []string{"SiZ5"})
+		require.ErrorContains(t, err, "unknown market")
+	})
+	t.Run("DoRequest err", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return(nil, errors.New("could not do request")).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		_, err := moexClient.GetMarketSecurities(ctx, models.MarketShares, []string{"SBER"})
+		require.ErrorContains(t, err, "failed DoRequest")
+	})
+}
+
+func TestGetSecurityDescription(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.
+			On("DoRequest", ctx, "iss/securities/SU26238RMFS4.json",
+				mock.MatchedBy(func(v url.Values) bool {
+					return v.Get("iss.only") == "description" && v.Get("description.columns") == "name,value"
+				}),
+			).Return(factories.NewDescriptionJSON(), nil).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		got, err := moexClient.GetSecurityDescription(ctx, "SU26238RMFS4")
+		require.NoError(t, err)
+		require.Equal(t, "1", got.Values()["EMITTER_ID"])
+		require.Equal(t, "Государственная облигация", got.Values()["TYPENAME"])
+	})
+	t.Run("DoRequest err", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return(nil, errors.New("could not do request")).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		_, err := moexClient.GetSecurityDescription(ctx, "SU26238RMFS4")
+		require.ErrorContains(t, err, "failed DoRequest")
+	})
+}
+
+func TestSearchSecurities(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.
+			On("DoRequest", ctx, "iss/securities.json",
+				mock.MatchedBy(func(v url.Values) bool {
+					return v.Get("q") == "SU26238RMFS4" && v.Get("securities.columns") == searchColumns
+				}),
+			).Return(factories.NewSecuritiesSearchJSON(), nil).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		got, err := moexClient.SearchSecurities(ctx, "SU26238RMFS4")
+		require.NoError(t, err)
+		require.Len(t, got.Securities.Rows(), 2)
+	})
+	t.Run("Invalid JSON", func(t *testing.T) {
+		transportMock := mocks.NewTransportClient(t)
+		transportMock.On("DoRequest", ctx, mock.Anything, mock.Anything).
+			Return([]byte(`invalid json`), nil).Once()
+		moexClient := NewMoexClient(logg, transportMock)
+		_, err := moexClient.SearchSecurities(ctx, "SU26238RMFS4")
+		require.ErrorContains(t, err, "failed unmarshall json")
+	})
 }
@@ -16,27 +16,139 @@ type MoexClient struct {
 	mock.Mock
 }
 
-// GetSpecifications provides a mock function with given fields: ctx, ticker, date
-func (_m *MoexClient) GetSpecifications(ctx context.Context, ticker string, date time.Time) (models.SpecificationsResponce, error) {
-	ret := _m.Called(ctx, ticker, date)
+// GetBoardSecurities provides a mock function with given fields: ctx, board
+func (_m *MoexClient) GetBoardSecurities(ctx context.Context, board string) (models.BoardSecuritiesResponce, error) {
+	ret := _m.Called(ctx, board)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSpecifications")
+		panic("no return value specified for GetBoardSecurities")
+	}
+
+	var r0 models.BoardSecuritiesResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.BoardSecuritiesResponce, error)); ok {
+		return rf(ctx, board)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.BoardSecuritiesResponce); ok {
+		r0 = rf(ctx, board)
+	} else {
+		r0 = ret.Get(0).(models.BoardSecuritiesResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, board)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, ticker, from, till, start
+func (_m *MoexClient) GetHistory(ctx context.Context, ticker string, from time.Time, till time.Time, start int) (models.SpecificationsResponce, error) {
+	ret := _m.Called(ctx, ticker, from, till, start)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
 	}
 
 	var r0 models.SpecificationsResponce
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.SpecificationsResponce, error)); ok {
-		return rf(ctx, ticker, date)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int) (models.SpecificationsResponce, error)); ok {
+		return rf(ctx, ticker, from, till, start)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.SpecificationsResponce); ok {
-		r0 = rf(ctx, ticker, date)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int) models.SpecificationsResponce); ok {
+		r0 = rf(ctx, ticker, from, till, start)
 	} else {
 		r0 = ret.Get(0).(models.SpecificationsResponce)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = rf(ctx, ticker, date)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, ticker, from, till, start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMarketSecurities provides a mock function with given fields: ctx, market, secIDs
+func (_m *MoexClient) GetMarketSecurities(ctx context.Context, market models.Market, secIDs []string) (models.BoardSecuritiesResponce, error) {
+	ret := _m.Called(ctx, market, secIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarketSecurities")
+	}
+
+	var r0 models.BoardSecuritiesResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Market, []string) (models.BoardSecuritiesResponce, error)); ok {
+		return rf(ctx, market, secIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Market, []string) models.BoardSecuritiesResponce); ok {
+		r0 = rf(ctx, market, secIDs)
+	} else {
+		r0 = ret.Get(0).(models.BoardSecuritiesResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Market, []string) error); ok {
+		r1 = rf(ctx, market, secIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSecurityDescription provides a mock function with given fields: ctx, secID
+func (_m *MoexClient) GetSecurityDescription(ctx context.Context, secID string) (models.DescriptionResponce, error) {
+	ret := _m.Called(ctx, secID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecurityDescription")
+	}
+
+	var r0 models.DescriptionResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.DescriptionResponce, error)); ok {
+		return rf(ctx, secID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.DescriptionResponce); ok {
+		r0 = rf(ctx, secID)
+	} else {
+		r0 = ret.Get(0).(models.DescriptionResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, secID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchSecurities provides a mock function with given fields: ctx, query
+func (_m *MoexClient) SearchSecurities(ctx context.Context, query string) (models.SecuritiesSearchResponce, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSecurities")
+	}
+
+	var r0 models.SecuritiesSearchResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.SecuritiesSearchResponce, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.SecuritiesSearchResponce); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(models.SecuritiesSearchResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,6 +60,114 @@ func (h *Handlers) GetSpecifications(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (h *Handlers) GetHistory(c echo.Context) error {
+	const op = "handlers.GetHistory"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var req models.HistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	}
+
+	if err := validateHistoryRequest(req); err != nil {
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	}
+
+	resp, err := h.service.GetHistory(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrInvalidRange):
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	case errors.Is(err, service.ErrNoData):
+		return newHTTPError(http.StatusNotFound, service.ErrNoData, err)
+	case err != nil:
+		return newHTTPError(http.StatusInternalServerError, errGetData, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handlers) GetBonds(c echo.Context) error {
+	const op = "handlers.GetBonds"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var req models.BondsRequest
+	if err := c.Bind(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	}
+
+	resp, err := h.service.GetBonds(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrUnknownBoard):
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	case errors.Is(err, service.ErrNoData):
+		return newHTTPError(http.StatusNotFound, service.ErrNoData, err)
+	case err != nil:
+		return newHTTPError(http.StatusInternalServerError, errGetData, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handlers) GetSecurities(c echo.Context) error {
+	const op = "handlers.GetSecurities"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var req models.SecuritiesRequest
+	if err := c.Bind(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	}
+
+	resp, err := h.service.GetSecurities(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrEmptyRequest):
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	case err != nil:
+		return newHTTPError(http.StatusInternalServerError, errGetData, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handlers) GetIssuers(c echo.Context) error {
+	const op = "handlers.GetIssuers"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var req models.IssuersRequest
+	if err := c.Bind(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	}
+
+	resp, err := h.service.GetIssuers(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrEmptyRequest):
+		return newHTTPError(http.StatusBadRequest, errInvalidRequestBody, err)
+	case err != nil:
+		return newHTTPError(http.StatusInternalServerError, errGetData, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func validateRequest(req models.SpecificationsRequest) error {
 	const op = "handlers.requestValidate"
 	if req.Date.IsZero() {
@@ -71,6 +179,16 @@ func validateRequest(req models.SpecificationsRequest) error {
 	return nil
 }
 
+func validateHistoryRequest(req models.HistoryRequest) error {
+	if req.From.IsZero() || req.To.IsZero() {
+		return errors.New("from and to are required")
+	}
+	if req.Ticker == "" {
+		return errors.New("ticker is required")
+	}
+	return nil
+}
+
 func newHTTPError(code int, public error, cause error) *echo.HTTPError {
 	return echo.NewHTTPError(code, public).
 		SetInternal(e.WrapIfErr(public.Error(), cause))
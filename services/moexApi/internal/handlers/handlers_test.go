@@ -9,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"moex/internal/models"
+	"moex/internal/service"
 	"moex/internal/service/mocks"
 	"moex/internal/testdata/factories"
 	"net/http"
@@ -214,3 +215,241 @@ func TestGetSpecifications(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestGetHistory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockService := mocks.NewServiceClient(t)
+	h := NewHandlers(logger, mockService)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.POST("/moex/history", h.GetHistory)
+
+	doRequest := func(inputBody map[string]any) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(inputBody)
+		req := httptest.NewRequest(http.MethodPost, "/moex/history", bytes.NewReader(bodyBytes))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	validBody := map[string]any{
+		"ticker": "RU000A10B9Q9",
+		"from":   "2025-01-01T00:00:00+03:00",
+		"to":     "2025-11-16T00:00:00+03:00",
+	}
+
+	t.Run("Success: returns series", func(t *testing.T) {
+		want := models.HistoryResponce{
+			Ticker: "RU000A10B9Q9",
+			Data:   []models.Values{factories.NewValues()},
+		}
+		mockService.On(
+			"GetHistory",
+			mock.Anything,
+			mock.MatchedBy(func(req models.HistoryRequest) bool {
+				return req.Ticker == "RU000A10B9Q9" && req.From.Before(req.To)
+			}),
+		).Return(want, nil).Once()
+
+		rec := doRequest(validBody)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), want.Data[0].TradeDate.Value)
+		assert.Contains(t, rec.Body.String(), `"CLOSE"`)
+	})
+
+	t.Run("Err: validateHistoryRequest empty from", func(t *testing.T) {
+		rec := doRequest(map[string]any{
+			"ticker": "RU000A10B9Q9",
+			"to":     "2025-11-16T00:00:00+03:00",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), errInvalidRequestBody.Error())
+	})
+
+	t.Run("Err: invalid range", func(t *testing.T) {
+		mockService.On("GetHistory", mock.Anything, mock.Anything).
+			Return(models.HistoryResponce{}, service.ErrInvalidRange).Once()
+
+		rec := doRequest(validBody)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Err: no data", func(t *testing.T) {
+		mockService.On("GetHistory", mock.Anything, mock.Anything).
+			Return(models.HistoryResponce{}, service.ErrNoData).Once()
+
+		rec := doRequest(validBody)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), service.ErrNoData.Error())
+	})
+
+	t.Run("Err: GetHistory err", func(t *testing.T) {
+		mockService.On("GetHistory", mock.Anything, mock.Anything).
+			Return(models.HistoryResponce{}, errors.New("moex is down")).Once()
+
+		rec := doRequest(validBody)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), errGetData.Error())
+	})
+}
+
+func TestGetBonds(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockService := mocks.NewServiceClient(t)
+	h := NewHandlers(logger, mockService)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.POST("/moex/bonds", h.GetBonds)
+
+	doRequest := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/moex/bonds", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Success: returns bonds", func(t *testing.T) {
+		want := models.BondsResponce{Bonds: []models.Bond{{SecID: "SU26238RMFS4", BoardID: "TQOB", Yield: factories.NF(14.55)}}}
+		mockService.On("GetBonds", mock.Anything, models.BondsRequest{Boards: []string{"TQOB"}}).
+			Return(want, nil).Once()
+
+		rec := doRequest(`{"boards":["TQOB"]}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"SECID":"SU26238RMFS4"`)
+	})
+
+	t.Run("Err: invalid body", func(t *testing.T) {
+		rec := doRequest(`{"boards":"TQOB"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), errInvalidRequestBody.Error())
+	})
+
+	t.Run("Err: unknown board", func(t *testing.T) {
+		mockService.On("GetBonds", mock.Anything, mock.Anything).
+			Return(models.BondsResponce{}, service.ErrUnknownBoard).Once()
+
+		rec := doRequest(`{"boards":["TQBR"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Err: no data", func(t *testing.T) {
+		mockService.On("GetBonds", mock.Anything, mock.Anything).
+			Return(models.BondsResponce{}, service.ErrNoData).Once()
+
+		rec := doRequest(`{}`)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Err: GetBonds err", func(t *testing.T) {
+		mockService.On("GetBonds", mock.Anything, mock.Anything).
+			Return(models.BondsResponce{}, errors.New("moex is down")).Once()
+
+		rec := doRequest(`{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), errGetData.Error())
+	})
+}
+
+func TestGetSecurities(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockService := mocks.NewServiceClient(t)
+	h := NewHandlers(logger, mockService)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.POST("/moex/securities", h.GetSecurities)
+
+	doRequest := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/moex/securities", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Success: returns securities", func(t *testing.T) {
+		want := models.SecuritiesResponce{Shares: []models.Share{{SecID: "SBER", LotSize: 10, Price: factories.NF(306.2)}}}
+		mockService.On("GetSecurities", mock.Anything, models.SecuritiesRequest{Shares: []string{"SBER"}}).
+			Return(want, nil).Once()
+
+		rec := doRequest(`{"shares":["SBER"]}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"LOTSIZE":10`)
+	})
+
+	t.Run("Err: empty request", func(t *testing.T) {
+		mockService.On("GetSecurities", mock.Anything, mock.Anything).
+			Return(models.SecuritiesResponce{}, service.ErrEmptyRequest).Once()
+
+		rec := doRequest(`{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), errInvalidRequestBody.Error())
+	})
+
+	t.Run("Err: GetSecurities err", func(t *testing.T) {
+		mockService.On("GetSecurities", mock.Anything, mock.Anything).
+			Return(models.SecuritiesResponce{}, errors.New("moex is down")).Once()
+
+		rec := doRequest(`{"etfs":["TMOS"]}`)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestGetIssuers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockService := mocks.NewServiceClient(t)
+	h := NewHandlers(logger, mockService)
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(logger)
+	e.POST("/moex/issuers", h.GetIssuers)
+
+	doRequest := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/moex/issuers", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Success: returns issuers", func(t *testing.T) {
+		want := models.IssuersResponce{Issuers: []models.Issuer{{SecID: "SU26238RMFS4", IssuerID: 1, INN: "7710168360"}}}
+		mockService.On("GetIssuers", mock.Anything, models.IssuersRequest{SecIDs: []string{"SU26238RMFS4"}}).
+			Return(want, nil).Once()
+
+		rec := doRequest(`{"secIds":["SU26238RMFS4"]}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"EMITTER_ID":1`)
+	})
+
+	t.Run("Err: empty request", func(t *testing.T) {
+		mockService.On("GetIssuers", mock.Anything, mock.Anything).
+			Return(models.IssuersResponce{}, service.ErrEmptyRequest).Once()
+
+		rec := doRequest(`{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Err: GetIssuers err", func(t *testing.T) {
+		mockService.On("GetIssuers", mock.Anything, mock.Anything).
+			Return(models.IssuersResponce{}, errors.New("moex is down")).Once()
+
+		rec := doRequest(`{"secIds":["SU26238RMFS4"]}`)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -11,8 +11,39 @@ type SpecificationsRequest struct {
 	Date   time.Time `json:"date"`
 }
 
+type HistoryRequest struct {
+	Ticker string    `json:"ticker"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// HistoryResponce - дневной ряд торгов по бумаге, по одной строке на торговую дату.
+type HistoryResponce struct {
+	Ticker string   `json:"ticker"`
+	Data   []Values `json:"data"`
+}
+
 type SpecificationsResponce struct {
 	History *History `json:"history"`
+	Cursor  *Cursor  `json:"history.cursor"`
+}
+
+// NextStart возвращает значение параметра start для следующей страницы истории.
+// ok=false, если страница последняя или ISS не вернул курсор.
+func (r SpecificationsResponce) NextStart() (start int, ok bool) {
+	if r.Cursor == nil || len(r.Cursor.Data) == 0 || len(r.Cursor.Data[0]) < 3 {
+		return 0, false
+	}
+	index, total, pageSize := r.Cursor.Data[0][0], r.Cursor.Data[0][1], r.Cursor.Data[0][2]
+	if pageSize <= 0 || index+pageSize >= total {
+		return 0, false
+	}
+	return index + pageSize, true
+}
+
+// Cursor - блок history.cursor ответа ISS: [[INDEX, TOTAL, PAGESIZE]].
+type Cursor struct {
+	Data [][]int `json:"data"`
 }
 
 type History struct {
@@ -70,6 +101,8 @@ type Values struct {
 	FaceValue       NullFloat64 `json:"FACEVALUE"`
 	FaceUnit        NullString  `json:"FACEUNIT"` // номинальная стоимость облигации
 	Duration        NullFloat64 `json:"DURATION"` // дюрация (средневзвешенный срок платежей)
+	Close           NullFloat64 `json:"CLOSE"`    // цена закрытия в процентах от номинала
+	Volume          NullFloat64 `json:"VOLUME"`   // объем торгов в штуках
 }
 
 func (v *Values) UnmarshalJSON(data []byte) error {
@@ -143,6 +176,23 @@ func (v *Values) UnmarshalJSON(data []byte) error {
 	}
 	v.ShortName = shortName
 
+	// CLOSE и VOLUME запрашиваются только для дневного ряда
+	if len(dataSlice) < 12 {
+		return nil
+	}
+
+	closePrice, err := parseNullFloat64(dataSlice[10])
+	if err != nil {
+		return fmt.Errorf("element 10 (CLOSE): %w", err)
+	}
+	v.Close = closePrice
+
+	volume, err := parseNullFloat64(dataSlice[11])
+	if err != nil {
+		return fmt.Errorf("element 11 (VOLUME): %w", err)
+	}
+	v.Volume = volume
+
 	return nil
 }
 
@@ -179,6 +229,206 @@ func parseNullFloat64(input any) (NullFloat64, error) {
 	return nf, nil
 }
 
+// BondsRequest - запрос облигаций, торгуемых в режимах Boards.
+// Пустой список означает TQOB, TQCB и TQIR.
+type BondsRequest struct {
+	Boards []string `json:"boards"`
+}
+
+type BondsResponce struct {
+	Bonds []Bond `json:"bonds"`
+}
+
+// Bond - облигация из списка режима торгов вместе с текущими рыночными данными.
+type Bond struct {
+	SecID         string      `json:"SECID"`
+	BoardID       string      `json:"BOARDID"`
+	ShortName     string      `json:"SHORTNAME"`
+	ISIN          string      `json:"ISIN"`
+	MaturityDate  NullString  `json:"MATDATE"`
+	OfferDate     NullString  `json:"OFFERDATE"`
+	CouponPercent NullFloat64 `json:"COUPONPERCENT"` // ставка текущего купона
+	CouponValue   NullFloat64 `json:"COUPONVALUE"`
+	CouponPeriod  NullFloat64 `json:"COUPONPERIOD"` // длительность купона в днях
+	NextCoupon    NullString  `json:"NEXTCOUPON"`   // дата ближайшего купона
+	FaceValue     NullFloat64 `json:"FACEVALUE"`
+	FaceUnit      string      `json:"FACEUNIT"`   // валюта номинала
+	CurrencyID    string      `json:"CURRENCYID"` // валюта расчетов
+	ListLevel     int         `json:"LISTLEVEL"`
+	BondType      string      `json:"BONDTYPE"`
+	BondSubType   string      `json:"BONDSUBTYPE"`
+	Price         NullFloat64 `json:"PRICE"`    // последняя цена, а без сделок - цена закрытия прошлого дня
+	Yield         NullFloat64 `json:"YIELD"`    // доходность по последней сделке или по средневзвешенной цене прошлого дня
+	Duration      NullFloat64 `json:"DURATION"` // дюрация в днях
+}
+
+// Market - рынок MOEX. Облигации запрашиваются по режимам торгов целиком, остальные рынки - по тикерам.
+type Market string
+
+const (
+	MarketBonds    Market = "bonds"
+	MarketShares   Market = "shares"
+	MarketETF      Market = "etf"
+	MarketCurrency Market = "currency"
+)
+
+// SecuritiesRequest - тикеры MOEX (SECID) по рынкам. Пустой список рынка не запрашивается.
+type SecuritiesRequest struct {
+	Shares     []string `json:"shares"`
+	ETFs       []string `json:"etfs"`
+	Currencies []string `json:"currencies"`
+}
+
+// SecuritiesResponce - найденные на MOEX бумаги. Тикеры, которых нет в основном режиме рынка, пропускаются.
+type SecuritiesResponce struct {
+	Shares     []Share    `json:"shares"`
+	ETFs       []ETF      `json:"etfs"`
+	Currencies []Currency `json:"currencies"`
+}
+
+// Share - акция в режиме TQBR.
+type Share struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	ISIN      string      `json:"ISIN"`
+	LotSize   int         `json:"LOTSIZE"`
+	Currency  string      `json:"CURRENCYID"`
+	Sector    string      `json:"SECTORID"`
+	ListLevel int         `json:"LISTLEVEL"`
+	Price     NullFloat64 `json:"PRICE"` // последняя цена, а без сделок - цена закрытия прошлого дня
+}
+
+// ETF - биржевой фонд в режиме TQTF.
+type ETF struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	ISIN      string      `json:"ISIN"`
+	LotSize   int         `json:"LOTSIZE"`
+	Currency  string      `json:"CURRENCYID"`
+	ListLevel int         `json:"LISTLEVEL"`
+	Price     NullFloat64 `json:"PRICE"`
+}
+
+// Currency - валютная пара в режиме CETS: FaceUnit - покупаемая валюта, Currency - валюта расчетов.
+type Currency struct {
+	SecID     string      `json:"SECID"`
+	BoardID   string      `json:"BOARDID"`
+	ShortName string      `json:"SHORTNAME"`
+	Name      string      `json:"SECNAME"`
+	LotSize   int         `json:"LOTSIZE"`
+	FaceUnit  string      `json:"FACEUNIT"`
+	Currency  string      `json:"CURRENCYID"`
+	Price     NullFloat64 `json:"PRICE"`
+}
+
+// IssuersRequest - облигации (SECID или ISIN), для которых нужны эмитенты.
+type IssuersRequest struct {
+	SecIDs []string `json:"secIds"`
+}
+
+// IssuersResponce - эмитенты найденных бумаг. Бумаги, которых нет в ISS, пропускаются.
+type IssuersResponce struct {
+	Issuers []Issuer `json:"issuers"`
+}
+
+// Issuer - эмитент бумаги по справочнику ISS.
+type Issuer struct {
+	SecID    string `json:"SECID"`
+	IssuerID int64  `json:"EMITTER_ID"` // код эмитента MOEX, общий для всех его выпусков
+	Name     string `json:"EMITENT_TITLE"`
+	INN      string `json:"EMITENT_INN"`
+	TypeName string `json:"TYPENAME"` // вид бумаги: государственная, корпоративная, муниципальная облигация
+}
+
+// DescriptionResponce - описание бумаги из iss/securities/<secid>: строки name/value.
+type DescriptionResponce struct {
+	Description *Table `json:"description"`
+}
+
+// Values собирает описание в словарь по именам полей, например EMITTER_ID или TYPENAME.
+func (d DescriptionResponce) Values() map[string]string {
+	res := make(map[string]string)
+	for _, row := range d.Description.Rows() {
+		if name := row.String("name"); name != "" {
+			res[name] = row.String("value")
+		}
+	}
+	return res
+}
+
+// SecuritiesSearchResponce - результат поиска iss/securities?q=: бумаги вместе с эмитентами.
+type SecuritiesSearchResponce struct {
+	Securities *Table `json:"securities"`
+}
+
+// BoardSecuritiesResponce - ответ ISS по режиму торгов: справочник бумаг и рыночные данные.
+type BoardSecuritiesResponce struct {
+	Securities *Table `json:"securities"`
+	MarketData *Table `json:"marketdata"`
+}
+
+// Table - таблица ISS в формате columns/data.
+type Table struct {
+	Columns []string `json:"columns"`
+	Data    [][]any  `json:"data"`
+}
+
+// Rows раскладывает строки таблицы по именам колонок, чтобы не зависеть от их порядка.
+func (t *Table) Rows() []Row {
+	if t == nil {
+		return nil
+	}
+	rows := make([]Row, 0, len(t.Data))
+	for _, data := range t.Data {
+		row := make(Row, len(t.Columns))
+		for i, column := range t.Columns {
+			if i < len(data) {
+				row[column] = data[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Row - строка таблицы ISS по именам колонок.
+type Row map[string]any
+
+// String возвращает строковое значение колонки или "", если его нет.
+func (r Row) String(column string) string {
+	ns, err := parseNullString(r[column])
+	if err != nil {
+		return ""
+	}
+	return ns.Value
+}
+
+// NullString возвращает значение колонки; отсутствующая колонка считается null.
+func (r Row) NullString(column string) NullString {
+	ns, err := parseNullString(r[column])
+	if err != nil {
+		return NullString{IsSet: true, IsNull: true}
+	}
+	// ISS отдает пустые даты как "0000-00-00"
+	if ns.Value == "0000-00-00" {
+		return NullString{IsSet: true, IsNull: true}
+	}
+	return ns
+}
+
+// NullFloat64 возвращает значение колонки; отсутствующая колонка считается null.
+func (r Row) NullFloat64(column string) NullFloat64 {
+	nf, err := parseNullFloat64(r[column])
+	if err != nil {
+		return NullFloat64{IsSet: true, IsNull: true}
+	}
+	return nf
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
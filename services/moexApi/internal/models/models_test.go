@@ -198,6 +198,38 @@ func TestUnmarshalJSON(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "Close and volume",
+			input: []byte(`[
+			"2025-05-21",
+			null,
+			null,
+			null,
+			null,
+			null,
+			null,
+			null,
+			null,
+			null,
+			98.5,
+			1200
+			]`),
+			expected: Values{
+				ShortName:       NullString{IsSet: true, IsNull: true},
+				TradeDate:       NullString{Value: "2025-05-21", IsSet: true},
+				MaturityDate:    NullString{IsSet: true, IsNull: true},
+				OfferDate:       NullString{IsSet: true, IsNull: true},
+				BuybackDate:     NullString{IsSet: true, IsNull: true},
+				YieldToMaturity: NullFloat64{IsSet: true, IsNull: true},
+				YieldToOffer:    NullFloat64{IsSet: true, IsNull: true},
+				FaceValue:       NullFloat64{IsSet: true, IsNull: true},
+				FaceUnit:        NullString{IsSet: true, IsNull: true},
+				Duration:        NullFloat64{IsSet: true, IsNull: true},
+				Close:           NullFloat64{Value: 98.5, IsSet: true},
+				Volume:          NullFloat64{Value: 1200, IsSet: true},
+			},
+			expectedErr: false,
+		},
 		{
 			name: "Error: less 10 elements",
 			input: []byte(`[
@@ -437,3 +469,48 @@ func assertNullFloat64Equal(t *testing.T, fieldName string, expected, actual Nul
 	require.Equal(t, expected.IsNull, actual.IsNull, "%s.IsNull", fieldName)
 	require.Equal(t, expected.IsSet, actual.IsSet, "%s.IsSet", fieldName)
 }
+
+func TestNextStart(t *testing.T) {
+	cases := []struct {
+		name      string
+		cursor    *Cursor
+		wantStart int
+		wantOk    bool
+	}{
+		{name: "No cursor", cursor: nil},
+		{name: "Empty cursor", cursor: &Cursor{}},
+		{name: "First page", cursor: &Cursor{Data: [][]int{{0, 250, 100}}}, wantStart: 100, wantOk: true},
+		{name: "Middle page", cursor: &Cursor{Data: [][]int{{100, 250, 100}}}, wantStart: 200, wantOk: true},
+		{name: "Last page", cursor: &Cursor{Data: [][]int{{200, 250, 100}}}},
+		{name: "Exact last page", cursor: &Cursor{Data: [][]int{{0, 100, 100}}}},
+		{name: "Zero page size", cursor: &Cursor{Data: [][]int{{0, 250, 0}}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, ok := SpecificationsResponce{Cursor: tc.cursor}.NextStart()
+			require.Equal(t, tc.wantOk, ok)
+			require.Equal(t, tc.wantStart, start)
+		})
+	}
+}
+
+func TestTableRows(t *testing.T) {
+	body := []byte(`{"securities":{"columns":["SECID","MATDATE","LISTLEVEL","PREVPRICE"],` +
+		`"data":[["SU26238RMFS4","0000-00-00",1,null],["SU26240RMFS0","2036-07-30"]]}}`)
+	var resp BoardSecuritiesResponce
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Nil(t, resp.MarketData.Rows())
+
+	rows := resp.Securities.Rows()
+	require.Len(t, rows, 2)
+
+	require.Equal(t, "SU26238RMFS4", rows[0].String("SECID"))
+	require.True(t, rows[0].NullString("MATDATE").IsNull, "ISS empty date")
+	require.Equal(t, 1.0, rows[0].NullFloat64("LISTLEVEL").Value)
+	require.True(t, rows[0].NullFloat64("PREVPRICE").IsNull)
+	require.True(t, rows[0].NullFloat64("SECID").IsNull, "wrong type")
+
+	require.Equal(t, "2036-07-30", rows[1].NullString("MATDATE").Value)
+	require.True(t, rows[1].NullFloat64("PREVPRICE").IsNull, "short row")
+	require.Equal(t, "", rows[1].String("UNKNOWN"))
+}
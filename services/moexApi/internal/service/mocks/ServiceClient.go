@@ -14,6 +14,118 @@ type ServiceClient struct {
 	mock.Mock
 }
 
+// GetBonds provides a mock function with given fields: ctx, req
+func (_m *ServiceClient) GetBonds(ctx context.Context, req models.BondsRequest) (models.BondsResponce, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBonds")
+	}
+
+	var r0 models.BondsResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.BondsRequest) (models.BondsResponce, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.BondsRequest) models.BondsResponce); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.BondsResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.BondsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHistory provides a mock function with given fields: ctx, req
+func (_m *ServiceClient) GetHistory(ctx context.Context, req models.HistoryRequest) (models.HistoryResponce, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 models.HistoryResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.HistoryRequest) (models.HistoryResponce, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.HistoryRequest) models.HistoryResponce); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.HistoryResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.HistoryRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIssuers provides a mock function with given fields: ctx, req
+func (_m *ServiceClient) GetIssuers(ctx context.Context, req models.IssuersRequest) (models.IssuersResponce, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIssuers")
+	}
+
+	var r0 models.IssuersResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IssuersRequest) (models.IssuersResponce, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.IssuersRequest) models.IssuersResponce); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.IssuersResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.IssuersRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSecurities provides a mock function with given fields: ctx, req
+func (_m *ServiceClient) GetSecurities(ctx context.Context, req models.SecuritiesRequest) (models.SecuritiesResponce, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecurities")
+	}
+
+	var r0 models.SecuritiesResponce
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SecuritiesRequest) (models.SecuritiesResponce, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SecuritiesRequest) models.SecuritiesResponce); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.SecuritiesResponce)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SecuritiesRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSpecifications provides a mock function with given fields: ctx, req
 func (_m *ServiceClient) GetSpecifications(ctx context.Context, req models.SpecificationsRequest) (models.Values, error) {
 	ret := _m.Called(ctx, req)
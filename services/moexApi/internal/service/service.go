@@ -3,18 +3,47 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"moex/internal/clients/moex"
 	"moex/internal/models"
 	"moex/internal/utils/logging"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gladinov/e"
 )
 
+const (
+	// specificationsLookbackDays - на сколько дней назад ищется ближайшая торговая дата.
+	specificationsLookbackDays = 14
+	// maxHistoryPages - ограничение на число страниц истории за один запрос.
+	maxHistoryPages = 100
+
+	tradeDateLayout = "2006-01-02"
+)
+
+var (
+	ErrNoData       = errors.New("could not find data in MOEX")
+	ErrInvalidRange = errors.New("from must not be after to")
+	ErrUnknownBoard = errors.New("unknown bond board")
+	ErrEmptyRequest = errors.New("no securities requested")
+)
+
+// bondBoards - режимы торгов облигациями, доступные через GetBonds:
+// ОФЗ, корпоративные облигации и облигации в режиме для квалифицированных инвесторов.
+var bondBoards = []string{"TQOB", "TQCB", "TQIR"}
+
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=ServiceClient
 type ServiceClient interface {
 	GetSpecifications(ctx context.Context, req models.SpecificationsRequest) (values models.Values, err error)
+	GetHistory(ctx context.Context, req models.HistoryRequest) (resp models.HistoryResponce, err error)
+	GetBonds(ctx context.Context, req models.BondsRequest) (resp models.BondsResponce, err error)
+	GetSecurities(ctx context.Context, req models.SecuritiesRequest) (resp models.SecuritiesResponce, err error)
+	GetIssuers(ctx context.Context, req models.IssuersRequest) (resp models.IssuersResponce, err error)
 }
 
 type Service struct {
@@ -29,41 +58,451 @@ func NewServiceClient(logger *slog.Logger, client moex.MoexClient) *Service {
 	}
 }
 
+// GetSpecifications возвращает данные бумаги на ближайшую торговую дату не позже req.Date.
+// Вся глубина поиска запрашивается у MOEX одним запросом.
 func (c *Service) GetSpecifications(ctx context.Context, req models.SpecificationsRequest) (values models.Values, err error) {
 	const op = "service.GetSpecifications"
 
 	logg := c.logger.With()
 	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
 
-	ticker := req.Ticker
-	date := req.Date
+	date := clampDate(req.Date, time.Now())
+	from := date.AddDate(0, 0, -specificationsLookbackDays)
+
+	rows, err := c.fetchHistory(ctx, req.Ticker, from, date)
+	if err != nil {
+		return models.Values{}, e.WrapIfErr("could not get specification from moexClient", err)
+	}
+
+	values, ok := nearestTradeDate(rows, date)
+	if !ok {
+		return models.Values{}, ErrNoData
+	}
+	return values, nil
+}
+
+// GetHistory возвращает дневной ряд торгов (цена закрытия, доходность, дюрация, объем)
+// за период, по одной строке на торговую дату в порядке возрастания.
+func (c *Service) GetHistory(ctx context.Context, req models.HistoryRequest) (resp models.HistoryResponce, err error) {
+	const op = "service.GetHistory"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	till := clampDate(req.To, time.Now())
+	if req.From.After(till) {
+		return models.HistoryResponce{}, ErrInvalidRange
+	}
+
+	rows, err := c.fetchHistory(ctx, req.Ticker, req.From, till)
+	if err != nil {
+		return models.HistoryResponce{}, e.WrapIfErr("could not get history from moexClient", err)
+	}
+	if len(rows) == 0 {
+		return models.HistoryResponce{}, ErrNoData
+	}
+
+	return models.HistoryResponce{
+		Ticker: req.Ticker,
+		Data:   dailySeries(rows),
+	}, nil
+}
+
+// GetBonds возвращает все облигации из режимов req.Boards с текущей ценой,
+// доходностью и дюрацией. Бумаги отсортированы по режиму и SECID.
+func (c *Service) GetBonds(ctx context.Context, req models.BondsRequest) (resp models.BondsResponce, err error) {
+	const op = "service.GetBonds"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
 
-	now := time.Now()
-	date = clampDate(req.Date, now)
+	boards, err := normalizeBoards(req.Boards)
+	if err != nil {
+		return models.BondsResponce{}, err
+	}
 
-	var data models.SpecificationsResponce
-	daysMax := 14
-	var dayToSubstract int
-	for dayToSubstract = 1; dayToSubstract <= daysMax; dayToSubstract++ {
-		data, err = c.client.GetSpecifications(ctx, ticker, date)
+	var bonds []models.Bond
+	for _, board := range boards {
+		data, err := c.client.GetBoardSecurities(ctx, board)
 		if err != nil {
-			return models.Values{}, e.WrapIfErr("could not get specification from moexClient", err)
+			return models.BondsResponce{}, e.WrapIfErr("could not get board securities from moexClient", err)
+		}
+		bonds = append(bonds, mergeBoardSecurities(board, data)...)
+	}
+	if len(bonds) == 0 {
+		return models.BondsResponce{}, ErrNoData
+	}
+
+	sort.SliceStable(bonds, func(i, j int) bool {
+		if bonds[i].BoardID != bonds[j].BoardID {
+			return bonds[i].BoardID < bonds[j].BoardID
+		}
+		return bonds[i].SecID < bonds[j].SecID
+	})
+	return models.BondsResponce{Bonds: bonds}, nil
+}
+
+// normalizeBoards приводит режимы к верхнему регистру, убирает повторы и
+// отклоняет режимы не из bondBoards. Пустой список заменяется на bondBoards.
+func normalizeBoards(boards []string) ([]string, error) {
+	if len(boards) == 0 {
+		return bondBoards, nil
+	}
+	seen := make(map[string]struct{}, len(boards))
+	res := make([]string, 0, len(boards))
+	for _, board := range boards {
+		board = strings.ToUpper(strings.TrimSpace(board))
+		if !slices.Contains(bondBoards, board) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownBoard, board)
+		}
+		if _, ok := seen[board]; ok {
+			continue
+		}
+		seen[board] = struct{}{}
+		res = append(res, board)
+	}
+	return res, nil
+}
+
+// mergeBoardSecurities соединяет справочник бумаг с рыночными данными по SECID.
+// Если сделок сегодня не было, цена и доходность берутся по итогам прошлого дня.
+func mergeBoardSecurities(board string, data models.BoardSecuritiesResponce) []models.Bond {
+	marketData := make(map[string]models.Row)
+	for _, row := range data.MarketData.Rows() {
+		marketData[row.String("SECID")] = row
+	}
+
+	securities := data.Securities.Rows()
+	bonds := make([]models.Bond, 0, len(securities))
+	for _, row := range securities {
+		secID := row.String("SECID")
+		if secID == "" {
+			continue
+		}
+		market := marketData[secID]
+
+		boardID := row.String("BOARDID")
+		if boardID == "" {
+			boardID = board
+		}
+		bond := models.Bond{
+			SecID:         secID,
+			BoardID:       boardID,
+			ShortName:     row.String("SHORTNAME"),
+			ISIN:          row.String("ISIN"),
+			MaturityDate:  row.NullString("MATDATE"),
+			OfferDate:     row.NullString("OFFERDATE"),
+			CouponPercent: row.NullFloat64("COUPONPERCENT"),
+			CouponValue:   row.NullFloat64("COUPONVALUE"),
+			CouponPeriod:  row.NullFloat64("COUPONPERIOD"),
+			NextCoupon:    row.NullString("NEXTCOUPON"),
+			FaceValue:     row.NullFloat64("FACEVALUE"),
+			FaceUnit:      row.String("FACEUNIT"),
+			CurrencyID:    row.String("CURRENCYID"),
+			ListLevel:     int(row.NullFloat64("LISTLEVEL").Value),
+			BondType:      row.String("BONDTYPE"),
+			BondSubType:   row.String("BONDSUBTYPE"),
+			Price:         firstValue(market.NullFloat64("LAST"), row.NullFloat64("PREVPRICE")),
+			Yield:         firstValue(market.NullFloat64("YIELD"), row.NullFloat64("YIELDATPREVWAPRICE")),
+			Duration:      market.NullFloat64("DURATION"),
+		}
+		bonds = append(bonds, bond)
+	}
+	return bonds
+}
+
+// firstValue возвращает первое непустое и ненулевое значение: ISS отдает 0 там,
+// где сделок не было.
+func firstValue(values ...models.NullFloat64) models.NullFloat64 {
+	for _, v := range values {
+		if v.IsSet && !v.IsNull && v.Value != 0 {
+			return v
+		}
+	}
+	return models.NullFloat64{IsSet: true, IsNull: true}
+}
+
+// GetSecurities возвращает названия, размер лота, сектор и последнюю цену акций, фондов
+// и валютных пар. Каждый рынок запрашивается одним запросом и только если по нему есть тикеры.
+func (c *Service) GetSecurities(ctx context.Context, req models.SecuritiesRequest) (resp models.SecuritiesResponce, err error) {
+	const op = "service.GetSecurities"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	shares, etfs, currencies := normalizeSecIDs(req.Shares), normalizeSecIDs(req.ETFs), normalizeSecIDs(req.Currencies)
+	if len(shares) == 0 && len(etfs) == 0 && len(currencies) == 0 {
+		return models.SecuritiesResponce{}, ErrEmptyRequest
+	}
+
+	resp = models.SecuritiesResponce{
+		Shares:     []models.Share{},
+		ETFs:       []models.ETF{},
+		Currencies: []models.Currency{},
+	}
+	err = c.forEachSecurity(ctx, models.MarketShares, shares, func(sec, market models.Row) {
+		resp.Shares = append(resp.Shares, models.Share{
+			SecID:     sec.String("SECID"),
+			BoardID:   sec.String("BOARDID"),
+			ShortName: sec.String("SHORTNAME"),
+			Name:      sec.String("SECNAME"),
+			ISIN:      sec.String("ISIN"),
+			LotSize:   int(sec.NullFloat64("LOTSIZE").Value),
+			Currency:  sec.String("CURRENCYID"),
+			Sector:    sec.String("SECTORID"),
+			ListLevel: int(sec.NullFloat64("LISTLEVEL").Value),
+			Price:     firstValue(market.NullFloat64("LAST"), sec.NullFloat64("PREVPRICE")),
+		})
+	})
+	if err != nil {
+		return models.SecuritiesResponce{}, err
+	}
+	if err := c.fillShareSectors(ctx, resp.Shares); err != nil {
+		return models.SecuritiesResponce{}, err
+	}
+	err = c.forEachSecurity(ctx, models.MarketETF, etfs, func(sec, market models.Row) {
+		resp.ETFs = append(resp.ETFs, models.ETF{
+			SecID:     sec.String("SECID"),
+			BoardID:   sec.String("BOARDID"),
+			ShortName: sec.String("SHORTNAME"),
+			Name:      sec.String("SECNAME"),
+			ISIN:      sec.String("ISIN"),
+			LotSize:   int(sec.NullFloat64("LOTSIZE").Value),
+			Currency:  sec.String("CURRENCYID"),
+			ListLevel: int(sec.NullFloat64("LISTLEVEL").Value),
+			Price:     firstValue(market.NullFloat64("LAST"), sec.NullFloat64("PREVPRICE")),
+		})
+	})
+	if err != nil {
+		return models.SecuritiesResponce{}, err
+	}
+	err = c.forEachSecurity(ctx, models.MarketCurrency, currencies, func(sec, market models.Row) {
+		resp.Currencies = append(resp.Currencies, models.Currency{
+			SecID:     sec.String("SECID"),
+			BoardID:   sec.String("BOARDID"),
+			ShortName: sec.String("SHORTNAME"),
+			Name:      sec.String("SECNAME"),
+			LotSize:   int(sec.NullFloat64("LOTSIZE").Value),
+			FaceUnit:  sec.String("FACEUNIT"),
+			Currency:  sec.String("CURRENCYID"),
+			Price:     firstValue(market.NullFloat64("LAST"), sec.NullFloat64("PREVPRICE")),
+		})
+	})
+	if err != nil {
+		return models.SecuritiesResponce{}, err
+	}
+	return resp, nil
+}
+
+// forEachSecurity запрашивает бумаги рынка и вызывает fn для каждой строки справочника
+// вместе с ее рыночными данными.
+func (c *Service) forEachSecurity(ctx context.Context, market models.Market, secIDs []string, fn func(sec, market models.Row)) error {
+	if len(secIDs) == 0 {
+		return nil
+	}
+	data, err := c.client.GetMarketSecurities(ctx, market, secIDs)
+	if err != nil {
+		return e.WrapIfErr(fmt.Sprintf("could not get %s from moexClient", market), err)
+	}
+	marketData := make(map[string]models.Row)
+	for _, row := range data.MarketData.Rows() {
+		marketData[row.String("SECID")] = row
+	}
+	for _, row := range data.Securities.Rows() {
+		secID := row.String("SECID")
+		if secID == "" {
+			continue
+		}
+		fn(row, marketData[secID])
+	}
+	return nil
+}
+
+// fillShareSectors дополняет сектор акций, у которых его нет в режиме торгов, из описания бумаги.
+// Сектор не обязателен: ошибка по одной бумаге пишется в лог и оставляет его пустым.
+func (c *Service) fillShareSectors(ctx context.Context, shares []models.Share) error {
+	for i := range shares {
+		if shares[i].Sector != "" {
+			continue
 		}
-		if data.History != nil {
-			if len(data.History.Data) != 0 {
-				break
+		description, err := c.client.GetSecurityDescription(ctx, shares[i].SecID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			c.logger.WarnContext(ctx, "share sector skipped",
+				slog.String("secid", shares[i].SecID),
+				slog.Any("error", err))
+			continue
 		}
-		date = date.AddDate(0, 0, -1)
+		shares[i].Sector = description.Values()["SECTOR"]
 	}
-	if dayToSubstract > daysMax {
-		return models.Values{}, errors.New("could not find data in MOEX")
+	return nil
+}
+
+// GetIssuers находит эмитентов облигаций. Код эмитента и вид бумаги берутся из описания
+// бумаги, а название и ИНН - из поиска ISS, потому что в описании их нет.
+// Бумаги, которых нет в ISS, пропускаются. Ошибка по одной бумаге пишется в лог и тоже
+// пропускает только ее, ошибка возвращается, если не найден ни один эмитент.
+func (c *Service) GetIssuers(ctx context.Context, req models.IssuersRequest) (resp models.IssuersResponce, err error) {
+	const op = "service.GetIssuers"
+
+	logg := c.logger.With()
+	defer logging.LogOperation_Debug(ctx, logg, op, &err)()
+
+	secIDs := normalizeSecIDs(req.SecIDs)
+	if len(secIDs) == 0 {
+		return models.IssuersResponce{}, ErrEmptyRequest
 	}
 
-	resp := data.History.Data[0]
+	var firstErr error
+	resp.Issuers = make([]models.Issuer, 0, len(secIDs))
+	for _, secID := range secIDs {
+		issuer, ok, err := c.getIssuer(ctx, secID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return models.IssuersResponce{}, ctx.Err()
+			}
+			err = e.WrapIfErr(fmt.Sprintf("could not get issuer of %s from moexClient", secID), err)
+			logg.WarnContext(ctx, "issuer skipped", slog.String("op", op), slog.Any("error", err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if ok {
+			resp.Issuers = append(resp.Issuers, issuer)
+		}
+	}
+	if len(resp.Issuers) == 0 && firstErr != nil {
+		return models.IssuersResponce{}, firstErr
+	}
 	return resp, nil
 }
 
+func (c *Service) getIssuer(ctx context.Context, secID string) (models.Issuer, bool, error) {
+	description, err := c.client.GetSecurityDescription(ctx, secID)
+	if err != nil {
+		return models.Issuer{}, false, err
+	}
+	values := description.Values()
+	if len(values) == 0 {
+		return models.Issuer{}, false, nil
+	}
+
+	issuer := models.Issuer{
+		SecID:    secID,
+		TypeName: values["TYPENAME"],
+	}
+	issuer.IssuerID, _ = strconv.ParseInt(values["EMITTER_ID"], 10, 64)
+
+	// В описании по ISIN SECID бумаги свой, искать нужно по нему.
+	if id := values["SECID"]; id != "" {
+		secID = id
+	}
+	search, err := c.client.SearchSecurities(ctx, secID)
+	if err != nil {
+		return models.Issuer{}, false, err
+	}
+	for _, row := range search.Securities.Rows() {
+		if !strings.EqualFold(row.String("secid"), secID) {
+			continue
+		}
+		issuer.Name = row.String("emitent_title")
+		issuer.INN = row.String("emitent_inn")
+		if id := row.NullFloat64("emitent_id"); issuer.IssuerID == 0 && !id.IsNull {
+			issuer.IssuerID = int64(id.Value)
+		}
+		break
+	}
+	return issuer, true, nil
+}
+
+// normalizeSecIDs приводит тикеры к верхнему регистру и убирает пустые и повторы.
+func normalizeSecIDs(secIDs []string) []string {
+	res := make([]string, 0, len(secIDs))
+	for _, secID := range secIDs {
+		secID = strings.ToUpper(strings.TrimSpace(secID))
+		if secID == "" || slices.Contains(res, secID) {
+			continue
+		}
+		res = append(res, secID)
+	}
+	return res
+}
+
+// fetchHistory забирает все страницы истории за период, следуя курсору ISS.
+func (c *Service) fetchHistory(ctx context.Context, ticker string, from, till time.Time) ([]models.Values, error) {
+	var rows []models.Values
+	start := 0
+	for page := 0; page < maxHistoryPages; page++ {
+		data, err := c.client.GetHistory(ctx, ticker, from, till, start)
+		if err != nil {
+			return nil, err
+		}
+		if data.History == nil || len(data.History.Data) == 0 {
+			return rows, nil
+		}
+		rows = append(rows, data.History.Data...)
+
+		next, ok := data.NextStart()
+		if !ok {
+			return rows, nil
+		}
+		start = next
+	}
+	return nil, fmt.Errorf("history of %s has more than %d pages", ticker, maxHistoryPages)
+}
+
+// nearestTradeDate возвращает строку с последней торговой датой не позже date.
+func nearestTradeDate(rows []models.Values, date time.Time) (models.Values, bool) {
+	day := date.Format(tradeDateLayout)
+	var (
+		best  models.Values
+		found bool
+	)
+	for _, row := range rows {
+		tradeDate := row.TradeDate.Value
+		if row.TradeDate.IsNull || tradeDate > day {
+			continue
+		}
+		// при нескольких режимах торгов за день берется последняя строка
+		if !found || tradeDate >= best.TradeDate.Value {
+			best, found = row, true
+		}
+	}
+	return best, found
+}
+
+// dailySeries оставляет одну строку на торговую дату и сортирует ряд по дате.
+// Если за день несколько строк, предпочтение отдается строке с ценой закрытия.
+func dailySeries(rows []models.Values) []models.Values {
+	byDate := make(map[string]models.Values, len(rows))
+	for _, row := range rows {
+		if row.TradeDate.IsNull || row.TradeDate.Value == "" {
+			continue
+		}
+		prev, ok := byDate[row.TradeDate.Value]
+		if ok && hasClose(prev) && !hasClose(row) {
+			continue
+		}
+		byDate[row.TradeDate.Value] = row
+	}
+
+	series := make([]models.Values, 0, len(byDate))
+	for _, row := range byDate {
+		series = append(series, row)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].TradeDate.Value < series[j].TradeDate.Value
+	})
+	return series
+}
+
+func hasClose(v models.Values) bool {
+	return v.Close.IsSet && !v.Close.IsNull
+}
+
 func clampDate(date, now time.Time) time.Time {
 	if date.After(now) {
 		return now
@@ -4,6 +4,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
@@ -17,25 +18,41 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func historyPage(cursor []int, rows ...models.Values) models.SpecificationsResponce {
+	resp := models.SpecificationsResponce{History: &models.History{Data: rows}}
+	if cursor != nil {
+		resp.Cursor = &models.Cursor{Data: [][]int{cursor}}
+	}
+	return resp
+}
+
+func historyRow(tradeDate string, closePrice float64) models.Values {
+	row := factories.NewValues()
+	row.TradeDate = factories.NS(tradeDate)
+	row.Close = factories.NF(closePrice)
+	return row
+}
+
 func TestGetSpecifications(t *testing.T) {
 	ctx := context.Background()
 	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
 	date := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
 	ticker := "OFZ26238"
+	req := models.SpecificationsRequest{
+		Ticker: ticker,
+		Date:   date,
+	}
 
-	t.Run("Success", func(t *testing.T) {
+	t.Run("Success: one range request", func(t *testing.T) {
 		mockMoexClient := mocks.NewMoexClient(t)
-		req := models.SpecificationsRequest{
-			Ticker: ticker,
-			Date:   date,
-		}
-		wantMock := factories.NewSpecificationsResponse()
 		want := factories.NewValues()
-		mockMoexClient.On("GetSpecifications",
+		mockMoexClient.On("GetHistory",
 			mock.Anything,
-			mock.AnythingOfType("string"),
-			mock.AnythingOfType("time.Time")).
-			Return(wantMock, nil).Once()
+			ticker,
+			date.AddDate(0, 0, -14),
+			date,
+			0).
+			Return(factories.NewSpecificationsResponse(), nil).Once()
 		serviceClient := NewServiceClient(logg, mockMoexClient)
 		got, err := serviceClient.GetSpecifications(ctx, req)
 		require.NoError(t, err)
@@ -43,20 +60,43 @@ func TestGetSpecifications(t *testing.T) {
 
 		mockMoexClient.AssertExpectations(t)
 	})
+	t.Run("Success: nearest trading date", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		// 16.11.2025 - воскресенье, ближайшая торговая дата - пятница 14.11
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, 0).
+			Return(historyPage(nil,
+				historyRow("2025-11-12", 60),
+				historyRow("2025-11-14", 61),
+				historyRow("2025-11-13", 62),
+			), nil).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetSpecifications(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "2025-11-14", got.TradeDate.Value)
+		require.Equal(t, 61.0, got.Close.Value)
+	})
+	t.Run("Success: follows cursor", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, 0).
+			Return(historyPage([]int{0, 3, 2}, historyRow("2025-11-10", 60), historyRow("2025-11-11", 61)), nil).Once()
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, 2).
+			Return(historyPage([]int{2, 3, 2}, historyRow("2025-11-12", 62)), nil).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetSpecifications(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "2025-11-12", got.TradeDate.Value)
+	})
 	t.Run("Err:Get specification err", func(t *testing.T) {
 		mockMoexClient := mocks.NewMoexClient(t)
-		req := models.SpecificationsRequest{
-			Ticker: ticker,
-			Date:   date,
-		}
-		wantMock := models.SpecificationsResponce{}
 		want := models.Values{}
 		errContains := "could not get specification from moexClient"
-		mockMoexClient.On("GetSpecifications",
+		mockMoexClient.On("GetHistory",
 			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("time.Time")).
-			Return(wantMock, errors.New("GetSpecification failed")).Once()
+			mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("int")).
+			Return(models.SpecificationsResponce{}, errors.New("GetHistory failed")).Once()
 		serviceClient := NewServiceClient(logg, mockMoexClient)
 		got, err := serviceClient.GetSpecifications(ctx, req)
 		require.Error(t, err)
@@ -67,27 +107,82 @@ func TestGetSpecifications(t *testing.T) {
 	})
 	t.Run("Err:No data in MOEX", func(t *testing.T) {
 		mockMoexClient := mocks.NewMoexClient(t)
-		timesCallMock := 14
-		req := models.SpecificationsRequest{
-			Ticker: ticker,
-			Date:   date,
-		}
-		wantMock := models.SpecificationsResponce{}
 		want := models.Values{}
-		errContains := "could not find data in MOEX"
-		mockMoexClient.On("GetSpecifications",
+		mockMoexClient.On("GetHistory",
 			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("time.Time")).
-			Return(wantMock, nil).Times(timesCallMock)
+			mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("time.Time"),
+			mock.AnythingOfType("int")).
+			Return(models.SpecificationsResponce{}, nil).Once()
 		serviceClient := NewServiceClient(logg, mockMoexClient)
 		got, err := serviceClient.GetSpecifications(ctx, req)
-		require.Error(t, err)
-		require.ErrorContains(t, err, errContains)
+		require.ErrorIs(t, err, ErrNoData)
 		require.Equal(t, want, got)
 
 		mockMoexClient.AssertExpectations(t)
 	})
+	t.Run("Err:Only later dates", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, 0).
+			Return(historyPage(nil, historyRow("2025-11-17", 60)), nil).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetSpecifications(ctx, req)
+		require.ErrorIs(t, err, ErrNoData)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ticker := "OFZ26238"
+	req := models.HistoryRequest{
+		Ticker: ticker,
+		From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Success: pages merged into daily series", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		boardWithoutTrades := historyRow("2025-11-11", 0)
+		boardWithoutTrades.Close = models.NullFloat64{IsSet: true, IsNull: true}
+
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, req.From, req.To, 0).
+			Return(historyPage([]int{0, 4, 2}, historyRow("2025-11-12", 62), historyRow("2025-11-11", 61)), nil).Once()
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, req.From, req.To, 2).
+			Return(historyPage([]int{2, 4, 2}, boardWithoutTrades, historyRow("2025-11-10", 60)), nil).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetHistory(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, ticker, got.Ticker)
+		require.Len(t, got.Data, 3)
+		for i, want := range []float64{60, 61, 62} {
+			require.Equal(t, want, got.Data[i].Close.Value)
+		}
+	})
+	t.Run("Err:Invalid range", func(t *testing.T) {
+		serviceClient := NewServiceClient(logg, mocks.NewMoexClient(t))
+		_, err := serviceClient.GetHistory(ctx, models.HistoryRequest{Ticker: ticker, From: req.To, To: req.From})
+		require.ErrorIs(t, err, ErrInvalidRange)
+	})
+	t.Run("Err:No data in MOEX", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, 0).
+			Return(historyPage(nil), nil).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetHistory(ctx, req)
+		require.ErrorIs(t, err, ErrNoData)
+	})
+	t.Run("Err:Too many pages", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetHistory", mock.Anything, ticker, mock.Anything, mock.Anything, mock.AnythingOfType("int")).
+			Return(historyPage([]int{0, 1 << 20, 1}, historyRow("2025-11-10", 60)), nil)
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetHistory(ctx, req)
+		require.ErrorContains(t, err, "more than")
+		mockMoexClient.AssertNumberOfCalls(t, "GetHistory", maxHistoryPages)
+	})
 }
 
 func TestClampDate_FutureDate(t *testing.T) {
@@ -98,3 +193,196 @@ func TestClampDate_FutureDate(t *testing.T) {
 
 	require.Equal(t, now, got)
 }
+
+func boardSecurities(t *testing.T) models.BoardSecuritiesResponce {
+	t.Helper()
+	var resp models.BoardSecuritiesResponce
+	require.NoError(t, json.Unmarshal(factories.NewBoardSecuritiesJSON(), &resp))
+	return resp
+}
+
+func TestGetBonds(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: securities merged with market data", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQOB").
+			Return(boardSecurities(t), nil).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetBonds(ctx, models.BondsRequest{Boards: []string{"tqob", "TQOB"}})
+		require.NoError(t, err)
+		require.Len(t, got.Bonds, 2)
+
+		traded := got.Bonds[0]
+		require.Equal(t, "SU26238RMFS4", traded.SecID)
+		require.Equal(t, 1, traded.ListLevel)
+		require.True(t, traded.OfferDate.IsNull)
+		require.Equal(t, "2026-05-20", traded.NextCoupon.Value)
+		require.Equal(t, 58.7, traded.Price.Value)
+		require.Equal(t, 14.55, traded.Yield.Value)
+		require.Equal(t, 3650.0, traded.Duration.Value)
+
+		notTraded := got.Bonds[1]
+		require.Equal(t, 99.9, notTraded.Price.Value)
+		require.Equal(t, 17.2, notTraded.Yield.Value)
+	})
+	t.Run("Success: default boards", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQOB").
+			Return(models.BoardSecuritiesResponce{}, nil).Once()
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQCB").
+			Return(boardSecurities(t), nil).Once()
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQIR").
+			Return(models.BoardSecuritiesResponce{}, nil).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetBonds(ctx, models.BondsRequest{})
+		require.NoError(t, err)
+		require.Len(t, got.Bonds, 2)
+	})
+	t.Run("Err:Unknown board", func(t *testing.T) {
+		serviceClient := NewServiceClient(logg, mocks.NewMoexClient(t))
+		_, err := serviceClient.GetBonds(ctx, models.BondsRequest{Boards: []string{"TQBR"}})
+		require.ErrorIs(t, err, ErrUnknownBoard)
+	})
+	t.Run("Err:No data in MOEX", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQIR").
+			Return(models.BoardSecuritiesResponce{}, nil).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetBonds(ctx, models.BondsRequest{Boards: []string{"TQIR"}})
+		require.ErrorIs(t, err, ErrNoData)
+	})
+	t.Run("Err:Client err", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetBoardSecurities", mock.Anything, "TQOB").
+			Return(models.BoardSecuritiesResponce{}, errors.New("moex is down")).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetBonds(ctx, models.BondsRequest{Boards: []string{"TQOB"}})
+		require.ErrorContains(t, err, "could not get board securities")
+	})
+}
+
+func TestGetSecurities(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var shares models.BoardSecuritiesResponce
+	require.NoError(t, json.Unmarshal(factories.NewSharesJSON(), &shares))
+	var gazpDescription models.DescriptionResponce
+	require.NoError(t, json.Unmarshal(factories.NewShareDescriptionJSON(), &gazpDescription))
+
+	t.Run("Success: only requested markets", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetMarketSecurities", mock.Anything, models.MarketShares, []string{"SBER", "GAZP"}).
+			Return(shares, nil).Once()
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "GAZP").
+			Return(gazpDescription, nil).Once()
+		mockMoexClient.On("GetMarketSecurities", mock.Anything, models.MarketCurrency, []string{"CNYRUB_TOM"}).
+			Return(models.BoardSecuritiesResponce{}, nil).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetSecurities(ctx, models.SecuritiesRequest{
+			Shares:     []string{"sber", "GAZP", "SBER", " "},
+			Currencies: []string{"cnyrub_tom"},
+		})
+		require.NoError(t, err)
+		require.Len(t, got.Shares, 2)
+		require.Empty(t, got.ETFs)
+		require.Empty(t, got.Currencies)
+
+		sber := got.Shares[0]
+		require.Equal(t, "Сбербанк", sber.ShortName)
+		require.Equal(t, 10, sber.LotSize)
+		require.Equal(t, 1, sber.ListLevel)
+		require.Equal(t, 306.2, sber.Price.Value)
+		require.Equal(t, 128.4, got.Shares[1].Price.Value, "no trades today")
+		require.Equal(t, "FNL", sber.Sector)
+		require.Equal(t, "OIL", got.Shares[1].Sector, "sector from description")
+	})
+	t.Run("Success: description error leaves sector empty", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetMarketSecurities", mock.Anything, models.MarketShares, []string{"GAZP"}).
+			Return(shares, nil).Once()
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "GAZP").
+			Return(models.DescriptionResponce{}, errors.New("moex is down")).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetSecurities(ctx, models.SecuritiesRequest{Shares: []string{"GAZP"}})
+		require.NoError(t, err)
+		require.Empty(t, got.Shares[1].Sector)
+	})
+	t.Run("Err:Empty request", func(t *testing.T) {
+		serviceClient := NewServiceClient(logg, mocks.NewMoexClient(t))
+		_, err := serviceClient.GetSecurities(ctx, models.SecuritiesRequest{ETFs: []string{""}})
+		require.ErrorIs(t, err, ErrEmptyRequest)
+	})
+	t.Run("Err:Client err", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetMarketSecurities", mock.Anything, models.MarketETF, mock.Anything).
+			Return(models.BoardSecuritiesResponce{}, errors.New("moex is down")).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetSecurities(ctx, models.SecuritiesRequest{ETFs: []string{"TMOS"}})
+		require.ErrorContains(t, err, "could not get etf")
+	})
+}
+
+func TestGetIssuers(t *testing.T) {
+	ctx := context.Background()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var description models.DescriptionResponce
+	require.NoError(t, json.Unmarshal(factories.NewDescriptionJSON(), &description))
+	var search models.SecuritiesSearchResponce
+	require.NoError(t, json.Unmarshal(factories.NewSecuritiesSearchJSON(), &search))
+
+	t.Run("Success: unknown securities skipped", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "RU000A1038V6").
+			Return(description, nil).Once()
+		mockMoexClient.On("SearchSecurities", mock.Anything, "SU26238RMFS4").
+			Return(search, nil).Once()
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "UNKNOWN").
+			Return(models.DescriptionResponce{}, nil).Once()
+
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetIssuers(ctx, models.IssuersRequest{SecIDs: []string{"ru000a1038v6", "UNKNOWN", ""}})
+		require.NoError(t, err)
+		require.Equal(t, []models.Issuer{{
+			SecID:    "RU000A1038V6",
+			IssuerID: 1,
+			Name:     "Министерство Финансов Российской Федерации",
+			INN:      "7710168360",
+			TypeName: "Государственная облигация",
+		}}, got.Issuers)
+	})
+	t.Run("Err:Empty request", func(t *testing.T) {
+		serviceClient := NewServiceClient(logg, mocks.NewMoexClient(t))
+		_, err := serviceClient.GetIssuers(ctx, models.IssuersRequest{SecIDs: []string{" "}})
+		require.ErrorIs(t, err, ErrEmptyRequest)
+	})
+	t.Run("Err:Client err", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "SU26238RMFS4").
+			Return(description, nil).Once()
+		mockMoexClient.On("SearchSecurities", mock.Anything, "SU26238RMFS4").
+			Return(models.SecuritiesSearchResponce{}, errors.New("moex is down")).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		_, err := serviceClient.GetIssuers(ctx, models.IssuersRequest{SecIDs: []string{"SU26238RMFS4"}})
+		require.ErrorContains(t, err, "could not get issuer of SU26238RMFS4")
+	})
+	t.Run("Success: failed security skipped", func(t *testing.T) {
+		mockMoexClient := mocks.NewMoexClient(t)
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "RU000A1038V6").
+			Return(description, nil).Once()
+		mockMoexClient.On("SearchSecurities", mock.Anything, "SU26238RMFS4").
+			Return(search, nil).Once()
+		mockMoexClient.On("GetSecurityDescription", mock.Anything, "RU000A105A95").
+			Return(models.DescriptionResponce{}, errors.New("moex is down")).Once()
+		serviceClient := NewServiceClient(logg, mockMoexClient)
+		got, err := serviceClient.GetIssuers(ctx, models.IssuersRequest{SecIDs: []string{"RU000A105A95", "RU000A1038V6"}})
+		require.NoError(t, err)
+		require.Len(t, got.Issuers, 1)
+		require.Equal(t, "RU000A1038V6", got.Issuers[0].SecID)
+	})
+}
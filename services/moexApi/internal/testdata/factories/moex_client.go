@@ -76,3 +76,125 @@ func NewSpecificationsResponse() models.SpecificationsResponce {
 		},
 	}
 }
+
+// NewBoardSecuritiesJSON - ответ ISS по режиму TQOB: у первой бумаги были сделки,
+// у второй нет, и цена с доходностью берутся по итогам прошлого дня.
+func NewBoardSecuritiesJSON() []byte {
+	resp := map[string]any{
+		"securities": map[string]any{
+			"columns": []string{"SECID", "BOARDID", "SHORTNAME", "ISIN", "MATDATE", "OFFERDATE", "COUPONPERCENT",
+				"COUPONVALUE", "COUPONPERIOD", "NEXTCOUPON", "FACEVALUE", "FACEUNIT", "CURRENCYID", "LISTLEVEL", "BONDTYPE",
+				"BONDSUBTYPE", "PREVPRICE", "YIELDATPREVWAPRICE"},
+			"data": []any{
+				[]any{"SU26238RMFS4", "TQOB", "ОФЗ 26238", "RU000A1038V6", "2041-05-15", "0000-00-00", 7.1,
+					35.4, 182.0, "2026-05-20", 1000.0, "SUR", "SUR", 1.0, "ofz_bond", "ofz_bond", 58.5, 14.6},
+				[]any{"SU29006RMFS2", "TQOB", "ОФЗ 29006", "RU000A0JV4L2", "2025-01-29", nil, 16.39,
+					40.86, 182.0, "0000-00-00", 1000.0, "SUR", "SUR", 1.0, "ofz_bond", "ofz_bond", 99.9, 17.2},
+			},
+		},
+		"marketdata": map[string]any{
+			"columns": []string{"SECID", "LAST", "YIELD", "DURATION"},
+			"data": []any{
+				[]any{"SU26238RMFS4", 58.7, 14.55, 3650.0},
+				[]any{"SU29006RMFS2", nil, 0.0, 20.0},
+			},
+		},
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewSharesJSON - ответ ISS по режиму TQBR: SBER торговался сегодня, у GAZP сделок не было
+// и в режиме торгов не заполнен сектор.
+func NewSharesJSON() []byte {
+	resp := map[string]any{
+		"securities": map[string]any{
+			"columns": []string{"SECID", "BOARDID", "SHORTNAME", "SECNAME", "ISIN", "LOTSIZE", "CURRENCYID",
+				"SECTORID", "LISTLEVEL", "PREVPRICE"},
+			"data": []any{
+				[]any{"SBER", "TQBR", "Сбербанк", "Сбербанк России ПАО ао", "RU0009029540", 10.0, "SUR", "FNL", 1.0, 305.1},
+				[]any{"GAZP", "TQBR", "ГАЗПРОМ ао", "\"Газпром\" (ПАО) ао", "RU0007661625", 10.0, "SUR", "", 1.0, 128.4},
+			},
+		},
+		"marketdata": map[string]any{
+			"columns": []string{"SECID", "LAST"},
+			"data": []any{
+				[]any{"SBER", 306.2},
+				[]any{"GAZP", nil},
+			},
+		},
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewDescriptionJSON - описание облигации из iss/securities/<secid>: ISS отдает все значения строками.
+func NewDescriptionJSON() []byte {
+	resp := map[string]any{
+		"description": map[string]any{
+			"columns": []string{"name", "value"},
+			"data": []any{
+				[]any{"SECID", "SU26238RMFS4"},
+				[]any{"NAME", "ОФЗ-ПД 26238 15/05/2041"},
+				[]any{"ISIN", "RU000A1038V6"},
+				[]any{"TYPENAME", "Государственная облигация"},
+				[]any{"EMITTER_ID", "1"},
+			},
+		},
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewShareDescriptionJSON - описание акции GAZP из iss/securities/<secid> с сектором эмитента.
+func NewShareDescriptionJSON() []byte {
+	resp := map[string]any{
+		"description": map[string]any{
+			"columns": []string{"name", "value"},
+			"data": []any{
+				[]any{"SECID", "GAZP"},
+				[]any{"NAME", "\"Газпром\" (ПАО) ао"},
+				[]any{"TYPENAME", "Акция обыкновенная"},
+				[]any{"SECTOR", "OIL"},
+				[]any{"EMITTER_ID", "1016"},
+			},
+		},
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewSecuritiesSearchJSON - поиск iss/securities?q=: кроме искомой бумаги ISS находит похожие.
+func NewSecuritiesSearchJSON() []byte {
+	resp := map[string]any{
+		"securities": map[string]any{
+			"columns": []string{"secid", "isin", "emitent_id", "emitent_title", "emitent_inn"},
+			"data": []any{
+				[]any{"SU26238RMFS4", "RU000A1038V6", 1.0, "Министерство Финансов Российской Федерации", "7710168360"},
+				[]any{"SU26238RMFS", nil, nil, nil, nil},
+			},
+		},
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -15,10 +15,14 @@ import (
 	trace "github.com/gladinov/contracts/trace"
 
 	"github.com/gladinov/valuefromcontext"
+	"main.go/internal/i18n"
 )
 
 const defaultTimeout = 10 * time.Second
 
+// HeaderLanguage - язык, на котором bonds-report-service формирует таблицы и подписи.
+const HeaderLanguage = "Accept-Language"
+
 type Client struct {
 	logger *slog.Logger
 	host   string
@@ -74,12 +78,7 @@ func (c *Client) GetAccountsList(ctx context.Context) (AccountListResponce, erro
 		return AccountListResponce{}, fmt.Errorf("%s: %w", op, err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err = json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return AccountListResponce{}, fmt.Errorf("%s: %w", op, err)
-		}
-		return AccountListResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return AccountListResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 
 	var accountResponce AccountListResponce
@@ -133,12 +132,7 @@ func (c *Client) GetUsd(ctx context.Context) (UsdResponce, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return UsdResponce{}, fmt.Errorf("%s:%w", op, err)
-		}
-		return UsdResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return UsdResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 
 	var usdResponce UsdResponce
@@ -189,17 +183,13 @@ func (c *Client) GetBondReportsByFifo(ctx context.Context) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
-		}
-		return fmt.Errorf("%s:"+statusErr["error"], op)
+		return fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 	return nil
 }
 
-func (c *Client) GetBondReports(ctx context.Context) (BondReportsResponce, error) {
+// GetBondReports возвращает таблицы облигаций по счетам. Пустой accountID - все счета.
+func (c *Client) GetBondReports(ctx context.Context, accountID string) (BondReportsResponce, error) {
 	const op = "bondreportservice.GetBondReports"
 
 	start := time.Now()
@@ -212,10 +202,15 @@ func (c *Client) GetBondReports(ctx context.Context) (BondReportsResponce, error
 	}()
 
 	pth := path.Join("bondReportService", "getBondReports")
+	query := url.Values{}
+	if accountID != "" {
+		query.Set("account", accountID)
+	}
 	u := url.URL{
-		Scheme: "http",
-		Host:   c.host,
-		Path:   pth,
+		Scheme:   "http",
+		Host:     c.host,
+		Path:     pth,
+		RawQuery: query.Encode(),
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
@@ -238,12 +233,7 @@ func (c *Client) GetBondReports(ctx context.Context) (BondReportsResponce, error
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return BondReportsResponce{}, fmt.Errorf("%s:%w", op, err)
-		}
-		return BondReportsResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return BondReportsResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 	var bondReportResponce BondReportsResponce
 	err = json.Unmarshal(body, &bondReportResponce)
@@ -293,12 +283,7 @@ func (c *Client) GetPortfolioStructure(ctx context.Context) (PortfolioStructureF
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return PortfolioStructureForEachAccountResponce{}, fmt.Errorf("%s:%w", op, err)
-		}
-		return PortfolioStructureForEachAccountResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return PortfolioStructureForEachAccountResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 	var bondReportResponce PortfolioStructureForEachAccountResponce
 	err = json.Unmarshal(body, &bondReportResponce)
@@ -348,12 +333,7 @@ func (c *Client) GetUnionPortfolioStructure(ctx context.Context) (UnionPortfolio
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return UnionPortfolioStructureResponce{}, fmt.Errorf("%s:%w", op, err)
-		}
-		return UnionPortfolioStructureResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return UnionPortfolioStructureResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 	var bondReportResponce UnionPortfolioStructureResponce
 	err = json.Unmarshal(body, &bondReportResponce)
@@ -363,6 +343,278 @@ func (c *Client) GetUnionPortfolioStructure(ctx context.Context) (UnionPortfolio
 	return bondReportResponce, nil
 }
 
+func (c *Client) GetCash(ctx context.Context) (CashResponce, error) {
+	const op = "bondreportservice.GetCash"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	pth := path.Join("bondReportService", "getCash")
+	u := url.URL{
+		Scheme: "http",
+		Host:   c.host,
+		Path:   pth,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return CashResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	reqWithHeaders, err := c.setHeaders(ctx, req)
+	if err != nil {
+		return CashResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := c.client.Do(reqWithHeaders)
+	if err != nil {
+		return CashResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CashResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return CashResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
+	}
+	var cashResponce CashResponce
+	err = json.Unmarshal(body, &cashResponce)
+	if err != nil {
+		return CashResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	return cashResponce, nil
+}
+
+func (c *Client) GetDividends(ctx context.Context) (DividendsResponce, error) {
+	const op = "bondreportservice.GetDividends"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	pth := path.Join("bondReportService", "getDividends")
+	u := url.URL{
+		Scheme: "http",
+		Host:   c.host,
+		Path:   pth,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return DividendsResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	reqWithHeaders, err := c.setHeaders(ctx, req)
+	if err != nil {
+		return DividendsResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := c.client.Do(reqWithHeaders)
+	if err != nil {
+		return DividendsResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DividendsResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return DividendsResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
+	}
+	var dividendsResponce DividendsResponce
+	err = json.Unmarshal(body, &dividendsResponce)
+	if err != nil {
+		return DividendsResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	return dividendsResponce, nil
+}
+
+// GetRateScenario возвращает PNG с изменением стоимости облигаций при сдвиге ставок
+// (+100bp, -50bp, +1%). Пустой сдвиг - +100 б.п.
+func (c *Client) GetRateScenario(ctx context.Context, shift string) (ImageData, error) {
+	const op = "bondreportservice.GetRateScenario"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	pth := path.Join("bondReportService", "getRateScenario")
+	query := url.Values{}
+	if shift != "" {
+		query.Set("shift", shift)
+	}
+	u := url.URL{
+		Scheme:   "http",
+		Host:     c.host,
+		Path:     pth,
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	reqWithHeaders, err := c.setHeaders(ctx, req)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := c.client.Do(reqWithHeaders)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ImageData{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
+	}
+	var scenario ImageData
+	err = json.Unmarshal(body, &scenario)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	return scenario, nil
+}
+
+// GetBondScreener возвращает таблицу облигаций MOEX, отобранных по filter (ytm, dur, mat,
+// coupon, cur, level, board, limit), со сравнением с облигациями пользователя.
+func (c *Client) GetBondScreener(ctx context.Context, filter map[string]string) (BondScreenerResponce, error) {
+	const op = "bondreportservice.GetBondScreener"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	pth := path.Join("bondReportService", "getBondScreener")
+	query := url.Values{}
+	for key, value := range filter {
+		query.Set(key, value)
+	}
+	u := url.URL{
+		Scheme:   "http",
+		Host:     c.host,
+		Path:     pth,
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return BondScreenerResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	reqWithHeaders, err := c.setHeaders(ctx, req)
+	if err != nil {
+		return BondScreenerResponce{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := c.client.Do(reqWithHeaders)
+	if err != nil {
+		return BondScreenerResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return BondScreenerResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return BondScreenerResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
+	}
+	var screenerResponce BondScreenerResponce
+	err = json.Unmarshal(body, &screenerResponce)
+	if err != nil {
+		return BondScreenerResponce{}, fmt.Errorf("%s:%w", op, err)
+	}
+	return screenerResponce, nil
+}
+
+// GetBondChart возвращает PNG с ценой и доходностью облигации за период (1m, 3m, 6m, 1y, 3y, 5y).
+// Пустой период - год или с первой покупки, если она раньше.
+func (c *Client) GetBondChart(ctx context.Context, ticker, period string) (ImageData, error) {
+	const op = "bondreportservice.GetBondChart"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	pth := path.Join("bondReportService", "getBondChart")
+	query := url.Values{}
+	query.Set("ticker", ticker)
+	if period != "" {
+		query.Set("period", period)
+	}
+	u := url.URL{
+		Scheme:   "http",
+		Host:     c.host,
+		Path:     pth,
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	reqWithHeaders, err := c.setHeaders(ctx, req)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := c.client.Do(reqWithHeaders)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ImageData{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
+	}
+	var chart ImageData
+	err = json.Unmarshal(body, &chart)
+	if err != nil {
+		return ImageData{}, fmt.Errorf("%s:%w", op, err)
+	}
+	return chart, nil
+}
+
 func (c *Client) GetUnionPortfolioStructureWithSber(ctx context.Context) (UnionPortfolioStructureWithSberResponce, error) {
 	const op = "bondreportservice.GetUnionPortfolioStructureWithSber"
 
@@ -403,12 +655,7 @@ func (c *Client) GetUnionPortfolioStructureWithSber(ctx context.Context) (UnionP
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var statusErr map[string]string
-		err := json.Unmarshal(body, &statusErr)
-		if err != nil {
-			return UnionPortfolioStructureWithSberResponce{}, fmt.Errorf("%s:%w", op, err)
-		}
-		return UnionPortfolioStructureWithSberResponce{}, fmt.Errorf("%s:"+statusErr["error"], op)
+		return UnionPortfolioStructureWithSberResponce{}, fmt.Errorf("%s: %w", op, statusError(resp.StatusCode, body))
 	}
 	var bondReportResponce UnionPortfolioStructureWithSberResponce
 	err = json.Unmarshal(body, &bondReportResponce)
@@ -437,6 +684,7 @@ func (c *Client) setHeaders(ctx context.Context, req *http.Request) (*http.Reque
 		logg.WarnContext(ctx, "hasn't traceID in ctx")
 	}
 	req.Header.Set(httpheaders.HeaderTraceID, traceID)
+	req.Header.Set(HeaderLanguage, string(i18n.FromContext(ctx)))
 
 	return req, nil
 }
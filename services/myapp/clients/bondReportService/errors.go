@@ -0,0 +1,68 @@
+package bondreportservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Коды ошибок bonds-report-service, по ним бот показывает пользователю понятное сообщение.
+var (
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrNotFound        = errors.New("not found")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnavailable     = errors.New("service unavailable")
+	ErrInternal        = errors.New("internal error")
+)
+
+// StatusError - ответ bonds-report-service с ошибкой.
+type StatusError struct {
+	Status  int
+	Code    string
+	Message string
+	kind    error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %s: %s", e.Status, e.kind, e.Message)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.kind
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+func statusError(status int, body []byte) error {
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		resp.Error = string(body)
+	}
+	return &StatusError{
+		Status:  status,
+		Code:    resp.Code,
+		Message: resp.Error,
+		kind:    codeError(resp.Code),
+	}
+}
+
+func codeError(code string) error {
+	switch code {
+	case "unauthorized":
+		return ErrUnauthorized
+	case "not_found":
+		return ErrNotFound
+	case "rate_limited":
+		return ErrRateLimited
+	case "invalid_argument":
+		return ErrInvalidArgument
+	case "unavailable":
+		return ErrUnavailable
+	default:
+		return ErrInternal
+	}
+}
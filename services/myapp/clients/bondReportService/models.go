@@ -21,7 +21,13 @@ func NewImageData() *ImageData {
 }
 
 type AccountListResponce struct {
-	Accounts string `json:"accounts,omitempty"`
+	Accounts string    `json:"accounts,omitempty"`
+	List     []Account `json:"list,omitempty"`
+}
+
+type Account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type BondReportsRequest struct {
@@ -44,6 +50,18 @@ type UnionPortfolioStructureResponce struct {
 	Report string `json:"report"`
 }
 
+type CashResponce struct {
+	Report string `json:"report"`
+}
+
+type DividendsResponce struct {
+	Report string `json:"report"`
+}
+
+type BondScreenerResponce struct {
+	Report string `json:"report"`
+}
+
 type UnionPortfolioStructureWithSberResponce struct {
 	Report string `json:"report"`
 }
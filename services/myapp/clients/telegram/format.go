@@ -0,0 +1,126 @@
+package telegram
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// MaxMessageLength - ограничение Telegram на длину текста сообщения
+// (в UTF-16 символах, после разбора разметки).
+const MaxMessageLength = 4096
+
+type ParseMode string
+
+const (
+	ParseModeNone       ParseMode = ""
+	ParseModeHTML       ParseMode = "HTML"
+	ParseModeMarkdownV2 ParseMode = "MarkdownV2"
+)
+
+var (
+	htmlReplacer = strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+
+	markdownV2Replacer = strings.NewReplacer(
+		`\`, `\\`,
+		"_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+		"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`,
+		"=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+
+	markdownV2CodeReplacer = strings.NewReplacer(
+		`\`, `\\`,
+		"`", "\\`",
+	)
+)
+
+// EscapeHTML экранирует текст для parse_mode=HTML.
+func EscapeHTML(text string) string {
+	return htmlReplacer.Replace(text)
+}
+
+// EscapeMarkdownV2 экранирует текст для parse_mode=MarkdownV2 вне блоков кода.
+func EscapeMarkdownV2(text string) string {
+	return markdownV2Replacer.Replace(text)
+}
+
+// EscapeMarkdownV2Code экранирует текст внутри блоков ``` и `.
+func EscapeMarkdownV2Code(text string) string {
+	return markdownV2CodeReplacer.Replace(text)
+}
+
+// Pre оборачивает уже разбитый на части текст в моноширинный блок.
+func Pre(text string, mode ParseMode) string {
+	switch mode {
+	case ParseModeHTML:
+		return "<pre>" + EscapeHTML(text) + "</pre>"
+	case ParseModeMarkdownV2:
+		return "```\n" + EscapeMarkdownV2Code(text) + "\n```"
+	default:
+		return text
+	}
+}
+
+// SplitMessage разбивает текст на части не длиннее limit UTF-16 символов.
+// Текст режется по границам строк; строка длиннее limit режется по символам.
+func SplitMessage(text string, limit int) []string {
+	if limit <= 0 || textLength(text) <= limit {
+		return []string{text}
+	}
+
+	chunks := make([]string, 0, 2)
+	var current strings.Builder
+	currentLen := 0
+
+	flush := func() {
+		if currentLen == 0 {
+			return
+		}
+		chunks = append(chunks, strings.TrimSuffix(current.String(), "\n"))
+		current.Reset()
+		currentLen = 0
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineLen := textLength(line)
+		if currentLen+lineLen > limit {
+			flush()
+		}
+		for lineLen > limit {
+			head, tail := cutAtLength(line, limit)
+			chunks = append(chunks, head)
+			line = tail
+			lineLen = textLength(line)
+		}
+		current.WriteString(line)
+		currentLen += lineLen
+	}
+	flush()
+
+	return chunks
+}
+
+func textLength(text string) int {
+	n := 0
+	for _, r := range text {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// cutAtLength отрезает от text префикс длиной не больше limit UTF-16 символов,
+// не разрывая руны.
+func cutAtLength(text string, limit int) (string, string) {
+	n := 0
+	for i, r := range text {
+		l := utf16.RuneLen(r)
+		if n+l > limit {
+			return text[:i], text[i:]
+		}
+		n += l
+	}
+	return text, ""
+}
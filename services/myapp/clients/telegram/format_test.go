@@ -0,0 +1,53 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, "a &lt;b&gt; &amp; c", EscapeHTML("a <b> & c"))
+	require.Equal(t, `1\.5 \- \(x\_y\) \\ \!`, EscapeMarkdownV2(`1.5 - (x_y) \ !`))
+	require.Equal(t, "a\\`b\\\\c", EscapeMarkdownV2Code("a`b\\c"))
+}
+
+func TestPre(t *testing.T) {
+	require.Equal(t, "<pre>a &lt; b</pre>", Pre("a < b", ParseModeHTML))
+	require.Equal(t, "```\na.b\n```", Pre("a.b", ParseModeMarkdownV2))
+	require.Equal(t, "a < b", Pre("a < b", ParseModeNone))
+}
+
+func TestSplitMessage(t *testing.T) {
+	t.Run("short text", func(t *testing.T) {
+		require.Equal(t, []string{"hello"}, SplitMessage("hello", 10))
+	})
+
+	t.Run("split on lines", func(t *testing.T) {
+		got := SplitMessage("aaa\nbbb\nccc", 8)
+		require.Equal(t, []string{"aaa\nbbb", "ccc"}, got)
+	})
+
+	t.Run("long line is cut", func(t *testing.T) {
+		got := SplitMessage("ab\n"+strings.Repeat("x", 7), 3)
+		require.Equal(t, []string{"ab", "xxx", "xxx", "x"}, got)
+	})
+
+	t.Run("utf16 length", func(t *testing.T) {
+		// эмодзи занимает два UTF-16 символа
+		got := SplitMessage("😀😀😀", 4)
+		require.Equal(t, []string{"😀😀", "😀"}, got)
+	})
+
+	t.Run("chunks fit limit", func(t *testing.T) {
+		line := strings.Repeat("Строка таблицы ", 10) + "\n"
+		text := strings.Repeat(line, 100)
+		chunks := SplitMessage(text, MaxMessageLength)
+		require.Greater(t, len(chunks), 1)
+		for _, chunk := range chunks {
+			require.LessOrEqual(t, textLength(chunk), MaxMessageLength)
+		}
+		require.Equal(t, strings.TrimSuffix(text, "\n"), strings.Join(chunks, "\n"))
+	})
+}
@@ -0,0 +1,174 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Лимиты Bot API (https://core.telegram.org/bots/faq#my-bot-is-hitting-limits-how-do-i-avoid-this):
+// не больше 30 сообщений в секунду всего, не больше 1 сообщения в секунду в один чат
+// и не больше 20 сообщений в минуту в одну группу.
+const (
+	globalRatePerSecond = 30
+	chatRatePerSecond   = 1
+	groupRatePerSecond  = 20.0 / 60.0
+	chatBurst           = 3
+	maxChatBuckets      = 10000
+)
+
+type Limits struct {
+	GlobalRate  float64
+	GlobalBurst float64
+	ChatRate    float64
+	GroupRate   float64
+	ChatBurst   float64
+}
+
+func DefaultLimits() Limits {
+	return Limits{
+		GlobalRate:  globalRatePerSecond,
+		GlobalBurst: globalRatePerSecond,
+		ChatRate:    chatRatePerSecond,
+		GroupRate:   groupRatePerSecond,
+		ChatBurst:   chatBurst,
+	}
+}
+
+// tokenBucket - корзина токенов. Токены могут уходить в минус: это очередь
+// уже зарезервированных отправок, которые ждут своей очереди.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// reserve забирает токен и возвращает, сколько нужно подождать до отправки.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// block запрещает отправку на время d (используется после 429 с retry_after).
+func (b *tokenBucket) block(now time.Time, d time.Duration) {
+	b.refill(now)
+	b.tokens = min(b.tokens, -d.Seconds()*b.rate)
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// rateLimiter - каждое сообщение ждет токен в общей корзине и в корзине своего чата.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limits Limits
+	global *tokenBucket
+	chats  map[int]*tokenBucket
+	now    func() time.Time
+}
+
+func newRateLimiter(limits Limits) *rateLimiter {
+	now := time.Now
+	return &rateLimiter{
+		limits: limits,
+		global: newTokenBucket(limits.GlobalRate, limits.GlobalBurst, now()),
+		chats:  make(map[int]*tokenBucket),
+		now:    now,
+	}
+}
+
+// Wait блокирует до момента, когда в чат chatID можно отправить сообщение.
+func (l *rateLimiter) Wait(ctx context.Context, chatID int) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve(chatID)
+	if delay <= 0 {
+		return nil
+	}
+	return sleep(ctx, delay)
+}
+
+// Block откладывает отправки на время d после 429. Из ответа не понять, какой лимит
+// превышен, поэтому ждут и чат chatID, и все остальные чаты.
+func (l *rateLimiter) Block(chatID int, d time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.global.block(now, d)
+	if chatID != 0 {
+		l.chat(chatID, now).block(now, d)
+	}
+}
+
+func (l *rateLimiter) reserve(chatID int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	return max(l.global.reserve(now), l.chat(chatID, now).reserve(now))
+}
+
+func (l *rateLimiter) chat(chatID int, now time.Time) *tokenBucket {
+	bucket, ok := l.chats[chatID]
+	if ok {
+		return bucket
+	}
+	if len(l.chats) >= maxChatBuckets {
+		l.evictIdle(now)
+	}
+	rate := l.limits.ChatRate
+	// отрицательный chat_id - группа или канал
+	if chatID < 0 {
+		rate = l.limits.GroupRate
+	}
+	bucket = newTokenBucket(rate, l.limits.ChatBurst, now)
+	l.chats[chatID] = bucket
+	return bucket
+}
+
+// evictIdle удаляет корзины чатов, которые успели полностью восстановиться.
+func (l *rateLimiter) evictIdle(now time.Time) {
+	for chatID, bucket := range l.chats {
+		if bucket.full(now) {
+			delete(l.chats, chatID)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
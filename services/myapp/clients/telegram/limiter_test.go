@@ -0,0 +1,77 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(limits Limits, now *time.Time) *rateLimiter {
+	l := newRateLimiter(limits)
+	l.now = func() time.Time { return *now }
+	l.global = newTokenBucket(limits.GlobalRate, limits.GlobalBurst, *now)
+	return l
+}
+
+func TestRateLimiter(t *testing.T) {
+	limits := Limits{GlobalRate: 30, GlobalBurst: 30, ChatRate: 1, GroupRate: 1.0 / 3, ChatBurst: 1}
+
+	t.Run("per chat", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		l := newTestLimiter(limits, &now)
+
+		require.Zero(t, l.reserve(1))
+		require.Equal(t, time.Second, l.reserve(1))
+		require.Equal(t, 2*time.Second, l.reserve(1))
+		// другой чат своей очереди не ждет
+		require.Zero(t, l.reserve(2))
+
+		now = now.Add(3 * time.Second)
+		require.Zero(t, l.reserve(1))
+	})
+
+	t.Run("group", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		l := newTestLimiter(limits, &now)
+
+		require.Zero(t, l.reserve(-100))
+		require.Equal(t, 3*time.Second, l.reserve(-100))
+	})
+
+	t.Run("global", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		l := newTestLimiter(Limits{GlobalRate: 2, GlobalBurst: 2, ChatRate: 100, ChatBurst: 100}, &now)
+
+		require.Zero(t, l.reserve(1))
+		require.Zero(t, l.reserve(2))
+		require.Equal(t, 500*time.Millisecond, l.reserve(3))
+	})
+
+	t.Run("block", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		l := newTestLimiter(limits, &now)
+
+		l.Block(1, 5*time.Second)
+		require.Equal(t, 6*time.Second, l.reserve(1))
+		// 429 останавливает и другие чаты
+		require.InDelta(t, 5*time.Second, l.reserve(2), float64(100*time.Millisecond))
+	})
+
+	t.Run("wait canceled", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		l := newTestLimiter(limits, &now)
+		l.Block(1, time.Hour)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, l.Wait(ctx, 1), context.Canceled)
+	})
+
+	t.Run("nil limiter", func(t *testing.T) {
+		var l *rateLimiter
+		require.NoError(t, l.Wait(context.Background(), 1))
+		l.Block(1, time.Second)
+	})
+}
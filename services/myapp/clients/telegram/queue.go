@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	bondreportservice "main.go/clients/bondReportService"
+)
+
+// DefaultMaxPending - сколько отправок может ждать в очереди одного чата.
+const DefaultMaxPending = 100
+
+var (
+	ErrQueueFull   = errors.New("telegram: send queue is full")
+	ErrQueueClosed = errors.New("telegram: send queue is closed")
+)
+
+// Sender - отправка сообщений в Bot API, ее выполняет Client.
+type Sender interface {
+	SendMessage(ctx context.Context, chatID int, text string) error
+	SendPreformatted(ctx context.Context, chatID int, text string) error
+	SendImageFromBuffer(ctx context.Context, chatID int, imageData []byte, caption string) error
+	SendMediaGroupFromBuffer(ctx context.Context, chatID int, images []*bondreportservice.ImageData) error
+}
+
+type sendJob struct {
+	ctx  context.Context
+	op   string
+	send func(ctx context.Context) error
+}
+
+// Queue - очередь отправки. Методы Send* только ставят сообщение в очередь своего чата
+// и сразу возвращаются, поэтому ожидание лимита одного чата не задерживает обработку
+// обновлений от других. У каждого чата с непустой очередью свой воркер: сообщения
+// уходят в порядке постановки, ошибки отправки пишутся в лог.
+type Queue struct {
+	logger     *slog.Logger
+	sender     Sender
+	maxPending int
+
+	mu     sync.Mutex
+	chats  map[int][]sendJob
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewQueue(logger *slog.Logger, sender Sender, maxPending int) *Queue {
+	if maxPending <= 0 {
+		maxPending = DefaultMaxPending
+	}
+	return &Queue{
+		logger:     logger,
+		sender:     sender,
+		maxPending: maxPending,
+		chats:      make(map[int][]sendJob),
+	}
+}
+
+func (q *Queue) SendMessage(ctx context.Context, chatID int, text string) error {
+	return q.enqueue(ctx, chatID, "telegram.Queue.SendMessage", func(ctx context.Context) error {
+		return q.sender.SendMessage(ctx, chatID, text)
+	})
+}
+
+func (q *Queue) SendPreformatted(ctx context.Context, chatID int, text string) error {
+	return q.enqueue(ctx, chatID, "telegram.Queue.SendPreformatted", func(ctx context.Context) error {
+		return q.sender.SendPreformatted(ctx, chatID, text)
+	})
+}
+
+func (q *Queue) SendImageFromBuffer(ctx context.Context, chatID int, imageData []byte, caption string) error {
+	return q.enqueue(ctx, chatID, "telegram.Queue.SendImageFromBuffer", func(ctx context.Context) error {
+		return q.sender.SendImageFromBuffer(ctx, chatID, imageData, caption)
+	})
+}
+
+func (q *Queue) SendMediaGroupFromBuffer(ctx context.Context, chatID int, images []*bondreportservice.ImageData) error {
+	if len(images) == 0 {
+		return errors.New("no images to send")
+	}
+	return q.enqueue(ctx, chatID, "telegram.Queue.SendMediaGroupFromBuffer", func(ctx context.Context) error {
+		return q.sender.SendMediaGroupFromBuffer(ctx, chatID, images)
+	})
+}
+
+// Close перестает принимать сообщения и ждет, пока уйдет уже поставленное, но не дольше ctx.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) enqueue(ctx context.Context, chatID int, op string, send func(ctx context.Context) error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	jobs, running := q.chats[chatID]
+	if len(jobs) >= q.maxPending {
+		return ErrQueueFull
+	}
+	// Обработка обновления закончится раньше отправки: отменять ее нельзя, trace id нужен в логах
+	q.chats[chatID] = append(jobs, sendJob{ctx: context.WithoutCancel(ctx), op: op, send: send})
+	if !running {
+		q.wg.Add(1)
+		go q.run(chatID)
+	}
+	return nil
+}
+
+// run отправляет сообщения чата по одному, пока очередь не опустеет.
+func (q *Queue) run(chatID int) {
+	defer q.wg.Done()
+	for {
+		job, ok := q.next(chatID)
+		if !ok {
+			return
+		}
+		if err := job.send(job.ctx); err != nil {
+			q.logger.ErrorContext(job.ctx, "failed to send message",
+				slog.String("op", job.op),
+				slog.Int("chat_id", chatID),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// next снимает первую отправку из очереди чата. Пустая очередь удаляется под той же
+// блокировкой, чтобы следующая постановка запустила новый воркер.
+func (q *Queue) next(chatID int) (sendJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := q.chats[chatID]
+	if len(jobs) == 0 {
+		delete(q.chats, chatID)
+		return sendJob{}, false
+	}
+	job := jobs[0]
+	jobs[0] = sendJob{}
+	if len(jobs) == 1 {
+		q.chats[chatID] = jobs[:0]
+	} else {
+		q.chats[chatID] = jobs[1:]
+	}
+	return job, true
+}
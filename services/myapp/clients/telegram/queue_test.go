@@ -0,0 +1,115 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	bondreportservice "main.go/clients/bondReportService"
+)
+
+// blockingSender держит отправки в чат blocked, пока не закрыт release.
+type blockingSender struct {
+	blocked int
+	release chan struct{}
+
+	mu   sync.Mutex
+	sent map[int][]string
+}
+
+func newBlockingSender(blocked int) *blockingSender {
+	return &blockingSender{blocked: blocked, release: make(chan struct{}), sent: make(map[int][]string)}
+}
+
+func (s *blockingSender) SendMessage(ctx context.Context, chatID int, text string) error {
+	if chatID == s.blocked {
+		<-s.release
+	}
+	if text == "fail" {
+		return errors.New("boom")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[chatID] = append(s.sent[chatID], text)
+	return nil
+}
+
+func (s *blockingSender) SendPreformatted(ctx context.Context, chatID int, text string) error {
+	return s.SendMessage(ctx, chatID, text)
+}
+
+func (s *blockingSender) SendImageFromBuffer(ctx context.Context, chatID int, imageData []byte, caption string) error {
+	return s.SendMessage(ctx, chatID, caption)
+}
+
+func (s *blockingSender) SendMediaGroupFromBuffer(ctx context.Context, chatID int, images []*bondreportservice.ImageData) error {
+	return s.SendMessage(ctx, chatID, images[0].Caption)
+}
+
+func (s *blockingSender) messages(chatID int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.sent[chatID]...)
+}
+
+func TestQueue(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("slow chat does not block others", func(t *testing.T) {
+		sender := newBlockingSender(1)
+		q := NewQueue(logger, sender, 10)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, q.SendMessage(ctx, 1, "a"))
+		require.NoError(t, q.SendMessage(ctx, 2, "b"))
+		// обработка обновления закончилась, отправка все равно доходит
+		cancel()
+
+		require.Eventually(t, func() bool { return len(sender.messages(2)) == 1 }, time.Second, time.Millisecond)
+		require.Empty(t, sender.messages(1))
+
+		close(sender.release)
+		require.NoError(t, q.Close(context.Background()))
+		require.Equal(t, []string{"a"}, sender.messages(1))
+	})
+
+	t.Run("keeps order within chat", func(t *testing.T) {
+		sender := newBlockingSender(1)
+		q := NewQueue(logger, sender, 10)
+
+		require.NoError(t, q.SendMessage(context.Background(), 1, "first"))
+		require.NoError(t, q.SendPreformatted(context.Background(), 1, "fail"))
+		require.NoError(t, q.SendImageFromBuffer(context.Background(), 1, nil, "second"))
+		require.NoError(t, q.SendMediaGroupFromBuffer(context.Background(), 1, []*bondreportservice.ImageData{{Caption: "third"}}))
+		close(sender.release)
+
+		require.NoError(t, q.Close(context.Background()))
+		require.Equal(t, []string{"first", "second", "third"}, sender.messages(1), "failed send does not stop the queue")
+	})
+
+	t.Run("full and closed", func(t *testing.T) {
+		sender := newBlockingSender(1)
+		q := NewQueue(logger, sender, 1)
+
+		// первое сообщение сразу забирает воркер, второе ждет в очереди
+		require.NoError(t, q.SendMessage(context.Background(), 1, "a"))
+		require.Eventually(t, func() bool {
+			return q.SendMessage(context.Background(), 1, "b") == nil
+		}, time.Second, time.Millisecond)
+		require.ErrorIs(t, q.SendMessage(context.Background(), 1, "c"), ErrQueueFull)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, q.Close(ctx), context.DeadlineExceeded)
+		require.ErrorIs(t, q.SendMessage(context.Background(), 2, "d"), ErrQueueClosed)
+
+		close(sender.release)
+		require.NoError(t, q.Close(context.Background()))
+		require.Equal(t, []string{"a", "b"}, sender.messages(1))
+	})
+}
@@ -20,13 +20,22 @@ import (
 	bondreportservice "main.go/clients/bondReportService"
 )
 
-const defaultTimeout = 10 * time.Second
+const (
+	defaultTimeout       = 10 * time.Second
+	defaultMaxRetries    = 3
+	defaultMaxRetryAfter = time.Minute
+)
+
+var ErrTooManyRequests = errors.New("telegram: too many requests")
 
 type Client struct {
-	logger   *slog.Logger
-	host     string
-	basePath string
-	client   http.Client
+	logger        *slog.Logger
+	host          string
+	basePath      string
+	client        http.Client
+	limiter       *rateLimiter
+	maxRetries    int
+	maxRetryAfter time.Duration
 }
 
 const (
@@ -37,11 +46,18 @@ const (
 )
 
 func New(logger *slog.Logger, host string, token string) *Client {
+	return NewWithLimits(logger, host, token, DefaultLimits())
+}
+
+func NewWithLimits(logger *slog.Logger, host string, token string, limits Limits) *Client {
 	return &Client{
-		logger:   logger,
-		host:     host,
-		basePath: newBasePath(token),
-		client:   http.Client{Timeout: defaultTimeout},
+		logger:        logger,
+		host:          host,
+		basePath:      newBasePath(token),
+		client:        http.Client{Timeout: defaultTimeout},
+		limiter:       newRateLimiter(limits),
+		maxRetries:    defaultMaxRetries,
+		maxRetryAfter: defaultMaxRetryAfter,
 	}
 }
 
@@ -58,7 +74,7 @@ func (c *Client) Updates(ctx context.Context, offset int, limit int) (updates []
 	q.Add("offset", strconv.Itoa(offset))
 	q.Add("limit", strconv.Itoa(limit))
 
-	data, err := c.doRequest(ctx, getUpdatesMethod, q)
+	data, err := c.doRequest(ctx, 0, getUpdatesMethod, q)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +88,36 @@ func (c *Client) Updates(ctx context.Context, offset int, limit int) (updates []
 	return res.Result, nil
 }
 
+// SendMessage отправляет текст без разметки. Длинный текст отправляется
+// несколькими сообщениями, разбитыми по границам строк.
 func (c *Client) SendMessage(ctx context.Context, chatID int, text string) error {
+	return c.SendMessageWithMode(ctx, chatID, text, ParseModeNone)
+}
+
+// SendMessageWithMode отправляет текст с разметкой mode. Текст разбивается
+// по строкам, поэтому теги и сущности разметки не должны переходить через
+// перевод строки.
+func (c *Client) SendMessageWithMode(ctx context.Context, chatID int, text string, mode ParseMode) error {
+	for _, chunk := range SplitMessage(text, MaxMessageLength) {
+		if err := c.sendMessage(ctx, chatID, chunk, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendPreformatted отправляет текст моноширинным блоком (например, таблицы).
+// Текст сначала разбивается на части, и каждая часть оборачивается в <pre> отдельно.
+func (c *Client) SendPreformatted(ctx context.Context, chatID int, text string) error {
+	for _, chunk := range SplitMessage(text, MaxMessageLength) {
+		if err := c.sendMessage(ctx, chatID, Pre(chunk, ParseModeHTML), ParseModeHTML); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) sendMessage(ctx context.Context, chatID int, text string, mode ParseMode) error {
 	const op = "telegram.SendMessage"
 	logg := c.logger.With(
 		slog.String("op", op),
@@ -81,45 +126,89 @@ func (c *Client) SendMessage(ctx context.Context, chatID int, text string) error
 	q := url.Values{}
 	q.Add("chat_id", strconv.Itoa(chatID))
 	q.Add("text", text)
+	if mode != ParseModeNone {
+		q.Add("parse_mode", string(mode))
+	}
 
-	_, err := c.doRequest(ctx, sendUpdateMethod, q)
+	if err := c.limiter.Wait(ctx, chatID); err != nil {
+		return e.Wrap("can`t send message", err)
+	}
+	_, err := c.doRequest(ctx, chatID, sendUpdateMethod, q)
 	if err != nil {
 		return e.Wrap("can`t send message", err)
 	}
 	return nil
 }
 
-func (c *Client) doRequest(ctx context.Context, method string, query url.Values) (data []byte, err error) {
+func (c *Client) doRequest(ctx context.Context, chatID int, method string, query url.Values) (data []byte, err error) {
 	defer func() { err = e.WrapIfErr("can`t do request", err) }()
 
-	const op = "telegram.doRequest"
-
 	u := url.URL{
 		Scheme: "https",
 		Host:   c.host,
 		Path:   path.Join(c.basePath, method),
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, parseErr(err, c.basePath)
-	}
+	return c.send(ctx, chatID, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, parseErr(err, c.basePath)
+		}
+		req.URL.RawQuery = query.Encode()
+		return req, nil
+	})
+}
 
-	req.URL.RawQuery = query.Encode()
+// send выполняет запрос к Bot API. На 429 ждет retry_after и повторяет запрос
+// (не больше maxRetries раз), остальные ответы с ok=false возвращает ошибкой.
+// newReq вызывается на каждую попытку, чтобы тело запроса читалось заново.
+func (c *Client) send(ctx context.Context, chatID int, newReq func() (*http.Request, error)) ([]byte, error) {
+	const op = "telegram.send"
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
 
-	defer func() { _ = resp.Body.Close() }()
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, parseErr(err, c.basePath)
+		}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
 
-	return body, nil
+		if resp.StatusCode == http.StatusOK {
+			return body, nil
+		}
+
+		var apiResp APIResponse
+		_ = json.Unmarshal(body, &apiResp)
+
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return nil, fmt.Errorf("telegram API error: %s - %s", resp.Status, apiResp.Description)
+		}
+
+		retryAfter := apiResp.retryAfter()
+		if attempt >= c.maxRetries || retryAfter > c.maxRetryAfter {
+			return nil, fmt.Errorf("%w: retry after %s", ErrTooManyRequests, retryAfter)
+		}
+
+		c.logger.WarnContext(ctx, "telegram rate limit exceeded",
+			slog.String("op", op),
+			slog.Int("chat_id", chatID),
+			slog.Duration("retry_after", retryAfter),
+			slog.Int("attempt", attempt+1),
+		)
+		c.limiter.Block(chatID, retryAfter)
+		if err := sleep(ctx, retryAfter); err != nil {
+			return nil, err
+		}
+	}
 }
 
 func parseErr(err error, token string) error {
@@ -162,7 +251,7 @@ func (c *Client) SendImageFromBuffer(ctx context.Context, chatID int, imageData
 
 	writer.Close()
 
-	_, err = c.doMultipartRequest(ctx, "sendPhoto", body, writer.FormDataContentType())
+	_, err = c.doMultipartRequest(ctx, chatID, sendPhotoMethod, body, writer.FormDataContentType())
 	return err
 }
 
@@ -225,7 +314,7 @@ func (c *Client) SendMediaGroupFromBuffer(ctx context.Context, chatID int, image
 
 	writer.Close()
 
-	_, err := c.doMultipartRequest(ctx, "sendMediaGroup", body, writer.FormDataContentType())
+	_, err := c.doMultipartRequest(ctx, chatID, sendMediaGroupMethod, body, writer.FormDataContentType())
 	if err != nil {
 		return fmt.Errorf("can't send media group: %v", err)
 	}
@@ -233,7 +322,7 @@ func (c *Client) SendMediaGroupFromBuffer(ctx context.Context, chatID int, image
 	return nil
 }
 
-func (c *Client) doMultipartRequest(ctx context.Context, method string, body *bytes.Buffer, contentType string) (data []byte, err error) {
+func (c *Client) doMultipartRequest(ctx context.Context, chatID int, method string, body *bytes.Buffer, contentType string) (data []byte, err error) {
 	defer func() { err = e.WrapIfErr("can't do multipart request", err) }()
 
 	const op = "telegram.doMultipartRequest"
@@ -253,28 +342,18 @@ func (c *Client) doMultipartRequest(ctx context.Context, method string, body *by
 		Path:   path.Join(c.basePath, method),
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
-	if err != nil {
-		return nil, parseErr(err, c.basePath)
-	}
-
-	req.Header.Set("Content-Type", contentType)
+	payload := body.Bytes()
 
-	resp, err := c.client.Do(req)
-	if err != nil {
+	if err := c.limiter.Wait(ctx, chatID); err != nil {
 		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	bodyResponse, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("telegram API error: %s - %s", resp.Status, string(bodyResponse))
-	}
-
-	return bodyResponse, nil
+	return c.send(ctx, chatID, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(payload))
+		if err != nil {
+			return nil, parseErr(err, c.basePath)
+		}
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
 }
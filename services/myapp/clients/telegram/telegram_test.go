@@ -1,8 +1,19 @@
 package telegram
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -42,3 +53,114 @@ func TestParseErr(t *testing.T) {
 		t.Log(got)
 	})
 }
+
+// newTestClient поднимает фейковый Bot API и возвращает клиента без ограничения скорости.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	return &Client{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		host:          u.Host,
+		basePath:      newBasePath("test"),
+		client:        *srv.Client(),
+		maxRetries:    defaultMaxRetries,
+		maxRetryAfter: defaultMaxRetryAfter,
+	}
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter int) {
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(APIResponse{
+		ErrorCode:   http.StatusTooManyRequests,
+		Description: "Too Many Requests: retry after " + strconv.Itoa(retryAfter),
+		Parameters:  &ResponseParameters{RetryAfter: retryAfter},
+	})
+}
+
+func TestSendMessageRetryAfter(t *testing.T) {
+	t.Run("retry after 429", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/bottest/sendMessage", r.URL.Path)
+			require.Equal(t, "hello", r.URL.Query().Get("text"))
+			if calls.Add(1) == 1 {
+				writeTooManyRequests(w, 1)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+		})
+		c.limiter = newRateLimiter(DefaultLimits())
+
+		start := time.Now()
+		require.NoError(t, c.SendMessage(context.Background(), 1, "hello"))
+		require.EqualValues(t, 2, calls.Load())
+		require.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("retry after too long", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeTooManyRequests(w, 3600)
+		})
+
+		err := c.SendMessage(context.Background(), 1, "hello")
+		require.ErrorIs(t, err, ErrTooManyRequests)
+		require.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeTooManyRequests(w, 1)
+		})
+		c.maxRetries = 1
+
+		err := c.SendMessage(context.Background(), 1, "hello")
+		require.ErrorIs(t, err, ErrTooManyRequests)
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+		})
+
+		err := c.SendMessage(context.Background(), 1, "hello")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrTooManyRequests)
+		require.Contains(t, err.Error(), "chat not found")
+	})
+}
+
+func TestSendImageRetryResendsBody(t *testing.T) {
+	image := bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 1024)
+
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/bottest/sendPhoto", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		file, _, err := r.FormFile("photo")
+		require.NoError(t, err)
+		got, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, image, got)
+
+		if calls.Add(1) == 1 {
+			writeTooManyRequests(w, 0)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	})
+
+	require.NoError(t, c.SendImageFromBuffer(context.Background(), 1, image, "caption"))
+	require.EqualValues(t, 2, calls.Load())
+}
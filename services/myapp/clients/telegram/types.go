@@ -1,5 +1,7 @@
 package telegram
 
+import "time"
+
 type UpdatesResponse struct {
 	Ok     bool     `json:"ok"`
 	Result []Update `json:"result"`
@@ -17,9 +19,30 @@ type IncomingMessage struct {
 }
 
 type From struct {
-	Username string `json:"username"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
 }
 
 type Chat struct {
 	ID int `json:"id"`
 }
+
+// APIResponse - общая обертка ответа Bot API. Parameters приходит, например, с 429.
+type APIResponse struct {
+	Ok          bool                `json:"ok"`
+	ErrorCode   int                 `json:"error_code,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
+}
+
+type ResponseParameters struct {
+	RetryAfter      int   `json:"retry_after,omitempty"`
+	MigrateToChatID int64 `json:"migrate_to_chat_id,omitempty"`
+}
+
+func (r APIResponse) retryAfter() time.Duration {
+	if r.Parameters == nil || r.Parameters.RetryAfter <= 0 {
+		return time.Second
+	}
+	return time.Duration(r.Parameters.RetryAfter) * time.Second
+}
@@ -2,6 +2,8 @@ package tinkoffApi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -12,10 +14,18 @@ import (
 
 	httpheaders "github.com/gladinov/contracts/http"
 	"github.com/gladinov/contracts/trace"
+	"github.com/gladinov/valuefromcontext"
 )
 
 const defaultTimeout = 10 * time.Second
 
+var (
+	// ErrUnauthorized - Invest API не принял токен.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrSandboxUnavailable - песочница выключена в конфигурации tinkoffApi.
+	ErrSandboxUnavailable = errors.New("sandbox is unavailable")
+)
+
 type Client struct {
 	logger *slog.Logger
 	host   string
@@ -32,13 +42,20 @@ func NewClient(logger *slog.Logger, host string) *Client {
 	}
 }
 
-func (c *Client) CheckToken(ctx context.Context, tokenInBase64 string) error {
-	const op = "tinkoffApi.CheckToken"
+// GetTokenScope проверяет токен и возвращает его права. Неверный токен - ErrUnauthorized.
+func (c *Client) GetTokenScope(ctx context.Context, tokenInBase64 string) (TokenScope, error) {
+	const op = "tinkoffApi.GetTokenScope"
 
 	start := time.Now()
 	logg := c.logger.With(slog.String("op", op))
 	logg.DebugContext(ctx, "start")
-	Path := path.Join("tinkoff", "checktoken")
+	defer func() {
+		logg.InfoContext(ctx, "finished",
+			slog.Duration("duration", time.Since(start)),
+		)
+	}()
+
+	Path := path.Join("tinkoff", "tokenscope")
 
 	u := url.URL{
 		Scheme: "http",
@@ -48,7 +65,7 @@ func (c *Client) CheckToken(ctx context.Context, tokenInBase64 string) error {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return fmt.Errorf("op:%s, could not create http.NewRequest", op)
+		return TokenScope{}, fmt.Errorf("op:%s, could not create http.NewRequest", op)
 	}
 
 	req.Header.Set(httpheaders.HeaderEncryptedToken, tokenInBase64)
@@ -62,22 +79,81 @@ func (c *Client) CheckToken(ctx context.Context, tokenInBase64 string) error {
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("op:%s, err in client.Do", op)
+		return TokenScope{}, fmt.Errorf("op:%s, err in client.Do", op)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return TokenScope{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return TokenScope{}, fmt.Errorf("%s: %w", op, ErrUnauthorized)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return TokenScope{}, fmt.Errorf("%s:unexpected responce status code %d", op, resp.StatusCode)
 	}
 
-	body, _ := io.ReadAll(resp.Body)
+	var scope TokenScope
+	err = json.Unmarshal(body, &scope)
+	if err != nil {
+		return TokenScope{}, fmt.Errorf("%s: %w", op, err)
+	}
 
-	defer func() { _ = resp.Body.Close() }()
+	return scope, nil
+}
+
+// SetSandboxMode включает или выключает песочницу Invest API для токена пользователя из ctx.
+// Пока режим включен, отчеты строятся по счетам песочницы.
+func (c *Client) SetSandboxMode(ctx context.Context, enabled bool) error {
+	const op = "tinkoffApi.SetSandboxMode"
+
+	start := time.Now()
+	logg := c.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start", slog.Bool("sandbox", enabled))
 	defer func() {
 		logg.InfoContext(ctx, "finished",
 			slog.Duration("duration", time.Since(start)),
-			slog.Int("code", resp.StatusCode),
-			slog.String("body", string(body)),
 		)
 	}()
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("%s:unexpected responce status code", op)
+
+	chatID, err := valuefromcontext.GetChatIDFromCtxStr(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	u := url.URL{
+		Scheme: "http",
+		Host:   c.host,
+		Path:   path.Join("tinkoff", "sandbox", "mode"),
+	}
+	method := http.MethodDelete
+	if enabled {
+		method = http.MethodPut
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("op:%s, could not create http.NewRequest", op)
+	}
+	req.Header.Set(httpheaders.HeaderChatID, chatID)
+	if traceID, ok := trace.TraceIDFromContext(ctx); ok {
+		req.Header.Set(httpheaders.HeaderTraceID, traceID)
 	}
 
-	return nil
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("op:%s, err in client.Do", op)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusBadRequest:
+		return fmt.Errorf("%s: %w", op, ErrSandboxUnavailable)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%s: %w", op, ErrUnauthorized)
+	default:
+		return fmt.Errorf("%s:unexpected responce status code %d", op, resp.StatusCode)
+	}
 }
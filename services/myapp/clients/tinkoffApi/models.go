@@ -213,3 +213,23 @@ type ShareCurrencyByResponse struct {
 type ShareCurrencyByRequest struct {
 	Figi string `json:"figi,omitempty"`
 }
+
+const (
+	AccessLevelFullAccess = "full_access"
+	AccessLevelReadOnly   = "read_only"
+	AccessLevelNoAccess   = "no_access"
+)
+
+type TokenScope struct {
+	AccessLevel string              `json:"accessLevel,omitempty"`
+	ReadOnly    bool                `json:"readOnly"`
+	Tariff      string              `json:"tariff,omitempty"`
+	QualStatus  bool                `json:"qualStatus,omitempty"`
+	Accounts    []TokenScopeAccount `json:"accounts,omitempty"`
+}
+
+type TokenScopeAccount struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	AccessLevel string `json:"accessLevel,omitempty"`
+}
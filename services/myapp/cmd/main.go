@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gladinov/cryptotoken"
 	"github.com/gladinov/mylogger"
@@ -16,13 +17,16 @@ import (
 	event_consumer "main.go/internal/app/consumer/event-consumer"
 	"main.go/internal/app/events/telegram"
 	"main.go/internal/config"
+	"main.go/internal/dialog"
 	storage "main.go/internal/repository"
-	"main.go/internal/repository/redis"
+	redisRepo "main.go/internal/repository/redis"
 	tokenauth "main.go/internal/tokenAuth"
 )
 
 const (
 	batchSize = 100
+	// sendQueueCloseTimeout - сколько ждать отправки уже поставленных сообщений при остановке.
+	sendQueueCloseTimeout = 10 * time.Second
 )
 
 func main() {
@@ -40,7 +44,7 @@ func main() {
 	)
 
 	logg.Info("initialize Redis client", slog.String("addres", conf.RedisHTTPServer.GetAddress()))
-	redis, err := redis.NewClient(ctx, conf)
+	redis, err := redisRepo.NewClient(ctx, conf)
 	if err != nil {
 		logg.Error("haven't connect with redis", slog.String("err", err.Error()))
 		return
@@ -51,6 +55,14 @@ func main() {
 
 	logg.Info("initialize Telegram client", slog.String("addres", conf.ClientsHosts.TelegramHost))
 	telegrammClient := tgClient.New(logg, conf.ClientsHosts.TelegramHost, conf.Token)
+	sendQueue := tgClient.NewQueue(logg, telegrammClient, tgClient.DefaultMaxPending)
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), sendQueueCloseTimeout)
+		defer cancel()
+		if err := sendQueue.Close(closeCtx); err != nil {
+			logg.Warn("send queue is not drained", slog.String("err", err.Error()))
+		}
+	}()
 
 	logg.Info("initialize Tinkoff client", slog.String("addres", conf.ClientsHosts.GetTinkoffApiAddress()))
 	tinkoffApiClient := tinkoffapi.NewClient(logg, conf.ClientsHosts.GetTinkoffApiAddress())
@@ -79,15 +91,24 @@ func main() {
 		redis, // TODO: Переместить redis cashe из слоя service в слой repo
 		userStorage,
 		tinkoffApiClient,
-		tokenCrypter)
+		tokenCrypter,
+		tokenauth.TokenPolicy(conf.TokenPolicy))
+
+	logg.Info("initialize dialog manager", slog.Duration("ttl", conf.DialogTTL))
+	dialogManager := dialog.NewManager(
+		redisRepo.NewDialogStore(redis, conf.DialogTTL),
+		telegram.Flows()...,
+	)
 
 	logg.Info("initialize Processor")
 	processor := telegram.NewProccesor(
 		logg,
-		telegrammClient,
+		sendQueue,
 		tinkoffApiClient,
 		bondReportServiceClient,
 		tokenAuthService,
+		dialogManager,
+		redisRepo.NewLangStore(redis),
 	)
 
 	logg.Info("initialize Fetcher")
@@ -9,6 +9,9 @@ import (
 
 	contextkeys "github.com/gladinov/contracts/context"
 	"github.com/gladinov/e"
+	bondreportservice "main.go/clients/bondReportService"
+	"main.go/clients/tinkoffApi"
+	"main.go/internal/i18n"
 	tokenauth "main.go/internal/tokenAuth"
 )
 
@@ -22,6 +25,14 @@ const (
 	GetPortfolioStructure      = "/portfoliostructure"
 	GetUnionPortfolioStructure = "/unionportfoliostructure"
 	GetUnionWithSber           = "/unionpswithsber"
+	CashCmd                    = "/cash"
+	DividendsCmd               = "/dividends"
+	ChartCmd                   = "/chart"
+	ScreenCmd                  = "/screen"
+	ScenarioCmd                = "/scenario"
+	ReportCmd                  = "/report"
+	LangCmd                    = "/lang"
+	SandboxCmd                 = "/sandbox"
 )
 
 type TokenStatus int
@@ -42,6 +53,14 @@ var constList = []string{
 	GetPortfolioStructure,
 	GetUnionPortfolioStructure,
 	GetUnionWithSber,
+	CashCmd,
+	DividendsCmd,
+	ChartCmd,
+	ScreenCmd,
+	ScenarioCmd,
+	ReportCmd,
+	LangCmd,
+	SandboxCmd,
 }
 
 func ContainsInConstantCommands(text string) bool {
@@ -55,6 +74,25 @@ func ContainsInConstantCommands(text string) bool {
 
 var ErrIncorrectToken = errors.New("incorrect token")
 
+// serviceErrorMsg подбирает сообщение пользователю по коду ошибки bonds-report-service.
+// Для внутренних ошибок сообщения нет.
+func serviceErrorMsg(err error) (i18n.MsgID, bool) {
+	switch {
+	case errors.Is(err, bondreportservice.ErrUnauthorized):
+		return i18n.MsgErrUnauthorized, true
+	case errors.Is(err, bondreportservice.ErrNotFound):
+		return i18n.MsgErrNotFound, true
+	case errors.Is(err, bondreportservice.ErrRateLimited):
+		return i18n.MsgErrRateLimited, true
+	case errors.Is(err, bondreportservice.ErrInvalidArgument):
+		return i18n.MsgErrInvalidArgument, true
+	case errors.Is(err, bondreportservice.ErrUnavailable):
+		return i18n.MsgErrUnavailable, true
+	default:
+		return "", false
+	}
+}
+
 func (p *Processor) doCmd(ctx context.Context, text string, chatID int, username string) error {
 	const op = "telegram.doCmd"
 
@@ -86,20 +124,70 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, username
 		return p.sendHello(ctx, chatID)
 	}
 
+	// язык меняется без токена и не прерывает текущий диалог
+	if code, ok := strings.CutPrefix(text, LangCmd+" "); ok {
+		return p.setLang(ctx, chatID, code)
+	}
+
+	if err := p.cancelDialogOnCommand(ctx, chatID, text); err != nil {
+		return err
+	}
+
+	// выбор языка доступен без токена
+	if text == LangCmd {
+		return p.startDialog(ctx, chatID, langFlow)
+	}
+	flow, err := p.dialogs.Active(ctx, chatID)
+	if err != nil {
+		return e.WrapIfErr("processor: can't get dialog", err)
+	}
+	if flow == langFlow {
+		_, err = p.handleDialog(ctx, chatID, text)
+		return err
+	}
+
 	tokenStatus, err := p.tokenAuthService.Auth(ctx, text, username)
 
 	switch {
-	case err != nil && !errors.Is(err, tokenauth.ErrIncorrectToken):
-		return err
 	case errors.Is(err, tokenauth.ErrIncorrectToken):
-		return p.tg.SendMessage(ctx, chatID, msgNoToken)
-	case err == nil:
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgNoToken))
+	case errors.Is(err, tokenauth.ErrFullAccessToken):
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgFullAccessToken))
+	case errors.Is(err, tokenauth.ErrNoAccessToken):
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgNoAccessToken))
+	case err != nil:
+		return err
+	default:
 		switch tokenStatus {
 		case tokenauth.TokenInserted:
-			return p.tg.SendMessage(ctx, chatID, msgTrueToken)
+			return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgTrueToken))
+		case tokenauth.TokenInsertedFullAccess:
+			return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgTrueToken)+"\n\n"+tr(ctx, i18n.MsgFullAccessTokenWarning))
 		}
 	}
 
+	// ответы на вопросы диалогов принимаются только от пользователей с токеном
+	handled, err := p.handleDialog(ctx, chatID, text)
+	if err != nil {
+		return err
+	}
+	if handled {
+		return nil
+	}
+
+	if args, ok := commandArgs(text, ChartCmd); ok {
+		return p.getChart(ctx, chatID, args)
+	}
+	if args, ok := commandArgs(text, ScreenCmd); ok {
+		return p.getScreen(ctx, chatID, args)
+	}
+	if args, ok := commandArgs(text, ScenarioCmd); ok {
+		return p.getScenario(ctx, chatID, args)
+	}
+	if args, ok := commandArgs(text, SandboxCmd); ok {
+		return p.setSandboxMode(ctx, chatID, args)
+	}
+
 	switch text {
 	case HelpCmd:
 		return p.sendHelp(ctx, chatID)
@@ -108,7 +196,7 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, username
 	case GetBondReport:
 		return p.getBondReports(ctx, chatID)
 	case GetGeneralBondReport:
-		return p.getBondRepotsWithPng(ctx, chatID)
+		return p.getBondRepotsWithPng(ctx, chatID, "")
 	case GetUSD:
 		return p.getUSD(ctx, chatID)
 	case GetPortfolioStructure:
@@ -117,8 +205,14 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, username
 		return p.GetUnionPortfolioStructure(ctx, chatID)
 	case GetUnionWithSber:
 		return p.GetUnionPortfolioStructureWithSber(ctx, chatID)
+	case CashCmd:
+		return p.getCash(ctx, chatID)
+	case DividendsCmd:
+		return p.getDividends(ctx, chatID)
+	case ReportCmd:
+		return p.startDialog(ctx, chatID, reportFlow)
 	default:
-		return p.tg.SendMessage(ctx, chatID, msgUnknownCommand)
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgUnknownCommand))
 	}
 }
 
@@ -138,8 +232,7 @@ func (p *Processor) sendAccounts(ctx context.Context, chatID int) error {
 		return e.WrapIfErr("can't get account", err)
 	}
 	accounts := accountsResponce.Accounts
-	p.tg.SendMessage(ctx, chatID, accounts)
-	return nil
+	return p.tg.SendMessage(ctx, chatID, accounts)
 }
 
 func (p *Processor) getBondReports(ctx context.Context, chatID int) (err error) {
@@ -147,12 +240,12 @@ func (p *Processor) getBondReports(ctx context.Context, chatID int) (err error)
 		return e.WrapIfErr("getBondReport: can't get Bond reports", err)
 	}
 
-	p.tg.SendMessage(ctx, chatID, "Отчет по облигациям по методу FIFO успешно сохранен в базу данных")
+	p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgBondFifoSaved))
 	return nil
 }
 
-func (p *Processor) getBondRepotsWithPng(ctx context.Context, chatID int) (err error) {
-	bondReportsResponce, err := p.bondReportService.GetBondReports(ctx)
+func (p *Processor) getBondRepotsWithPng(ctx context.Context, chatID int, accountID string) (err error) {
+	bondReportsResponce, err := p.bondReportService.GetBondReports(ctx, accountID)
 	if err != nil {
 		return e.WrapIfErr("can't get bond report with png", err)
 	}
@@ -184,7 +277,9 @@ func (p *Processor) GetPortfolioStructure(ctx context.Context, chatID int) (err
 		return e.WrapIfErr("can't get portfolio structure", err)
 	}
 	for _, report := range portfolioStructures.PortfolioStructures {
-		p.tg.SendMessage(ctx, chatID, report)
+		if err = p.tg.SendPreformatted(ctx, chatID, report); err != nil {
+			return e.WrapIfErr("can't send portfolio structure", err)
+		}
 	}
 	return nil
 }
@@ -195,8 +290,7 @@ func (p *Processor) GetUnionPortfolioStructure(ctx context.Context, chatID int)
 		return e.WrapIfErr("processor: can't get union portfolio structure", err)
 	}
 	unionPortfolioStructure := unionPortfolioStructureResponce.Report
-	p.tg.SendMessage(ctx, chatID, unionPortfolioStructure)
-	return nil
+	return p.tg.SendPreformatted(ctx, chatID, unionPortfolioStructure)
 }
 
 func (p *Processor) GetUnionPortfolioStructureWithSber(ctx context.Context, chatID int) (err error) {
@@ -205,14 +299,139 @@ func (p *Processor) GetUnionPortfolioStructureWithSber(ctx context.Context, chat
 		return e.WrapIfErr("processor: can't get union portfolio structure", err)
 	}
 	unionPortfolioStructure := unionPortfolioStructureResponce.Report
-	p.tg.SendMessage(ctx, chatID, unionPortfolioStructure)
-	return nil
+	return p.tg.SendPreformatted(ctx, chatID, unionPortfolioStructure)
+}
+
+func (p *Processor) getCash(ctx context.Context, chatID int) (err error) {
+	cashResponce, err := p.bondReportService.GetCash(ctx)
+	if err != nil {
+		return e.WrapIfErr("processor: can't get cash", err)
+	}
+	return p.tg.SendPreformatted(ctx, chatID, cashResponce.Report)
+}
+
+func (p *Processor) getDividends(ctx context.Context, chatID int) (err error) {
+	dividendsResponce, err := p.bondReportService.GetDividends(ctx)
+	if err != nil {
+		return e.WrapIfErr("processor: can't get dividends", err)
+	}
+	return p.tg.SendPreformatted(ctx, chatID, dividendsResponce.Report)
+}
+
+// chartPeriods - периоды, которые понимает bonds-report-service.
+var chartPeriods = map[string]bool{"1m": true, "3m": true, "6m": true, "1y": true, "3y": true, "5y": true}
+
+// getChart отправляет график облигации: /chart <тикер> [период].
+func (p *Processor) getChart(ctx context.Context, chatID int, args []string) (err error) {
+	if len(args) == 0 || len(args) > 2 {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgChartUsage))
+	}
+	ticker := strings.ToUpper(args[0])
+	var period string
+	if len(args) == 2 {
+		period = strings.ToLower(args[1])
+		if !chartPeriods[period] {
+			return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgChartUsage))
+		}
+	}
+
+	chart, err := p.bondReportService.GetBondChart(ctx, ticker, period)
+	if err != nil {
+		return e.WrapIfErr("processor: can't get bond chart", err)
+	}
+	return p.tg.SendImageFromBuffer(ctx, chatID, chart.Data, chart.Caption)
+}
+
+// screenFilterKeys - параметры фильтра скринера, которые понимает bonds-report-service.
+var screenFilterKeys = map[string]bool{
+	"ytm": true, "dur": true, "mat": true, "coupon": true,
+	"cur": true, "level": true, "board": true, "limit": true,
+}
+
+// getScreen отправляет таблицу облигаций по фильтру: /screen [ключ=значение ...].
+// Значения проверяет сервис, а на его отказ бот отвечает подсказкой по синтаксису.
+func (p *Processor) getScreen(ctx context.Context, chatID int, args []string) (err error) {
+	filter := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		key = strings.ToLower(key)
+		if !ok || value == "" || !screenFilterKeys[key] {
+			return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgScreenUsage))
+		}
+		filter[key] = value
+	}
+
+	screener, err := p.bondReportService.GetBondScreener(ctx, filter)
+	if errors.Is(err, bondreportservice.ErrInvalidArgument) {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgScreenUsage))
+	}
+	if err != nil {
+		return e.WrapIfErr("processor: can't get bond screener", err)
+	}
+	return p.tg.SendPreformatted(ctx, chatID, screener.Report)
+}
+
+// getScenario отправляет таблицу изменения стоимости облигаций: /scenario [сдвиг].
+// Сдвиг проверяет сервис, а на его отказ бот отвечает подсказкой.
+func (p *Processor) getScenario(ctx context.Context, chatID int, args []string) (err error) {
+	if len(args) > 1 {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgScenarioUsage))
+	}
+	var shift string
+	if len(args) == 1 {
+		shift = args[0]
+	}
+
+	scenario, err := p.bondReportService.GetRateScenario(ctx, shift)
+	if errors.Is(err, bondreportservice.ErrInvalidArgument) {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgScenarioUsage))
+	}
+	if err != nil {
+		return e.WrapIfErr("processor: can't get rate scenario", err)
+	}
+	return p.tg.SendImageFromBuffer(ctx, chatID, scenario.Data, scenario.Caption)
+}
+
+// setSandboxMode переключает отчеты пользователя на песочницу Invest API и обратно: /sandbox on|off.
+func (p *Processor) setSandboxMode(ctx context.Context, chatID int, args []string) error {
+	if len(args) != 1 {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgSandboxUsage))
+	}
+	var enabled bool
+	switch strings.ToLower(args[0]) {
+	case "on":
+		enabled = true
+	case "off":
+	default:
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgSandboxUsage))
+	}
+
+	err := p.tinkoffApi.SetSandboxMode(ctx, enabled)
+	if errors.Is(err, tinkoffApi.ErrSandboxUnavailable) {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgSandboxUnavailable))
+	}
+	if err != nil {
+		return e.WrapIfErr("processor: can't set sandbox mode", err)
+	}
+	if enabled {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgSandboxOn))
+	}
+	return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgSandboxOff))
+}
+
+// commandArgs возвращает аргументы команды cmd, если text - эта команда с аргументами или без.
+func commandArgs(text, cmd string) ([]string, bool) {
+	rest, ok := strings.CutPrefix(text, cmd)
+	if !ok || (rest != "" && rest[0] != ' ') {
+		return nil, false
+	}
+	return strings.Fields(rest), true
 }
 
 func (p *Processor) sendHelp(ctx context.Context, chatID int) error {
-	return p.tg.SendMessage(ctx, chatID, msgHelp)
+	return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgHelp))
 }
 
 func (p *Processor) sendHello(ctx context.Context, chatID int) error {
-	return p.tg.SendMessage(ctx, chatID, msgHello)
+	return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgHello))
 }
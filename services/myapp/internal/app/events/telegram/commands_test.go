@@ -0,0 +1,186 @@
+//go:build unit
+
+package telegram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contextkeys "github.com/gladinov/contracts/context"
+	httpheaders "github.com/gladinov/contracts/http"
+	"github.com/stretchr/testify/require"
+	bondreportservice "main.go/clients/bondReportService"
+	"main.go/internal/i18n"
+)
+
+func TestServiceErrorMsg(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = io.WriteString(w, `{"error":"could not get union portfolio structure","code":"rate_limited"}`)
+	}))
+	defer srv.Close()
+
+	p, _, _ := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+	err := p.GetUnionPortfolioStructure(ctx, chatID)
+	require.ErrorIs(t, err, bondreportservice.ErrRateLimited)
+
+	msg, ok := serviceErrorMsg(err)
+	require.True(t, ok)
+	require.Equal(t, i18n.MsgErrRateLimited, msg)
+
+	_, ok = serviceErrorMsg(errors.New("boom"))
+	require.False(t, ok, "internal errors have no user message")
+}
+
+func TestCommandArgs(t *testing.T) {
+	args, ok := commandArgs("/chart  SU26238RMFS4 3y", ChartCmd)
+	require.True(t, ok)
+	require.Equal(t, []string{"SU26238RMFS4", "3y"}, args)
+
+	args, ok = commandArgs("/chart", ChartCmd)
+	require.True(t, ok)
+	require.Empty(t, args)
+
+	_, ok = commandArgs("/charts", ChartCmd)
+	require.False(t, ok)
+}
+
+func TestGetChart(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/bondReportService/getBondChart", r.URL.Path)
+		gotQuery = r.URL.RawQuery
+		_, _ = io.WriteString(w, `{"name":"chart_SU26238RMFS4","data":"iVBORw==","caption":"ОФЗ 26238 (SU26238RMFS4): цена и доходность"}`)
+	}))
+	defer srv.Close()
+
+	p, tg, _ := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+
+	require.NoError(t, p.getChart(ctx, chatID, []string{"su26238rmfs4", "3Y"}))
+	require.Equal(t, "period=3y&ticker=SU26238RMFS4", gotQuery)
+	require.Equal(t, "ОФЗ 26238 (SU26238RMFS4): цена и доходность", tg.last())
+
+	require.NoError(t, p.getChart(ctx, chatID, []string{"SU26238RMFS4"}))
+	require.Equal(t, "ticker=SU26238RMFS4", gotQuery)
+
+	for _, args := range [][]string{nil, {"SU26238RMFS4", "2w"}, {"A", "1y", "extra"}} {
+		require.NoError(t, p.getChart(ctx, chatID, args))
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgChartUsage), tg.last(), args)
+	}
+}
+
+func TestGetScreen(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/bondReportService/getBondScreener", r.URL.Path)
+		gotQuery = r.URL.RawQuery
+		if r.URL.Query().Get("ytm") == "20-10" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid screener filter","code":"invalid_argument"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"report":"screener table"}`)
+	}))
+	defer srv.Close()
+
+	p, tg, _ := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+
+	require.NoError(t, p.getScreen(ctx, chatID, []string{"YTM=14-", "coupon=fixed"}))
+	require.Equal(t, "coupon=fixed&ytm=14-", gotQuery)
+	require.Equal(t, "screener table", tg.last())
+
+	require.NoError(t, p.getScreen(ctx, chatID, nil))
+	require.Equal(t, "", gotQuery)
+
+	for _, args := range [][]string{{"ytm"}, {"rating=AAA"}, {"ytm="}, {"ytm=20-10"}} {
+		require.NoError(t, p.getScreen(ctx, chatID, args))
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgScreenUsage), tg.last(), args)
+	}
+}
+
+func TestGetScenario(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/bondReportService/getRateScenario", r.URL.Path)
+		gotQuery = r.URL.RawQuery
+		if r.URL.Query().Get("shift") == "abc" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":"invalid rate shift","code":"invalid_argument"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"name":"scenario","data":"iVBORw==","caption":"Облигации при изменении ставок на +100 б.п."}`)
+	}))
+	defer srv.Close()
+
+	p, tg, _ := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+
+	require.NoError(t, p.getScenario(ctx, chatID, []string{"+100bp"}))
+	require.Equal(t, "shift=%2B100bp", gotQuery)
+	require.Equal(t, "Облигации при изменении ставок на +100 б.п.", tg.last())
+
+	require.NoError(t, p.getScenario(ctx, chatID, nil))
+	require.Equal(t, "", gotQuery)
+
+	for _, args := range [][]string{{"abc"}, {"+100bp", "-50bp"}} {
+		require.NoError(t, p.getScenario(ctx, chatID, args))
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgScenarioUsage), tg.last(), args)
+	}
+}
+
+func TestSetSandboxMode(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	var gotMethod string
+	disabled := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/tinkoff/sandbox/mode", r.URL.Path)
+		require.Equal(t, "7", r.Header.Get(httpheaders.HeaderChatID))
+		gotMethod = r.Method
+		if disabled {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"code":"invalid_argument","message":"sandbox is disabled"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"sandbox":true}`)
+	}))
+	defer srv.Close()
+
+	p, tg, _ := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+
+	require.NoError(t, p.setSandboxMode(ctx, chatID, []string{"ON"}))
+	require.Equal(t, http.MethodPut, gotMethod)
+	require.Equal(t, i18n.T(i18n.RU, i18n.MsgSandboxOn), tg.last())
+
+	require.NoError(t, p.setSandboxMode(ctx, chatID, []string{"off"}))
+	require.Equal(t, http.MethodDelete, gotMethod)
+	require.Equal(t, i18n.T(i18n.RU, i18n.MsgSandboxOff), tg.last())
+
+	disabled = true
+	require.NoError(t, p.setSandboxMode(ctx, chatID, []string{"on"}))
+	require.Equal(t, i18n.T(i18n.RU, i18n.MsgSandboxUnavailable), tg.last())
+
+	gotMethod = ""
+	for _, args := range [][]string{nil, {"yes"}, {"on", "off"}} {
+		require.NoError(t, p.setSandboxMode(ctx, chatID, args))
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgSandboxUsage), tg.last(), args)
+	}
+	require.Empty(t, gotMethod, "usage errors do not reach tinkoffApi")
+}
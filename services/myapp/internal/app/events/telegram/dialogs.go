@@ -0,0 +1,251 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"main.go/internal/dialog"
+	"main.go/internal/i18n"
+)
+
+const (
+	reportFlow = "report"
+	langFlow   = "lang"
+
+	reportStep  = "report"
+	formatStep  = "format"
+	accountStep = "account"
+	langStep    = "lang"
+
+	reportKey  = "report"
+	formatKey  = "format"
+	accountKey = "account"
+	langKey    = "lang"
+
+	// accountDataPrefix - ключи State.Data с открытыми счетами: id счета -> название.
+	accountDataPrefix = "account:"
+	allAccounts       = "all"
+
+	reportBonds     = "bonds"
+	reportStructure = "structure"
+
+	formatPng        = "png"
+	formatFifo       = "fifo"
+	formatByAccounts = "accounts"
+	formatUnion      = "union"
+	formatUnionSber  = "sber"
+)
+
+// Flows возвращает все диалоги бота для регистрации в dialog.Manager.
+func Flows() []dialog.Flow {
+	return []dialog.Flow{
+		newReportFlow(),
+		newLangFlow(),
+	}
+}
+
+// newReportFlow - выбор отчета по шагам: отчет -> формат -> счет.
+// Счет выбирается только для таблиц по облигациям и только если счетов больше одного.
+func newReportFlow() dialog.Flow {
+	return dialog.Flow{
+		Name:  reportFlow,
+		First: reportStep,
+		Steps: map[string]dialog.Step{
+			reportStep: {
+				Key:    reportKey,
+				Prompt: i18n.MsgChooseReport,
+				Options: func(dialog.State) []dialog.Option {
+					return []dialog.Option{
+						{Value: reportBonds, Title: i18n.MsgOptionBonds},
+						{Value: reportStructure, Title: i18n.MsgOptionStructure},
+					}
+				},
+				Next: func(dialog.State) string { return formatStep },
+			},
+			formatStep: {
+				Key:    formatKey,
+				Prompt: i18n.MsgChooseFormat,
+				Options: func(state dialog.State) []dialog.Option {
+					switch state.Data[reportKey] {
+					case reportBonds:
+						return []dialog.Option{
+							{Value: formatPng, Title: i18n.MsgOptionPng},
+							{Value: formatFifo, Title: i18n.MsgOptionFifo},
+						}
+					default:
+						return []dialog.Option{
+							{Value: formatByAccounts, Title: i18n.MsgOptionByAccounts},
+							{Value: formatUnion, Title: i18n.MsgOptionUnion},
+							{Value: formatUnionSber, Title: i18n.MsgOptionUnionSber},
+						}
+					}
+				},
+				Next: func(state dialog.State) string {
+					if state.Data[formatKey] == formatPng && len(accountOptions(state)) > 2 {
+						return accountStep
+					}
+					return ""
+				},
+			},
+			accountStep: {
+				Key:     accountKey,
+				Prompt:  i18n.MsgChooseAccount,
+				Options: accountOptions,
+				Next:    func(dialog.State) string { return "" },
+			},
+		},
+	}
+}
+
+// accountOptions - все счета и каждый открытый счет по названию.
+// Названия счетов задает пользователь, поэтому они не переводятся.
+func accountOptions(state dialog.State) []dialog.Option {
+	var accounts []dialog.Option
+	for key, name := range state.Data {
+		if id, ok := strings.CutPrefix(key, accountDataPrefix); ok {
+			accounts = append(accounts, dialog.Option{Value: id, Label: name})
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Label != accounts[j].Label {
+			return accounts[i].Label < accounts[j].Label
+		}
+		return accounts[i].Value < accounts[j].Value
+	})
+	return append([]dialog.Option{{Value: allAccounts, Title: i18n.MsgOptionAllAccount}}, accounts...)
+}
+
+// newLangFlow - выбор языка интерфейса. Названия языков не переводятся.
+func newLangFlow() dialog.Flow {
+	return dialog.Flow{
+		Name:  langFlow,
+		First: langStep,
+		Steps: map[string]dialog.Step{
+			langStep: {
+				Key:    langKey,
+				Prompt: i18n.MsgChooseLang,
+				Options: func(dialog.State) []dialog.Option {
+					return []dialog.Option{
+						{Value: string(i18n.RU), Label: "Русский"},
+						{Value: string(i18n.EN), Label: "English"},
+					}
+				},
+				Next: func(dialog.State) string { return "" },
+			},
+		},
+	}
+}
+
+func (p *Processor) startDialog(ctx context.Context, chatID int, flow string) error {
+	var data map[string]string
+	if flow == reportFlow {
+		data = p.reportDialogData(ctx)
+	}
+	reply, err := p.dialogs.StartWith(ctx, chatID, flow, data)
+	if err != nil {
+		return fmt.Errorf("processor: can't start dialog: %w", err)
+	}
+	return p.tg.SendMessage(ctx, chatID, reply.Text)
+}
+
+// reportDialogData загружает открытые счета для шага выбора счета. Без них диалог
+// строит отчет по всем счетам, поэтому ошибка только пишется в лог.
+func (p *Processor) reportDialogData(ctx context.Context) map[string]string {
+	const op = "telegram.reportDialogData"
+
+	accounts, err := p.bondReportService.GetAccountsList(ctx)
+	if err != nil {
+		p.logger.WarnContext(ctx, "could not get accounts", slog.String("op", op), slog.Any("error", err))
+		return nil
+	}
+	data := make(map[string]string, len(accounts.List))
+	for _, account := range accounts.List {
+		data[accountDataPrefix+account.ID] = account.Name
+	}
+	return data
+}
+
+// cancelDialogOnCommand прерывает текущий диалог, если пришла команда бота, кроме /cancel и /back:
+// команда не может быть ответом на вопрос диалога.
+func (p *Processor) cancelDialogOnCommand(ctx context.Context, chatID int, text string) error {
+	if !strings.HasPrefix(text, "/") || text == dialog.CancelCmd || text == dialog.BackCmd {
+		return nil
+	}
+	if err := p.dialogs.Cancel(ctx, chatID); err != nil {
+		return fmt.Errorf("processor: can't cancel dialog: %w", err)
+	}
+	return nil
+}
+
+// handleDialog передает сообщение активному диалогу чата.
+// Возвращает false, если сообщение диалогу не адресовано и его нужно обработать как обычно.
+func (p *Processor) handleDialog(ctx context.Context, chatID int, text string) (bool, error) {
+	reply, err := p.dialogs.Handle(ctx, chatID, text)
+	switch {
+	case errors.Is(err, dialog.ErrNoDialog):
+		if text == dialog.CancelCmd || text == dialog.BackCmd {
+			return true, p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgNoDialog))
+		}
+		return false, nil
+	case err != nil:
+		return true, fmt.Errorf("processor: can't handle dialog: %w", err)
+	}
+
+	switch {
+	case reply.Cancelled:
+		return true, p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgDialogCancelled))
+	case reply.Finished:
+		return true, p.finishDialog(ctx, chatID, reply)
+	default:
+		return true, p.tg.SendMessage(ctx, chatID, reply.Text)
+	}
+}
+
+func (p *Processor) finishDialog(ctx context.Context, chatID int, reply dialog.Reply) error {
+	switch reply.Flow {
+	case reportFlow:
+		return p.finishReportDialog(ctx, chatID, reply.Data)
+	case langFlow:
+		return p.setLang(ctx, chatID, reply.Data[langKey])
+	default:
+		return fmt.Errorf("processor: unknown dialog flow %q", reply.Flow)
+	}
+}
+
+func (p *Processor) finishReportDialog(ctx context.Context, chatID int, data map[string]string) error {
+	switch data[formatKey] {
+	case formatPng:
+		accountID := data[accountKey]
+		if accountID == allAccounts {
+			accountID = ""
+		}
+		return p.getBondRepotsWithPng(ctx, chatID, accountID)
+	case formatFifo:
+		return p.getBondReports(ctx, chatID)
+	case formatByAccounts:
+		return p.GetPortfolioStructure(ctx, chatID)
+	case formatUnion:
+		return p.GetUnionPortfolioStructure(ctx, chatID)
+	case formatUnionSber:
+		return p.GetUnionPortfolioStructureWithSber(ctx, chatID)
+	default:
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgUnknownCommand))
+	}
+}
+
+// setLang сохраняет выбранный язык и отвечает уже на нем.
+func (p *Processor) setLang(ctx context.Context, chatID int, code string) error {
+	lang, ok := i18n.Parse(code)
+	if !ok {
+		return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgUnknownLang))
+	}
+	if err := p.langs.Save(ctx, chatID, lang); err != nil {
+		return fmt.Errorf("processor: can't save language: %w", err)
+	}
+	ctx = i18n.WithLang(ctx, lang)
+	return p.tg.SendMessage(ctx, chatID, tr(ctx, i18n.MsgLangChanged))
+}
@@ -0,0 +1,266 @@
+//go:build unit
+
+package telegram
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contextkeys "github.com/gladinov/contracts/context"
+	"github.com/stretchr/testify/require"
+	bondreportservice "main.go/clients/bondReportService"
+	"main.go/clients/tinkoffApi"
+	"main.go/internal/dialog"
+	"main.go/internal/i18n"
+)
+
+type fakeTelegram struct {
+	messages []string
+}
+
+func (f *fakeTelegram) SendMessage(_ context.Context, _ int, text string) error {
+	f.messages = append(f.messages, text)
+	return nil
+}
+
+func (f *fakeTelegram) SendPreformatted(_ context.Context, _ int, text string) error {
+	f.messages = append(f.messages, text)
+	return nil
+}
+
+func (f *fakeTelegram) SendImageFromBuffer(_ context.Context, _ int, _ []byte, caption string) error {
+	f.messages = append(f.messages, caption)
+	return nil
+}
+
+func (f *fakeTelegram) SendMediaGroupFromBuffer(_ context.Context, _ int, _ []*bondreportservice.ImageData) error {
+	return nil
+}
+
+func (f *fakeTelegram) last() string {
+	if len(f.messages) == 0 {
+		return ""
+	}
+	return f.messages[len(f.messages)-1]
+}
+
+type memoryDialogStore struct {
+	states map[int]dialog.State
+}
+
+func (s *memoryDialogStore) Get(_ context.Context, chatID int) (dialog.State, error) {
+	state, ok := s.states[chatID]
+	if !ok {
+		return dialog.State{}, dialog.ErrNoDialog
+	}
+	return state, nil
+}
+
+func (s *memoryDialogStore) Save(_ context.Context, chatID int, state dialog.State) error {
+	s.states[chatID] = state
+	return nil
+}
+
+func (s *memoryDialogStore) Delete(_ context.Context, chatID int) error {
+	delete(s.states, chatID)
+	return nil
+}
+
+type memoryLangStore struct {
+	langs map[int]i18n.Lang
+}
+
+func (s *memoryLangStore) Get(_ context.Context, chatID int) (i18n.Lang, error) {
+	lang, ok := s.langs[chatID]
+	if !ok {
+		return "", i18n.ErrNoLang
+	}
+	return lang, nil
+}
+
+func (s *memoryLangStore) Save(_ context.Context, chatID int, lang i18n.Lang) error {
+	s.langs[chatID] = lang
+	return nil
+}
+
+func newTestProcessor(t *testing.T, bondReportHost string) (*Processor, *fakeTelegram, *memoryDialogStore) {
+	t.Helper()
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tg := &fakeTelegram{}
+	store := &memoryDialogStore{states: make(map[int]dialog.State)}
+	p := NewProccesor(
+		logg,
+		tg,
+		tinkoffApi.NewClient(logg, bondReportHost),
+		bondreportservice.New(logg, bondReportHost),
+		nil,
+		dialog.NewManager(store, Flows()...),
+		&memoryLangStore{langs: make(map[int]i18n.Lang)},
+	)
+	return p, tg, store
+}
+
+func TestReportDialog(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	t.Run("back and cancel", func(t *testing.T) {
+		p, tg, store := newTestProcessor(t, "localhost")
+
+		require.NoError(t, p.startDialog(ctx, chatID, reportFlow))
+		require.Contains(t, tg.last(), i18n.T(i18n.RU, i18n.MsgChooseReport))
+
+		handled, err := p.handleDialog(ctx, chatID, "2")
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Contains(t, tg.last(), i18n.T(i18n.RU, i18n.MsgChooseFormat))
+		require.Contains(t, tg.last(), "Объединенный портфель со Сбером")
+
+		handled, err = p.handleDialog(ctx, chatID, dialog.BackCmd)
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Contains(t, tg.last(), i18n.T(i18n.RU, i18n.MsgChooseReport))
+
+		handled, err = p.handleDialog(ctx, chatID, dialog.CancelCmd)
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgDialogCancelled), tg.last())
+		require.Empty(t, store.states)
+	})
+
+	t.Run("cancel without dialog", func(t *testing.T) {
+		p, tg, _ := newTestProcessor(t, "localhost")
+
+		handled, err := p.handleDialog(ctx, chatID, dialog.CancelCmd)
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgNoDialog), tg.last())
+	})
+
+	t.Run("free text without dialog is not handled", func(t *testing.T) {
+		p, tg, _ := newTestProcessor(t, "localhost")
+
+		handled, err := p.handleDialog(ctx, chatID, "some token")
+		require.NoError(t, err)
+		require.False(t, handled)
+		require.Empty(t, tg.messages)
+	})
+
+	t.Run("command interrupts dialog", func(t *testing.T) {
+		p, _, store := newTestProcessor(t, "localhost")
+		require.NoError(t, p.startDialog(ctx, chatID, reportFlow))
+
+		require.NoError(t, p.cancelDialogOnCommand(ctx, chatID, dialog.BackCmd))
+		require.NotEmpty(t, store.states, "/back is a dialog answer")
+		require.NoError(t, p.cancelDialogOnCommand(ctx, chatID, "2"))
+		require.NotEmpty(t, store.states)
+
+		require.NoError(t, p.cancelDialogOnCommand(ctx, chatID, "/unknown"))
+		require.Empty(t, store.states, "any command, even an unknown one")
+	})
+
+	t.Run("choose account", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/bondReportService/accounts":
+				_, _ = io.WriteString(w, `{"list":[{"id":"2001","name":"ИИС"},{"id":"2000","name":"Брокерский счет"}]}`)
+			case "/bondReportService/getBondReports":
+				require.Equal(t, "2001", r.URL.Query().Get("account"))
+				_, _ = io.WriteString(w, `{"media":[[{"reports":[{"name":"rub","caption":"iis report"}]}]]}`)
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer srv.Close()
+
+		p, tg, store := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+		require.NoError(t, p.startDialog(ctx, chatID, reportFlow))
+		_, err := p.handleDialog(ctx, chatID, "1")
+		require.NoError(t, err)
+		_, err = p.handleDialog(ctx, chatID, "1")
+		require.NoError(t, err)
+		require.Contains(t, tg.last(), i18n.T(i18n.RU, i18n.MsgChooseAccount))
+		require.Contains(t, tg.last(), "1. Все счета\n2. Брокерский счет\n3. ИИС")
+
+		handled, err := p.handleDialog(ctx, chatID, "ИИС")
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Equal(t, "iis report", tg.last())
+		require.Empty(t, store.states)
+	})
+
+	t.Run("finished dialog runs report", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/bondReportService/accounts" {
+				_, _ = io.WriteString(w, `{"list":[{"id":"2000","name":"Брокерский счет"}]}`)
+				return
+			}
+			require.Equal(t, "/bondReportService/getUnionPortfolioStructure", r.URL.Path)
+			require.Equal(t, "ru", r.Header.Get(bondreportservice.HeaderLanguage))
+			_, _ = io.WriteString(w, `{"report":"union report"}`)
+		}))
+		defer srv.Close()
+
+		p, tg, store := newTestProcessor(t, strings.TrimPrefix(srv.URL, "http://"))
+		require.NoError(t, p.startDialog(ctx, chatID, reportFlow))
+
+		_, err := p.handleDialog(ctx, chatID, "Структура портфеля")
+		require.NoError(t, err)
+		handled, err := p.handleDialog(ctx, chatID, "2")
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Equal(t, "union report", tg.last())
+		require.Empty(t, store.states)
+	})
+}
+
+func TestLang(t *testing.T) {
+	const chatID = 7
+	ctx := context.WithValue(context.Background(), contextkeys.ChatIDKey, "7")
+
+	t.Run("detected from telegram", func(t *testing.T) {
+		p, _, _ := newTestProcessor(t, "localhost")
+
+		require.Equal(t, i18n.EN, p.lang(ctx, Meta{ChatID: chatID, LanguageCode: "en-US"}))
+		require.Equal(t, i18n.RU, p.lang(ctx, Meta{ChatID: chatID, LanguageCode: "ru"}))
+		require.Equal(t, i18n.Default, p.lang(ctx, Meta{ChatID: chatID}))
+	})
+
+	t.Run("lang command overrides telegram language", func(t *testing.T) {
+		p, tg, _ := newTestProcessor(t, "localhost")
+
+		require.NoError(t, p.doCmd(ctx, LangCmd+" en", chatID, "user"))
+		require.Equal(t, i18n.T(i18n.EN, i18n.MsgLangChanged), tg.last())
+		require.Equal(t, i18n.EN, p.lang(ctx, Meta{ChatID: chatID, LanguageCode: "ru"}))
+
+		require.NoError(t, p.doCmd(ctx, LangCmd+" de", chatID, "user"))
+		require.Equal(t, i18n.T(i18n.RU, i18n.MsgUnknownLang), tg.last())
+	})
+
+	t.Run("lang dialog", func(t *testing.T) {
+		p, tg, store := newTestProcessor(t, "localhost")
+
+		require.NoError(t, p.startDialog(ctx, chatID, langFlow))
+		require.Contains(t, tg.last(), "2. English")
+
+		handled, err := p.handleDialog(ctx, chatID, "English")
+		require.NoError(t, err)
+		require.True(t, handled)
+		require.Equal(t, i18n.T(i18n.EN, i18n.MsgLangChanged), tg.last())
+		require.Empty(t, store.states)
+	})
+
+	t.Run("report dialog in english", func(t *testing.T) {
+		p, tg, _ := newTestProcessor(t, "localhost")
+		enCtx := i18n.WithLang(ctx, i18n.EN)
+
+		require.NoError(t, p.startDialog(enCtx, chatID, reportFlow))
+		require.Contains(t, tg.last(), "Which report do you need?")
+		require.Contains(t, tg.last(), "2. Portfolio structure")
+	})
+}
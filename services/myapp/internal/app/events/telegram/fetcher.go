@@ -50,8 +50,9 @@ func event(upd telegram.Update) events.Event {
 	}
 	if updType == events.Message {
 		res.Meta = Meta{
-			ChatID:   upd.Message.Chat.ID,
-			Username: upd.Message.From.Username,
+			ChatID:       upd.Message.Chat.ID,
+			Username:     upd.Message.From.Username,
+			LanguageCode: upd.Message.From.LanguageCode,
 		}
 	}
 	return res
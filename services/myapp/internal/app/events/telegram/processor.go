@@ -7,23 +7,35 @@ import (
 
 	"github.com/gladinov/e"
 	bondreportservice "main.go/clients/bondReportService"
-	"main.go/clients/telegram"
 	"main.go/clients/tinkoffApi"
 	"main.go/internal/app/events"
+	"main.go/internal/dialog"
+	"main.go/internal/i18n"
 	tokenauth "main.go/internal/tokenAuth"
 )
 
+// TelegramClient - методы telegram.Client, которыми пользуется Processor.
+type TelegramClient interface {
+	SendMessage(ctx context.Context, chatID int, text string) error
+	SendPreformatted(ctx context.Context, chatID int, text string) error
+	SendImageFromBuffer(ctx context.Context, chatID int, imageData []byte, caption string) error
+	SendMediaGroupFromBuffer(ctx context.Context, chatID int, images []*bondreportservice.ImageData) error
+}
+
 type Processor struct {
 	logger            *slog.Logger
-	tg                *telegram.Client
+	tg                TelegramClient
 	tinkoffApi        *tinkoffApi.Client
 	bondReportService *bondreportservice.Client
 	tokenAuthService  *tokenauth.TokenAuthService
+	dialogs           *dialog.Manager
+	langs             i18n.Store
 }
 
 type Meta struct {
-	ChatID   int
-	Username string
+	ChatID       int
+	Username     string
+	LanguageCode string
 }
 
 var (
@@ -33,10 +45,12 @@ var (
 
 func NewProccesor(
 	logger *slog.Logger,
-	client *telegram.Client,
+	client TelegramClient,
 	tinkoffApiClient *tinkoffApi.Client,
 	bondReportServiceClient *bondreportservice.Client,
 	tokenAuthService *tokenauth.TokenAuthService,
+	dialogs *dialog.Manager,
+	langs i18n.Store,
 ) *Processor {
 	return &Processor{
 		logger:            logger,
@@ -44,6 +58,8 @@ func NewProccesor(
 		tinkoffApi:        tinkoffApiClient,
 		bondReportService: bondReportServiceClient,
 		tokenAuthService:  tokenAuthService,
+		dialogs:           dialogs,
+		langs:             langs,
 	}
 }
 
@@ -62,13 +78,44 @@ func (p *Processor) processMessage(ctx context.Context, event events.Event) erro
 		return e.Wrap("can't process message", err)
 	}
 
+	ctx = i18n.WithLang(ctx, p.lang(ctx, meta))
+
 	if err := p.doCmd(ctx, event.Text, meta.ChatID, meta.Username); err != nil {
+		if msg, ok := serviceErrorMsg(err); ok {
+			if sendErr := p.tg.SendMessage(ctx, meta.ChatID, tr(ctx, msg)); sendErr != nil {
+				p.logger.WarnContext(ctx, "can't send error message",
+					slog.Int("chatID", meta.ChatID),
+					slog.String("error", sendErr.Error()),
+				)
+			}
+		}
 		return e.Wrap("can't process message", err)
 	}
 
 	return nil
 }
 
+// lang возвращает язык, выбранный пользователем через /lang,
+// а если выбора не было - язык его клиента Telegram.
+func (p *Processor) lang(ctx context.Context, meta Meta) i18n.Lang {
+	lang, err := p.langs.Get(ctx, meta.ChatID)
+	switch {
+	case err == nil:
+		return lang
+	case !errors.Is(err, i18n.ErrNoLang):
+		p.logger.WarnContext(ctx, "can't get chosen language",
+			slog.Int("chatID", meta.ChatID),
+			slog.String("error", err.Error()),
+		)
+	}
+	return i18n.Detect(meta.LanguageCode)
+}
+
+// tr возвращает текст сообщения на языке текущего запроса.
+func tr(ctx context.Context, id i18n.MsgID) string {
+	return i18n.T(i18n.FromContext(ctx), id)
+}
+
 func meta(event events.Event) (Meta, error) {
 	res, ok := event.Meta.(Meta)
 	if !ok {
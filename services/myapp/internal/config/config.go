@@ -22,6 +22,8 @@ type Config struct {
 	StorageSQLLitePath string          `yaml:"storageSQLLitePath"`
 	PostgresHost       PostgresHost    `yaml:"postgresHost"`
 	RedisHTTPServer    RedisHTTPServer `yaml:"redis"`
+	TokenPolicy        string          `yaml:"tokenPolicy" env-default:"refuse"`
+	DialogTTL          time.Duration   `yaml:"dialogTTL" env-default:"15m"`
 }
 
 type PostgresHost struct {
@@ -0,0 +1,284 @@
+package dialog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"main.go/internal/i18n"
+)
+
+const (
+	CancelCmd = "/cancel"
+	BackCmd   = "/back"
+)
+
+var (
+	ErrNoDialog    = errors.New("no active dialog")
+	ErrUnknownFlow = errors.New("unknown dialog flow")
+	ErrUnknownStep = errors.New("unknown dialog step")
+)
+
+// State - состояние диалога в конкретном чате.
+// History хранит пройденные шаги, чтобы по /back вернуться к предыдущему вопросу.
+type State struct {
+	Flow    string            `json:"flow"`
+	Step    string            `json:"step"`
+	Data    map[string]string `json:"data,omitempty"`
+	History []string          `json:"history,omitempty"`
+}
+
+type Store interface {
+	// Get возвращает ErrNoDialog, если в чате нет активного диалога.
+	Get(ctx context.Context, chatID int) (State, error)
+	Save(ctx context.Context, chatID int, state State) error
+	Delete(ctx context.Context, chatID int) error
+}
+
+// Option - вариант ответа. Title - ключ каталога, он переводится на язык пользователя.
+// Label - текст, который не переводится (например, название счета); если он задан, Title не используется.
+type Option struct {
+	Value string
+	Title i18n.MsgID
+	Label string
+}
+
+func (o Option) text(lang i18n.Lang) string {
+	if o.Label != "" {
+		return o.Label
+	}
+	return i18n.T(lang, o.Title)
+}
+
+// Step - один вопрос диалога. Выбранный пользователем Option.Value сохраняется в State.Data[Key].
+// Next возвращает имя следующего шага или пустую строку, если диалог завершен.
+// Язык вопросов берется из контекста (i18n.WithLang).
+type Step struct {
+	Key     string
+	Prompt  i18n.MsgID
+	Options func(state State) []Option
+	Next    func(state State) string
+}
+
+type Flow struct {
+	Name  string
+	First string
+	Steps map[string]Step
+}
+
+// Reply - результат обработки сообщения диалогом.
+// Если Finished == true, в Data лежат все ответы пользователя и диалог удален из Store.
+type Reply struct {
+	Text      string
+	Flow      string
+	Data      map[string]string
+	Finished  bool
+	Cancelled bool
+}
+
+type Manager struct {
+	store Store
+	flows map[string]Flow
+}
+
+func NewManager(store Store, flows ...Flow) *Manager {
+	m := &Manager{
+		store: store,
+		flows: make(map[string]Flow, len(flows)),
+	}
+	for _, flow := range flows {
+		m.flows[flow.Name] = flow
+	}
+	return m
+}
+
+// Start начинает диалог заново, даже если в чате уже был активный диалог.
+func (m *Manager) Start(ctx context.Context, chatID int, flowName string) (Reply, error) {
+	return m.StartWith(ctx, chatID, flowName, nil)
+}
+
+// StartWith начинает диалог с заранее известными данными, например вариантами ответа,
+// которые загружены из внешнего сервиса. Данные видны шагам в State.Data.
+func (m *Manager) StartWith(ctx context.Context, chatID int, flowName string, data map[string]string) (Reply, error) {
+	const op = "dialog.Start"
+
+	flow, ok := m.flows[flowName]
+	if !ok {
+		return Reply{}, fmt.Errorf("%s: %w: %s", op, ErrUnknownFlow, flowName)
+	}
+
+	state := State{
+		Flow: flow.Name,
+		Step: flow.First,
+		Data: make(map[string]string, len(data)),
+	}
+	maps.Copy(state.Data, data)
+	if err := m.store.Save(ctx, chatID, state); err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	text, err := m.prompt(ctx, flow, state, "")
+	if err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return Reply{Text: text, Flow: flow.Name}, nil
+}
+
+// Active возвращает имя диалога, который ведется в чате, или пустую строку.
+func (m *Manager) Active(ctx context.Context, chatID int) (string, error) {
+	const op = "dialog.Active"
+
+	state, err := m.store.Get(ctx, chatID)
+	switch {
+	case err == nil:
+		return state.Flow, nil
+	case errors.Is(err, ErrNoDialog):
+		return "", nil
+	default:
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+}
+
+// Cancel удаляет диалог чата. Отсутствие диалога ошибкой не считается.
+func (m *Manager) Cancel(ctx context.Context, chatID int) error {
+	const op = "dialog.Cancel"
+
+	if err := m.store.Delete(ctx, chatID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Handle обрабатывает ответ пользователя на текущий вопрос диалога.
+// Возвращает ErrNoDialog, если в чате нет активного диалога.
+func (m *Manager) Handle(ctx context.Context, chatID int, text string) (Reply, error) {
+	const op = "dialog.Handle"
+
+	state, err := m.store.Get(ctx, chatID)
+	if err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	flow, ok := m.flows[state.Flow]
+	if !ok {
+		_ = m.store.Delete(ctx, chatID)
+		return Reply{}, fmt.Errorf("%s: %w: %s", op, ErrUnknownFlow, state.Flow)
+	}
+
+	text = strings.TrimSpace(text)
+	switch text {
+	case CancelCmd:
+		if err := m.store.Delete(ctx, chatID); err != nil {
+			return Reply{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return Reply{Flow: flow.Name, Cancelled: true}, nil
+	case BackCmd:
+		return m.back(ctx, chatID, flow, state)
+	}
+
+	step, ok := flow.Steps[state.Step]
+	if !ok {
+		_ = m.store.Delete(ctx, chatID)
+		return Reply{}, fmt.Errorf("%s: %w: %s", op, ErrUnknownStep, state.Step)
+	}
+
+	option, ok := matchOption(i18n.FromContext(ctx), step.Options(state), text)
+	if !ok {
+		prompt, err := m.prompt(ctx, flow, state, i18n.MsgUnknownOption)
+		if err != nil {
+			return Reply{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return Reply{Text: prompt, Flow: flow.Name}, nil
+	}
+
+	if state.Data == nil {
+		state.Data = make(map[string]string)
+	}
+	state.Data[step.Key] = option.Value
+
+	next := step.Next(state)
+	if next == "" {
+		if err := m.store.Delete(ctx, chatID); err != nil {
+			return Reply{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return Reply{Flow: flow.Name, Data: state.Data, Finished: true}, nil
+	}
+
+	state.History = append(state.History, state.Step)
+	state.Step = next
+	if err := m.store.Save(ctx, chatID, state); err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	prompt, err := m.prompt(ctx, flow, state, "")
+	if err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return Reply{Text: prompt, Flow: flow.Name}, nil
+}
+
+// back возвращает диалог на предыдущий шаг и забывает ответ, данный на нем.
+// На первом шаге просто повторяет вопрос.
+func (m *Manager) back(ctx context.Context, chatID int, flow Flow, state State) (Reply, error) {
+	const op = "dialog.back"
+
+	if len(state.History) != 0 {
+		prev := state.History[len(state.History)-1]
+		state.History = state.History[:len(state.History)-1]
+		state.Step = prev
+		if step, ok := flow.Steps[prev]; ok {
+			delete(state.Data, step.Key)
+		}
+		if err := m.store.Save(ctx, chatID, state); err != nil {
+			return Reply{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	prompt, err := m.prompt(ctx, flow, state, "")
+	if err != nil {
+		return Reply{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return Reply{Text: prompt, Flow: flow.Name}, nil
+}
+
+func (m *Manager) prompt(ctx context.Context, flow Flow, state State, header i18n.MsgID) (string, error) {
+	step, ok := flow.Steps[state.Step]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownStep, state.Step)
+	}
+
+	lang := i18n.FromContext(ctx)
+	var b strings.Builder
+	if header != "" {
+		b.WriteString(i18n.T(lang, header))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(i18n.T(lang, step.Prompt))
+	b.WriteString("\n")
+	for i, option := range step.Options(state) {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, option.text(lang))
+	}
+	b.WriteString("\n")
+	b.WriteString(i18n.T(lang, i18n.MsgDialogControls))
+	return b.String(), nil
+}
+
+// matchOption принимает ответ в виде номера варианта, его значения или названия
+// на языке пользователя.
+func matchOption(lang i18n.Lang, options []Option, text string) (Option, bool) {
+	if n, err := strconv.Atoi(text); err == nil {
+		if n >= 1 && n <= len(options) {
+			return options[n-1], true
+		}
+		return Option{}, false
+	}
+	for _, option := range options {
+		if strings.EqualFold(option.Value, text) || strings.EqualFold(option.text(lang), text) {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
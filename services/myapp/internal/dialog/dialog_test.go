@@ -0,0 +1,223 @@
+//go:build unit
+
+package dialog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"main.go/internal/i18n"
+)
+
+type memoryStore struct {
+	states map[int]State
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{states: make(map[int]State)}
+}
+
+func (s *memoryStore) Get(_ context.Context, chatID int) (State, error) {
+	state, ok := s.states[chatID]
+	if !ok {
+		return State{}, ErrNoDialog
+	}
+	return state, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, chatID int, state State) error {
+	s.states[chatID] = state
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, chatID int) error {
+	delete(s.states, chatID)
+	return nil
+}
+
+func testFlow() Flow {
+	return Flow{
+		Name:  "test",
+		First: "first",
+		Steps: map[string]Step{
+			"first": {
+				Key:    "color",
+				Prompt: "color?",
+				Options: func(State) []Option {
+					return []Option{{Value: "red", Label: "Red"}, {Value: "blue", Label: "Blue"}}
+				},
+				Next: func(State) string { return "second" },
+			},
+			"second": {
+				Key:    "size",
+				Prompt: "size?",
+				Options: func(state State) []Option {
+					if state.Data["color"] == "red" {
+						return []Option{{Value: "s", Label: "Small"}}
+					}
+					return []Option{{Value: "s", Label: "Small"}, {Value: "l", Label: "Large"}}
+				},
+				Next: func(State) string { return "" },
+			},
+		},
+	}
+}
+
+func TestManager(t *testing.T) {
+	ctx := context.Background()
+	const chatID = 42
+
+	t.Run("full flow", func(t *testing.T) {
+		store := newMemoryStore()
+		m := NewManager(store, testFlow())
+
+		reply, err := m.Start(ctx, chatID, "test")
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, "color?")
+		require.Contains(t, reply.Text, "1. Red")
+
+		reply, err = m.Handle(ctx, chatID, "blue")
+		require.NoError(t, err)
+		require.False(t, reply.Finished)
+		require.Contains(t, reply.Text, "2. Large")
+
+		reply, err = m.Handle(ctx, chatID, "2")
+		require.NoError(t, err)
+		require.True(t, reply.Finished)
+		require.Equal(t, map[string]string{"color": "blue", "size": "l"}, reply.Data)
+
+		active, err := m.Active(ctx, chatID)
+		require.NoError(t, err)
+		require.Empty(t, active)
+	})
+
+	t.Run("start with data", func(t *testing.T) {
+		store := newMemoryStore()
+		m := NewManager(store, testFlow())
+		_, err := m.StartWith(ctx, chatID, "test", map[string]string{"hint": "x"})
+		require.NoError(t, err)
+
+		active, err := m.Active(ctx, chatID)
+		require.NoError(t, err)
+		require.Equal(t, "test", active)
+		require.Equal(t, map[string]string{"hint": "x"}, store.states[chatID].Data)
+	})
+
+	t.Run("unknown answer keeps step", func(t *testing.T) {
+		store := newMemoryStore()
+		m := NewManager(store, testFlow())
+		_, err := m.Start(ctx, chatID, "test")
+		require.NoError(t, err)
+
+		reply, err := m.Handle(ctx, chatID, "green")
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, i18n.T(i18n.Default, i18n.MsgUnknownOption))
+		require.Equal(t, "first", store.states[chatID].Step)
+
+		reply, err = m.Handle(ctx, chatID, "3")
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, i18n.T(i18n.Default, i18n.MsgUnknownOption))
+	})
+
+	t.Run("back returns to previous step and forgets answer", func(t *testing.T) {
+		store := newMemoryStore()
+		m := NewManager(store, testFlow())
+		_, err := m.Start(ctx, chatID, "test")
+		require.NoError(t, err)
+		_, err = m.Handle(ctx, chatID, "1")
+		require.NoError(t, err)
+
+		reply, err := m.Handle(ctx, chatID, BackCmd)
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, "color?")
+		require.Equal(t, "first", store.states[chatID].Step)
+		require.Empty(t, store.states[chatID].History)
+		require.NotContains(t, store.states[chatID].Data, "color")
+
+		reply, err = m.Handle(ctx, chatID, BackCmd)
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, "color?")
+	})
+
+	t.Run("cancel removes dialog", func(t *testing.T) {
+		store := newMemoryStore()
+		m := NewManager(store, testFlow())
+		_, err := m.Start(ctx, chatID, "test")
+		require.NoError(t, err)
+
+		reply, err := m.Handle(ctx, chatID, CancelCmd)
+		require.NoError(t, err)
+		require.True(t, reply.Cancelled)
+		require.Empty(t, store.states)
+	})
+
+	t.Run("no dialog", func(t *testing.T) {
+		m := NewManager(newMemoryStore(), testFlow())
+		_, err := m.Handle(ctx, chatID, "1")
+		require.ErrorIs(t, err, ErrNoDialog)
+	})
+
+	t.Run("unknown flow", func(t *testing.T) {
+		m := NewManager(newMemoryStore(), testFlow())
+		_, err := m.Start(ctx, chatID, "missing")
+		require.ErrorIs(t, err, ErrUnknownFlow)
+	})
+	t.Run("localized prompt and options", func(t *testing.T) {
+		flow := Flow{
+			Name:  "lang",
+			First: "first",
+			Steps: map[string]Step{
+				"first": {
+					Key:    "report",
+					Prompt: i18n.MsgChooseReport,
+					Options: func(State) []Option {
+						return []Option{{Value: "b", Title: i18n.MsgOptionBonds}}
+					},
+					Next: func(State) string { return "" },
+				},
+			},
+		}
+		m := NewManager(newMemoryStore(), flow)
+		enCtx := i18n.WithLang(ctx, i18n.EN)
+
+		reply, err := m.Start(enCtx, chatID, "lang")
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, i18n.T(i18n.EN, i18n.MsgChooseReport))
+		require.Contains(t, reply.Text, "1. Bonds")
+		require.Contains(t, reply.Text, i18n.T(i18n.EN, i18n.MsgDialogControls))
+
+		reply, err = m.Handle(enCtx, chatID, "Bonds")
+		require.NoError(t, err)
+		require.True(t, reply.Finished)
+		require.Equal(t, "b", reply.Data["report"])
+	})
+	t.Run("label is not translated", func(t *testing.T) {
+		flow := Flow{
+			Name:  "label",
+			First: "first",
+			Steps: map[string]Step{
+				"first": {
+					Key:    "account",
+					Prompt: i18n.MsgChooseAccount,
+					Options: func(State) []Option {
+						// название счета совпадает с ключом каталога
+						return []Option{{Value: "acc", Label: string(i18n.MsgOptionBonds)}}
+					},
+					Next: func(State) string { return "" },
+				},
+			},
+		}
+		m := NewManager(newMemoryStore(), flow)
+		enCtx := i18n.WithLang(ctx, i18n.EN)
+
+		reply, err := m.Start(enCtx, chatID, "label")
+		require.NoError(t, err)
+		require.Contains(t, reply.Text, "1. option_bonds")
+		require.NotContains(t, reply.Text, "Bonds")
+
+		reply, err = m.Handle(enCtx, chatID, "option_bonds")
+		require.NoError(t, err)
+		require.Equal(t, "acc", reply.Data["account"])
+	})
+}
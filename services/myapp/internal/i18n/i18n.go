@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Lang - язык интерфейса бота (двухбуквенный код ISO 639-1).
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	Default = RU
+)
+
+// Supported - языки, для которых есть перевод всех сообщений.
+var Supported = []Lang{RU, EN}
+
+var ErrNoLang = errors.New("language is not chosen")
+
+// Store хранит язык, явно выбранный пользователем через /lang.
+type Store interface {
+	// Get возвращает ErrNoLang, если пользователь язык не выбирал.
+	Get(ctx context.Context, chatID int) (Lang, error)
+	Save(ctx context.Context, chatID int, lang Lang) error
+}
+
+type langKey struct{}
+
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext возвращает язык запроса или Default, если он не задан.
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
+
+// Parse разбирает код языка вида "en", "en-US" или "EN_us".
+// Возвращает false, если язык не поддерживается.
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	for _, lang := range Supported {
+		if string(lang) == code {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Detect выбирает язык по language_code из Telegram. Пользователям с любым
+// другим известным языком бот отвечает по-английски, без языка - по-русски.
+func Detect(code string) Lang {
+	if lang, ok := Parse(code); ok {
+		return lang
+	}
+	if strings.TrimSpace(code) == "" {
+		return Default
+	}
+	return EN
+}
+
+// T возвращает текст сообщения на языке lang. Если перевода нет, берется
+// текст на языке по умолчанию, а если нет и его - сам идентификатор.
+func T(lang Lang, id MsgID) string {
+	if text, ok := catalog[lang][id]; ok {
+		return text
+	}
+	if text, ok := catalog[Default][id]; ok {
+		return text
+	}
+	return string(id)
+}
@@ -0,0 +1,58 @@
+//go:build unit
+
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, lang := range Supported {
+		require.Len(t, catalog[lang], len(catalog[Default]), "lang %s", lang)
+		for id := range catalog[Default] {
+			require.NotEmpty(t, catalog[lang][id], "lang %s, message %s", lang, id)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Lang
+		ok   bool
+	}{
+		{code: "ru", want: RU, ok: true},
+		{code: "en-US", want: EN, ok: true},
+		{code: " EN_gb ", want: EN, ok: true},
+		{code: "de", ok: false},
+		{code: "", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.code)
+		require.Equal(t, tt.ok, ok, tt.code)
+		require.Equal(t, tt.want, got, tt.code)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	require.Equal(t, RU, Detect("ru"))
+	require.Equal(t, EN, Detect("en"))
+	require.Equal(t, EN, Detect("de"))
+	require.Equal(t, Default, Detect(""))
+}
+
+func TestT(t *testing.T) {
+	require.Equal(t, "Unknown command 🤔", T(EN, MsgUnknownCommand))
+	require.Equal(t, "Неизвестная команда 🤔", T(RU, MsgUnknownCommand))
+	require.Equal(t, ru[MsgHelp], T(Lang("de"), MsgHelp))
+	require.Equal(t, "raw text", T(EN, MsgID("raw text")))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, Default, FromContext(ctx))
+	require.Equal(t, EN, FromContext(WithLang(ctx, EN)))
+}
@@ -0,0 +1,57 @@
+package i18n
+
+// MsgID - идентификатор сообщения в каталогах переводов.
+type MsgID string
+
+const (
+	MsgHelp                   MsgID = "help"
+	MsgHello                  MsgID = "hello"
+	MsgUnknownCommand         MsgID = "unknown_command"
+	MsgNoToken                MsgID = "no_token"
+	MsgTrueToken              MsgID = "true_token"
+	MsgFullAccessToken        MsgID = "full_access_token"
+	MsgFullAccessTokenWarning MsgID = "full_access_token_warning"
+	MsgNoAccessToken          MsgID = "no_access_token"
+	MsgBondFifoSaved          MsgID = "bond_fifo_saved"
+
+	MsgChooseReport    MsgID = "choose_report"
+	MsgChooseFormat    MsgID = "choose_format"
+	MsgChooseAccount   MsgID = "choose_account"
+	MsgDialogCancelled MsgID = "dialog_cancelled"
+	MsgNoDialog        MsgID = "no_dialog"
+	MsgDialogControls  MsgID = "dialog_controls"
+	MsgUnknownOption   MsgID = "unknown_option"
+
+	MsgOptionBonds      MsgID = "option_bonds"
+	MsgOptionStructure  MsgID = "option_structure"
+	MsgOptionPng        MsgID = "option_png"
+	MsgOptionFifo       MsgID = "option_fifo"
+	MsgOptionByAccounts MsgID = "option_by_accounts"
+	MsgOptionUnion      MsgID = "option_union"
+	MsgOptionUnionSber  MsgID = "option_union_sber"
+	MsgOptionAllAccount MsgID = "option_all_accounts"
+
+	MsgChooseLang  MsgID = "choose_lang"
+	MsgLangChanged MsgID = "lang_changed"
+	MsgUnknownLang MsgID = "unknown_lang"
+
+	MsgChartUsage    MsgID = "chart_usage"
+	MsgScreenUsage   MsgID = "screen_usage"
+	MsgScenarioUsage MsgID = "scenario_usage"
+
+	MsgSandboxUsage       MsgID = "sandbox_usage"
+	MsgSandboxOn          MsgID = "sandbox_on"
+	MsgSandboxOff         MsgID = "sandbox_off"
+	MsgSandboxUnavailable MsgID = "sandbox_unavailable"
+
+	MsgErrUnauthorized    MsgID = "err_unauthorized"
+	MsgErrNotFound        MsgID = "err_not_found"
+	MsgErrRateLimited     MsgID = "err_rate_limited"
+	MsgErrInvalidArgument MsgID = "err_invalid_argument"
+	MsgErrUnavailable     MsgID = "err_unavailable"
+)
+
+var catalog = map[Lang]map[MsgID]string{
+	RU: ru,
+	EN: en,
+}
@@ -0,0 +1,74 @@
+package i18n
+
+var en = map[MsgID]string{
+	MsgHelp: `I am a bot for T-Investments portfolio analytics.
+Available commands:
+/start - start the bot,
+/help - this help,
+/accounts - list of accounts available with the provided token,
+/cash - free and blocked cash and margin levels by account,
+/dividends - upcoming dividends on held shares, ruble ones after 13% tax,
+/chart <ticker> [period] - bond price and yield chart with your buys,
+/screen [filter] - MOEX bond screener by yield, duration, maturity and coupon,
+/scenario [shift] - bond value change under rate and key rate moves,
+/report - step-by-step report selection (/back - back, /cancel - cancel),
+/sandbox on|off - reports on T-Invest API sandbox accounts,
+/lang - interface language (/lang ru, /lang en)`,
+	MsgHello:          "Hello. To get started, send me your T-Invest API token 👾\n\n",
+	MsgUnknownCommand: "Unknown command 🤔",
+	MsgNoToken:        "No token provided. To get started, send me your T-Invest API token 👾\n\n",
+	MsgTrueToken:      "The token is valid and saved for this chat",
+	MsgFullAccessToken: "The token has full access (including trading) and can't be saved.\n" +
+		"A read-only token is enough for the bot. Please issue a read-only token and send it 👾",
+	MsgFullAccessTokenWarning: "⚠️ The token has full access, including trading. " +
+		"A read-only token is enough for the bot - we recommend replacing it.",
+	MsgNoAccessToken: "The token has no access to any account, so there is nothing to analyze.\n" +
+		"Please issue a read-only token for your accounts and send it 👾",
+	MsgBondFifoSaved: "FIFO bond report has been saved to the database",
+
+	MsgChooseReport:    "Which report do you need?",
+	MsgChooseFormat:    "In what form?",
+	MsgChooseAccount:   "For which account?",
+	MsgDialogCancelled: "Cancelled",
+	MsgNoDialog:        "Nothing to cancel or go back from",
+	MsgDialogControls:  "/back - back, /cancel - cancel",
+	MsgUnknownOption:   "I didn't get that. Please choose one of the options:",
+
+	MsgOptionBonds:      "Bonds",
+	MsgOptionStructure:  "Portfolio structure",
+	MsgOptionPng:        "Tables by account (PNG)",
+	MsgOptionFifo:       "Save FIFO report to the database",
+	MsgOptionByAccounts: "For each account",
+	MsgOptionUnion:      "Combined portfolio",
+	MsgOptionUnionSber:  "Combined portfolio with Sber",
+	MsgOptionAllAccount: "All accounts",
+
+	MsgChooseLang:  "Choose the interface language:",
+	MsgLangChanged: "Interface language: English",
+	MsgUnknownLang: "This language is not supported. Available: /lang ru, /lang en",
+
+	MsgChartUsage: "Usage: /chart <ticker> [period]\n" +
+		"Period: 1m, 3m, 6m, 1y, 3y, 5y. Without a period - one year or since the first buy.\n" +
+		"Example: /chart SU26238RMFS4 3y",
+	MsgScreenUsage: "Usage: /screen [key=value ...]\n" +
+		"ytm=8-12 - yield, %; dur=1-3 - duration, years; mat=-5 - years to maturity or put\n" +
+		"coupon=fixed|float|zero; cur=rub|cny|usd; level=1,2 - listing level\n" +
+		"board=TQOB,TQCB,TQIR - trading boards; limit=20 - table rows, up to 50\n" +
+		"A range bound can be omitted: ytm=15-\n" +
+		"Example: /screen ytm=14- dur=-3 coupon=fixed level=1,2",
+	MsgScenarioUsage: "Usage: /scenario [shift]\n" +
+		"Shift in basis points or percent: +100bp, -50bp, +1.5%, up to 1000 bp. Without a shift - +100bp.\n" +
+		"Example: /scenario -200bp",
+
+	MsgSandboxUsage: "Usage: /sandbox on|off\n" +
+		"on - reports use T-Invest API sandbox accounts, off - your real accounts",
+	MsgSandboxOn:          "Sandbox mode is on: reports use sandbox accounts. /sandbox off - back to real accounts",
+	MsgSandboxOff:         "Sandbox mode is off: reports use your real accounts",
+	MsgSandboxUnavailable: "The sandbox is unavailable right now",
+
+	MsgErrUnauthorized:    "The token is invalid or revoked. Please send a new T-Invest API token 👾",
+	MsgErrNotFound:        "No data found for the report. Please try again later",
+	MsgErrRateLimited:     "Too many requests to T-Invest API. Please wait a minute and try again",
+	MsgErrInvalidArgument: "T-Invest API rejected the request. Check that the account is open and has operations",
+	MsgErrUnavailable:     "T-Invest API is unavailable right now. Please try again in a few minutes",
+}
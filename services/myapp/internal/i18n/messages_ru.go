@@ -0,0 +1,74 @@
+package i18n
+
+var ru = map[MsgID]string{
+	MsgHelp: `Я бот для получение аналитики с Т-Инвестиций. 
+В данный момент обладаю следующими командами:
+/start - для запуска тг-бота,
+/help - хелп, сейчас мы тут,
+/accounts - получение списка счетов по предоставленому токену,
+/cash - свободные и заблокированные деньги и маржинальные показатели по счетам,
+/dividends - ближайшие дивиденды по акциям в портфеле, рублевые после НДФЛ 13%,
+/chart <тикер> [период] - график цены и доходности облигации с вашими покупками,
+/screen [фильтр] - подбор облигаций MOEX по доходности, дюрации, сроку и купону,
+/scenario [сдвиг] - изменение стоимости облигаций при сдвиге ставок и ключевой ставки,
+/report - выбор отчета по шагам (/back - назад, /cancel - отмена),
+/sandbox on|off - отчеты по счетам песочницы T-Invest API,
+/lang - язык интерфейса (/lang ru, /lang en)`,
+	MsgHello:          "Приветствую. Для дальнейшей работы пришлите токен от Тинькофф АПИ 👾\n\n",
+	MsgUnknownCommand: "Неизвестная команда 🤔",
+	MsgNoToken:        "Не предоставлен токен. Для дальнейшей работы пришлите токен от Тинькофф АПИ 👾\n\n",
+	MsgTrueToken:      "Токен верный и сохранен для работы в этом чате",
+	MsgFullAccessToken: "Токен имеет полный доступ (в том числе к торговым операциям) и не может быть сохранен.\n" +
+		"Боту достаточно токена только для чтения. Выпустите read-only токен и пришлите его 👾",
+	MsgFullAccessTokenWarning: "⚠️ Токен имеет полный доступ, включая торговые операции. " +
+		"Боту достаточно токена только для чтения - рекомендуем заменить его на read-only токен.",
+	MsgNoAccessToken: "У токена нет доступа ни к одному счету, анализировать нечего.\n" +
+		"Выпустите токен только для чтения с доступом к вашим счетам и отправьте его 👾",
+	MsgBondFifoSaved: "Отчет по облигациям по методу FIFO успешно сохранен в базу данных",
+
+	MsgChooseReport:    "Какой отчет подготовить?",
+	MsgChooseFormat:    "В каком виде?",
+	MsgChooseAccount:   "По какому счету?",
+	MsgDialogCancelled: "Действие отменено",
+	MsgNoDialog:        "Нет активного действия для отмены или возврата",
+	MsgDialogControls:  "/back - назад, /cancel - отмена",
+	MsgUnknownOption:   "Не понял ответ. Выберите один из вариантов:",
+
+	MsgOptionBonds:      "Облигации",
+	MsgOptionStructure:  "Структура портфеля",
+	MsgOptionPng:        "Таблицы по счетам (PNG)",
+	MsgOptionFifo:       "Сохранить отчет FIFO в базу данных",
+	MsgOptionByAccounts: "По каждому счету",
+	MsgOptionUnion:      "Объединенный портфель",
+	MsgOptionUnionSber:  "Объединенный портфель со Сбером",
+	MsgOptionAllAccount: "Все счета",
+
+	MsgChooseLang:  "Выберите язык интерфейса:",
+	MsgLangChanged: "Язык интерфейса: русский",
+	MsgUnknownLang: "Такой язык не поддерживается. Доступны: /lang ru, /lang en",
+
+	MsgChartUsage: "Использование: /chart <тикер> [период]\n" +
+		"Период: 1m, 3m, 6m, 1y, 3y, 5y. Без периода - за год или с первой покупки.\n" +
+		"Пример: /chart SU26238RMFS4 3y",
+	MsgScreenUsage: "Использование: /screen [ключ=значение ...]\n" +
+		"ytm=8-12 - доходность, %; dur=1-3 - дюрация, лет; mat=-5 - лет до погашения или оферты\n" +
+		"coupon=fixed|float|zero; cur=rub|cny|usd; level=1,2 - уровень листинга\n" +
+		"board=TQOB,TQCB,TQIR - режимы торгов; limit=20 - строк в таблице, до 50\n" +
+		"У интервала можно опустить границу: ytm=15-\n" +
+		"Пример: /screen ytm=14- dur=-3 coupon=fixed level=1,2",
+	MsgScenarioUsage: "Использование: /scenario [сдвиг]\n" +
+		"Сдвиг в базисных пунктах или процентах: +100bp, -50bp, +1.5%, до 1000 б.п. Без сдвига - +100bp.\n" +
+		"Пример: /scenario -200bp",
+
+	MsgSandboxUsage: "Использование: /sandbox on|off\n" +
+		"on - отчеты строятся по счетам песочницы T-Invest API, off - по вашим настоящим счетам",
+	MsgSandboxOn:          "Режим песочницы включен: отчеты строятся по счетам песочницы. /sandbox off - вернуться к настоящим счетам",
+	MsgSandboxOff:         "Режим песочницы выключен: отчеты строятся по вашим настоящим счетам",
+	MsgSandboxUnavailable: "Песочница сейчас недоступна",
+
+	MsgErrUnauthorized:    "Токен не подходит или отозван. Отправьте новый токен T-Invest API 👾",
+	MsgErrNotFound:        "Не нашел данных для отчета. Попробуйте позже",
+	MsgErrRateLimited:     "Слишком много запросов к T-Invest API. Подождите минуту и повторите",
+	MsgErrInvalidArgument: "T-Invest API не принял запрос. Проверьте, что счет открыт и по нему есть операции",
+	MsgErrUnavailable:     "T-Invest API сейчас недоступен. Попробуйте через несколько минут",
+}
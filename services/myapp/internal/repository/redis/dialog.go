@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"main.go/internal/dialog"
+)
+
+const dialogKeyPrefix = "dialog:"
+
+// DialogStore хранит состояние диалогов в redis. Ключ не пересекается
+// с ключами токенов (там используется chatID без префикса).
+type DialogStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewDialogStore(client *redis.Client, ttl time.Duration) *DialogStore {
+	return &DialogStore{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (s *DialogStore) Get(ctx context.Context, chatID int) (dialog.State, error) {
+	const op = "redis.DialogStore.Get"
+
+	data, err := s.client.Get(ctx, dialogKey(chatID)).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return dialog.State{}, dialog.ErrNoDialog
+	case err != nil:
+		return dialog.State{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var state dialog.State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return dialog.State{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return state, nil
+}
+
+func (s *DialogStore) Save(ctx context.Context, chatID int, state dialog.State) error {
+	const op = "redis.DialogStore.Save"
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.client.Set(ctx, dialogKey(chatID), data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *DialogStore) Delete(ctx context.Context, chatID int) error {
+	const op = "redis.DialogStore.Delete"
+
+	if err := s.client.Del(ctx, dialogKey(chatID)).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func dialogKey(chatID int) string {
+	return dialogKeyPrefix + strconv.Itoa(chatID)
+}
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"main.go/internal/i18n"
+)
+
+const langKeyPrefix = "lang:"
+
+// LangStore хранит выбранный через /lang язык. Выбор бессрочный.
+type LangStore struct {
+	client *redis.Client
+}
+
+func NewLangStore(client *redis.Client) *LangStore {
+	return &LangStore{client: client}
+}
+
+func (s *LangStore) Get(ctx context.Context, chatID int) (i18n.Lang, error) {
+	const op = "redis.LangStore.Get"
+
+	code, err := s.client.Get(ctx, langKey(chatID)).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return "", i18n.ErrNoLang
+	case err != nil:
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	lang, ok := i18n.Parse(code)
+	if !ok {
+		return "", i18n.ErrNoLang
+	}
+	return lang, nil
+}
+
+func (s *LangStore) Save(ctx context.Context, chatID int, lang i18n.Lang) error {
+	const op = "redis.LangStore.Save"
+
+	if err := s.client.Set(ctx, langKey(chatID), string(lang), 0).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func langKey(chatID int) string {
+	return langKeyPrefix + strconv.Itoa(chatID)
+}
@@ -21,9 +21,23 @@ const (
 	TokenError TokenStatus = iota
 	TokenFound
 	TokenInserted
+	TokenInsertedFullAccess
 )
 
-var ErrIncorrectToken = errors.New("incorrect token")
+// TokenPolicy определяет, что делать с токеном, у которого есть права на торговлю.
+// Боту такие права не нужны, поэтому по умолчанию такие токены отклоняются.
+type TokenPolicy string
+
+const (
+	TokenPolicyWarn   TokenPolicy = "warn"
+	TokenPolicyRefuse TokenPolicy = "refuse"
+)
+
+var (
+	ErrIncorrectToken  = errors.New("incorrect token")
+	ErrFullAccessToken = errors.New("full access token is not allowed")
+	ErrNoAccessToken   = errors.New("token has no access to accounts")
+)
 
 type TokenAuthService struct {
 	logger       *slog.Logger
@@ -31,6 +45,7 @@ type TokenAuthService struct {
 	storage      storage.Storage
 	tinkoffApi   *tinkoffApi.Client
 	tokenCrypter *cryptotoken.TokenCrypter
+	tokenPolicy  TokenPolicy
 }
 
 func NewTokenAuthService(logger *slog.Logger,
@@ -38,6 +53,7 @@ func NewTokenAuthService(logger *slog.Logger,
 	storage storage.Storage,
 	tinkoffApi *tinkoffApi.Client,
 	tokenCrypter *cryptotoken.TokenCrypter,
+	tokenPolicy TokenPolicy,
 ) *TokenAuthService {
 	return &TokenAuthService{
 		logger:       logger,
@@ -45,6 +61,7 @@ func NewTokenAuthService(logger *slog.Logger,
 		storage:      storage,
 		tinkoffApi:   tinkoffApi,
 		tokenCrypter: tokenCrypter,
+		tokenPolicy:  tokenPolicy,
 	}
 }
 
@@ -93,9 +110,16 @@ func (t *TokenAuthService) Auth(ctx context.Context, text string, username strin
 
 	case false:
 		logg.InfoContext(ctx, "user provided new token")
-		err := t.isToken(ctx, text)
+		scope, err := t.isToken(ctx, text)
+		if err != nil {
+			return TokenError, err
+		}
+		status, err := applyScopePolicy(scope, t.tokenPolicy)
 		if err != nil {
-			return TokenError, ErrIncorrectToken
+			logg.WarnContext(ctx, "token refused by policy",
+				slog.String("access_level", scope.AccessLevel),
+				slog.String("policy", string(t.tokenPolicy)))
+			return TokenError, err
 		}
 		tokenInBase64, err := t.tokenToBase64(text)
 		if err != nil {
@@ -110,17 +134,17 @@ func (t *TokenAuthService) Auth(ctx context.Context, text string, username strin
 			return TokenError, fmt.Errorf("%s:%w", op, err)
 		}
 		logg.InfoContext(ctx, "token stored and cached")
-		return TokenInserted, nil
+		return status, nil
 	}
 	return TokenFound, nil
 }
 
-func (t *TokenAuthService) isToken(ctx context.Context, text string) error {
+func (t *TokenAuthService) isToken(ctx context.Context, text string) (tinkoffApi.TokenScope, error) {
 	const op = "telegram.isToken"
 
 	chatID, err := valuefromcontext.GetChatIDFromCtxStr(ctx)
 	if err != nil {
-		return fmt.Errorf("%s:%w", op, err)
+		return tinkoffApi.TokenScope{}, fmt.Errorf("%s:%w", op, err)
 	}
 
 	log := t.logger.With(
@@ -131,19 +155,40 @@ func (t *TokenAuthService) isToken(ctx context.Context, text string) error {
 	if len(text) == 88 { // TODO:модифицировать проверку
 		tokenInBase64, err := t.tokenToBase64(text)
 		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
+			return tinkoffApi.TokenScope{}, fmt.Errorf("%s:%w", op, err)
 		}
 
-		err = t.tinkoffApi.CheckToken(ctx, tokenInBase64)
+		// Запрос прав заодно проверяет токен
+		scope, err := t.tinkoffApi.GetTokenScope(ctx, tokenInBase64)
+		if errors.Is(err, tinkoffApi.ErrUnauthorized) {
+			return tinkoffApi.TokenScope{}, ErrIncorrectToken
+		}
 		if err != nil {
-			return fmt.Errorf("%s:%w", op, err)
+			return tinkoffApi.TokenScope{}, fmt.Errorf("%s:%w", op, err)
 		}
 
-		log.Info("token validated")
-		return nil
+		log.Info("token validated", slog.String("access_level", scope.AccessLevel))
+		return scope, nil
 	}
 
-	return errors.New("is not token")
+	return tinkoffApi.TokenScope{}, ErrIncorrectToken
+}
+
+// applyScopePolicy решает, можно ли сохранить токен с данными правами.
+// Read-only токены принимаются всегда, полный доступ - по политике,
+// а токены без доступа к счетам или с неизвестным уровнем доступа отклоняются.
+func applyScopePolicy(scope tinkoffApi.TokenScope, policy TokenPolicy) (TokenStatus, error) {
+	switch scope.AccessLevel {
+	case tinkoffApi.AccessLevelReadOnly:
+		return TokenInserted, nil
+	case tinkoffApi.AccessLevelFullAccess:
+		if policy == TokenPolicyWarn {
+			return TokenInsertedFullAccess, nil
+		}
+		return TokenError, ErrFullAccessToken
+	default:
+		return TokenError, ErrNoAccessToken
+	}
 }
 
 func (t *TokenAuthService) checkUserToken(ctx context.Context) (res bool, err error) {
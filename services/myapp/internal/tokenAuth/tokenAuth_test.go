@@ -0,0 +1,69 @@
+//go:build unit
+
+package tokenauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"main.go/clients/tinkoffApi"
+)
+
+func TestApplyScopePolicy(t *testing.T) {
+	cases := []struct {
+		name       string
+		scope      tinkoffApi.TokenScope
+		policy     TokenPolicy
+		wantStatus TokenStatus
+		wantErr    error
+	}{
+		{
+			name:       "read only token accepted",
+			scope:      tinkoffApi.TokenScope{AccessLevel: tinkoffApi.AccessLevelReadOnly, ReadOnly: true},
+			policy:     TokenPolicyRefuse,
+			wantStatus: TokenInserted,
+		},
+		{
+			name:       "full access token refused",
+			scope:      tinkoffApi.TokenScope{AccessLevel: tinkoffApi.AccessLevelFullAccess},
+			policy:     TokenPolicyRefuse,
+			wantStatus: TokenError,
+			wantErr:    ErrFullAccessToken,
+		},
+		{
+			name:       "full access token accepted with warning",
+			scope:      tinkoffApi.TokenScope{AccessLevel: tinkoffApi.AccessLevelFullAccess},
+			policy:     TokenPolicyWarn,
+			wantStatus: TokenInsertedFullAccess,
+		},
+		{
+			name:       "no access token refused",
+			scope:      tinkoffApi.TokenScope{AccessLevel: tinkoffApi.AccessLevelNoAccess},
+			policy:     TokenPolicyWarn,
+			wantStatus: TokenError,
+			wantErr:    ErrNoAccessToken,
+		},
+		{
+			name:       "unspecified access level refused",
+			scope:      tinkoffApi.TokenScope{},
+			policy:     TokenPolicyWarn,
+			wantStatus: TokenError,
+			wantErr:    ErrNoAccessToken,
+		},
+		{
+			name:       "unknown policy refuses full access",
+			scope:      tinkoffApi.TokenScope{AccessLevel: tinkoffApi.AccessLevelFullAccess},
+			policy:     "",
+			wantStatus: TokenError,
+			wantErr:    ErrFullAccessToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, err := applyScopePolicy(tc.scope, tc.policy)
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
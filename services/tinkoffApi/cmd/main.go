@@ -10,6 +10,9 @@ import (
 	"time"
 	"tinkoffApi/internal/configs"
 	"tinkoffApi/internal/handlers"
+	"tinkoffApi/internal/instrumentcache"
+	"tinkoffApi/internal/pricestream"
+	"tinkoffApi/internal/ratelimit"
 	redisClient "tinkoffApi/internal/repository/redis"
 	"tinkoffApi/internal/service"
 	loggeradapter "tinkoffApi/lib/logger/loggerAdapter"
@@ -40,30 +43,71 @@ func main() {
 	logg.Info("initialize logger adapter")
 	loggAdapter := loggeradapter.NewLoggerAdapter(logg)
 
+	logg.Info("initialize rate limiter", slog.Int("quotas", len(confs.Config.RateLimit.Quotas)))
+	limiter := ratelimit.New(confs.Config.RateLimit)
+
+	logg.Info("initialize client pool",
+		slog.Int("max_size", confs.Config.ClientPool.MaxSize),
+		slog.Duration("idle_ttl", confs.Config.ClientPool.IdleTTL))
+	clientPools := service.Pools{
+		Prod: service.NewClientPool(logg, confs.TinkoffApiConfig, loggAdapter, confs.Config.ClientPool, limiter),
+	}
+	if confs.Config.Sandbox.Enabled {
+		logg.Info("initialize sandbox client pool", slog.String("endpoint", confs.Config.Sandbox.EndPoint))
+		sandboxConfig := *confs.TinkoffApiConfig
+		sandboxConfig.EndPoint = confs.Config.Sandbox.EndPoint
+		clientPools.Sandbox = service.NewClientPool(logg, &sandboxConfig, loggAdapter, confs.Config.ClientPool, limiter)
+	}
+
+	logg.Info("initialize redis", slog.String("adress", confs.Config.RedisHTTPServer.GetAddress()))
+	redis, err := redisClient.NewClient(ctx, confs.Config)
+	if err != nil {
+		logg.Error("haven't connect with redis", slog.String("error", err.Error()))
+	}
+
+	logg.Info("initialize instrument cache", slog.Duration("ttl", confs.Config.InstrumentCache.TTL))
+	instrumentCache := instrumentcache.New(logg, redisClient.NewInstrumentStore(redis), confs.Config.InstrumentCache)
+
 	logg.Info("initialize analyticsService")
-	analyticsService := service.NewAnalyticsServiceClient(confs.TinkoffApiConfig, loggAdapter)
+	analyticsService := service.NewAnalyticsServiceClient(clientPools, limiter, loggAdapter)
 	logg.Info("initialize portfolioService")
-	portfolioService := service.NewPortfolioServiceClient(confs.TinkoffApiConfig, loggAdapter)
+	portfolioService := service.NewPortfolioServiceClient(clientPools, limiter, loggAdapter)
 	logg.Info("initialize instrumentService")
-	instrumentService := service.NewInstrumentServiceClient(confs.TinkoffApiConfig, loggAdapter)
+	instrumentService := service.NewCachedInstrumentService(
+		service.NewInstrumentServiceClient(clientPools, limiter, loggAdapter),
+		instrumentCache)
+
+	var sandboxService service.SandboxService
+	if clientPools.Sandbox != nil {
+		logg.Info("initialize sandboxService")
+		sandboxService = service.NewSandboxServiceClient(clientPools, limiter, loggAdapter)
+	}
 
 	logg.Info("initialize serviceClient")
 	serviceClient := service.NewService(
 		analyticsService,
 		portfolioService,
-		instrumentService)
+		instrumentService,
+		sandboxService)
 
 	logg.Info("initialize tokenCrypter")
 	tokenCrypter := cryptotoken.NewTokenCrypter(confs.Config.Key)
 
-	logg.Info("initialize redis", slog.String("adress", confs.Config.RedisHTTPServer.GetAddress()))
-	redis, err := redisClient.NewClient(ctx, confs.Config)
-	if err != nil {
-		logg.Error("haven't connect with redis", slog.String("error", err.Error()))
-	}
+	logg.Info("initialize price stream", slog.Duration("reconnect_delay", confs.Config.PriceStream.ReconnectDelay))
+	priceBoard := pricestream.NewBoard(logg, redisClient.NewPriceStore(redis, confs.Config.PriceStream.TTL))
+	priceStreamer := pricestream.NewStreamer(logg,
+		priceBoard,
+		service.NewPriceFeedDialer(clientPools.Prod, loggAdapter),
+		redisClient.NewWatchStore(redis),
+		confs.Config.PriceStream)
+	go priceStreamer.Run()
 
 	logg.Info("initialize handlers")
-	handlrs := handlers.NewHandlers(logg, serviceClient, tokenCrypter, redis)
+	handlrs := handlers.NewHandlers(logg, serviceClient, tokenCrypter, redis, instrumentCache, priceBoard, priceStreamer,
+		redisClient.NewSandboxModeStore(redis))
+
+	logg.Info("watch prices of users holdings", slog.Duration("interval", confs.Config.PriceStream.HoldingsInterval))
+	go handlrs.RunWatchHoldings(ctx, redisClient.NewUserStore(redis), confs.Config.PriceStream.HoldingsInterval)
 
 	logg.Info("initialize router echo")
 	router := echo.New()
@@ -72,19 +116,44 @@ func main() {
 	router.Use(handlrs.ContextHeaderTraceIdMiddleWare)
 	router.Use(handlrs.LoggerMiddleWare)
 	router.Use(handlrs.CheckTokenFromRedisByChatIDMiddleWare)
+	router.Use(handlrs.SandboxModeMiddleWare)
 
 	router.GET("/tinkoff/checktoken", handlrs.CheckToken, handlrs.CheckTokenFromHeadersMiddleWare)
+	router.GET("/tinkoff/tokenscope", handlrs.GetTokenScope, handlrs.CheckTokenFromHeadersMiddleWare)
 	router.GET("/tinkoff/accounts", handlrs.GetAccounts)
 	router.POST("/tinkoff/portfolio", handlrs.GetPortfolio)
 	router.POST("/tinkoff/operations", handlrs.GetOperations)
+	router.POST("/tinkoff/operations/page", handlrs.GetOperationsPage)
+	router.POST("/tinkoff/positions", handlrs.GetPositions)
+	router.POST("/tinkoff/withdrawlimits", handlrs.GetWithdrawLimits)
+	router.POST("/tinkoff/marginattributes", handlrs.GetMarginAttributes)
 	router.GET("/tinkoff/allassetsuid", handlrs.GetAllAssetUids)
 	router.POST("/tinkoff/future", handlrs.GetFutureBy)
 	router.POST("/tinkoff/bond", handlrs.GetBondBy)
+	router.POST("/tinkoff/dividends", handlrs.GetDividends)
+	router.POST("/tinkoff/assetreports", handlrs.GetAssetReports)
 	router.POST("/tinkoff/currency", handlrs.GetCurrencyBy)
 	router.POST("/tinkoff/share/currency", handlrs.GetShareCurrencyBy)
 	router.POST("/tinkoff/findby", handlrs.FindBy)
 	router.POST("/tinkoff/bondactions", handlrs.GetBondsActions)
 	router.POST("/tinkoff/lastprice", handlrs.GetLastPriceInPersentageToNominal)
+	router.POST("/tinkoff/bonds", handlrs.GetBondsBy)
+	router.POST("/tinkoff/futures", handlrs.GetFuturesBy)
+	router.POST("/tinkoff/currencies", handlrs.GetCurrenciesBy)
+	router.POST("/tinkoff/bondsactions", handlrs.GetBondsActionsBatch)
+	router.POST("/tinkoff/lastprices", handlrs.GetLastPricesInPersentageToNominal)
+	router.GET("/tinkoff/cache/stats", handlrs.GetInstrumentCacheStats)
+	router.DELETE("/tinkoff/cache/instruments", handlrs.InvalidateInstrumentCache)
+	router.POST("/tinkoff/prices/watch", handlrs.WatchPrices)
+	router.DELETE("/tinkoff/prices/watch", handlrs.UnwatchPrices)
+	router.GET("/tinkoff/prices", handlrs.GetPrices)
+	router.GET("/tinkoff/prices/stream", handlrs.StreamPrices)
+	router.POST("/tinkoff/sandbox/accounts", handlrs.OpenSandboxAccount)
+	router.DELETE("/tinkoff/sandbox/accounts/:id", handlrs.CloseSandboxAccount)
+	router.POST("/tinkoff/sandbox/payin", handlrs.SandboxPayIn)
+	router.POST("/tinkoff/sandbox/orders", handlrs.PostSandboxOrder)
+	router.PUT("/tinkoff/sandbox/mode", handlrs.EnableSandboxMode)
+	router.DELETE("/tinkoff/sandbox/mode", handlrs.DisableSandboxMode)
 
 	address := confs.Config.GetTinkoffAppAddress()
 
@@ -96,6 +165,9 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// SSE стримы цен сами не завершаются, закрываем их при остановке сервера
+	httpSrv.RegisterOnShutdown(priceBoard.Close)
+
 	errCh := make(chan error, 1)
 
 	go func() {
@@ -110,15 +182,29 @@ func main() {
 	case err = <-errCh:
 		logg.ErrorContext(ctx, "server stopped with error", slog.Any("error", err))
 	}
-	gracefulShutdown(ctx, logg, httpSrv)
+	gracefulShutdown(ctx, logg, httpSrv, priceStreamer, clientPools)
+}
+
+type poolCloser interface {
+	Close(ctx context.Context) error
 }
 
-func gracefulShutdown(ctx context.Context, logg *slog.Logger, httpSrv *http.Server) {
+type streamCloser interface {
+	Close()
+}
+
+func gracefulShutdown(ctx context.Context, logg *slog.Logger, httpSrv *http.Server, streamer streamCloser, pool poolCloser) {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
 		logg.ErrorContext(ctx, "Forced shutdown", slog.Any("err", err))
 	}
+	// стрим цен держит клиента из пула, поэтому закрывается до пула
+	streamer.Close()
+	// после остановки http сервера новых запросов нет, ждем текущие и закрываем соединения
+	if err := pool.Close(shutdownCtx); err != nil {
+		logg.ErrorContext(ctx, "client pool closed with error", slog.Any("err", err))
+	}
 	logg.InfoContext(ctx, "Server exited gracefully")
 }
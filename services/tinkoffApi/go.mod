@@ -4,7 +4,10 @@ go 1.24.11
 
 require (
 	github.com/gladinov/contracts v0.1.5
+	github.com/gladinov/cryptotoken v0.1.0
 	github.com/gladinov/e v0.2.0
+	github.com/gladinov/traceidgenerator v0.1.0
+	github.com/gladinov/valuefromcontext v0.2.0
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	github.com/joho/godotenv v1.5.1
 	github.com/labstack/echo/v4 v4.13.4
@@ -16,9 +19,6 @@ require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	github.com/gladinov/cryptotoken v0.1.0 // indirect
-	github.com/gladinov/traceidgenerator v0.1.0 // indirect
-	github.com/gladinov/valuefromcontext v0.2.0 // indirect
 	github.com/labstack/gommon v0.4.2 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
@@ -49,7 +49,7 @@ require (
 	golang.org/x/text v0.25.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230530153820-e85fd2cbaebc // indirect
-	google.golang.org/grpc v1.57.0 // indirect
-	google.golang.org/protobuf v1.31.0 // indirect
+	google.golang.org/grpc v1.57.0
+	google.golang.org/protobuf v1.31.0
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
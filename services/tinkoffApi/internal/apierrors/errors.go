@@ -0,0 +1,174 @@
+package apierrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Code - машиночитаемый код ошибки, который tinkoffApi отдает клиентам.
+// Code сам является ошибкой, поэтому тип ошибки проверяется через errors.Is(err, apierrors.NotFound).
+type Code string
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+const (
+	Unauthorized    Code = "unauthorized"
+	NotFound        Code = "not_found"
+	RateLimited     Code = "rate_limited"
+	InvalidArgument Code = "invalid_argument"
+	Unavailable     Code = "unavailable"
+	Internal        Code = "internal"
+)
+
+// Error - ошибка с кодом для клиента и, если она пришла из Invest API, кодом ошибки Тинькофф.
+type Error struct {
+	Code Code
+	// TinkoffCode - код ошибки Invest API, например 30070 или 80002.
+	TinkoffCode string
+	Message     string
+	Err         error
+}
+
+// New создает ошибку с кодом, подходит для объявления sentinel ошибок.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	msg := string(e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.TinkoffCode != "" {
+		msg += " (tinkoff code " + e.TinkoffCode + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && code == e.Code
+}
+
+// FromError определяет тип ошибки: уже типизированной, gRPC статуса Invest API или ошибки контекста.
+// Все остальное считается внутренней ошибкой.
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	if st, ok := grpcStatus(err); ok {
+		return &Error{
+			Code:        codeFromGRPC(st.Code()),
+			TinkoffCode: tinkoffCode(st.Message()),
+			Err:         err,
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return &Error{Code: Unavailable, Err: err}
+	}
+	return &Error{Code: Internal, Err: err}
+}
+
+// TinkoffCode возвращает код ошибки Invest API из цепочки ошибок или пустую строку.
+func TinkoffCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	return FromError(err).TinkoffCode
+}
+
+// HTTPStatus возвращает HTTP статус для кода ошибки.
+func HTTPStatus(code Code) int {
+	switch code {
+	case Unauthorized:
+		return http.StatusUnauthorized
+	case NotFound:
+		return http.StatusNotFound
+	case RateLimited:
+		return http.StatusTooManyRequests
+	case InvalidArgument:
+		return http.StatusBadRequest
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Response - тело ответа tinkoffApi с ошибкой.
+type Response struct {
+	Code        Code   `json:"code"`
+	Message     string `json:"message"`
+	TinkoffCode string `json:"tinkoffCode,omitempty"`
+}
+
+// NewResponse собирает тело ответа. Детали внутренних ошибок клиенту не отдаются.
+func NewResponse(err *Error, internalMessage string) Response {
+	msg := err.Message
+	if msg == "" || err.Code == Internal {
+		msg = internalMessage
+	}
+	return Response{
+		Code:        err.Code,
+		Message:     msg,
+		TinkoffCode: err.TinkoffCode,
+	}
+}
+
+// grpcStatus достает исходный статус из цепочки ошибок.
+// status.FromError для обернутой ошибки подменяет сообщение статуса текстом всей цепочки,
+// а в сообщении Invest API передает свой код ошибки.
+func grpcStatus(err error) (*status.Status, bool) {
+	var gs interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &gs) || gs.GRPCStatus() == nil {
+		return nil, false
+	}
+	return gs.GRPCStatus(), true
+}
+
+func codeFromGRPC(code codes.Code) Code {
+	switch code {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return Unauthorized
+	case codes.NotFound:
+		return NotFound
+	case codes.ResourceExhausted:
+		return RateLimited
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.AlreadyExists:
+		return InvalidArgument
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.Aborted:
+		return Unavailable
+	default:
+		return Internal
+	}
+}
+
+// tinkoffCode возвращает код Invest API, если сообщение статуса состоит из цифр.
+func tinkoffCode(message string) string {
+	if message == "" {
+		return ""
+	}
+	for _, r := range message {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return message
+}
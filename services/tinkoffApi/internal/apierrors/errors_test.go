@@ -0,0 +1,117 @@
+//go:build unit
+
+package apierrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		wantCode        Code
+		wantTinkoffCode string
+	}{
+		{
+			name:            "wrapped grpc status with tinkoff code",
+			err:             fmt.Errorf("op: %w", status.Error(codes.InvalidArgument, "30070")),
+			wantCode:        InvalidArgument,
+			wantTinkoffCode: "30070",
+		},
+		{
+			name:            "rate limit",
+			err:             status.Error(codes.ResourceExhausted, "80002"),
+			wantCode:        RateLimited,
+			wantTinkoffCode: "80002",
+		},
+		{
+			name:     "unauthenticated without tinkoff code",
+			err:      status.Error(codes.Unauthenticated, "token is invalid"),
+			wantCode: Unauthorized,
+		},
+		{
+			name:     "permission denied",
+			err:      status.Error(codes.PermissionDenied, "40002"),
+			wantCode: Unauthorized,
+			// Код Тинькофф сохраняется для любого статуса
+			wantTinkoffCode: "40002",
+		},
+		{
+			name:            "not found",
+			err:             status.Error(codes.NotFound, "50002"),
+			wantCode:        NotFound,
+			wantTinkoffCode: "50002",
+		},
+		{
+			name:     "unavailable",
+			err:      status.Error(codes.Unavailable, "connection refused"),
+			wantCode: Unavailable,
+		},
+		{
+			name:     "context deadline",
+			err:      fmt.Errorf("op: %w", context.DeadlineExceeded),
+			wantCode: Unavailable,
+		},
+		{
+			name:     "typed error",
+			err:      fmt.Errorf("op: %w", New(InvalidArgument, "figi could not be empty string")),
+			wantCode: InvalidArgument,
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("boom"),
+			wantCode: Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromError(tt.err)
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantTinkoffCode, got.TinkoffCode)
+			assert.ErrorIs(t, got, tt.wantCode)
+		})
+	}
+
+	assert.Nil(t, FromError(nil))
+}
+
+func TestErrorIs(t *testing.T) {
+	errEmptyFigi := New(InvalidArgument, "figi could not be empty string")
+	errEmptyUid := New(InvalidArgument, "uid could not be empty string")
+	err := fmt.Errorf("op: %w", errEmptyFigi)
+
+	assert.ErrorIs(t, err, errEmptyFigi)
+	assert.ErrorIs(t, err, InvalidArgument)
+	assert.NotErrorIs(t, err, errEmptyUid, "sentinels with the same code must differ")
+	assert.NotErrorIs(t, err, NotFound)
+}
+
+func TestTinkoffCode(t *testing.T) {
+	err := fmt.Errorf("op: %w", fmt.Errorf("inner: %w", status.Error(codes.InvalidArgument, "30070")))
+	assert.Equal(t, "30070", TinkoffCode(err))
+	assert.Empty(t, TinkoffCode(errors.New("30070")))
+	assert.Empty(t, TinkoffCode(nil))
+}
+
+func TestNewResponse(t *testing.T) {
+	resp := NewResponse(FromError(status.Error(codes.ResourceExhausted, "80002")), "could not get data")
+	assert.Equal(t, Response{Code: RateLimited, Message: "could not get data", TinkoffCode: "80002"}, resp)
+
+	resp = NewResponse(New(InvalidArgument, "invalid request body"), "could not get data")
+	assert.Equal(t, "invalid request body", resp.Message)
+
+	resp = NewResponse(FromError(errors.New("db password leaked")), "could not get data")
+	assert.Equal(t, Response{Code: Internal, Message: "could not get data"}, resp)
+
+	assert.Equal(t, http.StatusTooManyRequests, HTTPStatus(RateLimited))
+	assert.Equal(t, http.StatusInternalServerError, HTTPStatus(Internal))
+}
@@ -0,0 +1,393 @@
+package clientpool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSize            = 100
+	defaultIdleTTL            = 5 * time.Minute
+	defaultHealthCheckTimeout = 5 * time.Second
+	closePollInterval         = 20 * time.Millisecond
+)
+
+var ErrClosed = errors.New("client pool is closed")
+
+// Client - соединение, которое пул умеет закрыть (investgo.Client).
+type Client interface {
+	Stop() error
+}
+
+// Dialer создает клиента для токена. ctx живет столько же, сколько пул:
+// investgo сохраняет его в клиенте и использует для всех последующих вызовов.
+type Dialer[C Client] func(ctx context.Context, token string) (C, error)
+
+// HealthChecker проверяет, что соединение клиента еще рабочее.
+type HealthChecker[C Client] func(ctx context.Context, client C) error
+
+type Config struct {
+	// MaxSize - максимальное число клиентов (токенов) в пуле.
+	MaxSize int `yaml:"maxSize" env-default:"100"`
+	// IdleTTL - через сколько неиспользуемый клиент закрывается.
+	IdleTTL time.Duration `yaml:"idleTTL" env-default:"5m"`
+	// HealthCheckInterval - как часто проверять простаивающих клиентов, 0 - не проверять.
+	HealthCheckInterval time.Duration `yaml:"healthCheckInterval" env-default:"1m"`
+}
+
+type Stats struct {
+	Size      int
+	InUse     int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+type entry[C Client] struct {
+	client      C
+	err         error
+	ready       chan struct{}
+	refs        int
+	lastUsed    time.Time
+	lastChecked time.Time
+	// discarded - клиент удален из пула, пока им пользовались;
+	// закрывается, когда его отпустит последний запрос.
+	discarded bool
+}
+
+func (e *entry[C]) isReady() bool {
+	select {
+	case <-e.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// Pool хранит по одному клиенту на токен и раздает его конкурентным запросам.
+// Клиенты различаются по хешу токена, сам токен в пуле не хранится.
+type Pool[C Client] struct {
+	logger *slog.Logger
+	cfg    Config
+	dial   Dialer[C]
+	check  HealthChecker[C]
+
+	mu      sync.Mutex
+	entries map[string]*entry[C]
+	closed  bool
+	stats   Stats
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	wg     sync.WaitGroup
+	now    func() time.Time
+}
+
+// New создает пул и запускает фоновую очистку. check может быть nil.
+func New[C Client](logger *slog.Logger, cfg Config, dial Dialer[C], check HealthChecker[C]) *Pool[C] {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	if cfg.IdleTTL <= 0 {
+		cfg.IdleTTL = defaultIdleTTL
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool[C]{
+		logger:  logger,
+		cfg:     cfg,
+		dial:    dial,
+		check:   check,
+		entries: make(map[string]*entry[C]),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		now:     time.Now,
+	}
+
+	p.wg.Add(1)
+	go p.janitor()
+
+	return p
+}
+
+// Get возвращает клиента для токена и функцию, которую нужно вызвать,
+// когда запрос закончил с ним работать. Останавливать клиента нельзя.
+func (p *Pool[C]) Get(ctx context.Context, token string) (_ C, release func(), err error) {
+	const op = "clientpool.Get"
+
+	var zero C
+	key := hashToken(token)
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return zero, nil, fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+
+	if e, ok := p.entries[key]; ok {
+		e.refs++
+		e.lastUsed = p.now()
+		p.stats.Hits++
+		p.mu.Unlock()
+
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			p.release(e)
+			return zero, nil, fmt.Errorf("%s: %w", op, ctx.Err())
+		}
+		if e.err != nil {
+			p.release(e)
+			return zero, nil, fmt.Errorf("%s: %w", op, e.err)
+		}
+		return e.client, p.releaser(e), nil
+	}
+
+	p.stats.Misses++
+	if len(p.entries) >= p.cfg.MaxSize && !p.evictLRULocked() {
+		p.mu.Unlock()
+		// все клиенты заняты: отдаем отдельного клиента, он закроется после запроса
+		p.logger.WarnContext(ctx, "client pool is full, using unpooled client",
+			slog.String("op", op),
+			slog.Int("max_size", p.cfg.MaxSize),
+		)
+		client, err := p.dial(p.ctx, token)
+		if err != nil {
+			return zero, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return client, func() { p.stop(client) }, nil
+	}
+
+	e := &entry[C]{
+		ready:    make(chan struct{}),
+		refs:     1,
+		lastUsed: p.now(),
+	}
+	p.entries[key] = e
+	p.mu.Unlock()
+
+	client, err := p.dial(p.ctx, token)
+
+	p.mu.Lock()
+	e.client, e.err = client, err
+	e.lastChecked = p.now()
+	if err != nil && p.entries[key] == e {
+		delete(p.entries, key)
+	}
+	close(e.ready)
+	p.mu.Unlock()
+
+	if err != nil {
+		return zero, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return client, p.releaser(e), nil
+}
+
+// Stats возвращает текущее состояние пула.
+func (p *Pool[C]) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Size = len(p.entries)
+	for _, e := range p.entries {
+		if e.refs > 0 {
+			stats.InUse++
+		}
+	}
+	return stats
+}
+
+// Close перестает выдавать клиентов, ждет, пока запросы отпустят занятых
+// (не дольше ctx), и закрывает все соединения.
+func (p *Pool[C]) Close(ctx context.Context) error {
+	const op = "clientpool.Close"
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	var waitErr error
+	for waitErr == nil && p.Stats().InUse > 0 {
+		select {
+		case <-ctx.Done():
+			waitErr = fmt.Errorf("%s: clients still in use: %w", op, ctx.Err())
+		case <-time.After(closePollInterval):
+		}
+	}
+
+	p.mu.Lock()
+	entries := p.entries
+	p.entries = make(map[string]*entry[C])
+	p.mu.Unlock()
+
+	for _, e := range entries {
+		if e.isReady() && e.err == nil {
+			p.stop(e.client)
+		}
+	}
+	p.cancel()
+
+	return waitErr
+}
+
+func (p *Pool[C]) releaser(e *entry[C]) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { p.release(e) })
+	}
+}
+
+func (p *Pool[C]) release(e *entry[C]) {
+	p.mu.Lock()
+	e.refs--
+	e.lastUsed = p.now()
+	stop := e.discarded && e.refs == 0 && e.err == nil
+	p.mu.Unlock()
+
+	if stop {
+		p.stop(e.client)
+	}
+}
+
+// evictLRULocked закрывает давно не использованного свободного клиента,
+// чтобы освободить место. Возвращает false, если свободных клиентов нет.
+func (p *Pool[C]) evictLRULocked() bool {
+	var (
+		lruKey string
+		lru    *entry[C]
+	)
+	for key, e := range p.entries {
+		if e.refs > 0 || !e.isReady() {
+			continue
+		}
+		if lru == nil || e.lastUsed.Before(lru.lastUsed) {
+			lruKey, lru = key, e
+		}
+	}
+	if lru == nil {
+		return false
+	}
+
+	delete(p.entries, lruKey)
+	p.stats.Evictions++
+	go p.stop(lru.client)
+	return true
+}
+
+func (p *Pool[C]) janitor() {
+	defer p.wg.Done()
+
+	interval := p.cfg.IdleTTL / 2
+	if p.cfg.HealthCheckInterval > 0 && p.cfg.HealthCheckInterval/2 < interval {
+		interval = p.cfg.HealthCheckInterval / 2
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.evictIdle()
+			p.healthCheck()
+		}
+	}
+}
+
+// evictIdle закрывает клиентов, которыми не пользовались дольше IdleTTL.
+func (p *Pool[C]) evictIdle() {
+	now := p.now()
+
+	p.mu.Lock()
+	idle := make([]C, 0)
+	for key, e := range p.entries {
+		if e.refs > 0 || !e.isReady() || now.Sub(e.lastUsed) < p.cfg.IdleTTL {
+			continue
+		}
+		delete(p.entries, key)
+		p.stats.Evictions++
+		idle = append(idle, e.client)
+	}
+	p.mu.Unlock()
+
+	for _, client := range idle {
+		p.stop(client)
+	}
+}
+
+// healthCheck проверяет клиентов, которых давно не проверяли, и выкидывает
+// из пула сломанных. Занятый клиент закроется, когда его отпустят.
+func (p *Pool[C]) healthCheck() {
+	const op = "clientpool.healthCheck"
+
+	if p.check == nil || p.cfg.HealthCheckInterval <= 0 {
+		return
+	}
+	now := p.now()
+
+	p.mu.Lock()
+	due := make(map[string]*entry[C])
+	for key, e := range p.entries {
+		if e.isReady() && now.Sub(e.lastChecked) >= p.cfg.HealthCheckInterval {
+			e.lastChecked = now
+			due[key] = e
+		}
+	}
+	p.mu.Unlock()
+
+	for key, e := range due {
+		ctx, cancel := context.WithTimeout(p.ctx, defaultHealthCheckTimeout)
+		err := p.check(ctx, e.client)
+		cancel()
+		if err == nil {
+			continue
+		}
+
+		p.logger.Warn("client health check failed",
+			slog.String("op", op),
+			slog.String("error", err.Error()),
+		)
+
+		p.mu.Lock()
+		removed := p.entries[key] == e
+		if removed {
+			delete(p.entries, key)
+			p.stats.Evictions++
+			e.discarded = true
+		}
+		stop := removed && e.refs == 0
+		p.mu.Unlock()
+
+		if stop {
+			p.stop(e.client)
+		}
+	}
+}
+
+func (p *Pool[C]) stop(client C) {
+	if err := client.Stop(); err != nil {
+		p.logger.Warn("client stop failed", slog.String("error", err.Error()))
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+//go:build unit
+
+package clientpool
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Бенчмарки сравнивают клиента на каждый запрос (как было в handlers) с пулом
+// на in-process gRPC сервере. Соединение без TLS, поэтому в проде разница больше:
+// investgo.NewClient еще и делает TLS handshake.
+
+type grpcClient struct {
+	conn   *grpc.ClientConn
+	health healthpb.HealthClient
+}
+
+func (c *grpcClient) Stop() error {
+	return c.conn.Close()
+}
+
+func startFakeServer(b *testing.B) string {
+	b.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	b.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func grpcDialer(addr string) Dialer[*grpcClient] {
+	return func(ctx context.Context, _ string) (*grpcClient, error) {
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+		return &grpcClient{conn: conn, health: healthpb.NewHealthClient(conn)}, nil
+	}
+}
+
+func callFakeServer(b *testing.B, client *grpcClient) {
+	if _, err := client.health.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkClientPerRequest(b *testing.B) {
+	dial := grpcDialer(startFakeServer(b))
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		client, err := dial(ctx, "token")
+		if err != nil {
+			b.Fatal(err)
+		}
+		callFakeServer(b, client)
+		_ = client.Stop()
+	}
+}
+
+func BenchmarkPooledClient(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := New(logger, Config{}, grpcDialer(startFakeServer(b)), nil)
+	ctx := context.Background()
+	defer func() { _ = p.Close(ctx) }()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		client, release, err := p.Get(ctx, "token")
+		if err != nil {
+			b.Fatal(err)
+		}
+		callFakeServer(b, client)
+		release()
+	}
+}
+
+func BenchmarkPooledClientParallel(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := New(logger, Config{}, grpcDialer(startFakeServer(b)), nil)
+	ctx := context.Background()
+	defer func() { _ = p.Close(ctx) }()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			client, release, err := p.Get(ctx, "token")
+			if err != nil {
+				b.Fatal(err)
+			}
+			callFakeServer(b, client)
+			release()
+		}
+	})
+}
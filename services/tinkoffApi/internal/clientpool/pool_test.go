@@ -0,0 +1,243 @@
+//go:build unit
+
+package clientpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	token   string
+	stopped atomic.Bool
+}
+
+func (c *fakeClient) Stop() error {
+	c.stopped.Store(true)
+	return nil
+}
+
+type fakeDialer struct {
+	mu      sync.Mutex
+	clients []*fakeClient
+	err     error
+	delay   time.Duration
+}
+
+func (d *fakeDialer) dial(_ context.Context, token string) (*fakeClient, error) {
+	time.Sleep(d.delay)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return nil, d.err
+	}
+	client := &fakeClient{token: token}
+	d.clients = append(d.clients, client)
+	return client, nil
+}
+
+func (d *fakeDialer) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.clients)
+}
+
+func newTestPool(t *testing.T, cfg Config, d *fakeDialer, check HealthChecker[*fakeClient]) *Pool[*fakeClient] {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := New(logger, cfg, d.dial, check)
+	t.Cleanup(func() { _ = p.Close(context.Background()) })
+	return p
+}
+
+func TestPoolReusesClient(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{}
+	p := newTestPool(t, Config{}, d, nil)
+
+	c1, release1, err := p.Get(ctx, "token")
+	require.NoError(t, err)
+	c2, release2, err := p.Get(ctx, "token")
+	require.NoError(t, err)
+	require.Same(t, c1, c2)
+	require.Equal(t, 1, d.count())
+
+	stats := p.Stats()
+	require.Equal(t, 1, stats.Size)
+	require.Equal(t, 1, stats.InUse)
+	require.EqualValues(t, 1, stats.Hits)
+	require.EqualValues(t, 1, stats.Misses)
+
+	release1()
+	release1()
+	release2()
+	require.Zero(t, p.Stats().InUse)
+	require.False(t, c1.stopped.Load())
+
+	c3, release3, err := p.Get(ctx, "other")
+	require.NoError(t, err)
+	defer release3()
+	require.NotSame(t, c1, c3)
+	require.Equal(t, 2, d.count())
+}
+
+func TestPoolConcurrentDialOnce(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{delay: 20 * time.Millisecond}
+	p := newTestPool(t, Config{}, d, nil)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, release, err := p.Get(ctx, "token")
+			require.NoError(t, err)
+			release()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, d.count())
+}
+
+func TestPoolDialError(t *testing.T) {
+	ctx := context.Background()
+	dialErr := errors.New("dial failed")
+	d := &fakeDialer{err: dialErr}
+	p := newTestPool(t, Config{}, d, nil)
+
+	_, _, err := p.Get(ctx, "token")
+	require.ErrorIs(t, err, dialErr)
+	require.Zero(t, p.Stats().Size)
+
+	d.err = nil
+	_, release, err := p.Get(ctx, "token")
+	require.NoError(t, err)
+	release()
+}
+
+func TestPoolMaxSize(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{}
+	p := newTestPool(t, Config{MaxSize: 1}, d, nil)
+
+	c1, release1, err := p.Get(ctx, "a")
+	require.NoError(t, err)
+
+	// единственный клиент занят - выдается клиент вне пула
+	c2, release2, err := p.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Equal(t, 1, p.Stats().Size)
+	release2()
+	require.True(t, c2.stopped.Load())
+
+	// после освобождения самый старый клиент вытесняется
+	release1()
+	_, release3, err := p.Get(ctx, "c")
+	require.NoError(t, err)
+	defer release3()
+	require.Eventually(t, c1.stopped.Load, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, p.Stats().Evictions)
+}
+
+func TestPoolEvictIdle(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{}
+	p := newTestPool(t, Config{IdleTTL: time.Hour}, d, nil)
+	now := time.Now()
+	p.mu.Lock()
+	p.now = func() time.Time { return now }
+	p.mu.Unlock()
+
+	idle, release, err := p.Get(ctx, "idle")
+	require.NoError(t, err)
+	release()
+	busy, releaseBusy, err := p.Get(ctx, "busy")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	p.evictIdle()
+
+	require.True(t, idle.stopped.Load())
+	require.False(t, busy.stopped.Load())
+	require.Equal(t, 1, p.Stats().Size)
+	releaseBusy()
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{}
+	check := func(_ context.Context, client *fakeClient) error {
+		if client.token == "broken" {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+	p := newTestPool(t, Config{HealthCheckInterval: time.Hour}, d, check)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	healthy, release, err := p.Get(ctx, "healthy")
+	require.NoError(t, err)
+	release()
+	broken, releaseBroken, err := p.Get(ctx, "broken")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	p.healthCheck()
+
+	// сломанный клиент удален из пула, но закроется только после release
+	require.Equal(t, 1, p.Stats().Size)
+	require.False(t, broken.stopped.Load())
+	releaseBroken()
+	require.True(t, broken.stopped.Load())
+	require.False(t, healthy.stopped.Load())
+}
+
+func TestPoolClose(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeDialer{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := New(logger, Config{}, d.dial, nil)
+
+	client, release, err := p.Get(ctx, "token")
+	require.NoError(t, err)
+
+	closed := make(chan error)
+	go func() { closed <- p.Close(ctx) }()
+
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.closed
+	}, time.Second, time.Millisecond)
+	_, _, err = p.Get(ctx, "token")
+	require.ErrorIs(t, err, ErrClosed)
+	require.False(t, client.stopped.Load())
+
+	release()
+	require.NoError(t, <-closed)
+	require.True(t, client.stopped.Load())
+}
+
+func TestPoolCloseTimeout(t *testing.T) {
+	d := &fakeDialer{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p := New(logger, Config{}, d.dial, nil)
+
+	client, _, err := p.Get(context.Background(), "token")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, p.Close(ctx), context.DeadlineExceeded)
+	require.True(t, client.stopped.Load())
+}
@@ -6,6 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+	"tinkoffApi/internal/clientpool"
+	"tinkoffApi/internal/instrumentcache"
+	"tinkoffApi/internal/pricestream"
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/russianinvestments/invest-api-go-sdk/investgo"
@@ -17,13 +22,18 @@ type Configs struct {
 }
 
 type Config struct {
-	Env               string          `env:"ENV" env-required:"true"`
-	RootPath          string          `env:"ROOT_PATH" env-required:"true"`
-	ConfigPath        string          `env:"CONFIG_PATH" env-required:"true"`
-	Key               string          `env:"KEY" env-required:"true"`
-	TinkoffApiAppPort string          `env:"TINKOFF_API_PORT" env-required:"true"`
-	TinkoffApiAppHost string          `yaml:"TinkoffApiAppHost"`
-	RedisHTTPServer   RedisHTTPServer `yaml:"redisHTTP"`
+	Env               string                 `env:"ENV" env-required:"true"`
+	RootPath          string                 `env:"ROOT_PATH" env-required:"true"`
+	ConfigPath        string                 `env:"CONFIG_PATH" env-required:"true"`
+	Key               string                 `env:"KEY" env-required:"true"`
+	TinkoffApiAppPort string                 `env:"TINKOFF_API_PORT" env-required:"true"`
+	TinkoffApiAppHost string                 `yaml:"TinkoffApiAppHost"`
+	RedisHTTPServer   RedisHTTPServer        `yaml:"redisHTTP"`
+	ClientPool        clientpool.Config      `yaml:"clientPool"`
+	InstrumentCache   instrumentcache.Config `yaml:"instrumentCache"`
+	PriceStream       pricestream.Config     `yaml:"priceStream"`
+	RateLimit         ratelimit.Config       `yaml:"rateLimit"`
+	Sandbox           sandbox.Config         `yaml:"sandbox"`
 }
 
 func (c *Config) GetTinkoffAppAddress() string {
@@ -2,13 +2,22 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"time"
+	"tinkoffApi/internal/apierrors"
+	"tinkoffApi/internal/instrumentcache"
+	"tinkoffApi/internal/pricestream"
 	"tinkoffApi/internal/service"
 
 	"github.com/gladinov/cryptotoken"
+	"github.com/gladinov/valuefromcontext"
+
+	httpheaders "github.com/gladinov/contracts/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -19,28 +28,53 @@ type Handlers struct {
 	service      *service.Service
 	tokenCrypter *cryptotoken.TokenCrypter
 	redis        *redis.Client
+	cache        *instrumentcache.Cache
+	prices       *pricestream.Board
+	streamer     *pricestream.Streamer
+	sandboxModes SandboxModeStore
 }
 
-func NewHandlers(logger *slog.Logger, service *service.Service, tokenCrypter *cryptotoken.TokenCrypter, redis *redis.Client) *Handlers {
+func NewHandlers(logger *slog.Logger,
+	service *service.Service,
+	tokenCrypter *cryptotoken.TokenCrypter,
+	redis *redis.Client,
+	cache *instrumentcache.Cache,
+	prices *pricestream.Board,
+	streamer *pricestream.Streamer,
+	sandboxModes SandboxModeStore,
+) *Handlers {
 	return &Handlers{
 		logger:       logger,
 		service:      service,
 		tokenCrypter: tokenCrypter,
 		redis:        redis,
+		cache:        cache,
+		prices:       prices,
+		streamer:     streamer,
+		sandboxModes: sandboxModes,
 	}
 }
 
 var (
-	errHeaderRequired     error = errors.New("header auth requierd")
-	errInvalidAuthFormat  error = errors.New("invalid Authorization format, expected: Bearer <token>")
-	errEmptyToken         error = errors.New("empty token")
-	errIncorrectToken     error = errors.New("incorrect token")
-	errNoTokenInRedis     error = errors.New("token not found for the provided user id")
-	errRedisDoNotAnswer   error = errors.New("token storage temporarily unavailable")
+	errHeaderRequired     error = apierrors.New(apierrors.Unauthorized, "header auth requierd")
+	errInvalidAuthFormat  error = apierrors.New(apierrors.Unauthorized, "invalid Authorization format, expected: Bearer <token>")
+	errEmptyToken         error = apierrors.New(apierrors.Unauthorized, "empty token")
+	errIncorrectToken     error = apierrors.New(apierrors.Unauthorized, "incorrect token")
+	errNoTokenInRedis     error = apierrors.New(apierrors.Unauthorized, "token not found for the provided user id")
+	errRedisDoNotAnswer   error = apierrors.New(apierrors.Unavailable, "token storage temporarily unavailable")
 	errGetData            error = errors.New("could not get data")
-	errInvalidRequestBody error = errors.New("invalid request body")
+	errInvalidRequestBody error = apierrors.New(apierrors.InvalidArgument, "invalid request body")
+	errKindRequired       error = apierrors.New(apierrors.InvalidArgument, "kind is required when id is set")
+	errPriceStream        error = apierrors.New(apierrors.Unavailable, "price stream temporarily unavailable")
 )
 
+// apiError отдает ошибку клиенту с HTTP статусом и машиночитаемым кодом из apierrors.
+// Ошибки без типа считаются внутренними, их текст клиенту не показывается.
+func apiError(err error) *echo.HTTPError {
+	apiErr := apierrors.FromError(err)
+	return echo.NewHTTPError(apierrors.HTTPStatus(apiErr.Code), apierrors.NewResponse(apiErr, errGetData.Error()))
+}
+
 func (h *Handlers) CheckToken(c echo.Context) (err error) {
 	const op = "handlers.CheckToken"
 
@@ -51,23 +85,44 @@ func (h *Handlers) CheckToken(c echo.Context) (err error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	client, err := h.service.PortfolioService.GetClient(ctx)
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
-	_, err = h.service.PortfolioService.GetAccounts(client)
+	defer release()
+	_, err = h.service.PortfolioService.GetAccounts(ctx, client)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetTokenScope проверяет токен и отдает его права одним запросом: неверный токен получает 401.
+func (h *Handlers) GetTokenScope(c echo.Context) (err error) {
+	const op = "handlers.GetTokenScope"
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.Debug("start")
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	scope, err := h.service.PortfolioService.GetTokenScope(ctx, client)
+	if err != nil {
+		return apiError(err)
+	}
+
+	return c.JSON(http.StatusOK, scope)
+}
+
 func (h *Handlers) GetAccounts(c echo.Context) (err error) {
 	const op = "handlers.GetAccount"
 	ctx := c.Request().Context()
@@ -77,20 +132,15 @@ func (h *Handlers) GetAccounts(c echo.Context) (err error) {
 	logg := h.logger.With(slog.String("op", op))
 	logg.DebugContext(ctx, "start")
 
-	client, err := h.service.PortfolioService.GetClient(ctx)
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
+	defer release()
 
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
-
-	accs, err := h.service.PortfolioService.GetAccounts(client)
+	accs, err := h.service.PortfolioService.GetAccounts(ctx, client)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, accs)
 }
@@ -107,23 +157,18 @@ func (h *Handlers) GetPortfolio(c echo.Context) (err error) {
 	var portffolioReq service.PortfolioRequest
 	err = c.Bind(&portffolioReq)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.PortfolioService.GetClient(ctx)
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
+	defer release()
 
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
-
-	portf, err := h.service.PortfolioService.GetPortfolio(client, portffolioReq)
+	portf, err := h.service.PortfolioService.GetPortfolio(ctx, client, portffolioReq)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, portf)
 }
@@ -140,27 +185,134 @@ func (h *Handlers) GetOperations(c echo.Context) error {
 	var operationReq service.OperationsRequest
 	err := c.Bind(&operationReq)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.PortfolioService.GetClient(ctx)
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
+	defer release()
 
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
-
-	operations, err := h.service.PortfolioService.MakeSafeGetOperationsRequest(client, operationReq)
+	operations, err := h.service.PortfolioService.MakeSafeGetOperationsRequest(ctx, client, operationReq)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, operations)
 }
 
+func (h *Handlers) GetOperationsPage(c echo.Context) error {
+	const op = "handlers.GetOperationsPage"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var pageReq service.OperationsPageRequest
+	err := c.Bind(&pageReq)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	page, err := h.service.PortfolioService.GetOperationsPage(ctx, client, pageReq)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *Handlers) GetPositions(c echo.Context) error {
+	const op = "handlers.GetPositions"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var accountReq service.AccountRequest
+	err := c.Bind(&accountReq)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	positions, err := h.service.PortfolioService.GetPositions(ctx, client, accountReq.AccountID)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, positions)
+}
+
+func (h *Handlers) GetWithdrawLimits(c echo.Context) error {
+	const op = "handlers.GetWithdrawLimits"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var accountReq service.AccountRequest
+	err := c.Bind(&accountReq)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	limits, err := h.service.PortfolioService.GetWithdrawLimits(ctx, client, accountReq.AccountID)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, limits)
+}
+
+func (h *Handlers) GetMarginAttributes(c echo.Context) error {
+	const op = "handlers.GetMarginAttributes"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var accountReq service.AccountRequest
+	err := c.Bind(&accountReq)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	margin, err := h.service.PortfolioService.GetMarginAttributes(ctx, client, accountReq.AccountID)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, margin)
+}
+
 func (h *Handlers) GetAllAssetUids(c echo.Context) error {
 	const op = "handlers.GetAllAssetUids"
 	ctx := c.Request().Context()
@@ -170,19 +322,15 @@ func (h *Handlers) GetAllAssetUids(c echo.Context) error {
 	logg := h.logger.With(slog.String("op", op))
 	logg.DebugContext(ctx, "start")
 
-	client, err := h.service.AnalyticsService.GetClient(ctx)
+	client, release, err := h.service.AnalyticsService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	allAssetUids, err := h.service.AnalyticsService.GetAllAssetUids(client)
+	allAssetUids, err := h.service.AnalyticsService.GetAllAssetUids(ctx, client)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, allAssetUids)
 }
@@ -200,26 +348,80 @@ func (h *Handlers) GetFutureBy(c echo.Context) error {
 	var body service.FutureReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.InstrumentService.GetClient(ctx)
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	future, err := h.service.InstrumentService.GetFutureBy(client, body.Figi)
+	future, err := h.service.InstrumentService.GetFutureBy(ctx, client, body.Figi)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, future)
 }
 
+func (h *Handlers) GetDividends(c echo.Context) error {
+	const op = "handlers.GetDividends"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.DividendsRequest
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	dividends, err := h.service.InstrumentService.GetDividends(ctx, client, body)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, dividends)
+}
+
+func (h *Handlers) GetAssetReports(c echo.Context) error {
+	const op = "handlers.GetAssetReports"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.AssetReportsRequest
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	reports, err := h.service.InstrumentService.GetAssetReports(ctx, client, body)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, reports)
+}
+
 func (h *Handlers) GetBondBy(c echo.Context) error {
 	const op = "handlers.GetBondBy"
 
@@ -233,22 +435,18 @@ func (h *Handlers) GetBondBy(c echo.Context) error {
 	var body service.BondReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.InstrumentService.GetClient(ctx)
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	bond, err := h.service.InstrumentService.GetBondByUid(client, body.Uid)
+	bond, err := h.service.InstrumentService.GetBondByUid(ctx, client, body.Uid)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, bond)
 }
@@ -266,22 +464,18 @@ func (h *Handlers) GetCurrencyBy(c echo.Context) error {
 	var body service.CurrencyReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.InstrumentService.GetClient(ctx)
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	currency, err := h.service.InstrumentService.GetCurrencyBy(client, body.Figi)
+	currency, err := h.service.InstrumentService.GetCurrencyBy(ctx, client, body.Figi)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, currency)
 }
@@ -299,22 +493,18 @@ func (h *Handlers) GetShareCurrencyBy(c echo.Context) error {
 	var body service.ShareCurrencyByRequest
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.InstrumentService.GetClient(ctx)
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	currency, err := h.service.InstrumentService.GetShareCurrencyBy(client, body.Figi)
+	currency, err := h.service.InstrumentService.GetShareCurrencyBy(ctx, client, body.Figi)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, currency)
 }
@@ -332,22 +522,18 @@ func (h *Handlers) FindBy(c echo.Context) error {
 	var body service.FindByReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.InstrumentService.GetClient(ctx)
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	instruments, err := h.service.InstrumentService.FindBy(client, body.Query)
+	instruments, err := h.service.InstrumentService.FindBy(ctx, client, body.Query)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, instruments)
 }
@@ -365,22 +551,18 @@ func (h *Handlers) GetBondsActions(c echo.Context) error {
 	var body service.BondsActionsReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.AnalyticsService.GetClient(ctx)
+	client, release, err := h.service.AnalyticsService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	bondIdentificators, err := h.service.AnalyticsService.GetBondsActions(client, body.InstrumentUid)
+	bondIdentificators, err := h.service.AnalyticsService.GetBondsActions(ctx, client, body.InstrumentUid)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 	return c.JSON(http.StatusOK, bondIdentificators)
 }
@@ -398,23 +580,356 @@ func (h *Handlers) GetLastPriceInPersentageToNominal(c echo.Context) error {
 	var body service.LastPriceReq
 	err := c.Bind(&body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errInvalidRequestBody)
+		return apiError(errInvalidRequestBody)
 	}
 
-	client, err := h.service.AnalyticsService.GetClient(ctx)
+	if price, ok := h.streamer.Live([]string{body.InstrumentUid})[body.InstrumentUid]; ok {
+		return c.JSON(http.StatusOK, service.LastPriceResponse{LastPrice: priceQuotation(price)})
+	}
+
+	client, release, err := h.service.AnalyticsService.GetClient(ctx)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, errIncorrectToken)
+		return apiError(errIncorrectToken)
 	}
-	defer func() {
-		if err := client.Stop(); err != nil {
-			logg.WarnContext(ctx, "client stop failed", slog.Any("error", err))
-		}
-	}()
+	defer release()
 
-	lastPrice, err := h.service.AnalyticsService.GetLastPriceInPersentageToNominal(client, body.InstrumentUid)
+	lastPrice, err := h.service.AnalyticsService.GetLastPriceInPersentageToNominal(ctx, client, body.InstrumentUid)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, errGetData)
+		return apiError(err)
 	}
 
 	return c.JSON(http.StatusOK, lastPrice)
 }
+
+func (h *Handlers) GetBondsBy(c echo.Context) error {
+	const op = "handlers.GetBondsBy"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.BondsReq
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	bonds, err := h.service.InstrumentService.GetBondsByUids(ctx, client, body.Uids)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, bonds)
+}
+
+func (h *Handlers) GetFuturesBy(c echo.Context) error {
+	const op = "handlers.GetFuturesBy"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.FuturesReq
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	futures, err := h.service.InstrumentService.GetFuturesBy(ctx, client, body.Figis)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, futures)
+}
+
+func (h *Handlers) GetCurrenciesBy(c echo.Context) error {
+	const op = "handlers.GetCurrenciesBy"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.CurrenciesReq
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.InstrumentService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	currencies, err := h.service.InstrumentService.GetCurrenciesBy(ctx, client, body.Figis)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, currencies)
+}
+
+func (h *Handlers) GetBondsActionsBatch(c echo.Context) error {
+	const op = "handlers.GetBondsActionsBatch"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.BondsActionsBatchReq
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.AnalyticsService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	bondsActions, err := h.service.AnalyticsService.GetBondsActionsBatch(ctx, client, body.InstrumentUids)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, bondsActions)
+}
+
+func (h *Handlers) GetLastPricesInPersentageToNominal(c echo.Context) error {
+	const op = "handlers.GetLastPricesInPersentageToNominal"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var body service.LastPricesReq
+	err := c.Bind(&body)
+	if err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	// Цены инструментов из подписки берутся с доски, в Invest API идут только остальные
+	live := h.streamer.Live(body.InstrumentUids)
+	rest := make([]string, 0, len(body.InstrumentUids))
+	for _, uid := range body.InstrumentUids {
+		if _, ok := live[uid]; !ok {
+			rest = append(rest, uid)
+		}
+	}
+
+	lastPrices := service.LastPricesResponse{LastPrices: make(map[string]service.Quotation, len(live))}
+	if len(rest) != 0 || len(live) == 0 {
+		client, release, err := h.service.AnalyticsService.GetClient(ctx)
+		if err != nil {
+			return apiError(errIncorrectToken)
+		}
+		defer release()
+
+		lastPrices, err = h.service.AnalyticsService.GetLastPricesInPersentageToNominal(ctx, client, rest)
+		if err != nil {
+			return apiError(err)
+		}
+	}
+	for uid, price := range live {
+		lastPrices.LastPrices[uid] = priceQuotation(price)
+	}
+	return c.JSON(http.StatusOK, lastPrices)
+}
+
+func (h *Handlers) GetInstrumentCacheStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.cache.Stats())
+}
+
+// InvalidateInstrumentCache сбрасывает кэш инструментов:
+// без параметров - весь, ?kind=bond - один тип, ?kind=bond&id=uid1&id=uid2 - отдельные инструменты.
+func (h *Handlers) InvalidateInstrumentCache(c echo.Context) error {
+	const op = "handlers.InvalidateInstrumentCache"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	kindParam := c.QueryParam("kind")
+	ids := c.QueryParams()["id"]
+
+	var deleted int64
+	var err error
+	if kindParam == "" {
+		if len(ids) != 0 {
+			return apiError(errKindRequired)
+		}
+		deleted, err = h.cache.InvalidateAll(ctx)
+	} else {
+		kind, parseErr := instrumentcache.ParseKind(kindParam)
+		if parseErr != nil {
+			return apiError(apierrors.New(apierrors.InvalidArgument, parseErr.Error()))
+		}
+		deleted, err = h.cache.Invalidate(ctx, kind, ids...)
+	}
+	if err != nil {
+		logg.ErrorContext(ctx, "failed to invalidate instrument cache", slog.Any("error", err))
+		return apiError(errRedisDoNotAnswer)
+	}
+
+	logg.InfoContext(ctx, "instrument cache invalidated", slog.String("kind", kindParam), slog.Int64("deleted", deleted))
+	return c.JSON(http.StatusOK, map[string]int64{"deleted": deleted})
+}
+
+// WatchPrices подписывает пользователя на живые цены инструментов из его портфелей.
+// Повторный вызов обновляет набор инструментов, например после сделки.
+func (h *Handlers) WatchPrices(c echo.Context) error {
+	const op = "handlers.WatchPrices"
+
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	chatID := c.Request().Header.Get(httpheaders.HeaderChatID)
+	token, err := valuefromcontext.GetToken(ctx)
+	if err != nil {
+		return apiError(errEmptyToken)
+	}
+
+	uids, err := h.watchHoldings(ctx, chatID, token)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, map[string][]string{"instrumentUids": uids})
+}
+
+func (h *Handlers) UnwatchPrices(c echo.Context) error {
+	const op = "handlers.UnwatchPrices"
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.Debug("start")
+
+	chatID := c.Request().Header.Get(httpheaders.HeaderChatID)
+	if err := h.streamer.Unwatch(chatID); err != nil {
+		logg.Error("failed to unwatch prices", slog.Any("error", err))
+		return apiError(errPriceStream)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetPrices отдает последние цены с доски: ?uid=a&uid=b или все инструменты пользователя.
+func (h *Handlers) GetPrices(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.prices.Get(h.priceUids(c)))
+}
+
+// StreamPrices отдает обновления цен через Server-Sent Events:
+// сначала текущие цены, затем каждое изменение событием price.
+func (h *Handlers) StreamPrices(c echo.Context) error {
+	const op = "handlers.StreamPrices"
+
+	ctx := c.Request().Context()
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	uids := h.priceUids(c)
+	filter := make(map[string]struct{}, len(uids))
+	for _, uid := range uids {
+		filter[uid] = struct{}{}
+	}
+
+	updates, unsubscribe := h.prices.Subscribe(sseBuffer)
+	defer unsubscribe()
+
+	// WriteTimeout сервера оборвал бы долгий стрим
+	if err := http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{}); err != nil {
+		logg.WarnContext(ctx, "could not reset write deadline", slog.Any("error", err))
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, price := range h.prices.Get(uids) {
+		if err := writePriceEvent(w, price); err != nil {
+			return nil
+		}
+	}
+	w.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case price, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if _, ok := filter[price.InstrumentUid]; len(filter) != 0 && !ok {
+				continue
+			}
+			if err := writePriceEvent(w, price); err != nil {
+				return nil
+			}
+		}
+		w.Flush()
+	}
+}
+
+const (
+	sseBuffer    = 64
+	sseKeepAlive = 15 * time.Second
+)
+
+// priceUids возвращает инструменты из ?uid=, а без параметров - инструменты, на которые подписан пользователь.
+func (h *Handlers) priceUids(c echo.Context) []string {
+	if uids := c.QueryParams()["uid"]; len(uids) != 0 {
+		return uids
+	}
+	return h.streamer.WatchedBy(c.Request().Header.Get(httpheaders.HeaderChatID))
+}
+
+func priceQuotation(price pricestream.Price) service.Quotation {
+	return service.Quotation{Units: price.Units, Nano: price.Nano}
+}
+
+func writePriceEvent(w io.Writer, price pricestream.Price) error {
+	data, err := json.Marshal(price)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: price\ndata: %s\n\n", data)
+	return err
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"tinkoffApi/internal/pricestream"
+
+	contextkeys "github.com/gladinov/contracts/context"
+)
+
+const (
+	holdingsTimeout         = 10 * time.Second
+	defaultHoldingsInterval = time.Hour
+)
+
+// UserStore перечисляет пользователей, сохранивших токен в боте.
+type UserStore interface {
+	ChatIDs(ctx context.Context) ([]string, error)
+}
+
+// RunWatchHoldings держит подписку на цены инструментов из портфелей всех пользователей бота:
+// сначала восстанавливает наборы, сохраненные до перезапуска, затем раз в interval
+// заново собирает инструменты из портфелей, чтобы подписка следовала за сделками.
+func (h *Handlers) RunWatchHoldings(ctx context.Context, users UserStore, interval time.Duration) {
+	const op = "handlers.RunWatchHoldings"
+
+	logg := h.logger.With(slog.String("op", op))
+	err := h.streamer.Restore(ctx, func(ctx context.Context, chatID string) (string, error) {
+		token, err := h.chatToken(ctx, chatID)
+		if errors.Is(err, errNoTokenInRedis) {
+			return "", pricestream.ErrNoToken
+		}
+		return token, err
+	})
+	if err != nil {
+		logg.WarnContext(ctx, "could not restore price watches", slog.Any("error", err))
+	}
+
+	if interval <= 0 {
+		interval = defaultHoldingsInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		h.watchAllHoldings(ctx, users)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchAllHoldings обновляет подписки всех пользователей. Ошибка одного пользователя не мешает остальным.
+// Пользователи в режиме песочницы пропускаются: стрим цен открыт в боевом контуре.
+func (h *Handlers) watchAllHoldings(ctx context.Context, users UserStore) {
+	const op = "handlers.watchAllHoldings"
+
+	logg := h.logger.With(slog.String("op", op))
+	chatIDs, err := users.ChatIDs(ctx)
+	if err != nil {
+		logg.WarnContext(ctx, "could not list users", slog.Any("error", err))
+		return
+	}
+	for _, chatID := range chatIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := h.watchUserHoldings(ctx, chatID); err != nil {
+			logg.WarnContext(ctx, "could not watch user holdings",
+				slog.String("chat_id", chatID),
+				slog.Any("error", err))
+		}
+	}
+}
+
+func (h *Handlers) watchUserHoldings(ctx context.Context, chatID string) error {
+	ctx, cancel := context.WithTimeout(ctx, holdingsTimeout)
+	defer cancel()
+
+	token, err := h.chatToken(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	sandboxMode, err := h.sandboxModes.IsEnabled(ctx, token)
+	if err != nil || sandboxMode {
+		return err
+	}
+	_, err = h.watchHoldings(ctx, chatID, token)
+	return err
+}
+
+// watchHoldings подписывает chatID на цены инструментов из портфелей всех открытых счетов токена
+// и возвращает итоговый набор.
+func (h *Handlers) watchHoldings(ctx context.Context, chatID, token string) ([]string, error) {
+	const op = "handlers.watchHoldings"
+
+	ctx = context.WithValue(ctx, contextkeys.EncryptedTokenKey, token)
+	client, release, err := h.service.PortfolioService.GetClient(ctx)
+	if err != nil {
+		return nil, errIncorrectToken
+	}
+	defer release()
+
+	uids, err := h.service.PortfolioService.GetHeldInstrumentUids(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.streamer.Watch(chatID, token, uids); err != nil {
+		h.logger.ErrorContext(ctx, "failed to watch prices", slog.String("op", op), slog.Any("error", err))
+		return nil, errPriceStream
+	}
+	return h.streamer.WatchedBy(chatID), nil
+}
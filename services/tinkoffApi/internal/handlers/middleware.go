@@ -98,42 +98,51 @@ func (h *Handlers) CheckTokenFromRedisByChatIDMiddleWare(next echo.HandlerFunc)
 	return func(c echo.Context) (err error) {
 		const op = "handlers.CheckTokenFromRedisByChatIDMiddleWare"
 
-		if c.Path() == "/tinkoff/checktoken" {
+		switch c.Path() {
+		case "/tinkoff/checktoken", "/tinkoff/tokenscope":
 			return next(c)
 		}
 
 		chatID := c.Request().Header.Get(httpheaders.HeaderChatID)
 		if chatID == "" {
 			err = errHeaderRequired
-			return echo.NewHTTPError(http.StatusUnauthorized, errHeaderRequired)
+			return apiError(errHeaderRequired)
 		}
 
-		ctx := c.Request().Context()
-		tokenInBase64, err := h.redis.Get(ctx, chatID).Result()
-		switch err {
-		case nil:
-		case redis.Nil:
-			return echo.NewHTTPError(http.StatusUnauthorized, errNoTokenInRedis)
-		default:
-			return echo.NewHTTPError(http.StatusServiceUnavailable, errRedisDoNotAnswer)
-		}
-
-		encryptedToken, err := cryptotoken.GetEncryptedTokenFromBase64(tokenInBase64)
+		token, err := h.chatToken(c.Request().Context(), chatID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, errHeaderRequired)
-		}
-		token, err := cryptotoken.DecryptToken(&encryptedToken, h.tokenCrypter.KeyInBase64)
-		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, errInvalidAuthFormat)
+			return apiError(err)
 		}
 
-		ctx = context.WithValue(c.Request().Context(), contextkeys.EncryptedTokenKey, token)
+		ctx := context.WithValue(c.Request().Context(), contextkeys.EncryptedTokenKey, token)
 		c.SetRequest(c.Request().WithContext(ctx))
 
 		return next(c)
 	}
 }
 
+// chatToken достает из Redis и расшифровывает токен, сохраненный ботом для chatID.
+func (h *Handlers) chatToken(ctx context.Context, chatID string) (string, error) {
+	tokenInBase64, err := h.redis.Get(ctx, chatID).Result()
+	switch err {
+	case nil:
+	case redis.Nil:
+		return "", errNoTokenInRedis
+	default:
+		return "", errRedisDoNotAnswer
+	}
+
+	encryptedToken, err := cryptotoken.GetEncryptedTokenFromBase64(tokenInBase64)
+	if err != nil {
+		return "", errHeaderRequired
+	}
+	token, err := cryptotoken.DecryptToken(&encryptedToken, h.tokenCrypter.KeyInBase64)
+	if err != nil {
+		return "", errInvalidAuthFormat
+	}
+	return token, nil
+}
+
 func (h *Handlers) CheckTokenFromHeadersMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) (err error) {
 		const op = "handlers.CheckTokenFromHeadersMiddleWare"
@@ -141,15 +150,15 @@ func (h *Handlers) CheckTokenFromHeadersMiddleWare(next echo.HandlerFunc) echo.H
 		tokenInBase64 := c.Request().Header.Get(httpheaders.HeaderEncryptedToken)
 		if tokenInBase64 == "" {
 			err = errHeaderRequired
-			return echo.NewHTTPError(http.StatusUnauthorized, err)
+			return apiError(err)
 		}
 		encryptedToken, err := cryptotoken.GetEncryptedTokenFromBase64(tokenInBase64)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, errHeaderRequired)
+			return apiError(errHeaderRequired)
 		}
 		token, err := cryptotoken.DecryptToken(&encryptedToken, h.tokenCrypter.KeyInBase64)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, errInvalidAuthFormat)
+			return apiError(errInvalidAuthFormat)
 		}
 		ctx := context.WithValue(c.Request().Context(), contextkeys.EncryptedTokenKey, token)
 
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"tinkoffApi/internal/sandbox"
+	"tinkoffApi/internal/service"
+
+	"github.com/gladinov/valuefromcontext"
+	"github.com/labstack/echo/v4"
+)
+
+const sandboxPathPrefix = "/tinkoff/sandbox/"
+
+// SandboxModeStore хранит режим песочницы, включенный для токена.
+type SandboxModeStore interface {
+	IsEnabled(ctx context.Context, token string) (bool, error)
+	Enable(ctx context.Context, token string) error
+	Disable(ctx context.Context, token string) error
+}
+
+type sandboxModeResponse struct {
+	Sandbox bool `json:"sandbox"`
+}
+
+type sandboxPayInResponse struct {
+	Balance service.MoneyValue `json:"balance"`
+}
+
+// SandboxModeMiddleWare выбирает контур Invest API для запроса. Порядок:
+// методы /tinkoff/sandbox/* всегда работают с песочницей, затем заголовок X-Sandbox,
+// затем режим, сохраненный для токена. Если режим не удалось прочитать, запрос отклоняется:
+// пользователь песочницы не должен по ошибке получить данные боевых счетов.
+func (h *Handlers) SandboxModeMiddleWare(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		const op = "handlers.SandboxModeMiddleWare"
+
+		ctx := c.Request().Context()
+		enabled, err := h.sandboxMode(c)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "could not get sandbox mode",
+				slog.String("op", op),
+				slog.Any("error", err))
+			return apiError(errRedisDoNotAnswer)
+		}
+		if !enabled {
+			return next(c)
+		}
+		if h.service.SandboxService == nil {
+			return apiError(service.ErrSandboxDisabled)
+		}
+
+		c.SetRequest(c.Request().WithContext(sandbox.WithSandbox(ctx)))
+		return next(c)
+	}
+}
+
+func (h *Handlers) sandboxMode(c echo.Context) (bool, error) {
+	path := c.Path()
+	if strings.HasPrefix(path, sandboxPathPrefix) {
+		return path != sandboxPathPrefix+"mode", nil
+	}
+	if enabled, ok := sandbox.ParseHeader(c.Request().Header.Get(sandbox.HeaderSandbox)); ok {
+		return enabled, nil
+	}
+	// checktoken и tokenscope получают токен позже, в своем middleware
+	token, err := valuefromcontext.GetToken(c.Request().Context())
+	if err != nil || h.sandboxModes == nil {
+		return false, nil
+	}
+	return h.sandboxModes.IsEnabled(c.Request().Context(), token)
+}
+
+// EnableSandboxMode включает песочницу по умолчанию для токена пользователя.
+func (h *Handlers) EnableSandboxMode(c echo.Context) error {
+	return h.setSandboxMode(c, true)
+}
+
+// DisableSandboxMode возвращает токен пользователя в боевой контур.
+func (h *Handlers) DisableSandboxMode(c echo.Context) error {
+	return h.setSandboxMode(c, false)
+}
+
+func (h *Handlers) setSandboxMode(c echo.Context, enabled bool) error {
+	const op = "handlers.setSandboxMode"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start", slog.Bool("sandbox", enabled))
+
+	if enabled && h.service.SandboxService == nil {
+		return apiError(service.ErrSandboxDisabled)
+	}
+	token, err := valuefromcontext.GetToken(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+
+	if enabled {
+		err = h.sandboxModes.Enable(ctx, token)
+	} else {
+		err = h.sandboxModes.Disable(ctx, token)
+	}
+	if err != nil {
+		logg.ErrorContext(ctx, "could not save sandbox mode", slog.Any("error", err))
+		return apiError(errRedisDoNotAnswer)
+	}
+	return c.JSON(http.StatusOK, sandboxModeResponse{Sandbox: enabled})
+}
+
+func (h *Handlers) OpenSandboxAccount(c echo.Context) error {
+	const op = "handlers.OpenSandboxAccount"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	client, release, err := h.service.SandboxService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	account, err := h.service.SandboxService.OpenAccount(ctx, client)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusCreated, account)
+}
+
+func (h *Handlers) CloseSandboxAccount(c echo.Context) error {
+	const op = "handlers.CloseSandboxAccount"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	client, release, err := h.service.SandboxService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	if err := h.service.SandboxService.CloseAccount(ctx, client, c.Param("id")); err != nil {
+		return apiError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) SandboxPayIn(c echo.Context) error {
+	const op = "handlers.SandboxPayIn"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var payInReq service.SandboxPayInRequest
+	if err := c.Bind(&payInReq); err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.SandboxService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	balance, err := h.service.SandboxService.PayIn(ctx, client, payInReq)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, sandboxPayInResponse{Balance: balance})
+}
+
+func (h *Handlers) PostSandboxOrder(c echo.Context) error {
+	const op = "handlers.PostSandboxOrder"
+	ctx := c.Request().Context()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	logg := h.logger.With(slog.String("op", op))
+	logg.DebugContext(ctx, "start")
+
+	var orderReq service.SandboxOrderRequest
+	if err := c.Bind(&orderReq); err != nil {
+		return apiError(errInvalidRequestBody)
+	}
+
+	client, release, err := h.service.SandboxService.GetClient(ctx)
+	if err != nil {
+		return apiError(errIncorrectToken)
+	}
+	defer release()
+
+	order, err := h.service.SandboxService.PostOrder(ctx, client, orderReq)
+	if err != nil {
+		return apiError(err)
+	}
+	return c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,194 @@
+//go:build unit
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"tinkoffApi/internal/sandbox"
+	"tinkoffApi/internal/service"
+	"tinkoffApi/internal/service/mocks"
+
+	contextkeys "github.com/gladinov/contracts/context"
+	"github.com/labstack/echo/v4"
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenHeader = "X-Test-Token"
+
+// fakeSandboxModeStore - хранилище режима песочницы в памяти вместо Redis.
+type fakeSandboxModeStore struct {
+	mu      sync.Mutex
+	enabled map[string]bool
+	err     error
+}
+
+func newFakeSandboxModeStore() *fakeSandboxModeStore {
+	return &fakeSandboxModeStore{enabled: make(map[string]bool)}
+}
+
+func (s *fakeSandboxModeStore) IsEnabled(ctx context.Context, token string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled[token], s.err
+}
+
+func (s *fakeSandboxModeStore) Enable(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.enabled[token] = true
+	return nil
+}
+
+func (s *fakeSandboxModeStore) Disable(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	delete(s.enabled, token)
+	return nil
+}
+
+func (s *fakeSandboxModeStore) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// newSandboxRouter собирает роутер с той же цепочкой middleware, что и в main,
+// только токен берется из заголовка, а не из Redis.
+func newSandboxRouter(t *testing.T, sandboxService service.SandboxService, store SandboxModeStore) *echo.Echo {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := NewHandlers(logger, service.NewService(nil, nil, nil, sandboxService), nil, nil, nil, nil, nil, store)
+
+	router := echo.New()
+	router.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := c.Request().Header.Get(testTokenHeader)
+			ctx := context.WithValue(c.Request().Context(), contextkeys.EncryptedTokenKey, token)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	router.Use(h.SandboxModeMiddleWare)
+
+	// Вместо боевого метода - проба, в какой контур ушел бы запрос
+	router.GET("/tinkoff/accounts", func(c echo.Context) error {
+		if sandbox.Enabled(c.Request().Context()) {
+			return c.String(http.StatusOK, "sandbox")
+		}
+		return c.String(http.StatusOK, "production")
+	})
+	router.POST("/tinkoff/sandbox/payin", h.SandboxPayIn)
+	router.PUT("/tinkoff/sandbox/mode", h.EnableSandboxMode)
+	router.DELETE("/tinkoff/sandbox/mode", h.DisableSandboxMode)
+	return router
+}
+
+func doSandboxRequest(router *echo.Echo, method, target, token string, header map[string]string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(testTokenHeader, token)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSandboxMode_EndToEnd(t *testing.T) {
+	store := newFakeSandboxModeStore()
+	router := newSandboxRouter(t, mocks.NewSandboxService(t), store)
+
+	route := func(token string, header map[string]string) string {
+		rec := doSandboxRequest(router, http.MethodGet, "/tinkoff/accounts", token, header, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		return rec.Body.String()
+	}
+
+	assert.Equal(t, "production", route("token-1", nil))
+
+	rec := doSandboxRequest(router, http.MethodPut, "/tinkoff/sandbox/mode", "token-1", nil, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"sandbox":true}`, rec.Body.String())
+
+	assert.Equal(t, "sandbox", route("token-1", nil))
+	assert.Equal(t, "production", route("token-2", nil), "mode is stored per token")
+	assert.Equal(t, "production", route("token-1", map[string]string{sandbox.HeaderSandbox: "false"}))
+	assert.Equal(t, "sandbox", route("token-2", map[string]string{sandbox.HeaderSandbox: "true"}))
+
+	rec = doSandboxRequest(router, http.MethodDelete, "/tinkoff/sandbox/mode", "token-1", nil, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "production", route("token-1", nil))
+}
+
+func TestSandboxMode_StoreErrorFailsClosed(t *testing.T) {
+	store := newFakeSandboxModeStore()
+	router := newSandboxRouter(t, mocks.NewSandboxService(t), store)
+	require.NoError(t, store.Enable(context.Background(), "token-1"))
+	store.setErr(errors.New("redis is down"))
+
+	rec := doSandboxRequest(router, http.MethodGet, "/tinkoff/accounts", "token-1", nil, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "request must not fall back to production")
+
+	// Явный выбор контура не зависит от хранилища
+	rec = doSandboxRequest(router, http.MethodGet, "/tinkoff/accounts", "token-1",
+		map[string]string{sandbox.HeaderSandbox: "true"}, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "sandbox", rec.Body.String())
+
+	rec = doSandboxRequest(router, http.MethodPut, "/tinkoff/sandbox/mode", "token-1", nil, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestSandboxMode_SandboxMethodsAlwaysUseSandbox(t *testing.T) {
+	sandboxService := mocks.NewSandboxService(t)
+	router := newSandboxRouter(t, sandboxService, newFakeSandboxModeStore())
+
+	inSandbox := mock.MatchedBy(func(ctx context.Context) bool { return sandbox.Enabled(ctx) })
+	sandboxService.On("GetClient", inSandbox).Return((*investgo.Client)(nil), func() {}, nil).Once()
+	sandboxService.On("PayIn", inSandbox, (*investgo.Client)(nil), service.SandboxPayInRequest{
+		AccountID: "sandbox-1",
+		Currency:  "rub",
+		Units:     1000,
+	}).Return(service.MoneyValue{Currency: "rub", Units: 1000}, nil).Once()
+
+	rec := doSandboxRequest(router, http.MethodPost, "/tinkoff/sandbox/payin", "token-1",
+		map[string]string{sandbox.HeaderSandbox: "false"},
+		`{"accountId":"sandbox-1","currency":"rub","units":1000}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"balance":{"currency":"rub","units":1000}}`, rec.Body.String())
+}
+
+func TestSandboxMode_Disabled(t *testing.T) {
+	store := newFakeSandboxModeStore()
+	router := newSandboxRouter(t, nil, store)
+
+	rec := doSandboxRequest(router, http.MethodPut, "/tinkoff/sandbox/mode", "token-1", nil, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = doSandboxRequest(router, http.MethodGet, "/tinkoff/accounts", "token-1",
+		map[string]string{sandbox.HeaderSandbox: "true"}, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = doSandboxRequest(router, http.MethodGet, "/tinkoff/accounts", "token-1", nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "production", rec.Body.String())
+}
@@ -0,0 +1,270 @@
+package instrumentcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	keyPrefix      = "instrument:"
+	defaultTTL     = 24 * time.Hour
+	defaultTimeout = 200 * time.Millisecond
+)
+
+var ErrUnknownKind = errors.New("unknown instrument kind")
+
+// Kind - тип справочных данных инструмента, у каждого свое пространство ключей.
+type Kind string
+
+const (
+	KindBond          Kind = "bond"
+	KindFuture        Kind = "future"
+	KindCurrency      Kind = "currency"
+	KindShareCurrency Kind = "share_currency"
+)
+
+var Kinds = []Kind{KindBond, KindFuture, KindCurrency, KindShareCurrency}
+
+// dailyKinds - типы, у которых часть данных меняется каждый торговый день
+// (у облигации НКД, а при амортизации и номинал). Они хранятся не дольше
+// ближайшей полуночи по Москве, статичная часть просто перечитывается раз в день.
+var dailyKinds = map[Kind]bool{KindBond: true}
+
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownKind, s)
+}
+
+// Store - хранилище закэшированных значений (Redis).
+type Store interface {
+	// MGet возвращает значения в порядке ключей, nil - промах.
+	MGet(ctx context.Context, keys []string) ([][]byte, error)
+	Set(ctx context.Context, values map[string][]byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) (int64, error)
+	DeleteByPrefix(ctx context.Context, prefix string) (int64, error)
+}
+
+type Config struct {
+	// TTL - сколько хранятся данные инструмента.
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+	// Timeout - ограничение на операцию с хранилищем, чтобы медленный Redis не тормозил запросы.
+	Timeout time.Duration `yaml:"timeout" env-default:"200ms"`
+}
+
+type KindStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Errors uint64 `json:"errors"`
+}
+
+type counters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+// Cache кэширует справочные данные инструментов независимо от токена:
+// облигация или фьючерс одинаковы для всех пользователей.
+// Ошибки хранилища не ломают запрос - данные просто загружаются из Invest API.
+type Cache struct {
+	logger *slog.Logger
+	store  Store
+	cfg    Config
+	stats  map[Kind]*counters
+	now    func() time.Time
+}
+
+func New(logger *slog.Logger, store Store, cfg Config) *Cache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	stats := make(map[Kind]*counters, len(Kinds))
+	for _, kind := range Kinds {
+		stats[kind] = &counters{}
+	}
+	return &Cache{
+		logger: logger,
+		store:  store,
+		cfg:    cfg,
+		stats:  stats,
+		now:    time.Now,
+	}
+}
+
+func key(kind Kind, id string) string {
+	return keyPrefix + string(kind) + ":" + id
+}
+
+// ttl возвращает срок хранения для типа: для дневных типов - до полуночи по Москве, но не дольше TTL.
+func (c *Cache) ttl(kind Kind) time.Duration {
+	if !dailyKinds[kind] {
+		return c.cfg.TTL
+	}
+	now := c.now().In(moscow)
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, moscow)
+	return min(c.cfg.TTL, midnight.Sub(now))
+}
+
+// Stats возвращает счетчики попаданий и промахов по типам инструментов.
+func (c *Cache) Stats() map[Kind]KindStats {
+	res := make(map[Kind]KindStats, len(c.stats))
+	for kind, cnt := range c.stats {
+		res[kind] = KindStats{
+			Hits:   cnt.hits.Load(),
+			Misses: cnt.misses.Load(),
+			Errors: cnt.errors.Load(),
+		}
+	}
+	return res
+}
+
+// Invalidate удаляет из кэша инструменты одного типа: перечисленные ids или все, если ids пуст.
+func (c *Cache) Invalidate(ctx context.Context, kind Kind, ids ...string) (int64, error) {
+	const op = "instrumentcache.Invalidate"
+	if _, ok := c.stats[kind]; !ok {
+		return 0, fmt.Errorf("%s: %w: %q", op, ErrUnknownKind, kind)
+	}
+	if len(ids) == 0 {
+		deleted, err := c.store.DeleteByPrefix(ctx, keyPrefix+string(kind)+":")
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		return deleted, nil
+	}
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, key(kind, id))
+	}
+	deleted, err := c.store.Delete(ctx, keys...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
+
+// InvalidateAll удаляет из кэша все инструменты.
+func (c *Cache) InvalidateAll(ctx context.Context) (int64, error) {
+	const op = "instrumentcache.InvalidateAll"
+	deleted, err := c.store.DeleteByPrefix(ctx, keyPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
+
+// Get возвращает инструмент из кэша, а при промахе загружает его через load и сохраняет.
+func Get[T any](ctx context.Context, c *Cache, kind Kind, id string, load func() (T, error)) (T, error) {
+	res, err := GetMany(ctx, c, kind, []string{id}, func(missing []string) (map[string]T, error) {
+		v, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]T{id: v}, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return res[id], nil
+}
+
+// GetMany достает из кэша сразу несколько инструментов одним запросом к хранилищу,
+// а отсутствующие загружает одним вызовом load. Не найденные load'ом ids в ответ не попадают.
+func GetMany[T any](ctx context.Context, c *Cache, kind Kind, ids []string, load func(missing []string) (map[string]T, error)) (map[string]T, error) {
+	cnt, ok := c.stats[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+
+	res := make(map[string]T, len(ids))
+	missing := lookup(ctx, c, kind, cnt, ids, res)
+	cnt.hits.Add(uint64(len(ids) - len(missing)))
+	cnt.misses.Add(uint64(len(missing)))
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	loaded, err := load(missing)
+	if err != nil {
+		return nil, err
+	}
+	save(ctx, c, kind, cnt, loaded)
+	for id, v := range loaded {
+		res[id] = v
+	}
+	return res, nil
+}
+
+func lookup[T any](ctx context.Context, c *Cache, kind Kind, cnt *counters, ids []string, res map[string]T) []string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, key(kind, id))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	values, err := c.store.MGet(ctx, keys)
+	if err == nil && len(values) != len(ids) {
+		err = fmt.Errorf("store returned %d values for %d keys", len(values), len(ids))
+	}
+	if err != nil {
+		cnt.errors.Add(1)
+		c.logger.WarnContext(ctx, "instrument cache lookup failed", slog.String("kind", string(kind)), slog.Any("error", err))
+		return ids
+	}
+
+	var missing []string
+	for i, raw := range values {
+		if raw == nil {
+			missing = append(missing, ids[i])
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			cnt.errors.Add(1)
+			missing = append(missing, ids[i])
+			continue
+		}
+		res[ids[i]] = v
+	}
+	return missing
+}
+
+func save[T any](ctx context.Context, c *Cache, kind Kind, cnt *counters, loaded map[string]T) {
+	if len(loaded) == 0 {
+		return
+	}
+	values := make(map[string][]byte, len(loaded))
+	for id, v := range loaded {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			cnt.errors.Add(1)
+			c.logger.WarnContext(ctx, "failed to encode instrument for cache", slog.String("kind", string(kind)), slog.Any("error", err))
+			return
+		}
+		values[key(kind, id)] = raw
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	if err := c.store.Set(ctx, values, c.ttl(kind)); err != nil {
+		cnt.errors.Add(1)
+		c.logger.WarnContext(ctx, "failed to save instruments to cache", slog.String("kind", string(kind)), slog.Any("error", err))
+	}
+}
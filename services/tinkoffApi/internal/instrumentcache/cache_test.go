@@ -0,0 +1,238 @@
+//go:build unit
+
+package instrumentcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	ttl    time.Duration
+	err    error
+}
+
+func newMemStore() *memStore {
+	return &memStore{values: make(map[string][]byte)}
+}
+
+func (s *memStore) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	res := make([][]byte, len(keys))
+	for i, k := range keys {
+		res[i] = s.values[k]
+	}
+	return res, nil
+}
+
+func (s *memStore) Set(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	for k, v := range values {
+		s.values[k] = v
+	}
+	s.ttl = ttl
+	return nil
+}
+
+func (s *memStore) Delete(ctx context.Context, keys ...string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for _, k := range keys {
+		if _, ok := s.values[k]; ok {
+			delete(s.values, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memStore) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for k := range s.values {
+		if strings.HasPrefix(k, prefix) {
+			delete(s.values, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+type bond struct {
+	Currency string `json:"currency"`
+}
+
+func newTestCache(store Store) *Cache {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := New(logger, store, Config{TTL: time.Hour})
+	c.now = func() time.Time { return time.Date(2026, 3, 2, 12, 0, 0, 0, moscow) }
+	return c
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("miss then hit", func(t *testing.T) {
+		store := newMemStore()
+		c := newTestCache(store)
+		loads := 0
+		load := func() (bond, error) {
+			loads++
+			return bond{Currency: "rub"}, nil
+		}
+
+		got, err := Get(ctx, c, KindBond, "uid-1", load)
+		require.NoError(t, err)
+		assert.Equal(t, bond{Currency: "rub"}, got)
+
+		got, err = Get(ctx, c, KindBond, "uid-1", load)
+		require.NoError(t, err)
+		assert.Equal(t, bond{Currency: "rub"}, got)
+
+		assert.Equal(t, 1, loads)
+		assert.Equal(t, time.Hour, store.ttl)
+		assert.Contains(t, store.values, "instrument:bond:uid-1")
+		assert.Equal(t, KindStats{Hits: 1, Misses: 1}, c.Stats()[KindBond])
+	})
+
+	t.Run("kinds do not collide", func(t *testing.T) {
+		c := newTestCache(newMemStore())
+
+		_, err := Get(ctx, c, KindFuture, "FIGI", func() (bond, error) { return bond{Currency: "usd"}, nil })
+		require.NoError(t, err)
+		got, err := Get(ctx, c, KindCurrency, "FIGI", func() (bond, error) { return bond{Currency: "cny"}, nil })
+		require.NoError(t, err)
+
+		assert.Equal(t, "cny", got.Currency)
+	})
+
+	t.Run("load error is not cached", func(t *testing.T) {
+		store := newMemStore()
+		c := newTestCache(store)
+		wantErr := errors.New("invest api error")
+
+		_, err := Get(ctx, c, KindBond, "uid-1", func() (bond, error) { return bond{}, wantErr })
+		assert.ErrorIs(t, err, wantErr)
+		assert.Empty(t, store.values)
+	})
+
+	t.Run("store error falls back to load", func(t *testing.T) {
+		store := newMemStore()
+		store.err = errors.New("redis down")
+		c := newTestCache(store)
+
+		got, err := Get(ctx, c, KindBond, "uid-1", func() (bond, error) { return bond{Currency: "rub"}, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "rub", got.Currency)
+		// Ошибка при чтении и при сохранении
+		assert.Equal(t, KindStats{Misses: 1, Errors: 2}, c.Stats()[KindBond])
+	})
+
+	t.Run("broken value is reloaded", func(t *testing.T) {
+		store := newMemStore()
+		store.values["instrument:bond:uid-1"] = []byte("{broken")
+		c := newTestCache(store)
+
+		got, err := Get(ctx, c, KindBond, "uid-1", func() (bond, error) { return bond{Currency: "rub"}, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "rub", got.Currency)
+		assert.JSONEq(t, `{"currency":"rub"}`, string(store.values["instrument:bond:uid-1"]))
+	})
+}
+
+func TestGetMany(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	c := newTestCache(store)
+
+	_, err := Get(ctx, c, KindBond, "uid-1", func() (bond, error) { return bond{Currency: "rub"}, nil })
+	require.NoError(t, err)
+
+	var gotMissing []string
+	got, err := GetMany(ctx, c, KindBond, []string{"uid-1", "uid-2", "uid-3"}, func(missing []string) (map[string]bond, error) {
+		gotMissing = missing
+		// uid-3 в Invest API не нашелся
+		return map[string]bond{"uid-2": {Currency: "usd"}}, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"uid-2", "uid-3"}, gotMissing)
+	assert.Equal(t, map[string]bond{"uid-1": {Currency: "rub"}, "uid-2": {Currency: "usd"}}, got)
+	assert.Equal(t, KindStats{Hits: 1, Misses: 3}, c.Stats()[KindBond])
+
+	_, err = GetMany(ctx, c, Kind("unknown"), []string{"uid-1"}, func(missing []string) (map[string]bond, error) { return nil, nil })
+	assert.ErrorIs(t, err, ErrUnknownKind)
+}
+
+func TestDailyTTL(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	c := newTestCache(store)
+	// 23:30 по Москве - облигация должна уйти из кэша в полночь, через 30 минут
+	c.now = func() time.Time { return time.Date(2026, 3, 2, 20, 30, 0, 0, time.UTC) }
+
+	_, err := Get(ctx, c, KindBond, "uid-1", func() (bond, error) { return bond{Currency: "rub"}, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, store.ttl)
+
+	_, err = Get(ctx, c, KindFuture, "FIGI", func() (bond, error) { return bond{Currency: "usd"}, nil })
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, store.ttl)
+}
+
+func TestInvalidate(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	c := newTestCache(store)
+	store.values[key(KindBond, "uid-1")] = []byte(`{}`)
+	store.values[key(KindBond, "uid-2")] = []byte(`{}`)
+	store.values[key(KindFuture, "FIGI")] = []byte(`{}`)
+	store.values["lang:1"] = []byte("ru")
+
+	deleted, err := c.Invalidate(ctx, KindBond, "uid-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = c.Invalidate(ctx, KindBond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Contains(t, store.values, key(KindFuture, "FIGI"))
+
+	deleted, err = c.InvalidateAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Contains(t, store.values, "lang:1")
+
+	_, err = c.Invalidate(ctx, Kind("unknown"))
+	assert.ErrorIs(t, err, ErrUnknownKind)
+}
+
+func TestParseKind(t *testing.T) {
+	kind, err := ParseKind("share_currency")
+	require.NoError(t, err)
+	assert.Equal(t, KindShareCurrency, kind)
+
+	_, err = ParseKind("share")
+	assert.ErrorIs(t, err, ErrUnknownKind)
+}
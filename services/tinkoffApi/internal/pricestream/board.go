@@ -0,0 +1,172 @@
+package pricestream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Price - последняя цена инструмента из MarketDataStream.
+// Для облигаций цена в процентах от номинала, как и в GetLastPrices.
+type Price struct {
+	InstrumentUid string    `json:"instrumentUid"`
+	Figi          string    `json:"figi,omitempty"`
+	Units         int64     `json:"units"`
+	Nano          int32     `json:"nano"`
+	Time          time.Time `json:"time"`
+}
+
+// Store - внешнее хранилище доски цен (Redis), чтобы цены были видны другим сервисам.
+type Store interface {
+	SavePrices(ctx context.Context, prices []Price) error
+}
+
+// Board хранит последние цены в памяти и раздает обновления подписчикам.
+// Медленный подписчик не тормозит остальных: обновления для него отбрасываются.
+type Board struct {
+	logger *slog.Logger
+	store  Store
+
+	mu     sync.RWMutex
+	prices map[string]Price
+	dirty  map[string]struct{}
+	subs   map[int]chan Price
+	nextID int
+	closed bool
+}
+
+// NewBoard создает доску цен. store может быть nil.
+func NewBoard(logger *slog.Logger, store Store) *Board {
+	return &Board{
+		logger: logger,
+		store:  store,
+		prices: make(map[string]Price),
+		dirty:  make(map[string]struct{}),
+		subs:   make(map[int]chan Price),
+	}
+}
+
+// Update сохраняет цену, если она не старее уже известной, и рассылает ее подписчикам.
+func (b *Board) Update(p Price) {
+	if p.InstrumentUid == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if prev, ok := b.prices[p.InstrumentUid]; ok && p.Time.Before(prev.Time) {
+		return
+	}
+	b.prices[p.InstrumentUid] = p
+	b.dirty[p.InstrumentUid] = struct{}{}
+	for _, ch := range b.subs {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+// Get возвращает последние цены по instrumentUid, а если uids пуст - все цены доски.
+func (b *Board) Get(uids []string) map[string]Price {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(uids) == 0 {
+		res := make(map[string]Price, len(b.prices))
+		for uid, p := range b.prices {
+			res[uid] = p
+		}
+		return res
+	}
+	res := make(map[string]Price, len(uids))
+	for _, uid := range uids {
+		if p, ok := b.prices[uid]; ok {
+			res[uid] = p
+		}
+	}
+	return res
+}
+
+// Subscribe возвращает канал обновлений цен и функцию отписки, которая закрывает канал.
+func (b *Board) Subscribe(buffer int) (<-chan Price, func()) {
+	ch := make(chan Price, buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if ch, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+}
+
+// Close закрывает каналы всех подписчиков, чтобы долгие HTTP стримы завершились при остановке сервера.
+func (b *Board) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for id, ch := range b.subs {
+		delete(b.subs, id)
+		close(ch)
+	}
+}
+
+// Flush сохраняет в хранилище цены, изменившиеся после прошлого сохранения.
+func (b *Board) Flush(ctx context.Context) error {
+	const op = "pricestream.Flush"
+	if b.store == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	prices := make([]Price, 0, len(b.dirty))
+	for uid := range b.dirty {
+		prices = append(prices, b.prices[uid])
+	}
+	b.dirty = make(map[string]struct{})
+	b.mu.Unlock()
+
+	if len(prices) == 0 {
+		return nil
+	}
+	if err := b.store.SavePrices(ctx, prices); err != nil {
+		// Вернем цены в очередь, чтобы сохранить их в следующий раз
+		b.mu.Lock()
+		for _, p := range prices {
+			b.dirty[p.InstrumentUid] = struct{}{}
+		}
+		b.mu.Unlock()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// RunFlush периодически сохраняет цены в хранилище, пока не отменен ctx.
+func (b *Board) RunFlush(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.Flush(ctx); err != nil {
+				b.logger.WarnContext(ctx, "failed to save prices", slog.Any("error", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+//go:build unit
+
+package pricestream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	saved []Price
+	err   error
+}
+
+func (s *memStore) SavePrices(ctx context.Context, prices []Price) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.saved = append(s.saved, prices...)
+	return nil
+}
+
+func newTestBoard(store Store) *Board {
+	return NewBoard(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+}
+
+func TestBoard_Update(t *testing.T) {
+	board := newTestBoard(nil)
+	now := time.Now()
+
+	board.Update(Price{InstrumentUid: "a", Units: 100, Time: now})
+	board.Update(Price{InstrumentUid: "a", Units: 99, Time: now.Add(-time.Second)})
+	board.Update(Price{InstrumentUid: "b", Units: 50, Time: now})
+	board.Update(Price{Units: 1, Time: now})
+
+	assert.Equal(t, int64(100), board.Get([]string{"a"})["a"].Units, "older price must be ignored")
+	assert.Len(t, board.Get(nil), 2)
+	assert.Len(t, board.Get([]string{"b", "unknown"}), 1)
+}
+
+func TestBoard_Subscribe(t *testing.T) {
+	board := newTestBoard(nil)
+
+	updates, unsubscribe := board.Subscribe(1)
+	board.Update(Price{InstrumentUid: "a", Units: 1, Time: time.Now()})
+	// Буфер заполнен - обновление отбрасывается, а не блокирует доску
+	board.Update(Price{InstrumentUid: "b", Units: 2, Time: time.Now()})
+
+	p := <-updates
+	assert.Equal(t, "a", p.InstrumentUid)
+
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-updates
+	assert.False(t, ok)
+	board.Update(Price{InstrumentUid: "c", Time: time.Now()})
+}
+
+func TestBoard_Close(t *testing.T) {
+	board := newTestBoard(nil)
+
+	updates, unsubscribe := board.Subscribe(1)
+	board.Close()
+	_, ok := <-updates
+	assert.False(t, ok)
+	unsubscribe()
+
+	updates, _ = board.Subscribe(1)
+	_, ok = <-updates
+	assert.False(t, ok, "subscribe after close returns closed channel")
+}
+
+func TestBoard_Flush(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{err: errors.New("redis down")}
+	board := newTestBoard(store)
+
+	board.Update(Price{InstrumentUid: "a", Units: 1, Time: time.Now()})
+	require.Error(t, board.Flush(ctx))
+
+	// После ошибки цены сохраняются при следующей попытке
+	store.err = nil
+	require.NoError(t, board.Flush(ctx))
+	require.Len(t, store.saved, 1)
+
+	require.NoError(t, board.Flush(ctx))
+	assert.Len(t, store.saved, 1, "unchanged prices are not saved again")
+}
@@ -0,0 +1,485 @@
+package pricestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReconnectDelay = 5 * time.Second
+	defaultFlushInterval  = time.Second
+	saveTimeout           = 5 * time.Second
+)
+
+var (
+	ErrClosed      = errors.New("price streamer is closed")
+	ErrEmptyHolder = errors.New("holder could not be empty")
+	ErrEmptyToken  = errors.New("token could not be empty")
+	// ErrNoToken возвращает функция токена в Restore, если пользователь удалил токен.
+	ErrNoToken = errors.New("holder has no token")
+)
+
+// Feed - открытый стрим последних цен Invest API.
+type Feed interface {
+	Subscribe(uids []string) error
+	Unsubscribe(uids []string) error
+	// Prices закрывается, когда стрим оборвался или закрыт через Close.
+	Prices() <-chan Price
+	Close()
+}
+
+// Dialer открывает стрим с токеном пользователя и сразу подписывается на uids.
+// ctx живет столько же, сколько Streamer.
+type Dialer func(ctx context.Context, token string, uids []string) (Feed, error)
+
+// WatchStore хранит наборы инструментов пользователей, чтобы подписки пережили перезапуск сервиса.
+// Пустой набор удаляет запись.
+type WatchStore interface {
+	SaveWatch(ctx context.Context, holderID string, uids []string) error
+	LoadWatches(ctx context.Context) (map[string][]string, error)
+}
+
+// TokenFunc возвращает токен пользователя для восстановления его подписки.
+type TokenFunc func(ctx context.Context, holderID string) (string, error)
+
+type Config struct {
+	// ReconnectDelay - пауза между попытками переподключиться к оборвавшемуся стриму.
+	ReconnectDelay time.Duration `yaml:"reconnectDelay" env-default:"5s"`
+	// FlushInterval - как часто сохранять изменившиеся цены в Redis.
+	FlushInterval time.Duration `yaml:"flushInterval" env-default:"1s"`
+	// TTL - сколько цена хранится в Redis после последнего обновления.
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+	// HoldingsInterval - как часто обновлять подписки по портфелям пользователей, чтобы они следовали за сделками.
+	HoldingsInterval time.Duration `yaml:"holdingsInterval" env-default:"1h"`
+}
+
+type holder struct {
+	token string
+	uids  []string
+	// version отличает последнюю замену набора, чтобы откат неудачного Watch не затер более новый.
+	version uint64
+}
+
+// Streamer держит один стрим последних цен на все инструменты пользователей.
+// Подписка на инструмент живет, пока он есть хотя бы у одного пользователя.
+// Market data не зависит от токена, поэтому для стрима берется токен любого пользователя,
+// а при обрыве стрим переподключается с токеном любого из оставшихся.
+// Стрим открывается вне s.mu: dial ходит в сеть и не должен блокировать подписки и чтение цен.
+type Streamer struct {
+	logger  *slog.Logger
+	board   *Board
+	dial    Dialer
+	watches WatchStore
+	cfg     Config
+
+	mu      sync.Mutex
+	holders map[string]holder
+	refs    map[string]int
+	version uint64
+	feed    Feed
+	// since - когда открыт текущий стрим. Цены старше пришли до обрыва и могли устареть.
+	since  time.Time
+	closed bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStreamer создает стример. watches может быть nil, тогда подписки не сохраняются.
+func NewStreamer(logger *slog.Logger, board *Board, dial Dialer, watches WatchStore, cfg Config) *Streamer {
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = defaultReconnectDelay
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Streamer{
+		logger:  logger,
+		board:   board,
+		dial:    dial,
+		watches: watches,
+		cfg:     cfg,
+		holders: make(map[string]holder),
+		refs:    make(map[string]int),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Watch подписывает holder (chatID пользователя) на цены uids, заменяя его прошлый набор.
+func (s *Streamer) Watch(holderID, token string, uids []string) error {
+	const op = "pricestream.Watch"
+	if holderID == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyHolder)
+	}
+	if token == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyToken)
+	}
+	uids = unique(uids)
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+
+	prev := s.holders[holderID]
+	added, removed := s.applyLocked(prev.uids, uids)
+	s.version++
+	version := s.version
+	s.setHolderLocked(holderID, holder{token: token, uids: uids, version: version})
+
+	var err error
+	dial := s.feed == nil && len(s.refs) > 0
+	if s.feed != nil {
+		if err = s.syncFeedLocked(s.feed, added, removed); err == nil && len(s.refs) == 0 {
+			s.closeFeedLocked()
+		}
+	}
+	if err != nil {
+		s.rollbackLocked(holderID, prev, version)
+	}
+	s.mu.Unlock()
+
+	if dial {
+		if err = s.connect(token); err != nil {
+			s.mu.Lock()
+			s.rollbackLocked(holderID, prev, version)
+			s.mu.Unlock()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.saveWatch(holderID, uids)
+	return nil
+}
+
+// Unwatch отписывает holder от всех его инструментов.
+func (s *Streamer) Unwatch(holderID string) error {
+	s.mu.Lock()
+	token := s.holders[holderID].token
+	s.mu.Unlock()
+	if token == "" {
+		return nil
+	}
+	return s.Watch(holderID, token, nil)
+}
+
+// Restore подписывает пользователей на наборы инструментов, сохраненные до перезапуска.
+// Ошибка одного пользователя не мешает остальным, а набор пользователя без токена удаляется.
+func (s *Streamer) Restore(ctx context.Context, token TokenFunc) error {
+	const op = "pricestream.Restore"
+	if s.watches == nil {
+		return nil
+	}
+	watches, err := s.watches.LoadWatches(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids := make([]string, 0, len(watches))
+	for id := range watches {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		t, err := token(ctx, id)
+		if errors.Is(err, ErrNoToken) {
+			s.saveWatch(id, nil)
+			continue
+		}
+		if err == nil {
+			err = s.Watch(id, t, watches[id])
+		}
+		if err != nil {
+			s.logger.WarnContext(ctx, "could not restore price watch",
+				slog.String("op", op),
+				slog.String("holder", id),
+				slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+// WatchedBy возвращает инструменты, на которые подписан holder.
+func (s *Streamer) WatchedBy(holderID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.holders[holderID].uids)
+}
+
+// Watched возвращает все инструменты, на которые открыта подписка.
+func (s *Streamer) Watched() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watchedLocked()
+}
+
+// Live возвращает цены с доски, которым можно верить вместо GetLastPrices: инструмент в подписке,
+// стрим открыт и цена пришла после его открытия. Остальные цены нужно запросить в Invest API.
+func (s *Streamer) Live(uids []string) map[string]Price {
+	s.mu.Lock()
+	if s.feed == nil {
+		s.mu.Unlock()
+		return map[string]Price{}
+	}
+	since := s.since
+	watched := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if s.refs[uid] > 0 {
+			watched = append(watched, uid)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(watched) == 0 {
+		return map[string]Price{}
+	}
+	prices := s.board.Get(watched)
+	for uid, p := range prices {
+		if p.Time.Before(since) {
+			delete(prices, uid)
+		}
+	}
+	return prices
+}
+
+// Run сохраняет цены в хранилище, пока стример не закрыт.
+func (s *Streamer) Run() {
+	s.board.RunFlush(s.ctx, s.cfg.FlushInterval)
+}
+
+// Close закрывает стрим и ждет завершения фоновых горутин.
+func (s *Streamer) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.cancel()
+	s.closeFeedLocked()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Streamer) applyLocked(prev, next []string) (added, removed []string) {
+	for _, uid := range next {
+		if s.refs[uid] == 0 {
+			added = append(added, uid)
+		}
+		s.refs[uid]++
+	}
+	for _, uid := range prev {
+		s.refs[uid]--
+		if s.refs[uid] <= 0 {
+			delete(s.refs, uid)
+			removed = append(removed, uid)
+		}
+	}
+	return added, removed
+}
+
+func (s *Streamer) setHolderLocked(holderID string, h holder) {
+	if len(h.uids) == 0 {
+		delete(s.holders, holderID)
+		return
+	}
+	s.holders[holderID] = h
+}
+
+// rollbackLocked возвращает holder прошлый набор, если после неудачного Watch его никто не заменил.
+func (s *Streamer) rollbackLocked(holderID string, prev holder, version uint64) {
+	cur, ok := s.holders[holderID]
+	if !ok || cur.version != version {
+		return
+	}
+	added, removed := s.applyLocked(cur.uids, prev.uids)
+	s.setHolderLocked(holderID, prev)
+	if s.feed != nil {
+		if err := s.syncFeedLocked(s.feed, added, removed); err != nil {
+			s.logger.Warn("could not roll back price subscription", slog.Any("error", err))
+		}
+	}
+}
+
+func (s *Streamer) watchedLocked() []string {
+	res := make([]string, 0, len(s.refs))
+	for uid := range s.refs {
+		res = append(res, uid)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// connect открывает стрим с токеном вне s.mu. Пока шло подключение, подписки могли измениться
+// или стрим мог открыть другой вызов, поэтому новый стрим досинхронизируется или закрывается.
+func (s *Streamer) connect(token string) error {
+	s.mu.Lock()
+	uids := s.watchedLocked()
+	s.mu.Unlock()
+
+	feed, err := s.dial(s.ctx, token, uids)
+	if err != nil {
+		return fmt.Errorf("could not open price stream: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		feed.Close()
+		return ErrClosed
+	}
+	if s.feed != nil || len(s.refs) == 0 {
+		feed.Close()
+		return nil
+	}
+	added, removed := diff(uids, s.watchedLocked())
+	if err := s.syncFeedLocked(feed, added, removed); err != nil {
+		feed.Close()
+		return err
+	}
+	s.feed = feed
+	s.since = time.Now()
+	s.wg.Add(1)
+	go s.pump(feed)
+	return nil
+}
+
+func (s *Streamer) syncFeedLocked(feed Feed, added, removed []string) error {
+	if len(added) > 0 {
+		if err := feed.Subscribe(added); err != nil {
+			return fmt.Errorf("could not subscribe: %w", err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := feed.Unsubscribe(removed); err != nil {
+			s.logger.Warn("could not unsubscribe from prices", slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (s *Streamer) closeFeedLocked() {
+	if s.feed == nil {
+		return
+	}
+	s.feed.Close()
+	s.feed = nil
+}
+
+// saveWatch сохраняет набор holder. Подписка уже работает, поэтому ошибка хранилища только пишется в лог.
+func (s *Streamer) saveWatch(holderID string, uids []string) {
+	if s.watches == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, saveTimeout)
+	defer cancel()
+	if err := s.watches.SaveWatch(ctx, holderID, uids); err != nil {
+		s.logger.Warn("could not save price watch", slog.String("holder", holderID), slog.Any("error", err))
+	}
+}
+
+// pump перекладывает цены из стрима на доску, а после обрыва стрима переподключается.
+func (s *Streamer) pump(feed Feed) {
+	defer s.wg.Done()
+	for p := range feed.Prices() {
+		s.board.Update(p)
+	}
+
+	s.mu.Lock()
+	if s.feed != feed {
+		// Стрим закрыт намеренно
+		s.mu.Unlock()
+		return
+	}
+	s.feed = nil
+	s.mu.Unlock()
+	feed.Close()
+
+	s.logger.Warn("price stream interrupted, reconnecting")
+	s.reconnect()
+}
+
+func (s *Streamer) reconnect() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.cfg.ReconnectDelay):
+		}
+
+		s.mu.Lock()
+		if s.closed || s.feed != nil || len(s.refs) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		tokens := s.tokensLocked()
+		s.mu.Unlock()
+
+		var lastErr error
+		for _, token := range tokens {
+			if lastErr = s.connect(token); lastErr == nil {
+				break
+			}
+		}
+		if lastErr == nil || errors.Is(lastErr, ErrClosed) {
+			return
+		}
+		s.logger.Warn("could not reconnect price stream", slog.Any("error", lastErr))
+	}
+}
+
+func (s *Streamer) tokensLocked() []string {
+	ids := make([]string, 0, len(s.holders))
+	for id := range s.holders {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	tokens := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if token := s.holders[id].token; !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// diff возвращает инструменты, которые есть только в next, и только в prev. Оба набора отсортированы.
+func diff(prev, next []string) (added, removed []string) {
+	for _, uid := range next {
+		if _, ok := slices.BinarySearch(prev, uid); !ok {
+			added = append(added, uid)
+		}
+	}
+	for _, uid := range prev {
+		if _, ok := slices.BinarySearch(next, uid); !ok {
+			removed = append(removed, uid)
+		}
+	}
+	return added, removed
+}
+
+func unique(uids []string) []string {
+	seen := make(map[string]struct{}, len(uids))
+	res := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if _, ok := seen[uid]; ok || uid == "" {
+			continue
+		}
+		seen[uid] = struct{}{}
+		res = append(res, uid)
+	}
+	return res
+}
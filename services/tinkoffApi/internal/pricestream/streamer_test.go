@@ -0,0 +1,322 @@
+//go:build unit
+
+package pricestream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFeed struct {
+	mu       sync.Mutex
+	token    string
+	uids     map[string]struct{}
+	prices   chan Price
+	closed   bool
+	closeOne sync.Once
+}
+
+func (f *fakeFeed) Subscribe(uids []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, uid := range uids {
+		f.uids[uid] = struct{}{}
+	}
+	return nil
+}
+
+func (f *fakeFeed) Unsubscribe(uids []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, uid := range uids {
+		delete(f.uids, uid)
+	}
+	return nil
+}
+
+func (f *fakeFeed) Prices() <-chan Price {
+	return f.prices
+}
+
+// Close закрывает стрим, как это делает investgo после Stop или обрыва.
+func (f *fakeFeed) Close() {
+	f.closeOne.Do(func() {
+		f.mu.Lock()
+		f.closed = true
+		f.mu.Unlock()
+		close(f.prices)
+	})
+}
+
+func (f *fakeFeed) subscribed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	res := make([]string, 0, len(f.uids))
+	for uid := range f.uids {
+		res = append(res, uid)
+	}
+	return res
+}
+
+type fakeDialer struct {
+	mu    sync.Mutex
+	feeds []*fakeFeed
+	bad   map[string]bool
+	// onDial вызывается до открытия стрима, как сетевой вызов investgo.
+	onDial func()
+}
+
+func (d *fakeDialer) dial(ctx context.Context, token string, uids []string) (Feed, error) {
+	if d.onDial != nil {
+		d.onDial()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.bad[token] {
+		return nil, errors.New("invalid token")
+	}
+	f := &fakeFeed{token: token, uids: make(map[string]struct{}), prices: make(chan Price, 16)}
+	_ = f.Subscribe(uids)
+	d.feeds = append(d.feeds, f)
+	return f, nil
+}
+
+func (d *fakeDialer) last() *fakeFeed {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.feeds) == 0 {
+		return nil
+	}
+	return d.feeds[len(d.feeds)-1]
+}
+
+func (d *fakeDialer) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.feeds)
+}
+
+type fakeWatchStore struct {
+	mu      sync.Mutex
+	watches map[string][]string
+}
+
+func (w *fakeWatchStore) SaveWatch(ctx context.Context, holderID string, uids []string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(uids) == 0 {
+		delete(w.watches, holderID)
+		return nil
+	}
+	w.watches[holderID] = uids
+	return nil
+}
+
+func (w *fakeWatchStore) LoadWatches(ctx context.Context) (map[string][]string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make(map[string][]string, len(w.watches))
+	for id, uids := range w.watches {
+		res[id] = uids
+	}
+	return res, nil
+}
+
+func newTestStreamer(t *testing.T, d *fakeDialer) (*Streamer, *Board) {
+	return newTestStreamerWithStore(t, d, nil)
+}
+
+func newTestStreamerWithStore(t *testing.T, d *fakeDialer, watches WatchStore) (*Streamer, *Board) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	board := NewBoard(logger, nil)
+	s := NewStreamer(logger, board, d.dial, watches, Config{ReconnectDelay: 10 * time.Millisecond})
+	t.Cleanup(s.Close)
+	return s, board
+}
+
+func TestStreamer_WatchRefCount(t *testing.T) {
+	d := &fakeDialer{}
+	s, _ := newTestStreamer(t, d)
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a", "b", "a"}))
+	require.NoError(t, s.Watch("2", "token-2", []string{"b", "c"}))
+
+	require.Equal(t, 1, d.count(), "one stream for all users")
+	feed := d.last()
+	assert.Equal(t, "token-1", feed.token)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, feed.subscribed())
+	assert.Equal(t, []string{"a", "b", "c"}, s.Watched())
+	assert.Equal(t, []string{"a", "b"}, s.WatchedBy("1"))
+
+	// b остается у второго пользователя
+	require.NoError(t, s.Unwatch("1"))
+	assert.ElementsMatch(t, []string{"b", "c"}, feed.subscribed())
+
+	require.NoError(t, s.Watch("2", "token-2", []string{"c", "d"}))
+	assert.ElementsMatch(t, []string{"c", "d"}, feed.subscribed())
+
+	// Последний пользователь отписался - стрим закрыт
+	require.NoError(t, s.Unwatch("2"))
+	assert.Empty(t, s.Watched())
+	assert.True(t, feed.closed)
+}
+
+func TestStreamer_PricesReachBoard(t *testing.T) {
+	d := &fakeDialer{}
+	s, board := newTestStreamer(t, d)
+
+	updates, unsubscribe := board.Subscribe(4)
+	defer unsubscribe()
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a"}))
+	d.last().prices <- Price{InstrumentUid: "a", Units: 101, Time: time.Now()}
+
+	select {
+	case p := <-updates:
+		assert.Equal(t, int64(101), p.Units)
+	case <-time.After(time.Second):
+		t.Fatal("price did not reach board")
+	}
+	assert.Equal(t, int64(101), board.Get([]string{"a"})["a"].Units)
+}
+
+func TestStreamer_Reconnect(t *testing.T) {
+	d := &fakeDialer{bad: map[string]bool{}}
+	s, _ := newTestStreamer(t, d)
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a"}))
+	require.NoError(t, s.Watch("2", "token-2", []string{"b"}))
+
+	// Токен первого пользователя отозван, стрим оборвался
+	d.mu.Lock()
+	d.bad["token-1"] = true
+	d.mu.Unlock()
+	d.last().Close()
+
+	require.Eventually(t, func() bool { return d.count() == 2 }, time.Second, 5*time.Millisecond)
+	feed := d.last()
+	assert.Equal(t, "token-2", feed.token)
+	assert.ElementsMatch(t, []string{"a", "b"}, feed.subscribed())
+}
+
+func TestStreamer_DialErrorRollsBack(t *testing.T) {
+	d := &fakeDialer{bad: map[string]bool{"token-1": true}}
+	s, _ := newTestStreamer(t, d)
+
+	err := s.Watch("1", "token-1", []string{"a"})
+	require.Error(t, err)
+	assert.Empty(t, s.Watched())
+	assert.Empty(t, s.WatchedBy("1"))
+
+	require.ErrorIs(t, s.Watch("", "token-1", []string{"a"}), ErrEmptyHolder)
+	require.ErrorIs(t, s.Watch("1", "", []string{"a"}), ErrEmptyToken)
+}
+
+func TestStreamer_Close(t *testing.T) {
+	d := &fakeDialer{}
+	s, _ := newTestStreamer(t, d)
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a"}))
+	s.Close()
+
+	assert.True(t, d.last().closed)
+	assert.ErrorIs(t, s.Watch("1", "token-1", []string{"a"}), ErrClosed)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 1, d.count(), "closed streamer must not reconnect")
+}
+
+func TestStreamer_DialOutsideLock(t *testing.T) {
+	d := &fakeDialer{}
+	s, _ := newTestStreamer(t, d)
+
+	// Пока открывается стрим, другие пользователи подписываются и читают набор без ожидания
+	d.onDial = func() {
+		d.onDial = nil
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.Equal(t, []string{"a"}, s.Watched())
+			assert.NoError(t, s.Watch("2", "token-2", []string{"b"}))
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("streamer is locked while dialing")
+		}
+	}
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a"}))
+	assert.Equal(t, []string{"a", "b"}, s.Watched())
+
+	// Второй пользователь успел открыть свой стрим, лишний закрыт
+	require.Equal(t, 2, d.count())
+	d.mu.Lock()
+	first, second := d.feeds[0], d.feeds[1]
+	d.mu.Unlock()
+	assert.False(t, first.closed)
+	assert.ElementsMatch(t, []string{"a", "b"}, first.subscribed())
+	assert.True(t, second.closed)
+}
+
+func TestStreamer_SaveAndRestore(t *testing.T) {
+	d := &fakeDialer{}
+	watches := &fakeWatchStore{watches: map[string][]string{}}
+	s, _ := newTestStreamerWithStore(t, d, watches)
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a", "b"}))
+	require.NoError(t, s.Watch("2", "token-2", []string{"c"}))
+	require.NoError(t, s.Watch("3", "token-3", []string{"d"}))
+	require.NoError(t, s.Unwatch("2"))
+	assert.Equal(t, map[string][]string{"1": {"a", "b"}, "3": {"d"}}, watches.watches)
+
+	// Ошибка подписки не сохраняется
+	d.mu.Lock()
+	d.bad = map[string]bool{"token-4": true}
+	d.mu.Unlock()
+	require.NoError(t, s.Unwatch("1"))
+	require.NoError(t, s.Unwatch("3"))
+	require.Error(t, s.Watch("4", "token-4", []string{"e"}))
+	assert.Empty(t, watches.watches)
+
+	watches.watches = map[string][]string{"1": {"a", "b"}, "2": {"c"}, "3": {"d"}}
+	restored, _ := newTestStreamerWithStore(t, &fakeDialer{}, watches)
+	err := restored.Restore(context.Background(), func(ctx context.Context, holderID string) (string, error) {
+		switch holderID {
+		case "2":
+			return "", ErrNoToken
+		case "3":
+			return "", errors.New("redis is down")
+		}
+		return "token-" + holderID, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, restored.Watched())
+	// Набор пользователя без токена удален, а при временной ошибке сохранен до следующего запуска
+	assert.Equal(t, map[string][]string{"1": {"a", "b"}, "3": {"d"}}, watches.watches)
+}
+
+func TestStreamer_Live(t *testing.T) {
+	d := &fakeDialer{}
+	s, board := newTestStreamer(t, d)
+	assert.Empty(t, s.Live([]string{"a"}), "no stream")
+
+	require.NoError(t, s.Watch("1", "token-1", []string{"a", "b"}))
+	board.Update(Price{InstrumentUid: "a", Units: 100, Time: time.Now().Add(-time.Hour)})
+	board.Update(Price{InstrumentUid: "c", Units: 300, Time: time.Now()})
+	d.last().prices <- Price{InstrumentUid: "b", Units: 200, Time: time.Now()}
+	require.Eventually(t, func() bool { return len(board.Get([]string{"b"})) == 1 }, time.Second, 5*time.Millisecond)
+
+	// a пришла до открытия стрима, c не в подписке
+	live := s.Live([]string{"a", "b", "c"})
+	require.Len(t, live, 1)
+	assert.Equal(t, int64(200), live["b"].Units)
+	assert.Empty(t, s.Live(nil))
+}
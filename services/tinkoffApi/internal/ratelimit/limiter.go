@@ -0,0 +1,296 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tinkoffApi/internal/apierrors"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Service - сервис Invest API. Тинькофф считает квоты на токен отдельно для каждого сервиса.
+type Service string
+
+const (
+	Users       Service = "UsersService"
+	Operations  Service = "OperationsService"
+	Instruments Service = "InstrumentsService"
+	MarketData  Service = "MarketDataService"
+	Sandbox     Service = "SandboxService"
+)
+
+// Заголовки, которыми Invest API сообщает состояние квоты после каждого запроса.
+const (
+	headerLimit     = "x-ratelimit-limit"
+	headerRemaining = "x-ratelimit-remaining"
+	headerReset     = "x-ratelimit-reset"
+)
+
+var ErrQuotaExceeded = apierrors.New(apierrors.RateLimited, "invest api request quota exceeded")
+
+// Quota - сколько запросов к сервису можно сделать за период.
+type Quota struct {
+	Limit  int           `yaml:"limit"`
+	Period time.Duration `yaml:"period"`
+}
+
+type Config struct {
+	// Quotas - квоты по сервисам. Запросы к сервисам без квоты не ограничиваются.
+	Quotas map[Service]Quota `yaml:"quotas"`
+	// IdleTTL - через сколько удаляется очередь токена, к которой не было запросов.
+	IdleTTL time.Duration `yaml:"idleTTL" env-default:"10m"`
+}
+
+// DefaultQuotas - лимиты unary запросов тарифа "Инвестор".
+func DefaultQuotas() map[Service]Quota {
+	return map[Service]Quota{
+		Users:       {Limit: 100, Period: time.Minute},
+		Operations:  {Limit: 200, Period: time.Minute},
+		Instruments: {Limit: 200, Period: time.Minute},
+		MarketData:  {Limit: 600, Period: time.Minute},
+		Sandbox:     {Limit: 200, Period: time.Minute},
+	}
+}
+
+type bucketKey struct {
+	token   string
+	service Service
+}
+
+// bucket - token bucket одного сервиса для одного токена.
+// tokens уходит в минус, когда запросы стоят в очереди.
+type bucket struct {
+	quota    Quota
+	tokens   float64
+	last     time.Time
+	lastUsed time.Time
+}
+
+func newBucket(quota Quota, now time.Time) *bucket {
+	return &bucket{quota: quota, tokens: float64(quota.Limit), last: now, lastUsed: now}
+}
+
+// rate - сколько запросов восполняется за секунду.
+func (b *bucket) rate() float64 {
+	return float64(b.quota.Limit) / b.quota.Period.Seconds()
+}
+
+func (b *bucket) refill(now time.Time) {
+	// last в будущем - квота исчерпана до сброса, о котором сообщил Invest API
+	if !now.After(b.last) {
+		return
+	}
+	b.tokens = min(float64(b.quota.Limit), b.tokens+now.Sub(b.last).Seconds()*b.rate())
+	b.last = now
+}
+
+// delay - сколько ждать запросу, который только что занял место в бакете.
+func (b *bucket) delay(now time.Time) time.Duration {
+	var d time.Duration
+	if b.last.After(now) {
+		d = b.last.Sub(now)
+	}
+	if b.tokens < 0 {
+		d += time.Duration(-b.tokens / b.rate() * float64(time.Second))
+	}
+	return d
+}
+
+// Limiter планирует запросы к Invest API так, чтобы не выходить за квоты токена.
+// Квоты берутся из конфига и уточняются по заголовкам x-ratelimit-* из ответов.
+// Нулевой *Limiter ничего не ограничивает.
+type Limiter struct {
+	cfg Config
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastPrune time.Time
+}
+
+func New(cfg Config) *Limiter {
+	if cfg.Quotas == nil {
+		cfg.Quotas = DefaultQuotas()
+	}
+	return &Limiter{
+		cfg:     cfg,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Wait ждет, пока в квоте сервиса для токена освободится запрос.
+// Если запрос не успеет выполниться до дедлайна ctx, он не ставится в очередь
+// и сразу возвращается ErrQuotaExceeded.
+func (l *Limiter) Wait(ctx context.Context, token string, service Service) error {
+	if l == nil {
+		return nil
+	}
+	delay, err := l.reserve(ctx, token, service)
+	if err != nil || delay <= 0 {
+		return err
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(token, service)
+		return fmt.Errorf("%w: %s: %w", ErrQuotaExceeded, service, ctx.Err())
+	}
+}
+
+func (l *Limiter) reserve(ctx context.Context, token string, service Service) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	b := l.bucket(token, service, now)
+	if b == nil {
+		return 0, nil
+	}
+	b.refill(now)
+	b.tokens--
+	b.lastUsed = now
+
+	delay := b.delay(now)
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		b.tokens++
+		return 0, fmt.Errorf("%w: %s: wait %s exceeds request deadline", ErrQuotaExceeded, service, delay)
+	}
+	return delay, nil
+}
+
+// cancel возвращает место запроса, который не дождался своей очереди.
+func (l *Limiter) cancel(token string, service Service) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[bucketKey{token: token, service: service}]; ok {
+		b.tokens = min(float64(b.quota.Limit), b.tokens+1)
+	}
+}
+
+// Observe уточняет квоту по заголовкам ответа Invest API:
+// x-ratelimit-limit задает размер квоты, x-ratelimit-remaining - сколько запросов осталось,
+// x-ratelimit-reset - через сколько секунд квота сбросится.
+func (l *Limiter) Observe(token string, service Service, md metadata.MD) {
+	if l == nil || md == nil {
+		return
+	}
+	limit, period, hasLimit := parseLimit(md.Get(headerLimit))
+	remaining, hasRemaining := parseInt(md.Get(headerRemaining))
+	reset, hasReset := parseInt(md.Get(headerReset))
+	if !hasLimit && !hasRemaining {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	key := bucketKey{token: token, service: service}
+	b, ok := l.buckets[key]
+	if !ok {
+		if !hasLimit {
+			return
+		}
+		b = newBucket(Quota{Limit: limit, Period: time.Minute}, now)
+		l.buckets[key] = b
+	}
+	b.refill(now)
+	if hasLimit && limit > 0 {
+		b.quota.Limit = limit
+		if period > 0 {
+			b.quota.Period = period
+		}
+	}
+	if hasRemaining && float64(remaining) < b.tokens {
+		b.tokens = float64(remaining)
+	}
+	// Квота кончилась: до сброса новые запросы не восполняются
+	if hasRemaining && remaining == 0 && hasReset && reset > 0 {
+		if resetAt := now.Add(time.Duration(reset) * time.Second); resetAt.After(b.last) {
+			b.last = resetAt
+		}
+	}
+}
+
+// Quota возвращает текущую квоту сервиса для токена.
+func (l *Limiter) Quota(token string, service Service) (Quota, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[bucketKey{token: token, service: service}]; ok {
+		return b.quota, true
+	}
+	quota, ok := l.cfg.Quotas[service]
+	return quota, ok
+}
+
+func (l *Limiter) bucket(token string, service Service, now time.Time) *bucket {
+	key := bucketKey{token: token, service: service}
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	quota, ok := l.cfg.Quotas[service]
+	if !ok || quota.Limit <= 0 || quota.Period <= 0 {
+		return nil
+	}
+	b := newBucket(quota, now)
+	l.buckets[key] = b
+	return b
+}
+
+// prune удаляет бакеты токенов, которые давно не делали запросов и у которых нет очереди.
+func (l *Limiter) prune(now time.Time) {
+	if l.cfg.IdleTTL <= 0 || now.Sub(l.lastPrune) < l.cfg.IdleTTL {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if b.tokens >= 0 && !b.last.After(now) && now.Sub(b.lastUsed) >= l.cfg.IdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// parseLimit разбирает x-ratelimit-limit вида "200, 200;w=60".
+func parseLimit(values []string) (int, time.Duration, bool) {
+	if len(values) == 0 {
+		return 0, 0, false
+	}
+	parts := strings.Split(values[0], ",")
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	var period time.Duration
+	for _, part := range parts[1:] {
+		_, window, ok := strings.Cut(part, "w=")
+		if !ok {
+			continue
+		}
+		if seconds, err := strconv.Atoi(strings.TrimSpace(window)); err == nil && seconds > 0 {
+			period = time.Duration(seconds) * time.Second
+		}
+	}
+	return limit, period, true
+}
+
+func parseInt(values []string) (int, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(values[0]))
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,129 @@
+//go:build unit
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tinkoffApi/internal/apierrors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestLimiter(quota Quota) (*Limiter, *time.Time) {
+	now := time.Now()
+	l := New(Config{Quotas: map[Service]Quota{Users: quota}, IdleTTL: time.Minute})
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	ctx := context.Background()
+	l, now := newTestLimiter(Quota{Limit: 2, Period: time.Second})
+
+	for i := 0; i < 2; i++ {
+		delay, err := l.reserve(ctx, "token", Users)
+		require.NoError(t, err)
+		assert.Zero(t, delay, "burst fits into quota")
+	}
+	delay, err := l.reserve(ctx, "token", Users)
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, delay)
+
+	delay, err = l.reserve(ctx, "other-token", Users)
+	require.NoError(t, err)
+	assert.Zero(t, delay, "quotas are per token")
+
+	*now = now.Add(2 * time.Second)
+	delay, err = l.reserve(ctx, "token", Users)
+	require.NoError(t, err)
+	assert.Zero(t, delay, "quota refills over time")
+
+	delay, err = l.reserve(ctx, "token", Operations)
+	require.NoError(t, err)
+	assert.Zero(t, delay, "services without quota are not limited")
+}
+
+func TestLimiter_Deadline(t *testing.T) {
+	l, _ := newTestLimiter(Quota{Limit: 1, Period: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, l.Wait(ctx, "token", Users))
+
+	err := l.Wait(ctx, "token", Users)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.ErrorIs(t, err, apierrors.RateLimited)
+
+	// Отклоненный запрос не занимает место в очереди
+	delay, err := l.reserve(context.Background(), "token", Users)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestLimiter_WaitCancel(t *testing.T) {
+	l := New(Config{Quotas: map[Service]Quota{Users: {Limit: 1, Period: time.Hour}}})
+	require.NoError(t, l.Wait(context.Background(), "token", Users))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err := l.Wait(ctx, "token", Users)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLimiter_Observe(t *testing.T) {
+	ctx := context.Background()
+	l, now := newTestLimiter(Quota{Limit: 100, Period: time.Minute})
+
+	l.Observe("token", Users, metadata.Pairs(headerLimit, "50, 50;w=30", headerRemaining, "49"))
+	quota, ok := l.Quota("token", Users)
+	require.True(t, ok)
+	assert.Equal(t, Quota{Limit: 50, Period: 30 * time.Second}, quota)
+
+	// Другие экземпляры сервиса потратили квоту: ждем сброса
+	l.Observe("token", Users, metadata.Pairs(headerRemaining, "0", headerReset, "20"))
+	delay, err := l.reserve(ctx, "token", Users)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second+600*time.Millisecond, delay)
+
+	*now = now.Add(time.Minute)
+	delay, err = l.reserve(ctx, "token", Users)
+	require.NoError(t, err)
+	assert.Zero(t, delay)
+
+	l.Observe("new-token", Operations, metadata.Pairs(headerLimit, "200"))
+	quota, ok = l.Quota("new-token", Operations)
+	require.True(t, ok)
+	assert.Equal(t, Quota{Limit: 200, Period: time.Minute}, quota, "quota is learned from headers")
+
+	l.Observe("token", Users, nil)
+	l.Observe("token", Users, metadata.Pairs(headerRemaining, "many"))
+}
+
+func TestLimiter_Prune(t *testing.T) {
+	ctx := context.Background()
+	l, now := newTestLimiter(Quota{Limit: 10, Period: time.Second})
+
+	_, err := l.reserve(ctx, "idle", Users)
+	require.NoError(t, err)
+	*now = now.Add(2 * time.Minute)
+	_, err = l.reserve(ctx, "active", Users)
+	require.NoError(t, err)
+
+	assert.Len(t, l.buckets, 1)
+	assert.Contains(t, l.buckets, bucketKey{token: "active", service: Users})
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var l *Limiter
+	require.NoError(t, l.Wait(context.Background(), "token", Users))
+	l.Observe("token", Users, metadata.Pairs(headerRemaining, "0"))
+}
@@ -0,0 +1,93 @@
+package redisClient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const scanCount = 500
+
+// InstrumentStore хранит кэш справочных данных инструментов в Redis.
+type InstrumentStore struct {
+	client *redis.Client
+}
+
+func NewInstrumentStore(client *redis.Client) *InstrumentStore {
+	return &InstrumentStore{client: client}
+}
+
+func (s *InstrumentStore) MGet(ctx context.Context, keys []string) ([][]byte, error) {
+	const op = "redis.InstrumentStore.MGet"
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	res := make([][]byte, len(values))
+	for i, v := range values {
+		if str, ok := v.(string); ok {
+			res[i] = []byte(str)
+		}
+	}
+	return res, nil
+}
+
+func (s *InstrumentStore) Set(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	const op = "redis.InstrumentStore.Set"
+	pipe := s.client.Pipeline()
+	for key, value := range values {
+		pipe.Set(ctx, key, value, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *InstrumentStore) Delete(ctx context.Context, keys ...string) (int64, error) {
+	const op = "redis.InstrumentStore.Delete"
+	deleted, err := s.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
+
+// DeleteByPrefix удаляет ключи через SCAN, чтобы не блокировать Redis командой KEYS.
+func (s *InstrumentStore) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	const op = "redis.InstrumentStore.DeleteByPrefix"
+	var deleted int64
+	keys := make([]string, 0, scanCount)
+
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		n, err := s.client.Del(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		deleted += n
+		keys = keys[:0]
+		return nil
+	}
+
+	iter := s.client.Scan(ctx, 0, prefix+"*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanCount {
+			if err := flush(); err != nil {
+				return deleted, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := flush(); err != nil {
+		return deleted, fmt.Errorf("%s: %w", op, err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,40 @@
+package redisClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tinkoffApi/internal/pricestream"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const pricePrefix = "price:last:"
+
+// PriceStore хранит доску последних цен в Redis, ключ - price:last:<instrumentUid>.
+type PriceStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewPriceStore(client *redis.Client, ttl time.Duration) *PriceStore {
+	return &PriceStore{client: client, ttl: ttl}
+}
+
+func (s *PriceStore) SavePrices(ctx context.Context, prices []pricestream.Price) error {
+	const op = "redis.PriceStore.SavePrices"
+	pipe := s.client.Pipeline()
+	for _, p := range prices {
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		pipe.Set(ctx, pricePrefix+p.InstrumentUid, raw, s.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
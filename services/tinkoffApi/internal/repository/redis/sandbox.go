@@ -0,0 +1,56 @@
+package redisClient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const sandboxModePrefix = "sandbox:token:"
+
+// SandboxModeStore хранит токены, запросы которых по умолчанию идут в песочницу.
+// Токен в ключе хранится только в виде хэша.
+type SandboxModeStore struct {
+	client *redis.Client
+}
+
+func NewSandboxModeStore(client *redis.Client) *SandboxModeStore {
+	return &SandboxModeStore{client: client}
+}
+
+func (s *SandboxModeStore) IsEnabled(ctx context.Context, token string) (bool, error) {
+	const op = "redis.SandboxModeStore.IsEnabled"
+	err := s.client.Get(ctx, sandboxModeKey(token)).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return true, nil
+}
+
+func (s *SandboxModeStore) Enable(ctx context.Context, token string) error {
+	const op = "redis.SandboxModeStore.Enable"
+	if err := s.client.Set(ctx, sandboxModeKey(token), 1, 0).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *SandboxModeStore) Disable(ctx context.Context, token string) error {
+	const op = "redis.SandboxModeStore.Disable"
+	if err := s.client.Del(ctx, sandboxModeKey(token)).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func sandboxModeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return sandboxModePrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,39 @@
+package redisClient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// chatIDPattern отбирает ключи токенов пользователей: токен хранится под chatID без префикса.
+const chatIDPattern = "[-0-9]*"
+
+// UserStore перечисляет пользователей, сохранивших токен в боте.
+type UserStore struct {
+	client *redis.Client
+}
+
+func NewUserStore(client *redis.Client) *UserStore {
+	return &UserStore{client: client}
+}
+
+// ChatIDs возвращает chatID пользователей через SCAN, чтобы не блокировать Redis командой KEYS.
+func (s *UserStore) ChatIDs(ctx context.Context) ([]string, error) {
+	const op = "redis.UserStore.ChatIDs"
+	var res []string
+	iter := s.client.Scan(ctx, 0, chatIDPattern, scanCount).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if _, err := strconv.ParseInt(key, 10, 64); err != nil {
+			continue
+		}
+		res = append(res, key)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package redisClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const watchPrefix = "price:watch:"
+
+// WatchStore хранит наборы инструментов, на цены которых подписан пользователь,
+// ключ - price:watch:<chatID>. Записи живут без TTL, пока пользователь не отпишется.
+type WatchStore struct {
+	client *redis.Client
+}
+
+func NewWatchStore(client *redis.Client) *WatchStore {
+	return &WatchStore{client: client}
+}
+
+func (s *WatchStore) SaveWatch(ctx context.Context, holderID string, uids []string) error {
+	const op = "redis.WatchStore.SaveWatch"
+	if len(uids) == 0 {
+		if err := s.client.Del(ctx, watchPrefix+holderID).Err(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	}
+	raw, err := json.Marshal(uids)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.client.Set(ctx, watchPrefix+holderID, raw, 0).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *WatchStore) LoadWatches(ctx context.Context) (map[string][]string, error) {
+	const op = "redis.WatchStore.LoadWatches"
+	var keys []string
+	iter := s.client.Scan(ctx, 0, watchPrefix+"*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res := make(map[string][]string, len(keys))
+	for start := 0; start < len(keys); start += scanCount {
+		batch := keys[start:min(start+scanCount, len(keys))]
+		values, err := s.client.MGet(ctx, batch...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for i, v := range values {
+			str, ok := v.(string)
+			if !ok {
+				continue
+			}
+			var uids []string
+			if err := json.Unmarshal([]byte(str), &uids); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", op, batch[i], err)
+			}
+			res[strings.TrimPrefix(batch[i], watchPrefix)] = uids
+		}
+	}
+	return res, nil
+}
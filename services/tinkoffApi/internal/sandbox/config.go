@@ -0,0 +1,8 @@
+package sandbox
+
+type Config struct {
+	// Enabled - разрешены ли запросы к песочнице.
+	Enabled bool `yaml:"enabled" env-default:"true"`
+	// EndPoint - адрес песочницы, можно подменить на локальный стенд.
+	EndPoint string `yaml:"endPoint" env-default:"sandbox-invest-public-api.tinkoff.ru:443"`
+}
@@ -0,0 +1,37 @@
+package sandbox
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// HeaderSandbox - заголовок, которым клиент выбирает песочницу для одного запроса.
+// "true" включает песочницу, "false" - боевой контур даже для токена с режимом песочницы.
+const HeaderSandbox = "X-Sandbox"
+
+type modeKey struct{}
+
+// WithSandbox помечает запрос как запрос к песочнице Invest API.
+func WithSandbox(ctx context.Context) context.Context {
+	return context.WithValue(ctx, modeKey{}, true)
+}
+
+// Enabled сообщает, что запрос нужно выполнить в песочнице.
+func Enabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(modeKey{}).(bool)
+	return enabled
+}
+
+// ParseHeader разбирает заголовок X-Sandbox. ok = false, если режим в запросе не задан.
+func ParseHeader(value string) (enabled bool, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, false
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return enabled, true
+}
@@ -0,0 +1,35 @@
+//go:build unit
+
+package sandbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMode(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, Enabled(ctx))
+	assert.True(t, Enabled(WithSandbox(ctx)))
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		value       string
+		wantEnabled bool
+		wantOk      bool
+	}{
+		{value: "true", wantEnabled: true, wantOk: true},
+		{value: " 1 ", wantEnabled: true, wantOk: true},
+		{value: "false", wantEnabled: false, wantOk: true},
+		{value: "", wantEnabled: false, wantOk: false},
+		{value: "sandbox", wantEnabled: false, wantOk: false},
+	}
+	for _, tt := range tests {
+		enabled, ok := ParseHeader(tt.value)
+		assert.Equal(t, tt.wantEnabled, enabled, tt.value)
+		assert.Equal(t, tt.wantOk, ok, tt.value)
+	}
+}
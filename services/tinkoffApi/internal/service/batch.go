@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"tinkoffApi/internal/apierrors"
+	"tinkoffApi/internal/ratelimit"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+)
+
+// MaxBatchSize ограничивает количество инструментов в одном пакетном запросе,
+// большие списки клиенты делят на пакеты сами.
+const MaxBatchSize = 300
+
+// batchWorkers - сколько запросов по отдельным инструментам пакета выполняется одновременно.
+// Темп запросов дополнительно ограничивает limiter токена.
+const batchWorkers = 8
+
+// uniqueIds проверяет пакет идентификаторов и убирает повторы, сохраняя порядок.
+func uniqueIds(ids []string, errEmptyId error) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	seen := make(map[string]struct{}, len(ids))
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, errEmptyId
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	if len(res) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	return res, nil
+}
+
+// fetchEach запрашивает инструменты по одному, не больше batchWorkers запросов одновременно:
+// в Invest API нет пакетных ручек для облигаций, фьючерсов и валют, а выгрузка всего
+// справочника ради нескольких бумаг медленнее. Инструмент, который Invest API не нашел,
+// не ломает пакет и попадает в NotFound. Любая другая ошибка, например лимит запросов,
+// таймаут или недействительный токен, возвращается и останавливает оставшиеся запросы:
+// выдавать такие инструменты за несуществующие нельзя.
+func fetchEach[V any](
+	ctx context.Context,
+	logg investgo.Logger,
+	op string,
+	ids []string,
+	fetch func(ctx context.Context, id string) (V, error),
+) (map[string]V, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	res := make(map[string]V, len(ids))
+	sem := make(chan struct{}, batchWorkers)
+loop:
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			v, err := fetch(ctx, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if errors.Is(apierrors.FromError(err), apierrors.NotFound) {
+					if logg != nil {
+						logg.Infof("%s: instrument %s not found: %v", op, id, err)
+					}
+					return
+				}
+				if firstErr == nil {
+					firstErr = fmt.Errorf("instrument %s: %w", id, err)
+					cancel()
+				}
+				return
+			}
+			res[id] = v
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func notFoundIds[V any](ids []string, found map[string]V) []string {
+	var res []string
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func (c *AnalyticsServiceClient) GetLastPricesInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUids []string) (LastPricesResponse, error) {
+	const op = "service.GetLastPricesInPersentageToNominal"
+	ids, err := uniqueIds(instrumentUids, ErrEmptyInstrumentUid)
+	if err != nil {
+		return LastPricesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	marketDataClient := client.NewMarketDataServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.MarketData); err != nil {
+		return LastPricesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	lastPriceAnswer, err := marketDataClient.GetLastPrices(ids)
+	if err != nil {
+		return LastPricesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.MarketData, lastPriceAnswer.Header)
+
+	resp := LastPricesResponse{LastPrices: make(map[string]Quotation, len(ids))}
+	for _, lastPrice := range lastPriceAnswer.GetLastPrices() {
+		// Для несуществующего instrumentUid тинькофф отдает нулевую цену без типа, а не ошибку
+		if lastPrice.GetPrice().ToFloat() == 0 && lastPrice.GetLastPriceType().Number() == 0 {
+			continue
+		}
+		resp.LastPrices[lastPrice.GetInstrumentUid()] = ConvertPbToQuatation(lastPrice.GetPrice())
+	}
+	resp.NotFound = notFoundIds(ids, resp.LastPrices)
+	return resp, nil
+}
+
+func (c *InstrumentsServiceClient) GetBondsByUids(ctx context.Context, client *investgo.Client, uids []string) (BondsResponse, error) {
+	const op = "service.GetBondsByUids"
+	ids, err := uniqueIds(uids, ErrEmptyUid)
+	if err != nil {
+		return BondsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	bonds, err := fetchEach(ctx, c.Logg, op, ids, func(ctx context.Context, uid string) (Bond, error) {
+		return c.GetBondByUid(ctx, client, uid)
+	})
+	if err != nil {
+		return BondsResponse{}, fmt.Errorf("%s: could not get bonds: %w", op, err)
+	}
+	return BondsResponse{Bonds: bonds, NotFound: notFoundIds(ids, bonds)}, nil
+}
+
+func (c *AnalyticsServiceClient) GetBondsActionsBatch(ctx context.Context, client *investgo.Client, instrumentUids []string) (BondsActionsBatchResponse, error) {
+	const op = "service.GetBondsActionsBatch"
+	ids, err := uniqueIds(instrumentUids, ErrEmptyInstrumentUid)
+	if err != nil {
+		return BondsActionsBatchResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	bondsActions, err := fetchEach(ctx, c.Logg, op, ids, func(ctx context.Context, uid string) (BondIdentIdentifiers, error) {
+		return c.GetBondsActions(ctx, client, uid)
+	})
+	if err != nil {
+		return BondsActionsBatchResponse{}, fmt.Errorf("%s: could not get bonds actions: %w", op, err)
+	}
+	return BondsActionsBatchResponse{BondsActions: bondsActions, NotFound: notFoundIds(ids, bondsActions)}, nil
+}
+
+func (c *InstrumentsServiceClient) GetFuturesBy(ctx context.Context, client *investgo.Client, figis []string) (FuturesResponse, error) {
+	const op = "service.GetFuturesBy"
+	ids, err := uniqueIds(figis, ErrEmptyFigi)
+	if err != nil {
+		return FuturesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	futures, err := fetchEach(ctx, c.Logg, op, ids, func(ctx context.Context, figi string) (Future, error) {
+		return c.GetFutureBy(ctx, client, figi)
+	})
+	if err != nil {
+		return FuturesResponse{}, fmt.Errorf("%s: could not get futures: %w", op, err)
+	}
+	return FuturesResponse{Futures: futures, NotFound: notFoundIds(ids, futures)}, nil
+}
+
+func (c *InstrumentsServiceClient) GetCurrenciesBy(ctx context.Context, client *investgo.Client, figis []string) (CurrenciesResponse, error) {
+	const op = "service.GetCurrenciesBy"
+	ids, err := uniqueIds(figis, ErrEmptyFigi)
+	if err != nil {
+		return CurrenciesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	currencies, err := fetchEach(ctx, c.Logg, op, ids, func(ctx context.Context, figi string) (Currency, error) {
+		return c.GetCurrencyBy(ctx, client, figi)
+	})
+	if err != nil {
+		return CurrenciesResponse{}, fmt.Errorf("%s: could not get currencies: %w", op, err)
+	}
+	return CurrenciesResponse{Currencies: currencies, NotFound: notFoundIds(ids, currencies)}, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"tinkoffApi/internal/instrumentcache"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+)
+
+// CachedInstrumentService отдает справочные данные инструментов из кэша
+// и ходит в Invest API только за отсутствующими.
+// Операции с кэшем ограничены и ctx запроса, и собственным таймаутом кэша.
+// Облигации хранятся только до конца дня: НКД и номинал берутся из того же ответа
+// и на следующий день перечитываются вместе со справочными полями.
+type CachedInstrumentService struct {
+	InstrumentService
+	cache *instrumentcache.Cache
+}
+
+func NewCachedInstrumentService(next InstrumentService, cache *instrumentcache.Cache) InstrumentService {
+	return &CachedInstrumentService{
+		InstrumentService: next,
+		cache:             cache,
+	}
+}
+
+func (s *CachedInstrumentService) GetBondByUid(ctx context.Context, client *investgo.Client, uid string) (Bond, error) {
+	return instrumentcache.Get(ctx, s.cache, instrumentcache.KindBond, uid, func() (Bond, error) {
+		return s.InstrumentService.GetBondByUid(ctx, client, uid)
+	})
+}
+
+func (s *CachedInstrumentService) GetFutureBy(ctx context.Context, client *investgo.Client, figi string) (Future, error) {
+	return instrumentcache.Get(ctx, s.cache, instrumentcache.KindFuture, figi, func() (Future, error) {
+		return s.InstrumentService.GetFutureBy(ctx, client, figi)
+	})
+}
+
+func (s *CachedInstrumentService) GetCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (Currency, error) {
+	return instrumentcache.Get(ctx, s.cache, instrumentcache.KindCurrency, figi, func() (Currency, error) {
+		return s.InstrumentService.GetCurrencyBy(ctx, client, figi)
+	})
+}
+
+func (s *CachedInstrumentService) GetShareCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (ShareCurrencyByResponse, error) {
+	return instrumentcache.Get(ctx, s.cache, instrumentcache.KindShareCurrency, figi, func() (ShareCurrencyByResponse, error) {
+		return s.InstrumentService.GetShareCurrencyBy(ctx, client, figi)
+	})
+}
+
+func (s *CachedInstrumentService) GetBondsByUids(ctx context.Context, client *investgo.Client, uids []string) (BondsResponse, error) {
+	const op = "service.CachedInstrumentService.GetBondsByUids"
+	ids, err := uniqueIds(uids, ErrEmptyUid)
+	if err != nil {
+		return BondsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	bonds, err := instrumentcache.GetMany(ctx, s.cache, instrumentcache.KindBond, ids, func(missing []string) (map[string]Bond, error) {
+		resp, err := s.InstrumentService.GetBondsByUids(ctx, client, missing)
+		return resp.Bonds, err
+	})
+	if err != nil {
+		return BondsResponse{}, err
+	}
+	return BondsResponse{Bonds: bonds, NotFound: notFoundIds(ids, bonds)}, nil
+}
+
+func (s *CachedInstrumentService) GetFuturesBy(ctx context.Context, client *investgo.Client, figis []string) (FuturesResponse, error) {
+	const op = "service.CachedInstrumentService.GetFuturesBy"
+	ids, err := uniqueIds(figis, ErrEmptyFigi)
+	if err != nil {
+		return FuturesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	futures, err := instrumentcache.GetMany(ctx, s.cache, instrumentcache.KindFuture, ids, func(missing []string) (map[string]Future, error) {
+		resp, err := s.InstrumentService.GetFuturesBy(ctx, client, missing)
+		return resp.Futures, err
+	})
+	if err != nil {
+		return FuturesResponse{}, err
+	}
+	return FuturesResponse{Futures: futures, NotFound: notFoundIds(ids, futures)}, nil
+}
+
+func (s *CachedInstrumentService) GetCurrenciesBy(ctx context.Context, client *investgo.Client, figis []string) (CurrenciesResponse, error) {
+	const op = "service.CachedInstrumentService.GetCurrenciesBy"
+	ids, err := uniqueIds(figis, ErrEmptyFigi)
+	if err != nil {
+		return CurrenciesResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	currencies, err := instrumentcache.GetMany(ctx, s.cache, instrumentcache.KindCurrency, ids, func(missing []string) (map[string]Currency, error) {
+		resp, err := s.InstrumentService.GetCurrenciesBy(ctx, client, missing)
+		return resp.Currencies, err
+	})
+	if err != nil {
+		return CurrenciesResponse{}, err
+	}
+	return CurrenciesResponse{Currencies: currencies, NotFound: notFoundIds(ids, currencies)}, nil
+}
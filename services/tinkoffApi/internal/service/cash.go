@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	pb "github.com/russianinvestments/invest-api-go-sdk/proto"
+)
+
+// GetPositions возвращает денежные средства и бумаги счета с учетом блокировок.
+func (c *PortfolioServiceClient) GetPositions(ctx context.Context, client *investgo.Client, accountID string) (Positions, error) {
+	const op = "service.GetPositions"
+	if accountID == "" {
+		return Positions{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	}
+
+	positionsResp, err := c.getPositions(ctx, client, accountID)
+	if err != nil {
+		return Positions{}, fmt.Errorf("op:%s, err: could not get positions: %w", op, err)
+	}
+
+	positions := Positions{
+		Money:                   convertPbToMoneyValues(positionsResp.GetMoney()),
+		Blocked:                 convertPbToMoneyValues(positionsResp.GetBlocked()),
+		Securities:              make([]PositionSecurity, 0, len(positionsResp.GetSecurities())),
+		LimitsLoadingInProgress: positionsResp.GetLimitsLoadingInProgress(),
+	}
+	for _, security := range positionsResp.GetSecurities() {
+		positions.Securities = append(positions.Securities, PositionSecurity{
+			Figi:           security.GetFigi(),
+			InstrumentUid:  security.GetInstrumentUid(),
+			InstrumentType: security.GetInstrumentType(),
+			Balance:        security.GetBalance(),
+			Blocked:        security.GetBlocked(),
+		})
+	}
+	return positions, nil
+}
+
+func (c *PortfolioServiceClient) getPositions(ctx context.Context, client *investgo.Client, accountID string) (*pb.PositionsResponse, error) {
+	if sandbox.Enabled(ctx) {
+		if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+			return nil, err
+		}
+		positionsResp, err := client.NewSandboxServiceClient().GetSandboxPositions(accountID)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, positionsResp.Header)
+		return positionsResp.PositionsResponse, nil
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Operations); err != nil {
+		return nil, err
+	}
+	positionsResp, err := client.NewOperationsServiceClient().GetPositions(accountID)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Operations, positionsResp.Header)
+	return positionsResp.PositionsResponse, nil
+}
+
+// GetWithdrawLimits возвращает средства, доступные к выводу, и заблокированные суммы по валютам.
+func (c *PortfolioServiceClient) GetWithdrawLimits(ctx context.Context, client *investgo.Client, accountID string) (WithdrawLimits, error) {
+	const op = "service.GetWithdrawLimits"
+	if accountID == "" {
+		return WithdrawLimits{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	}
+
+	limitsResp, err := c.getWithdrawLimits(ctx, client, accountID)
+	if err != nil {
+		return WithdrawLimits{}, fmt.Errorf("op:%s, err: could not get withdraw limits: %w", op, err)
+	}
+
+	return WithdrawLimits{
+		Money:            convertPbToMoneyValues(limitsResp.GetMoney()),
+		Blocked:          convertPbToMoneyValues(limitsResp.GetBlocked()),
+		BlockedGuarantee: convertPbToMoneyValues(limitsResp.GetBlockedGuarantee()),
+	}, nil
+}
+
+func (c *PortfolioServiceClient) getWithdrawLimits(ctx context.Context, client *investgo.Client, accountID string) (*pb.WithdrawLimitsResponse, error) {
+	if sandbox.Enabled(ctx) {
+		if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+			return nil, err
+		}
+		limitsResp, err := client.NewSandboxServiceClient().GetSandboxWithdrawLimits(accountID)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, limitsResp.Header)
+		return limitsResp.WithdrawLimitsResponse, nil
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Operations); err != nil {
+		return nil, err
+	}
+	limitsResp, err := client.NewOperationsServiceClient().GetWithdrawLimits(accountID)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Operations, limitsResp.Header)
+	return limitsResp.WithdrawLimitsResponse, nil
+}
+
+// tinkoffCodeMarginDisabled - код ошибки Invest API для счета без маржинальной торговли.
+const tinkoffCodeMarginDisabled = "30051"
+
+// GetMarginAttributes возвращает маржинальные показатели счета.
+// Для счетов без маржинальной торговли Invest API возвращает ошибку с кодом tinkoffCodeMarginDisabled.
+func (c *PortfolioServiceClient) GetMarginAttributes(ctx context.Context, client *investgo.Client, accountID string) (MarginAttributes, error) {
+	const op = "service.GetMarginAttributes"
+	if accountID == "" {
+		return MarginAttributes{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	}
+	// В песочнице нет маржинальной торговли
+	if sandbox.Enabled(ctx) {
+		return MarginAttributes{}, fmt.Errorf("%s: %w", op, ErrNoMarginInSandbox)
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Users); err != nil {
+		return MarginAttributes{}, fmt.Errorf("%s: %w", op, err)
+	}
+	marginResp, err := client.NewUsersServiceClient().GetMarginAttributes(accountID)
+	if err != nil {
+		return MarginAttributes{}, fmt.Errorf("op:%s, err: could not get margin attributes: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Users, marginResp.Header)
+
+	return MarginAttributes{
+		LiquidPortfolio:       ConvertPbToMoneyValue(marginResp.GetLiquidPortfolio()),
+		StartingMargin:        ConvertPbToMoneyValue(marginResp.GetStartingMargin()),
+		MinimalMargin:         ConvertPbToMoneyValue(marginResp.GetMinimalMargin()),
+		FundsSufficiencyLevel: ConvertPbToQuatation(marginResp.GetFundsSufficiencyLevel()),
+		AmountOfMissingFunds:  ConvertPbToMoneyValue(marginResp.GetAmountOfMissingFunds()),
+		CorrectedMargin:       ConvertPbToMoneyValue(marginResp.GetCorrectedMargin()),
+	}, nil
+}
+
+func convertPbToMoneyValues(pbValues []*pb.MoneyValue) []MoneyValue {
+	values := make([]MoneyValue, 0, len(pbValues))
+	for _, value := range pbValues {
+		values = append(values, ConvertPbToMoneyValue(value))
+	}
+	return values
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tinkoffApi/internal/ratelimit"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultEventsHorizon - на сколько вперед ищутся дивиденды и отчетности, если период не задан.
+const defaultEventsHorizon = 365 * 24 * time.Hour
+
+// GetDividends возвращает дивиденды акции за период. Invest API отдает и объявленные,
+// и прогнозные выплаты, поэтому в будущем периоде список может меняться.
+func (c *InstrumentsServiceClient) GetDividends(ctx context.Context, client *investgo.Client, request DividendsRequest) (DividendsResponse, error) {
+	const op = "service.GetDividends"
+	if request.Figi == "" {
+		return DividendsResponse{}, fmt.Errorf("%s: %w", op, ErrEmptyFigi)
+	}
+	from, to, err := eventsPeriod(request.From, request.To)
+	if err != nil {
+		return DividendsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return DividendsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	dividendsResp, err := client.NewInstrumentsServiceClient().GetDividents(request.Figi, from, to)
+	if err != nil {
+		return DividendsResponse{}, fmt.Errorf("op:%s, err: could not get dividends: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, dividendsResp.Header)
+
+	resp := DividendsResponse{
+		Figi:      request.Figi,
+		Dividends: make([]Dividend, 0, len(dividendsResp.GetDividends())),
+	}
+	for _, dividend := range dividendsResp.GetDividends() {
+		resp.Dividends = append(resp.Dividends, Dividend{
+			DividendNet:  ConvertPbToMoneyValue(dividend.GetDividendNet()),
+			PaymentDate:  convertPbToTime(dividend.GetPaymentDate()),
+			DeclaredDate: convertPbToTime(dividend.GetDeclaredDate()),
+			LastBuyDate:  convertPbToTime(dividend.GetLastBuyDate()),
+			RecordDate:   convertPbToTime(dividend.GetRecordDate()),
+			DividendType: dividend.GetDividendType(),
+			Regularity:   dividend.GetRegularity(),
+			ClosePrice:   ConvertPbToMoneyValue(dividend.GetClosePrice()),
+			YieldValue:   ConvertPbToQuatation(dividend.GetYieldValue()),
+		})
+	}
+	return resp, nil
+}
+
+// GetAssetReports возвращает график публикации отчетностей эмитента инструмента.
+// График есть не у всех эмитентов, тогда список пустой.
+func (c *InstrumentsServiceClient) GetAssetReports(ctx context.Context, client *investgo.Client, request AssetReportsRequest) (AssetReportsResponse, error) {
+	const op = "service.GetAssetReports"
+	if request.InstrumentID == "" {
+		return AssetReportsResponse{}, fmt.Errorf("%s: %w", op, ErrEmptyInstrumentUid)
+	}
+	from, to, err := eventsPeriod(request.From, request.To)
+	if err != nil {
+		return AssetReportsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return AssetReportsResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
+	reportsResp, err := client.NewInstrumentsServiceClient().GetAssetReports(request.InstrumentID, from, to)
+	if err != nil {
+		return AssetReportsResponse{}, fmt.Errorf("op:%s, err: could not get asset reports: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, reportsResp.Header)
+
+	resp := AssetReportsResponse{
+		InstrumentID: request.InstrumentID,
+		Reports:      make([]AssetReport, 0, len(reportsResp.GetEvents())),
+	}
+	for _, event := range reportsResp.GetEvents() {
+		resp.Reports = append(resp.Reports, AssetReport{
+			ReportDate: convertPbToTime(event.GetReportDate()),
+			PeriodYear: event.GetPeriodYear(),
+			PeriodNum:  event.GetPeriodNum(),
+			PeriodType: event.GetPeriodType().String(),
+		})
+	}
+	return resp, nil
+}
+
+// eventsPeriod подставляет период по умолчанию: от текущего момента на год вперед.
+func eventsPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(defaultEventsHorizon)
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return from, to, nil
+}
+
+// convertPbToTime возвращает нулевое время для незаполненной даты вместо начала эпохи.
+func convertPbToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
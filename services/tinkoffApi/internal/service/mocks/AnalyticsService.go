@@ -16,9 +16,9 @@ type AnalyticsService struct {
 	mock.Mock
 }
 
-// GetAllAssetUids provides a mock function with given fields: client
-func (_m *AnalyticsService) GetAllAssetUids(client *investgo.Client) (map[string]string, error) {
-	ret := _m.Called(client)
+// GetAllAssetUids provides a mock function with given fields: ctx, client
+func (_m *AnalyticsService) GetAllAssetUids(ctx context.Context, client *investgo.Client) (map[string]string, error) {
+	ret := _m.Called(ctx, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllAssetUids")
@@ -26,19 +26,19 @@ func (_m *AnalyticsService) GetAllAssetUids(client *investgo.Client) (map[string
 
 	var r0 map[string]string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client) (map[string]string, error)); ok {
-		return rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) (map[string]string, error)); ok {
+		return rf(ctx, client)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client) map[string]string); ok {
-		r0 = rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) map[string]string); ok {
+		r0 = rf(ctx, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client) error); ok {
-		r1 = rf(client)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client) error); ok {
+		r1 = rf(ctx, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,9 +46,9 @@ func (_m *AnalyticsService) GetAllAssetUids(client *investgo.Client) (map[string
 	return r0, r1
 }
 
-// GetBondsActions provides a mock function with given fields: client, instrumentUid
-func (_m *AnalyticsService) GetBondsActions(client *investgo.Client, instrumentUid string) (service.BondIdentIdentifiers, error) {
-	ret := _m.Called(client, instrumentUid)
+// GetBondsActions provides a mock function with given fields: ctx, client, instrumentUid
+func (_m *AnalyticsService) GetBondsActions(ctx context.Context, client *investgo.Client, instrumentUid string) (service.BondIdentIdentifiers, error) {
+	ret := _m.Called(ctx, client, instrumentUid)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBondsActions")
@@ -56,17 +56,45 @@ func (_m *AnalyticsService) GetBondsActions(client *investgo.Client, instrumentU
 
 	var r0 service.BondIdentIdentifiers
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.BondIdentIdentifiers, error)); ok {
-		return rf(client, instrumentUid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.BondIdentIdentifiers, error)); ok {
+		return rf(ctx, client, instrumentUid)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.BondIdentIdentifiers); ok {
-		r0 = rf(client, instrumentUid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.BondIdentIdentifiers); ok {
+		r0 = rf(ctx, client, instrumentUid)
 	} else {
 		r0 = ret.Get(0).(service.BondIdentIdentifiers)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, instrumentUid)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, instrumentUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBondsActionsBatch provides a mock function with given fields: ctx, client, instrumentUids
+func (_m *AnalyticsService) GetBondsActionsBatch(ctx context.Context, client *investgo.Client, instrumentUids []string) (service.BondsActionsBatchResponse, error) {
+	ret := _m.Called(ctx, client, instrumentUids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBondsActionsBatch")
+	}
+
+	var r0 service.BondsActionsBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) (service.BondsActionsBatchResponse, error)); ok {
+		return rf(ctx, client, instrumentUids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) service.BondsActionsBatchResponse); ok {
+		r0 = rf(ctx, client, instrumentUids)
+	} else {
+		r0 = ret.Get(0).(service.BondsActionsBatchResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, []string) error); ok {
+		r1 = rf(ctx, client, instrumentUids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,7 +103,7 @@ func (_m *AnalyticsService) GetBondsActions(client *investgo.Client, instrumentU
 }
 
 // GetClient provides a mock function with given fields: ctx
-func (_m *AnalyticsService) GetClient(ctx context.Context) (*investgo.Client, error) {
+func (_m *AnalyticsService) GetClient(ctx context.Context) (*investgo.Client, func(), error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
@@ -83,8 +111,9 @@ func (_m *AnalyticsService) GetClient(ctx context.Context) (*investgo.Client, er
 	}
 
 	var r0 *investgo.Client
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, error)); ok {
+	var r1 func()
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, func(), error)); ok {
 		return rf(ctx)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context) *investgo.Client); ok {
@@ -95,18 +124,26 @@ func (_m *AnalyticsService) GetClient(ctx context.Context) (*investgo.Client, er
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context) func()); ok {
 		r1 = rf(ctx)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetLastPriceInPersentageToNominal provides a mock function with given fields: client, instrumentUid
-func (_m *AnalyticsService) GetLastPriceInPersentageToNominal(client *investgo.Client, instrumentUid string) (service.LastPriceResponse, error) {
-	ret := _m.Called(client, instrumentUid)
+// GetLastPriceInPersentageToNominal provides a mock function with given fields: ctx, client, instrumentUid
+func (_m *AnalyticsService) GetLastPriceInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUid string) (service.LastPriceResponse, error) {
+	ret := _m.Called(ctx, client, instrumentUid)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLastPriceInPersentageToNominal")
@@ -114,17 +151,45 @@ func (_m *AnalyticsService) GetLastPriceInPersentageToNominal(client *investgo.C
 
 	var r0 service.LastPriceResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.LastPriceResponse, error)); ok {
-		return rf(client, instrumentUid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.LastPriceResponse, error)); ok {
+		return rf(ctx, client, instrumentUid)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.LastPriceResponse); ok {
-		r0 = rf(client, instrumentUid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.LastPriceResponse); ok {
+		r0 = rf(ctx, client, instrumentUid)
 	} else {
 		r0 = ret.Get(0).(service.LastPriceResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, instrumentUid)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, instrumentUid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastPricesInPersentageToNominal provides a mock function with given fields: ctx, client, instrumentUids
+func (_m *AnalyticsService) GetLastPricesInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUids []string) (service.LastPricesResponse, error) {
+	ret := _m.Called(ctx, client, instrumentUids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastPricesInPersentageToNominal")
+	}
+
+	var r0 service.LastPricesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) (service.LastPricesResponse, error)); ok {
+		return rf(ctx, client, instrumentUids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) service.LastPricesResponse); ok {
+		r0 = rf(ctx, client, instrumentUids)
+	} else {
+		r0 = ret.Get(0).(service.LastPricesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, []string) error); ok {
+		r1 = rf(ctx, client, instrumentUids)
 	} else {
 		r1 = ret.Error(1)
 	}
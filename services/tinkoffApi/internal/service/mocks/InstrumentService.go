@@ -16,9 +16,9 @@ type InstrumentService struct {
 	mock.Mock
 }
 
-// FindBy provides a mock function with given fields: client, query
-func (_m *InstrumentService) FindBy(client *investgo.Client, query string) ([]service.InstrumentShort, error) {
-	ret := _m.Called(client, query)
+// FindBy provides a mock function with given fields: ctx, client, query
+func (_m *InstrumentService) FindBy(ctx context.Context, client *investgo.Client, query string) ([]service.InstrumentShort, error) {
+	ret := _m.Called(ctx, client, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindBy")
@@ -26,19 +26,19 @@ func (_m *InstrumentService) FindBy(client *investgo.Client, query string) ([]se
 
 	var r0 []service.InstrumentShort
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) ([]service.InstrumentShort, error)); ok {
-		return rf(client, query)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) ([]service.InstrumentShort, error)); ok {
+		return rf(ctx, client, query)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) []service.InstrumentShort); ok {
-		r0 = rf(client, query)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) []service.InstrumentShort); ok {
+		r0 = rf(ctx, client, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]service.InstrumentShort)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, query)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,9 +46,37 @@ func (_m *InstrumentService) FindBy(client *investgo.Client, query string) ([]se
 	return r0, r1
 }
 
-// GetBondByUid provides a mock function with given fields: client, uid
-func (_m *InstrumentService) GetBondByUid(client *investgo.Client, uid string) (service.Bond, error) {
-	ret := _m.Called(client, uid)
+// GetAssetReports provides a mock function with given fields: ctx, client, request
+func (_m *InstrumentService) GetAssetReports(ctx context.Context, client *investgo.Client, request service.AssetReportsRequest) (service.AssetReportsResponse, error) {
+	ret := _m.Called(ctx, client, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssetReports")
+	}
+
+	var r0 service.AssetReportsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.AssetReportsRequest) (service.AssetReportsResponse, error)); ok {
+		return rf(ctx, client, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.AssetReportsRequest) service.AssetReportsResponse); ok {
+		r0 = rf(ctx, client, request)
+	} else {
+		r0 = ret.Get(0).(service.AssetReportsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.AssetReportsRequest) error); ok {
+		r1 = rf(ctx, client, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBondByUid provides a mock function with given fields: ctx, client, uid
+func (_m *InstrumentService) GetBondByUid(ctx context.Context, client *investgo.Client, uid string) (service.Bond, error) {
+	ret := _m.Called(ctx, client, uid)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBondByUid")
@@ -56,17 +84,45 @@ func (_m *InstrumentService) GetBondByUid(client *investgo.Client, uid string) (
 
 	var r0 service.Bond
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.Bond, error)); ok {
-		return rf(client, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.Bond, error)); ok {
+		return rf(ctx, client, uid)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.Bond); ok {
-		r0 = rf(client, uid)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.Bond); ok {
+		r0 = rf(ctx, client, uid)
 	} else {
 		r0 = ret.Get(0).(service.Bond)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, uid)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBondsByUids provides a mock function with given fields: ctx, client, uids
+func (_m *InstrumentService) GetBondsByUids(ctx context.Context, client *investgo.Client, uids []string) (service.BondsResponse, error) {
+	ret := _m.Called(ctx, client, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBondsByUids")
+	}
+
+	var r0 service.BondsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) (service.BondsResponse, error)); ok {
+		return rf(ctx, client, uids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) service.BondsResponse); ok {
+		r0 = rf(ctx, client, uids)
+	} else {
+		r0 = ret.Get(0).(service.BondsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, []string) error); ok {
+		r1 = rf(ctx, client, uids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,7 +131,7 @@ func (_m *InstrumentService) GetBondByUid(client *investgo.Client, uid string) (
 }
 
 // GetClient provides a mock function with given fields: ctx
-func (_m *InstrumentService) GetClient(ctx context.Context) (*investgo.Client, error) {
+func (_m *InstrumentService) GetClient(ctx context.Context) (*investgo.Client, func(), error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
@@ -83,8 +139,9 @@ func (_m *InstrumentService) GetClient(ctx context.Context) (*investgo.Client, e
 	}
 
 	var r0 *investgo.Client
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, error)); ok {
+	var r1 func()
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, func(), error)); ok {
 		return rf(ctx)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context) *investgo.Client); ok {
@@ -95,8 +152,44 @@ func (_m *InstrumentService) GetClient(ctx context.Context) (*investgo.Client, e
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context) func()); ok {
 		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCurrenciesBy provides a mock function with given fields: ctx, client, figis
+func (_m *InstrumentService) GetCurrenciesBy(ctx context.Context, client *investgo.Client, figis []string) (service.CurrenciesResponse, error) {
+	ret := _m.Called(ctx, client, figis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrenciesBy")
+	}
+
+	var r0 service.CurrenciesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) (service.CurrenciesResponse, error)); ok {
+		return rf(ctx, client, figis)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) service.CurrenciesResponse); ok {
+		r0 = rf(ctx, client, figis)
+	} else {
+		r0 = ret.Get(0).(service.CurrenciesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, []string) error); ok {
+		r1 = rf(ctx, client, figis)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,9 +197,9 @@ func (_m *InstrumentService) GetClient(ctx context.Context) (*investgo.Client, e
 	return r0, r1
 }
 
-// GetCurrencyBy provides a mock function with given fields: client, figi
-func (_m *InstrumentService) GetCurrencyBy(client *investgo.Client, figi string) (service.Currency, error) {
-	ret := _m.Called(client, figi)
+// GetCurrencyBy provides a mock function with given fields: ctx, client, figi
+func (_m *InstrumentService) GetCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (service.Currency, error) {
+	ret := _m.Called(ctx, client, figi)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCurrencyBy")
@@ -114,17 +207,17 @@ func (_m *InstrumentService) GetCurrencyBy(client *investgo.Client, figi string)
 
 	var r0 service.Currency
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.Currency, error)); ok {
-		return rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.Currency, error)); ok {
+		return rf(ctx, client, figi)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.Currency); ok {
-		r0 = rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.Currency); ok {
+		r0 = rf(ctx, client, figi)
 	} else {
 		r0 = ret.Get(0).(service.Currency)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, figi)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, figi)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -132,9 +225,37 @@ func (_m *InstrumentService) GetCurrencyBy(client *investgo.Client, figi string)
 	return r0, r1
 }
 
-// GetFutureBy provides a mock function with given fields: client, figi
-func (_m *InstrumentService) GetFutureBy(client *investgo.Client, figi string) (service.Future, error) {
-	ret := _m.Called(client, figi)
+// GetDividends provides a mock function with given fields: ctx, client, request
+func (_m *InstrumentService) GetDividends(ctx context.Context, client *investgo.Client, request service.DividendsRequest) (service.DividendsResponse, error) {
+	ret := _m.Called(ctx, client, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDividends")
+	}
+
+	var r0 service.DividendsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.DividendsRequest) (service.DividendsResponse, error)); ok {
+		return rf(ctx, client, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.DividendsRequest) service.DividendsResponse); ok {
+		r0 = rf(ctx, client, request)
+	} else {
+		r0 = ret.Get(0).(service.DividendsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.DividendsRequest) error); ok {
+		r1 = rf(ctx, client, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFutureBy provides a mock function with given fields: ctx, client, figi
+func (_m *InstrumentService) GetFutureBy(ctx context.Context, client *investgo.Client, figi string) (service.Future, error) {
+	ret := _m.Called(ctx, client, figi)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFutureBy")
@@ -142,17 +263,45 @@ func (_m *InstrumentService) GetFutureBy(client *investgo.Client, figi string) (
 
 	var r0 service.Future
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.Future, error)); ok {
-		return rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.Future, error)); ok {
+		return rf(ctx, client, figi)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.Future); ok {
-		r0 = rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.Future); ok {
+		r0 = rf(ctx, client, figi)
 	} else {
 		r0 = ret.Get(0).(service.Future)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, figi)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, figi)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFuturesBy provides a mock function with given fields: ctx, client, figis
+func (_m *InstrumentService) GetFuturesBy(ctx context.Context, client *investgo.Client, figis []string) (service.FuturesResponse, error) {
+	ret := _m.Called(ctx, client, figis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFuturesBy")
+	}
+
+	var r0 service.FuturesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) (service.FuturesResponse, error)); ok {
+		return rf(ctx, client, figis)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, []string) service.FuturesResponse); ok {
+		r0 = rf(ctx, client, figis)
+	} else {
+		r0 = ret.Get(0).(service.FuturesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, []string) error); ok {
+		r1 = rf(ctx, client, figis)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -160,9 +309,9 @@ func (_m *InstrumentService) GetFutureBy(client *investgo.Client, figi string) (
 	return r0, r1
 }
 
-// GetShareCurrencyBy provides a mock function with given fields: client, figi
-func (_m *InstrumentService) GetShareCurrencyBy(client *investgo.Client, figi string) (service.ShareCurrencyByResponse, error) {
-	ret := _m.Called(client, figi)
+// GetShareCurrencyBy provides a mock function with given fields: ctx, client, figi
+func (_m *InstrumentService) GetShareCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (service.ShareCurrencyByResponse, error) {
+	ret := _m.Called(ctx, client, figi)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetShareCurrencyBy")
@@ -170,17 +319,17 @@ func (_m *InstrumentService) GetShareCurrencyBy(client *investgo.Client, figi st
 
 	var r0 service.ShareCurrencyByResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) (service.ShareCurrencyByResponse, error)); ok {
-		return rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.ShareCurrencyByResponse, error)); ok {
+		return rf(ctx, client, figi)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, string) service.ShareCurrencyByResponse); ok {
-		r0 = rf(client, figi)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.ShareCurrencyByResponse); ok {
+		r0 = rf(ctx, client, figi)
 	} else {
 		r0 = ret.Get(0).(service.ShareCurrencyByResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, string) error); ok {
-		r1 = rf(client, figi)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, figi)
 	} else {
 		r1 = ret.Error(1)
 	}
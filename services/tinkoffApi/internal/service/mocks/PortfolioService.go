@@ -16,9 +16,9 @@ type PortfolioService struct {
 	mock.Mock
 }
 
-// GetAccounts provides a mock function with given fields: client
-func (_m *PortfolioService) GetAccounts(client *investgo.Client) (map[string]service.Account, error) {
-	ret := _m.Called(client)
+// GetAccounts provides a mock function with given fields: ctx, client
+func (_m *PortfolioService) GetAccounts(ctx context.Context, client *investgo.Client) (map[string]service.Account, error) {
+	ret := _m.Called(ctx, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccounts")
@@ -26,19 +26,19 @@ func (_m *PortfolioService) GetAccounts(client *investgo.Client) (map[string]ser
 
 	var r0 map[string]service.Account
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client) (map[string]service.Account, error)); ok {
-		return rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) (map[string]service.Account, error)); ok {
+		return rf(ctx, client)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client) map[string]service.Account); ok {
-		r0 = rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) map[string]service.Account); ok {
+		r0 = rf(ctx, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]service.Account)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client) error); ok {
-		r1 = rf(client)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client) error); ok {
+		r1 = rf(ctx, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -47,7 +47,7 @@ func (_m *PortfolioService) GetAccounts(client *investgo.Client) (map[string]ser
 }
 
 // GetClient provides a mock function with given fields: ctx
-func (_m *PortfolioService) GetClient(ctx context.Context) (*investgo.Client, error) {
+func (_m *PortfolioService) GetClient(ctx context.Context) (*investgo.Client, func(), error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
@@ -55,8 +55,9 @@ func (_m *PortfolioService) GetClient(ctx context.Context) (*investgo.Client, er
 	}
 
 	var r0 *investgo.Client
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, error)); ok {
+	var r1 func()
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*investgo.Client, func(), error)); ok {
 		return rf(ctx)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context) *investgo.Client); ok {
@@ -67,8 +68,46 @@ func (_m *PortfolioService) GetClient(ctx context.Context) (*investgo.Client, er
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context) func()); ok {
 		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetHeldInstrumentUids provides a mock function with given fields: ctx, client
+func (_m *PortfolioService) GetHeldInstrumentUids(ctx context.Context, client *investgo.Client) ([]string, error) {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeldInstrumentUids")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) ([]string, error)); ok {
+		return rf(ctx, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) []string); ok {
+		r0 = rf(ctx, client)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client) error); ok {
+		r1 = rf(ctx, client)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,9 +115,37 @@ func (_m *PortfolioService) GetClient(ctx context.Context) (*investgo.Client, er
 	return r0, r1
 }
 
-// GetOperations provides a mock function with given fields: client, request
-func (_m *PortfolioService) GetOperations(client *investgo.Client, request service.OperationsRequest) ([]service.Operation, error) {
-	ret := _m.Called(client, request)
+// GetMarginAttributes provides a mock function with given fields: ctx, client, accountID
+func (_m *PortfolioService) GetMarginAttributes(ctx context.Context, client *investgo.Client, accountID string) (service.MarginAttributes, error) {
+	ret := _m.Called(ctx, client, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarginAttributes")
+	}
+
+	var r0 service.MarginAttributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.MarginAttributes, error)); ok {
+		return rf(ctx, client, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.MarginAttributes); ok {
+		r0 = rf(ctx, client, accountID)
+	} else {
+		r0 = ret.Get(0).(service.MarginAttributes)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperations provides a mock function with given fields: ctx, client, request
+func (_m *PortfolioService) GetOperations(ctx context.Context, client *investgo.Client, request service.OperationsRequest) ([]service.Operation, error) {
+	ret := _m.Called(ctx, client, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOperations")
@@ -86,19 +153,19 @@ func (_m *PortfolioService) GetOperations(client *investgo.Client, request servi
 
 	var r0 []service.Operation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.OperationsRequest) ([]service.Operation, error)); ok {
-		return rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsRequest) ([]service.Operation, error)); ok {
+		return rf(ctx, client, request)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.OperationsRequest) []service.Operation); ok {
-		r0 = rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsRequest) []service.Operation); ok {
+		r0 = rf(ctx, client, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]service.Operation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, service.OperationsRequest) error); ok {
-		r1 = rf(client, request)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.OperationsRequest) error); ok {
+		r1 = rf(ctx, client, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -106,9 +173,37 @@ func (_m *PortfolioService) GetOperations(client *investgo.Client, request servi
 	return r0, r1
 }
 
-// GetPortfolio provides a mock function with given fields: client, request
-func (_m *PortfolioService) GetPortfolio(client *investgo.Client, request service.PortfolioRequest) (service.Portfolio, error) {
-	ret := _m.Called(client, request)
+// GetOperationsPage provides a mock function with given fields: ctx, client, request
+func (_m *PortfolioService) GetOperationsPage(ctx context.Context, client *investgo.Client, request service.OperationsPageRequest) (service.OperationsPage, error) {
+	ret := _m.Called(ctx, client, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationsPage")
+	}
+
+	var r0 service.OperationsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsPageRequest) (service.OperationsPage, error)); ok {
+		return rf(ctx, client, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsPageRequest) service.OperationsPage); ok {
+		r0 = rf(ctx, client, request)
+	} else {
+		r0 = ret.Get(0).(service.OperationsPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.OperationsPageRequest) error); ok {
+		r1 = rf(ctx, client, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPortfolio provides a mock function with given fields: ctx, client, request
+func (_m *PortfolioService) GetPortfolio(ctx context.Context, client *investgo.Client, request service.PortfolioRequest) (service.Portfolio, error) {
+	ret := _m.Called(ctx, client, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPortfolio")
@@ -116,17 +211,101 @@ func (_m *PortfolioService) GetPortfolio(client *investgo.Client, request servic
 
 	var r0 service.Portfolio
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.PortfolioRequest) (service.Portfolio, error)); ok {
-		return rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.PortfolioRequest) (service.Portfolio, error)); ok {
+		return rf(ctx, client, request)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.PortfolioRequest) service.Portfolio); ok {
-		r0 = rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.PortfolioRequest) service.Portfolio); ok {
+		r0 = rf(ctx, client, request)
 	} else {
 		r0 = ret.Get(0).(service.Portfolio)
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, service.PortfolioRequest) error); ok {
-		r1 = rf(client, request)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.PortfolioRequest) error); ok {
+		r1 = rf(ctx, client, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPositions provides a mock function with given fields: ctx, client, accountID
+func (_m *PortfolioService) GetPositions(ctx context.Context, client *investgo.Client, accountID string) (service.Positions, error) {
+	ret := _m.Called(ctx, client, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPositions")
+	}
+
+	var r0 service.Positions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.Positions, error)); ok {
+		return rf(ctx, client, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.Positions); ok {
+		r0 = rf(ctx, client, accountID)
+	} else {
+		r0 = ret.Get(0).(service.Positions)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenScope provides a mock function with given fields: ctx, client
+func (_m *PortfolioService) GetTokenScope(ctx context.Context, client *investgo.Client) (service.TokenScope, error) {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenScope")
+	}
+
+	var r0 service.TokenScope
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) (service.TokenScope, error)); ok {
+		return rf(ctx, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client) service.TokenScope); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Get(0).(service.TokenScope)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client) error); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWithdrawLimits provides a mock function with given fields: ctx, client, accountID
+func (_m *PortfolioService) GetWithdrawLimits(ctx context.Context, client *investgo.Client, accountID string) (service.WithdrawLimits, error) {
+	ret := _m.Called(ctx, client, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithdrawLimits")
+	}
+
+	var r0 service.WithdrawLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) (service.WithdrawLimits, error)); ok {
+		return rf(ctx, client, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, string) service.WithdrawLimits); ok {
+		r0 = rf(ctx, client, accountID)
+	} else {
+		r0 = ret.Get(0).(service.WithdrawLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, string) error); ok {
+		r1 = rf(ctx, client, accountID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,9 +313,9 @@ func (_m *PortfolioService) GetPortfolio(client *investgo.Client, request servic
 	return r0, r1
 }
 
-// MakeSafeGetOperationsRequest provides a mock function with given fields: client, request
-func (_m *PortfolioService) MakeSafeGetOperationsRequest(client *investgo.Client, request service.OperationsRequest) ([]service.Operation, error) {
-	ret := _m.Called(client, request)
+// MakeSafeGetOperationsRequest provides a mock function with given fields: ctx, client, request
+func (_m *PortfolioService) MakeSafeGetOperationsRequest(ctx context.Context, client *investgo.Client, request service.OperationsRequest) ([]service.Operation, error) {
+	ret := _m.Called(ctx, client, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MakeSafeGetOperationsRequest")
@@ -144,19 +323,19 @@ func (_m *PortfolioService) MakeSafeGetOperationsRequest(client *investgo.Client
 
 	var r0 []service.Operation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.OperationsRequest) ([]service.Operation, error)); ok {
-		return rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsRequest) ([]service.Operation, error)); ok {
+		return rf(ctx, client, request)
 	}
-	if rf, ok := ret.Get(0).(func(*investgo.Client, service.OperationsRequest) []service.Operation); ok {
-		r0 = rf(client, request)
+	if rf, ok := ret.Get(0).(func(context.Context, *investgo.Client, service.OperationsRequest) []service.Operation); ok {
+		r0 = rf(ctx, client, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]service.Operation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*investgo.Client, service.OperationsRequest) error); ok {
-		r1 = rf(client, request)
+	if rf, ok := ret.Get(1).(func(context.Context, *investgo.Client, service.OperationsRequest) error); ok {
+		r1 = rf(ctx, client, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -47,9 +47,11 @@ type Future struct {
 }
 
 type Bond struct {
-	AciValue MoneyValue `json:"aciValue,omitempty"`
-	Currency string     `json:"currency,omitempty"`
-	Nominal  MoneyValue `json:"nominal,omitempty"`
+	AciValue  MoneyValue `json:"aciValue,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	Nominal   MoneyValue `json:"nominal,omitempty"`
+	Sector    string     `json:"sector,omitempty"`
+	RiskLevel string     `json:"riskLevel,omitempty"` // RISK_LEVEL_LOW, RISK_LEVEL_MODERATE, RISK_LEVEL_HIGH
 }
 
 type Currency struct {
@@ -103,6 +105,7 @@ type BondIdentIdentifiers struct {
 	Nominal         MoneyValue `json:"nominal,omitempty"`
 	NominalCurrency string     `json:"nominalCurrency,omitempty"`
 	Replaced        bool       `json:"replaced,omitempty"`
+	FloatingCoupon  bool       `json:"floatingCoupon,omitempty"`
 }
 
 type PortfolioRequest struct {
@@ -115,6 +118,25 @@ type OperationsRequest struct {
 	Date      time.Time `json:"date,omitempty"`
 }
 
+// OperationsPageRequest - запрос одной страницы операций.
+// Первая страница задается периодом From-To, следующие - курсором из предыдущего ответа.
+type OperationsPageRequest struct {
+	AccountID string    `json:"accountID,omitempty"`
+	From      time.Time `json:"from,omitempty"`
+	To        time.Time `json:"to,omitempty"`
+	Cursor    string    `json:"cursor,omitempty"`
+	Limit     int32     `json:"limit,omitempty"`
+}
+
+type OperationsPage struct {
+	Operations []Operation `json:"operations"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	HasNext    bool        `json:"hasNext"`
+	// From и To - фактический период запроса, чтобы клиент мог сохранить его вместе с курсором
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
 type FutureReq struct {
 	Figi string `json:"figi,omitempty"`
 }
@@ -147,6 +169,51 @@ type LastPriceResponse struct {
 	LastPrice Quotation `json:"lastPrice,omitempty"`
 }
 
+type LastPricesReq struct {
+	InstrumentUids []string `json:"instrumentUids,omitempty"`
+}
+
+type LastPricesResponse struct {
+	LastPrices map[string]Quotation `json:"lastPrices"`
+	NotFound   []string             `json:"notFound,omitempty"`
+}
+
+type BondsReq struct {
+	Uids []string `json:"uids,omitempty"`
+}
+
+type BondsResponse struct {
+	Bonds    map[string]Bond `json:"bonds"`
+	NotFound []string        `json:"notFound,omitempty"`
+}
+
+type BondsActionsBatchReq struct {
+	InstrumentUids []string `json:"instrumentUids,omitempty"`
+}
+
+type BondsActionsBatchResponse struct {
+	BondsActions map[string]BondIdentIdentifiers `json:"bondsActions"`
+	NotFound     []string                        `json:"notFound,omitempty"`
+}
+
+type FuturesReq struct {
+	Figis []string `json:"figis,omitempty"`
+}
+
+type FuturesResponse struct {
+	Futures  map[string]Future `json:"futures"`
+	NotFound []string          `json:"notFound,omitempty"`
+}
+
+type CurrenciesReq struct {
+	Figis []string `json:"figis,omitempty"`
+}
+
+type CurrenciesResponse struct {
+	Currencies map[string]Currency `json:"currencies"`
+	NotFound   []string            `json:"notFound,omitempty"`
+}
+
 type BaseShareFutureValuteResponse struct {
 	Currency string `json:"currency,omitempty"`
 }
@@ -158,3 +225,141 @@ type ShareCurrencyByResponse struct {
 type ShareCurrencyByRequest struct {
 	Figi string `json:"figi,omitempty"`
 }
+
+const (
+	AccessLevelFullAccess  = "full_access"
+	AccessLevelReadOnly    = "read_only"
+	AccessLevelNoAccess    = "no_access"
+	AccessLevelUnspecified = "unspecified"
+)
+
+// TokenScope описывает права токена: уровень доступа по счетам и тариф пользователя.
+type TokenScope struct {
+	AccessLevel string              `json:"accessLevel,omitempty"`
+	ReadOnly    bool                `json:"readOnly"`
+	Tariff      string              `json:"tariff,omitempty"`
+	QualStatus  bool                `json:"qualStatus,omitempty"`
+	Accounts    []TokenScopeAccount `json:"accounts,omitempty"`
+}
+
+type TokenScopeAccount struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	AccessLevel string `json:"accessLevel,omitempty"`
+}
+
+// AccountRequest - запрос данных одного счета.
+type AccountRequest struct {
+	AccountID string `json:"accountId"`
+}
+
+type Positions struct {
+	Money                   []MoneyValue       `json:"money"`
+	Blocked                 []MoneyValue       `json:"blocked"`
+	Securities              []PositionSecurity `json:"securities,omitempty"`
+	LimitsLoadingInProgress bool               `json:"limitsLoadingInProgress,omitempty"`
+}
+
+type PositionSecurity struct {
+	Figi           string `json:"figi,omitempty"`
+	InstrumentUid  string `json:"instrumentUid,omitempty"`
+	InstrumentType string `json:"instrumentType,omitempty"`
+	Balance        int64  `json:"balance"`
+	Blocked        int64  `json:"blocked,omitempty"`
+}
+
+type WithdrawLimits struct {
+	Money            []MoneyValue `json:"money"`
+	Blocked          []MoneyValue `json:"blocked"`
+	BlockedGuarantee []MoneyValue `json:"blockedGuarantee"`
+}
+
+type MarginAttributes struct {
+	LiquidPortfolio       MoneyValue `json:"liquidPortfolio"`
+	StartingMargin        MoneyValue `json:"startingMargin"`
+	MinimalMargin         MoneyValue `json:"minimalMargin"`
+	FundsSufficiencyLevel Quotation  `json:"fundsSufficiencyLevel"`
+	AmountOfMissingFunds  MoneyValue `json:"amountOfMissingFunds"`
+	CorrectedMargin       MoneyValue `json:"correctedMargin"`
+}
+
+const (
+	OrderDirectionBuy  = "buy"
+	OrderDirectionSell = "sell"
+)
+
+type SandboxAccount struct {
+	AccountID string `json:"accountId"`
+}
+
+type SandboxPayInRequest struct {
+	AccountID string `json:"accountId"`
+	Currency  string `json:"currency"`
+	Units     int64  `json:"units"`
+	Nano      int32  `json:"nano,omitempty"`
+}
+
+// SandboxOrderRequest - заявка в песочнице. Без Price выставляется рыночная заявка.
+type SandboxOrderRequest struct {
+	AccountID    string     `json:"accountId"`
+	InstrumentID string     `json:"instrumentId"`
+	Quantity     int64      `json:"quantity"`
+	Direction    string     `json:"direction"`
+	Price        *Quotation `json:"price,omitempty"`
+	// OrderID - ключ идемпотентности, если не задан - генерируется.
+	OrderID string `json:"orderId,omitempty"`
+}
+
+type SandboxOrder struct {
+	OrderID            string     `json:"orderId"`
+	Status             string     `json:"status"`
+	LotsRequested      int64      `json:"lotsRequested"`
+	LotsExecuted       int64      `json:"lotsExecuted"`
+	ExecutedOrderPrice MoneyValue `json:"executedOrderPrice,omitempty"`
+	TotalOrderAmount   MoneyValue `json:"totalOrderAmount,omitempty"`
+	ExecutedCommission MoneyValue `json:"executedCommission,omitempty"`
+}
+
+// DividendsRequest - запрос дивидендов по акции за период. Без периода берется год вперед.
+type DividendsRequest struct {
+	Figi string    `json:"figi,omitempty"`
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+type DividendsResponse struct {
+	Figi      string     `json:"figi"`
+	Dividends []Dividend `json:"dividends"`
+}
+
+// Dividend - объявленная или прогнозная выплата дивиденда на одну акцию.
+type Dividend struct {
+	DividendNet  MoneyValue `json:"dividendNet"`
+	PaymentDate  time.Time  `json:"paymentDate,omitempty"`
+	DeclaredDate time.Time  `json:"declaredDate,omitempty"`
+	LastBuyDate  time.Time  `json:"lastBuyDate,omitempty"`
+	RecordDate   time.Time  `json:"recordDate,omitempty"`
+	DividendType string     `json:"dividendType,omitempty"`
+	Regularity   string     `json:"regularity,omitempty"`
+	ClosePrice   MoneyValue `json:"closePrice"`
+	YieldValue   Quotation  `json:"yieldValue"`
+}
+
+// AssetReportsRequest - запрос графика публикации отчетностей эмитента за период.
+type AssetReportsRequest struct {
+	InstrumentID string    `json:"instrumentId,omitempty"`
+	From         time.Time `json:"from,omitempty"`
+	To           time.Time `json:"to,omitempty"`
+}
+
+type AssetReportsResponse struct {
+	InstrumentID string        `json:"instrumentId"`
+	Reports      []AssetReport `json:"reports"`
+}
+
+type AssetReport struct {
+	ReportDate time.Time `json:"reportDate"`
+	PeriodYear int32     `json:"periodYear,omitempty"`
+	PeriodNum  int32     `json:"periodNum,omitempty"`
+	PeriodType string    `json:"periodType,omitempty"`
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	pb "github.com/russianinvestments/invest-api-go-sdk/proto"
+)
+
+// MaxOperationsPageLimit - максимальный размер страницы GetOperationsByCursor в Invest API.
+const MaxOperationsPageLimit = 1000
+
+// GetOperationsPage возвращает одну страницу операций.
+// Клиент сам решает, когда запрашивать следующую, и может сохранить курсор,
+// чтобы продолжить выгрузку после сбоя.
+func (c *PortfolioServiceClient) GetOperationsPage(ctx context.Context, client *investgo.Client, request OperationsPageRequest) (_ OperationsPage, err error) {
+	const op = "service.GetOperationsPage"
+	if request.AccountID == "" {
+		return OperationsPage{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	}
+	if request.Limit == 0 {
+		request.Limit = MaxOperationsPageLimit
+	}
+	if request.Limit < 0 || request.Limit > MaxOperationsPageLimit {
+		return OperationsPage{}, fmt.Errorf("%s: %w", op, ErrInvalidPageLimit)
+	}
+
+	now := time.Now().UTC()
+	request.From = request.From.UTC()
+	if request.To.IsZero() || request.To.After(now) {
+		request.To = now
+	} else {
+		request.To = request.To.UTC()
+	}
+	if request.From.After(request.To) {
+		return OperationsPage{}, fmt.Errorf("%s: %w", op, ErrFromAfterNow)
+	}
+
+	// По курсору период уже зафиксирован на стороне Invest API, сдвигать нечего
+	if request.Cursor != "" {
+		page, err := c.getOperationsPage(ctx, client, request)
+		if err != nil {
+			return OperationsPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+		return page, nil
+	}
+
+	for _, offset := range []time.Duration{
+		0,
+		-1 * time.Minute,
+		-2 * time.Minute,
+		-3 * time.Minute,
+	} {
+		adjusted := request
+		if !request.From.IsZero() {
+			adjusted.From = request.From.Add(offset)
+		}
+		var page OperationsPage
+		page, err = c.getOperationsPage(ctx, client, adjusted)
+		if err == nil {
+			return page, nil
+		}
+		if !isTimeError(err) {
+			break
+		}
+	}
+	return OperationsPage{}, fmt.Errorf("%s: %w", op, err)
+}
+
+func (c *PortfolioServiceClient) getOperationsPage(ctx context.Context, client *investgo.Client, request OperationsPageRequest) (OperationsPage, error) {
+	operationsResp, err := c.getOperationsByCursor(ctx, client, &investgo.GetOperationsByCursorRequest{
+		AccountId: request.AccountID,
+		From:      request.From,
+		To:        request.To,
+		Limit:     request.Limit,
+		Cursor:    request.Cursor,
+	})
+	if err != nil {
+		return OperationsPage{}, fmt.Errorf("failed to get operations: %w", err)
+	}
+	nextCursor := operationsResp.GetNextCursor()
+	return OperationsPage{
+		Operations: convertOperationsPbToOperaions(operationsResp.GetItems()),
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+		From:       request.From,
+		To:         request.To,
+	}, nil
+}
+
+// getOperationsByCursor запрашивает страницу операций в OperationsService или, в режиме песочницы, в SandboxService.
+func (c *PortfolioServiceClient) getOperationsByCursor(ctx context.Context, client *investgo.Client, req *investgo.GetOperationsByCursorRequest) (*pb.GetOperationsByCursorResponse, error) {
+	if sandbox.Enabled(ctx) {
+		if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+			return nil, err
+		}
+		operationsResp, err := client.NewSandboxServiceClient().GetSandboxOperationsByCursor(req)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, operationsResp.Header)
+		return operationsResp.GetOperationsByCursorResponse, nil
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Operations); err != nil {
+		return nil, err
+	}
+	operationsResp, err := client.NewOperationsServiceClient().GetOperationsByCursor(req)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Operations, operationsResp.Header)
+	return operationsResp.GetOperationsByCursorResponse, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"tinkoffApi/internal/clientpool"
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
+
+	"github.com/gladinov/valuefromcontext"
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+)
+
+// NewClientPool создает общий для всех сервисов пул investgo клиентов.
+// Клиент создается на контексте пула, а не запроса: investgo хранит ctx
+// в клиенте и использует его во всех вызовах.
+// Проверка живости расходует лимит UsersService токена, поэтому идет через limiter.
+func NewClientPool(logger *slog.Logger, config *investgo.Config, logg investgo.Logger, poolConfig clientpool.Config, limiter *ratelimit.Limiter) *clientpool.Pool[*investgo.Client] {
+	dial := func(ctx context.Context, token string) (*investgo.Client, error) {
+		newConfig := *config
+		newConfig.Token = token
+		return investgo.NewClient(ctx, newConfig, logg)
+	}
+	check := func(ctx context.Context, client *investgo.Client) error {
+		// квоту выбрали запросы пользователя: клиент ими и так проверен, а ошибка
+		// ожидания выкинула бы из пула рабочего клиента
+		if err := limiter.Wait(ctx, client.Config.Token, ratelimit.Users); err != nil {
+			return nil
+		}
+		resp, err := client.NewUsersServiceClient().GetInfo()
+		if err != nil {
+			return err
+		}
+		limiter.Observe(client.Config.Token, ratelimit.Users, resp.Header)
+		return nil
+	}
+	return clientpool.New(logger, poolConfig, dial, check)
+}
+
+// Pools - пулы клиентов боевого контура и песочницы Invest API.
+// Один токен может работать в обоих контурах, поэтому пулы раздельные.
+type Pools struct {
+	Prod *clientpool.Pool[*investgo.Client]
+	// Sandbox - пул клиентов песочницы, nil - песочница выключена.
+	Sandbox *clientpool.Pool[*investgo.Client]
+}
+
+// pool выбирает пул по режиму запроса.
+func (p Pools) pool(ctx context.Context) (*clientpool.Pool[*investgo.Client], error) {
+	if !sandbox.Enabled(ctx) {
+		return p.Prod, nil
+	}
+	if p.Sandbox == nil {
+		return nil, ErrSandboxDisabled
+	}
+	return p.Sandbox, nil
+}
+
+// Close закрывает оба пула.
+func (p Pools) Close(ctx context.Context) error {
+	err := p.Prod.Close(ctx)
+	if p.Sandbox != nil {
+		err = errors.Join(err, p.Sandbox.Close(ctx))
+	}
+	return err
+}
+
+// getPooledClient берет клиента для токена из контекста из пула боевого контура или песочницы.
+// release нужно вызвать после работы с клиентом, Stop вызывать нельзя.
+func getPooledClient(ctx context.Context, pools Pools, op string) (*investgo.Client, func(), error) {
+	token, err := valuefromcontext.GetToken(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s:%w", op, err)
+	}
+	pool, err := pools.pool(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	client, release, err := pool.Get(ctx, token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("op:%s, err: can't connect with tinkoffApi client: %w", op, err)
+	}
+
+	return client, release, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"tinkoffApi/internal/clientpool"
+	"tinkoffApi/internal/pricestream"
+
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	pb "github.com/russianinvestments/invest-api-go-sdk/proto"
+)
+
+const priceFeedBuffer = 256
+
+// priceFeed - стрим последних цен investgo MarketDataStream.
+// Клиент берется из пула и удерживается, пока стрим открыт, чтобы пул его не закрыл.
+type priceFeed struct {
+	stream  *investgo.MarketDataStream
+	release func()
+	prices  chan pricestream.Price
+	once    sync.Once
+}
+
+// NewPriceFeedDialer открывает стримы последних цен через общий пул клиентов.
+func NewPriceFeedDialer(pool *clientpool.Pool[*investgo.Client], logg investgo.Logger) pricestream.Dialer {
+	return func(ctx context.Context, token string, uids []string) (pricestream.Feed, error) {
+		const op = "service.DialPriceFeed"
+		client, release, err := pool.Get(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("%s: can't connect with tinkoffApi client: %w", op, err)
+		}
+		stream, err := client.NewMarketDataStreamClient().MarketDataStream()
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("%s: can't open market data stream: %w", op, err)
+		}
+		lastPrices, err := stream.SubscribeLastPrice(uids)
+		if err != nil {
+			stream.Stop()
+			release()
+			return nil, fmt.Errorf("%s: can't subscribe last prices: %w", op, err)
+		}
+
+		f := &priceFeed{
+			stream:  stream,
+			release: release,
+			prices:  make(chan pricestream.Price, priceFeedBuffer),
+		}
+		go func() {
+			if err := stream.Listen(); err != nil {
+				logg.Errorf("%s: market data stream stopped: %v", op, err)
+			}
+		}()
+		// investgo закрывает канал цен, когда Listen завершается
+		go func() {
+			defer close(f.prices)
+			for lastPrice := range lastPrices {
+				f.prices <- convertLastPricePbToPrice(lastPrice)
+			}
+		}()
+		return f, nil
+	}
+}
+
+func (f *priceFeed) Subscribe(uids []string) error {
+	_, err := f.stream.SubscribeLastPrice(uids)
+	return err
+}
+
+func (f *priceFeed) Unsubscribe(uids []string) error {
+	return f.stream.UnSubscribeLastPrice(uids)
+}
+
+func (f *priceFeed) Prices() <-chan pricestream.Price {
+	return f.prices
+}
+
+func (f *priceFeed) Close() {
+	f.once.Do(func() {
+		f.stream.Stop()
+		f.release()
+	})
+}
+
+func convertLastPricePbToPrice(lastPrice *pb.LastPrice) pricestream.Price {
+	price := ConvertPbToQuatation(lastPrice.GetPrice())
+	return pricestream.Price{
+		InstrumentUid: lastPrice.GetInstrumentUid(),
+		Figi:          lastPrice.GetFigi(),
+		Units:         price.Units,
+		Nano:          price.Nano,
+		Time:          lastPrice.GetTime().AsTime(),
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
+
+	"github.com/google/uuid"
+	"github.com/russianinvestments/invest-api-go-sdk/investgo"
+	pb "github.com/russianinvestments/invest-api-go-sdk/proto"
+)
+
+type SandboxServiceClient struct {
+	Logg    investgo.Logger
+	pools   Pools
+	limiter *ratelimit.Limiter
+}
+
+func NewSandboxServiceClient(pools Pools, limiter *ratelimit.Limiter, logg investgo.Logger) SandboxService {
+	return &SandboxServiceClient{
+		pools:   pools,
+		limiter: limiter,
+		Logg:    logg,
+	}
+}
+
+func (c *SandboxServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, release func(), err error) {
+	const op = "service.SandboxServiceClient.GetClient"
+
+	return getPooledClient(sandbox.WithSandbox(ctx), c.pools, op)
+}
+
+func (c *SandboxServiceClient) OpenAccount(ctx context.Context, client *investgo.Client) (SandboxAccount, error) {
+	const op = "service.OpenAccount"
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+		return SandboxAccount{}, fmt.Errorf("%s: %w", op, err)
+	}
+	resp, err := client.NewSandboxServiceClient().OpenSandboxAccount()
+	if err != nil {
+		return SandboxAccount{}, fmt.Errorf("op:%s, err: could not open sandbox account: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, resp.Header)
+
+	return SandboxAccount{AccountID: resp.GetAccountId()}, nil
+}
+
+func (c *SandboxServiceClient) CloseAccount(ctx context.Context, client *investgo.Client, accountID string) error {
+	const op = "service.CloseAccount"
+	if accountID == "" {
+		return fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	}
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	resp, err := client.NewSandboxServiceClient().CloseSandboxAccount(accountID)
+	if err != nil {
+		return fmt.Errorf("op:%s, err: could not close sandbox account: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, resp.Header)
+
+	return nil
+}
+
+// PayIn пополняет счет песочницы и возвращает баланс счета в валюте пополнения.
+func (c *SandboxServiceClient) PayIn(ctx context.Context, client *investgo.Client, request SandboxPayInRequest) (MoneyValue, error) {
+	const op = "service.PayIn"
+	switch {
+	case request.AccountID == "":
+		return MoneyValue{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	case request.Currency == "":
+		return MoneyValue{}, fmt.Errorf("%s: %w", op, ErrEmptyCurrency)
+	case request.Units < 0 || request.Nano < 0 || request.Units == 0 && request.Nano == 0:
+		return MoneyValue{}, fmt.Errorf("%s: %w", op, ErrInvalidAmount)
+	}
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+		return MoneyValue{}, fmt.Errorf("%s: %w", op, err)
+	}
+	resp, err := client.NewSandboxServiceClient().SandboxPayIn(&investgo.SandboxPayInRequest{
+		AccountId: request.AccountID,
+		Currency:  strings.ToLower(request.Currency),
+		Unit:      request.Units,
+		Nano:      request.Nano,
+	})
+	if err != nil {
+		return MoneyValue{}, fmt.Errorf("op:%s, err: could not pay in sandbox account: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, resp.Header)
+
+	return ConvertPbToMoneyValue(resp.GetBalance()), nil
+}
+
+// PostOrder выставляет заявку в песочнице: лимитную, если задана цена, иначе рыночную.
+func (c *SandboxServiceClient) PostOrder(ctx context.Context, client *investgo.Client, request SandboxOrderRequest) (SandboxOrder, error) {
+	const op = "service.PostOrder"
+	direction, err := convertOrderDirection(request.Direction)
+	switch {
+	case err != nil:
+		return SandboxOrder{}, fmt.Errorf("%s: %w", op, err)
+	case request.AccountID == "":
+		return SandboxOrder{}, fmt.Errorf("%s: %w", op, ErrEmptyAccountIdInRequest)
+	case request.InstrumentID == "":
+		return SandboxOrder{}, fmt.Errorf("%s: %w", op, ErrEmptyInstrumentUid)
+	case request.Quantity <= 0:
+		return SandboxOrder{}, fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
+
+	orderReq := &investgo.PostOrderRequest{
+		InstrumentId: request.InstrumentID,
+		Quantity:     request.Quantity,
+		Direction:    direction,
+		AccountId:    request.AccountID,
+		OrderType:    pb.OrderType_ORDER_TYPE_MARKET,
+		OrderId:      request.OrderID,
+	}
+	if request.Price != nil {
+		orderReq.OrderType = pb.OrderType_ORDER_TYPE_LIMIT
+		orderReq.Price = &pb.Quotation{Units: request.Price.Units, Nano: request.Price.Nano}
+	}
+	if orderReq.OrderId == "" {
+		orderReq.OrderId = uuid.NewString()
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+		return SandboxOrder{}, fmt.Errorf("%s: %w", op, err)
+	}
+	resp, err := client.NewSandboxServiceClient().PostSandboxOrder(orderReq)
+	if err != nil {
+		return SandboxOrder{}, fmt.Errorf("op:%s, err: could not post sandbox order: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, resp.Header)
+
+	return SandboxOrder{
+		OrderID:            resp.GetOrderId(),
+		Status:             resp.GetExecutionReportStatus().String(),
+		LotsRequested:      resp.GetLotsRequested(),
+		LotsExecuted:       resp.GetLotsExecuted(),
+		ExecutedOrderPrice: ConvertPbToMoneyValue(resp.GetExecutedOrderPrice()),
+		TotalOrderAmount:   ConvertPbToMoneyValue(resp.GetTotalOrderAmount()),
+		ExecutedCommission: ConvertPbToMoneyValue(resp.GetExecutedCommission()),
+	}, nil
+}
+
+func convertOrderDirection(direction string) (pb.OrderDirection, error) {
+	switch strings.ToLower(direction) {
+	case OrderDirectionBuy:
+		return pb.OrderDirection_ORDER_DIRECTION_BUY, nil
+	case OrderDirectionSell:
+		return pb.OrderDirection_ORDER_DIRECTION_SELL, nil
+	default:
+		return pb.OrderDirection_ORDER_DIRECTION_UNSPECIFIED, ErrInvalidDirection
+	}
+}
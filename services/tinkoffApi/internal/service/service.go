@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/gladinov/valuefromcontext"
+	"tinkoffApi/internal/apierrors"
+	"tinkoffApi/internal/ratelimit"
+	"tinkoffApi/internal/sandbox"
 
 	"github.com/gladinov/e"
 
@@ -15,37 +17,43 @@ import (
 )
 
 type InstrumentsServiceClient struct {
-	Logg   investgo.Logger
-	config *investgo.Config
+	Logg    investgo.Logger
+	pools   Pools
+	limiter *ratelimit.Limiter
 }
 
 type PortfolioServiceClient struct {
-	Logg   investgo.Logger
-	config *investgo.Config
+	Logg    investgo.Logger
+	pools   Pools
+	limiter *ratelimit.Limiter
 }
 type AnalyticsServiceClient struct {
-	Logg   investgo.Logger
-	config *investgo.Config
+	Logg    investgo.Logger
+	pools   Pools
+	limiter *ratelimit.Limiter
 }
 
-func NewInstrumentServiceClient(config *investgo.Config, logg investgo.Logger) InstrumentService {
+func NewInstrumentServiceClient(pools Pools, limiter *ratelimit.Limiter, logg investgo.Logger) InstrumentService {
 	return &InstrumentsServiceClient{
-		config: config,
-		Logg:   logg,
+		pools:   pools,
+		limiter: limiter,
+		Logg:    logg,
 	}
 }
 
-func NewPortfolioServiceClient(config *investgo.Config, logg investgo.Logger) PortfolioService {
+func NewPortfolioServiceClient(pools Pools, limiter *ratelimit.Limiter, logg investgo.Logger) PortfolioService {
 	return &PortfolioServiceClient{
-		config: config,
-		Logg:   logg,
+		pools:   pools,
+		limiter: limiter,
+		Logg:    logg,
 	}
 }
 
-func NewAnalyticsServiceClient(config *investgo.Config, logg investgo.Logger) AnalyticsService {
+func NewAnalyticsServiceClient(pools Pools, limiter *ratelimit.Limiter, logg investgo.Logger) AnalyticsService {
 	return &AnalyticsServiceClient{
-		config: config,
-		Logg:   logg,
+		pools:   pools,
+		limiter: limiter,
+		Logg:    logg,
 	}
 }
 
@@ -53,80 +61,41 @@ func NewService(
 	analyticsService AnalyticsService,
 	portfolioService PortfolioService,
 	instrumentService InstrumentService,
+	sandboxService SandboxService,
 ) *Service {
 	return &Service{
 		AnalyticsService:  analyticsService,
 		PortfolioService:  portfolioService,
 		InstrumentService: instrumentService,
+		SandboxService:    sandboxService,
 	}
 }
 
-func (c *InstrumentsServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, err error) {
+func (c *InstrumentsServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, release func(), err error) {
 	const op = "sevrice.InstrumentsServiceClient.GetClient"
-	token, err := valuefromcontext.GetToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("%s:%w", op, err)
-	}
 
-	newConfig := *c.config
-	newConfig.Token = token
-
-	client, err := investgo.NewClient(ctx, newConfig, c.Logg)
-	if err != nil {
-		return nil, fmt.Errorf("op:%s, err: can't connect with tinkoffApi client", op)
-	}
-
-	return client, nil
+	return getPooledClient(ctx, c.pools, op)
 }
 
-func (c *AnalyticsServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, err error) {
+func (c *AnalyticsServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, release func(), err error) {
 	const op = "sevrice.AnalyticsServiceClient.GetClient"
 
-	token, err := valuefromcontext.GetToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("%s:%w", op, err)
-	}
-
-	newConfig := *c.config
-	newConfig.Token = token
-
-	client, err := investgo.NewClient(ctx, newConfig, c.Logg)
-	if err != nil {
-		return nil, fmt.Errorf("op:%s, err: can't connect with tinkoffApi client", op)
-	}
-
-	return client, nil
+	return getPooledClient(ctx, c.pools, op)
 }
 
-func (c *PortfolioServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, err error) {
+func (c *PortfolioServiceClient) GetClient(ctx context.Context) (_ *investgo.Client, release func(), err error) {
 	const op = "sevrice.PortfolioServiceClient.GetClient"
 
-	token, err := valuefromcontext.GetToken(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("%s:%w", op, err)
-	}
-
-	newConfig := *c.config
-	newConfig.Token = token
-
-	client, err := investgo.NewClient(ctx, newConfig, c.Logg)
-	if err != nil {
-		return nil, fmt.Errorf("op:%s, err: can't connect with tinkoffApi client", op)
-	}
-
-	return client, nil
+	return getPooledClient(ctx, c.pools, op)
 }
 
-func (c *PortfolioServiceClient) GetAccounts(client *investgo.Client) (map[string]Account, error) {
+func (c *PortfolioServiceClient) GetAccounts(ctx context.Context, client *investgo.Client) (map[string]Account, error) {
 	const op = "sevrice.GetAccounts"
-	usersService := client.NewUsersServiceClient()
 	accounts := make(map[string]Account)
-	status := pb.AccountStatus_ACCOUNT_STATUS_ALL
-	accsResp, err := usersService.GetAccounts(&status)
+	accs, err := c.getAccounts(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("op:%s, err: could not get accounts", op)
+		return nil, fmt.Errorf("op:%s, err: could not get accounts: %w", op, err)
 	}
-	accs := accsResp.GetAccounts()
 	for _, acc := range accs {
 		account := Account{
 			Id:          acc.GetId(),
@@ -143,8 +112,109 @@ func (c *PortfolioServiceClient) GetAccounts(client *investgo.Client) (map[strin
 	return accounts, nil
 }
 
+// getAccounts запрашивает счета в UsersService или, в режиме песочницы, в SandboxService.
+func (c *PortfolioServiceClient) getAccounts(ctx context.Context, client *investgo.Client) ([]*pb.Account, error) {
+	if sandbox.Enabled(ctx) {
+		if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+			return nil, err
+		}
+		accsResp, err := client.NewSandboxServiceClient().GetSandboxAccounts()
+		if err != nil {
+			return nil, err
+		}
+		c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, accsResp.Header)
+		return accsResp.GetAccounts(), nil
+	}
+
+	status := pb.AccountStatus_ACCOUNT_STATUS_ALL
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Users); err != nil {
+		return nil, err
+	}
+	accsResp, err := client.NewUsersServiceClient().GetAccounts(&status)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Users, accsResp.Header)
+	return accsResp.GetAccounts(), nil
+}
+
+// GetTokenScope определяет права токена по уровню доступа к счетам (GetAccounts),
+// заодно проверяя, что токен действует. Токен считается read-only, если ни по одному
+// счету у него нет полного доступа, и no_access, если нет доступа ни к одному счету.
+func (c *PortfolioServiceClient) GetTokenScope(ctx context.Context, client *investgo.Client) (TokenScope, error) {
+	const op = "service.GetTokenScope"
+	usersService := client.NewUsersServiceClient()
+
+	// закрытые счета не дают токену прав, поэтому смотрим только открытые
+	status := pb.AccountStatus_ACCOUNT_STATUS_OPEN
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Users); err != nil {
+		return TokenScope{}, fmt.Errorf("%s: %w", op, err)
+	}
+	accsResp, err := usersService.GetAccounts(&status)
+	if err != nil {
+		return TokenScope{}, fmt.Errorf("op:%s, err: could not get accounts: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Users, accsResp.Header)
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Users); err != nil {
+		return TokenScope{}, fmt.Errorf("%s: %w", op, err)
+	}
+	infoResp, err := usersService.GetInfo()
+	if err != nil {
+		return TokenScope{}, fmt.Errorf("op:%s, err: could not get user info: %w", op, err)
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Users, infoResp.Header)
+
+	scope := TokenScope{
+		Tariff:     infoResp.GetTariff(),
+		QualStatus: infoResp.GetQualStatus(),
+		Accounts:   make([]TokenScopeAccount, 0, len(accsResp.GetAccounts())),
+	}
+	levels := make([]string, 0, len(accsResp.GetAccounts()))
+	for _, acc := range accsResp.GetAccounts() {
+		accessLevel := convertPbAccessLevel(acc.GetAccessLevel())
+		levels = append(levels, accessLevel)
+		scope.Accounts = append(scope.Accounts, TokenScopeAccount{
+			Id:          acc.GetId(),
+			Name:        acc.GetName(),
+			AccessLevel: accessLevel,
+		})
+	}
+	scope.AccessLevel = tokenAccessLevel(levels)
+	scope.ReadOnly = scope.AccessLevel == AccessLevelReadOnly
+
+	return scope, nil
+}
+
+// tokenAccessLevel сводит уровни доступа по счетам к уровню токена: берется самый широкий.
+func tokenAccessLevel(levels []string) string {
+	res := AccessLevelNoAccess
+	for _, level := range levels {
+		switch level {
+		case AccessLevelFullAccess:
+			return AccessLevelFullAccess
+		case AccessLevelReadOnly:
+			res = AccessLevelReadOnly
+		}
+	}
+	return res
+}
+
+func convertPbAccessLevel(level pb.AccessLevel) string {
+	switch level {
+	case pb.AccessLevel_ACCOUNT_ACCESS_LEVEL_FULL_ACCESS:
+		return AccessLevelFullAccess
+	case pb.AccessLevel_ACCOUNT_ACCESS_LEVEL_READ_ONLY:
+		return AccessLevelReadOnly
+	case pb.AccessLevel_ACCOUNT_ACCESS_LEVEL_NO_ACCESS:
+		return AccessLevelNoAccess
+	default:
+		return AccessLevelUnspecified
+	}
+}
+
 // TODO: Валидацию оставить в service, а вызовы перенести в clients
-func (c *PortfolioServiceClient) GetPortfolio(client *investgo.Client, request PortfolioRequest) (_ Portfolio, err error) {
+func (c *PortfolioServiceClient) GetPortfolio(ctx context.Context, client *investgo.Client, request PortfolioRequest) (_ Portfolio, err error) {
 	const op = "service.GetPortfolio"
 	accountID := request.AccountID
 	accountStatus := request.AccountStatus
@@ -160,12 +230,10 @@ func (c *PortfolioServiceClient) GetPortfolio(client *investgo.Client, request P
 	if accountID == "" {
 		return Portfolio{}, ErrEmptyAccountIdInRequest
 	}
-	operationsService := client.NewOperationsServiceClient()
 
-	portfolioResp, err := operationsService.GetPortfolio(accountID,
-		pb.PortfolioRequest_RUB)
+	portfolioResp, err := c.getPortfolio(ctx, client, accountID)
 	if err != nil {
-		return Portfolio{}, fmt.Errorf("op: %s, error: can't get portifolio positions from tinkoff Api", op)
+		return Portfolio{}, fmt.Errorf("op: %s, error: can't get portifolio positions from tinkoff Api: %w", op, err)
 	}
 	portfolio.Positions = ConvertPbToPortfolioPositions(portfolioResp.GetPositions())
 	portfolio.TotalAmount = ConvertPbToMoneyValue(portfolioResp.GetTotalAmountPortfolio())
@@ -173,6 +241,64 @@ func (c *PortfolioServiceClient) GetPortfolio(client *investgo.Client, request P
 	return portfolio, nil
 }
 
+// getPortfolio запрашивает портфель в OperationsService или, в режиме песочницы, в SandboxService.
+func (c *PortfolioServiceClient) getPortfolio(ctx context.Context, client *investgo.Client, accountID string) (*pb.PortfolioResponse, error) {
+	if sandbox.Enabled(ctx) {
+		if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Sandbox); err != nil {
+			return nil, err
+		}
+		portfolioResp, err := client.NewSandboxServiceClient().GetSandboxPortfolio(accountID, pb.PortfolioRequest_RUB)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter.Observe(client.Config.Token, ratelimit.Sandbox, portfolioResp.Header)
+		return portfolioResp.PortfolioResponse, nil
+	}
+
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Operations); err != nil {
+		return nil, err
+	}
+	portfolioResp, err := client.NewOperationsServiceClient().GetPortfolio(accountID, pb.PortfolioRequest_RUB)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Operations, portfolioResp.Header)
+	return portfolioResp.PortfolioResponse, nil
+}
+
+// GetHeldInstrumentUids возвращает инструменты из портфелей всех открытых счетов токена.
+func (c *PortfolioServiceClient) GetHeldInstrumentUids(ctx context.Context, client *investgo.Client) (_ []string, err error) {
+	const op = "service.GetHeldInstrumentUids"
+	accounts, err := c.GetAccounts(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	seen := make(map[string]struct{})
+	uids := make([]string, 0)
+	for _, account := range accounts {
+		portfolio, err := c.GetPortfolio(ctx, client, PortfolioRequest{AccountID: account.Id, AccountStatus: account.Status})
+		switch {
+		case errors.Is(err, ErrCloseAccount),
+			errors.Is(err, ErrUnspecifiedAccount),
+			errors.Is(err, ErrNewNotOpenYetAccount):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, position := range portfolio.Positions {
+			if position.InstrumentUid == "" {
+				continue
+			}
+			if _, ok := seen[position.InstrumentUid]; ok {
+				continue
+			}
+			seen[position.InstrumentUid] = struct{}{}
+			uids = append(uids, position.InstrumentUid)
+		}
+	}
+	return uids, nil
+}
+
 func ConvertPbToPortfolioPositions(pbPositions []*pb.PortfolioPosition) []PortfolioPositions {
 	positions := make([]PortfolioPositions, 0, len(pbPositions))
 	for _, pbPosition := range pbPositions {
@@ -220,59 +346,46 @@ func ConvertPbToQuatation(pbQuatation *pb.Quotation) Quotation {
 	}
 }
 
-func (c *PortfolioServiceClient) GetOperations(client *investgo.Client, request OperationsRequest) (_ []Operation, err error) {
+// GetOperations выгружает все операции начиная с request.Date постранично, без ограничения на количество.
+func (c *PortfolioServiceClient) GetOperations(ctx context.Context, client *investgo.Client, request OperationsRequest) (_ []Operation, err error) {
 	const op = "tinkoffApi.GetOperations"
 	defer func() { err = e.WrapIfErr(op+": can't get operations from tinkoff API", err) }()
 
-	accountID := request.AccountID
 	if request.AccountID == "" {
-		return nil, fmt.Errorf("op: %s, error: empty account ID", op)
+		return nil, fmt.Errorf("op: %s, error: %w", op, ErrEmptyAccountIdInRequest)
 	}
 	date := request.Date.UTC()
 	now := time.Now().UTC()
 
 	if date.After(now) {
-		return nil, fmt.Errorf("op:%s, from can't be more than the current date", op)
+		return nil, fmt.Errorf("op:%s, %w", op, ErrFromAfterNow)
 	}
-	allOperations := make([]*pb.OperationItem, 0)
 
-	operationsService := client.NewOperationsServiceClient()
-	operationsResp, err := operationsService.GetOperationsByCursor(&investgo.GetOperationsByCursorRequest{
-		AccountId: accountID,
+	allOperations := make([]Operation, 0)
+	pageReq := OperationsPageRequest{
+		AccountID: request.AccountID,
 		From:      date,
 		To:        now,
-		Limit:     1000,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("op:%s, failed to get operations: %w", op, err)
-	}
-	operations := operationsResp.GetOperationsByCursorResponse.GetItems()
-	allOperations = append(allOperations, operations...)
-	nextCursor := operationsResp.NextCursor
-	for nextCursor != "" {
-		operationsResp, err := operationsService.GetOperationsByCursor(&investgo.GetOperationsByCursorRequest{
-			AccountId: accountID,
-			Limit:     1000,
-			Cursor:    nextCursor,
-		})
+		Limit:     MaxOperationsPageLimit,
+	}
+	for {
+		page, err := c.getOperationsPage(ctx, client, pageReq)
 		if err != nil {
-			return nil, fmt.Errorf("op:%s, failed to get operations with cursor: %w", op, err)
+			return nil, fmt.Errorf("op:%s, %w", op, err)
 		}
-		nextCursor = operationsResp.NextCursor
-		operations := operationsResp.GetOperationsByCursorResponse.Items
-		allOperations = append(allOperations, operations...)
-
-		// TODO: Refactor this block. Add more context.
-		if len(allOperations) > 10000 {
+		allOperations = append(allOperations, page.Operations...)
+		if !page.HasNext {
 			break
 		}
-
+		if page.NextCursor == pageReq.Cursor {
+			return nil, fmt.Errorf("op:%s, invest api returned the same cursor %q", op, page.NextCursor)
+		}
+		pageReq.Cursor = page.NextCursor
 	}
-	resp := convertOperationsPbToOperaions(allOperations)
-	return resp, nil
+	return allOperations, nil
 }
 
-func (c *PortfolioServiceClient) MakeSafeGetOperationsRequest(client *investgo.Client, request OperationsRequest) ([]Operation, error) {
+func (c *PortfolioServiceClient) MakeSafeGetOperationsRequest(ctx context.Context, client *investgo.Client, request OperationsRequest) ([]Operation, error) {
 	var lastErr error
 
 	// Пробуем с разными сдвигами времени
@@ -283,7 +396,7 @@ func (c *PortfolioServiceClient) MakeSafeGetOperationsRequest(client *investgo.C
 		-3 * time.Minute,
 	} {
 		adjustedRequest := adjustRequestTime(request, offset)
-		operations, err := c.GetOperations(client, adjustedRequest)
+		operations, err := c.GetOperations(ctx, client, adjustedRequest)
 		if err == nil {
 			return operations, nil
 		}
@@ -298,13 +411,12 @@ func (c *PortfolioServiceClient) MakeSafeGetOperationsRequest(client *investgo.C
 	return nil, lastErr
 }
 
-func isTimeError(inputErr error) bool {
-	if inputErr == nil {
-		return false
-	}
+// tinkoffCodeInvalidPeriod - Invest API отклонил период запроса операций,
+// обычно из-за расхождения часов: from оказался позже времени сервера.
+const tinkoffCodeInvalidPeriod = "30070"
 
-	errorStr := inputErr.Error()
-	return strings.Contains(errorStr, "30070")
+func isTimeError(inputErr error) bool {
+	return apierrors.TinkoffCode(inputErr) == tinkoffCodeInvalidPeriod
 }
 
 func adjustRequestTime(request OperationsRequest, offset time.Duration) OperationsRequest {
@@ -346,13 +458,17 @@ func convertOperationsPbToOperaions(operations []*pb.OperationItem) []Operation
 	return transformOperations
 }
 
-func (c *AnalyticsServiceClient) GetAllAssetUids(client *investgo.Client) (map[string]string, error) {
+func (c *AnalyticsServiceClient) GetAllAssetUids(ctx context.Context, client *investgo.Client) (map[string]string, error) {
 	const op = "service.GetAllAssetUids"
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	AssetsResponse, err := instrumentService.GetAssets()
 	if err != nil {
-		return nil, fmt.Errorf("op: %s, error: could not get assets uid", op)
+		return nil, fmt.Errorf("op: %s, error: could not get assets uid: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, AssetsResponse.Header)
 	assetUidInstrumentUidMap := make(map[string]string)
 	for _, v := range AssetsResponse.AssetsResponse.Assets {
 		asset_uid := v.Uid
@@ -365,15 +481,20 @@ func (c *AnalyticsServiceClient) GetAllAssetUids(client *investgo.Client) (map[s
 	return assetUidInstrumentUidMap, nil
 }
 
-func (c *InstrumentsServiceClient) GetFutureBy(client *investgo.Client, figi string) (Future, error) {
+func (c *InstrumentsServiceClient) GetFutureBy(ctx context.Context, client *investgo.Client, figi string) (Future, error) {
+	const op = "service.GetFutureBy"
 	if figi == "" {
 		return Future{}, ErrEmptyFigi
 	}
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return Future{}, fmt.Errorf("%s: %w", op, err)
+	}
 	futuresResponse, err := instrumentService.FutureByFigi(figi)
 	if err != nil {
 		return Future{}, e.WrapIfErr("can't get futures by figi", err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, futuresResponse.Header)
 	resp := convertFuturePbToFuture(futuresResponse.FutureResponse.Instrument)
 	return resp, nil
 }
@@ -388,38 +509,48 @@ func convertFuturePbToFuture(futurePb *pb.Future) Future {
 	}
 }
 
-func (c *InstrumentsServiceClient) GetBondByUid(client *investgo.Client, uid string) (Bond, error) {
+func (c *InstrumentsServiceClient) GetBondByUid(ctx context.Context, client *investgo.Client, uid string) (Bond, error) {
 	const op = "service.GetBondByUid"
 	if uid == "" {
-		return Bond{}, fmt.Errorf("op:%s, error: incorrect uid: can't be empty string", op)
+		return Bond{}, fmt.Errorf("op:%s, error: %w", op, ErrEmptyUid)
 	}
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return Bond{}, fmt.Errorf("%s: %w", op, err)
+	}
 	bondResponse, err := instrumentService.BondByUid(uid)
 	if err != nil {
-		return Bond{}, fmt.Errorf("op:%s, error: can't get share by figi", op)
+		return Bond{}, fmt.Errorf("op:%s, error: can't get share by figi: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, bondResponse.Header)
 	resp := convertBondPbToBond(bondResponse.BondResponse.Instrument)
 	return resp, nil
 }
 
 func convertBondPbToBond(bondPb *pb.Bond) Bond {
 	return Bond{
-		AciValue: ConvertPbToMoneyValue(bondPb.GetAciValue()),
-		Nominal:  ConvertPbToMoneyValue(bondPb.GetNominal()),
-		Currency: bondPb.GetCurrency(),
+		AciValue:  ConvertPbToMoneyValue(bondPb.GetAciValue()),
+		Nominal:   ConvertPbToMoneyValue(bondPb.GetNominal()),
+		Currency:  bondPb.GetCurrency(),
+		Sector:    bondPb.GetSector(),
+		RiskLevel: bondPb.GetRiskLevel().String(),
 	}
 }
 
-func (c *InstrumentsServiceClient) GetCurrencyBy(client *investgo.Client, figi string) (Currency, error) {
+func (c *InstrumentsServiceClient) GetCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (Currency, error) {
 	const op = "service.GetCurrencyBy"
 	if figi == "" {
 		return Currency{}, ErrEmptyFigi
 	}
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return Currency{}, fmt.Errorf("%s: %w", op, err)
+	}
 	currencyResponse, err := instrumentService.CurrencyByFigi(figi)
 	if err != nil {
-		return Currency{}, fmt.Errorf("op:%s, error: can't get curency by figi", op)
+		return Currency{}, fmt.Errorf("op:%s, error: can't get curency by figi: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, currencyResponse.Header)
 
 	resp := convertCurrencyPbToCurrency(currencyResponse.CurrencyResponse.Instrument)
 	return resp, nil
@@ -431,16 +562,20 @@ func convertCurrencyPbToCurrency(currencyPb *pb.Currency) Currency {
 	}
 }
 
-func (c *InstrumentsServiceClient) FindBy(client *investgo.Client, query string) ([]InstrumentShort, error) {
+func (c *InstrumentsServiceClient) FindBy(ctx context.Context, client *investgo.Client, query string) ([]InstrumentShort, error) {
 	const op = "service.FindBy"
 	if query == "" {
 		return nil, ErrEmptyQuery
 	}
 	instrumentServiceClient := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	findInstr, err := instrumentServiceClient.FindInstrument(query)
 	if err != nil {
-		return nil, fmt.Errorf("op: %s, error: could not find instrument by query", op)
+		return nil, fmt.Errorf("op: %s, error: could not find instrument by query: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, findInstr.Header)
 	resp := convertInstrumentShortPbToInstrumentShort(findInstr.FindInstrumentResponse.GetInstruments())
 	return resp, nil
 }
@@ -457,49 +592,62 @@ func convertInstrumentShortPbToInstrumentShort(instrumentShortPb []*pb.Instrumen
 	return instrumentShorts
 }
 
-func (c *AnalyticsServiceClient) GetBondsActions(client *investgo.Client, instrumentUid string) (BondIdentIdentifiers, error) {
+func (c *AnalyticsServiceClient) GetBondsActions(ctx context.Context, client *investgo.Client, instrumentUid string) (BondIdentIdentifiers, error) {
 	const op = "service.GetBondActions"
 	if instrumentUid == "" {
 		return BondIdentIdentifiers{}, ErrEmptyInstrumentUid
 	}
-	var res BondIdentIdentifiers
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return BondIdentIdentifiers{}, fmt.Errorf("%s: %w", op, err)
+	}
 	bondUid, err := instrumentService.BondByUid(instrumentUid)
 	if err != nil {
-		return BondIdentIdentifiers{}, fmt.Errorf("op: %s, error: could not get bond actions", op)
+		return BondIdentIdentifiers{}, fmt.Errorf("op: %s, error: could not get bond actions: %w", op, err)
 	}
-	res.Ticker = bondUid.BondResponse.Instrument.GetTicker()
-	res.ClassCode = bondUid.BondResponse.Instrument.GetClassCode()
-	res.Name = bondUid.BondResponse.Instrument.GetName()
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, bondUid.Header)
+	return convertBondPbToBondIdentIdentifiers(bondUid.BondResponse.Instrument), nil
+}
 
-	if bondUid.BondResponse.Instrument.GetBondType() == 1 {
+func convertBondPbToBondIdentIdentifiers(bondPb *pb.Bond) BondIdentIdentifiers {
+	res := BondIdentIdentifiers{
+		Ticker:          bondPb.GetTicker(),
+		ClassCode:       bondPb.GetClassCode(),
+		Name:            bondPb.GetName(),
+		Nominal:         ConvertPbToMoneyValue(bondPb.GetNominal()),
+		NominalCurrency: bondPb.GetNominal().GetCurrency(),
+		FloatingCoupon:  bondPb.GetFloatingCouponFlag(),
+	}
+	if bondPb.GetBondType() == 1 {
 		res.Replaced = true
 	}
-	res.Nominal = ConvertPbToMoneyValue(bondUid.BondResponse.Instrument.GetNominal())
-	res.NominalCurrency = bondUid.Instrument.GetNominal().Currency
-	return res, nil
+	return res
 }
 
-func (c *AnalyticsServiceClient) GetLastPriceInPersentageToNominal(client *investgo.Client, instrumentUid string) (LastPriceResponse, error) {
+func (c *AnalyticsServiceClient) GetLastPriceInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUid string) (LastPriceResponse, error) {
 	const op = "tinkoffApi.GetLastPriceInPercentageToNominal"
 	if instrumentUid == "" {
 		return LastPriceResponse{}, fmt.Errorf("%s: %w", op, ErrEmptyInstrumentUid)
 	}
 	marketDataClient := client.NewMarketDataServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.MarketData); err != nil {
+		return LastPriceResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
 	lastPriceAnswer, err := marketDataClient.GetLastPrices([]string{instrumentUid})
 	if err != nil {
 		return LastPriceResponse{}, fmt.Errorf("%s: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.MarketData, lastPriceAnswer.Header)
 
 	// Проверка через LastPriceType нужна для ошибке при некоректном instrumentUid.
 	// Т.к. тинькофф выдает нулевую ошибку при любом ошибочном instrumentUid
 	if lastPriceAnswer.GetLastPricesResponse.LastPrices[0].Price.ToFloat() == 0 &&
 		lastPriceAnswer.LastPrices[0].LastPriceType.Number() == 0 {
-		return LastPriceResponse{}, fmt.Errorf("%s: haven't response for instrument %s", op, instrumentUid)
+		return LastPriceResponse{}, fmt.Errorf("%s: haven't response for instrument %s: %w", op, instrumentUid, ErrNoPriceData)
 	}
 
 	if len(lastPriceAnswer.LastPrices) == 0 {
-		return LastPriceResponse{}, fmt.Errorf("%s: no price data for instrument %s", op, instrumentUid)
+		return LastPriceResponse{}, fmt.Errorf("%s: %w %s", op, ErrNoPriceData, instrumentUid)
 	}
 
 	lastPrice := ConvertPbToQuatation(lastPriceAnswer.LastPrices[0].Price)
@@ -509,16 +657,20 @@ func (c *AnalyticsServiceClient) GetLastPriceInPersentageToNominal(client *inves
 	return resp, nil
 }
 
-func (c *InstrumentsServiceClient) GetShareCurrencyBy(client *investgo.Client, figi string) (ShareCurrencyByResponse, error) {
+func (c *InstrumentsServiceClient) GetShareCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (ShareCurrencyByResponse, error) {
 	const op = "service.GetShareCurrencyBy"
 	if figi == "" {
 		return ShareCurrencyByResponse{}, ErrEmptyFigi
 	}
 	instrumentService := client.NewInstrumentsServiceClient()
+	if err := c.limiter.Wait(ctx, client.Config.Token, ratelimit.Instruments); err != nil {
+		return ShareCurrencyByResponse{}, fmt.Errorf("%s: %w", op, err)
+	}
 	shareResponse, err := instrumentService.ShareByFigi(figi)
 	if err != nil {
-		return ShareCurrencyByResponse{}, fmt.Errorf("op: %s, error: can't get share by figi", op)
+		return ShareCurrencyByResponse{}, fmt.Errorf("op: %s, error: can't get share by figi: %w", op, err)
 	}
+	c.limiter.Observe(client.Config.Token, ratelimit.Instruments, shareResponse.Header)
 	var resp ShareCurrencyByResponse
 	resp.Currency = shareResponse.ShareResponse.Instrument.GetCurrency()
 	return resp, nil
@@ -2,53 +2,98 @@ package service
 
 import (
 	"context"
-	"errors"
+
+	"tinkoffApi/internal/apierrors"
 
 	"github.com/russianinvestments/invest-api-go-sdk/investgo"
 )
 
 var (
-	ErrCloseAccount            = errors.New("close account haven't portffolio positions")
-	ErrNoAcces                 = errors.New("this token no access to account")
-	ErrEmptyAccountIdInRequest = errors.New("accountId could not be empty")
-	ErrUnspecifiedAccount      = errors.New("account is unspecified")
-	ErrNewNotOpenYetAccount    = errors.New("accountId is not opened yet")
-	ErrEmptyQuery              = errors.New("query could not be empty")
-	ErrEmptyFigi               = errors.New("figi could not be empty string")
-	ErrEmptyUid                = errors.New("uid could not be empty string")
-	ErrEmptyPositionUid        = errors.New("positionUid could not be empty string")
-	ErrEmptyInstrumentUid      = errors.New("instrumentUid could not be empty string")
+	ErrCloseAccount            = apierrors.New(apierrors.InvalidArgument, "close account haven't portffolio positions")
+	ErrNoAcces                 = apierrors.New(apierrors.Unauthorized, "this token no access to account")
+	ErrEmptyAccountIdInRequest = apierrors.New(apierrors.InvalidArgument, "accountId could not be empty")
+	ErrUnspecifiedAccount      = apierrors.New(apierrors.InvalidArgument, "account is unspecified")
+	ErrNewNotOpenYetAccount    = apierrors.New(apierrors.InvalidArgument, "accountId is not opened yet")
+	ErrEmptyQuery              = apierrors.New(apierrors.InvalidArgument, "query could not be empty")
+	ErrEmptyFigi               = apierrors.New(apierrors.InvalidArgument, "figi could not be empty string")
+	ErrEmptyUid                = apierrors.New(apierrors.InvalidArgument, "uid could not be empty string")
+	ErrEmptyPositionUid        = apierrors.New(apierrors.InvalidArgument, "positionUid could not be empty string")
+	ErrEmptyInstrumentUid      = apierrors.New(apierrors.InvalidArgument, "instrumentUid could not be empty string")
+	ErrEmptyBatch              = apierrors.New(apierrors.InvalidArgument, "batch could not be empty")
+	ErrBatchTooLarge           = apierrors.New(apierrors.InvalidArgument, "batch is too large")
+	ErrFromAfterNow            = apierrors.New(apierrors.InvalidArgument, "from can't be more than the current date")
+	ErrInvalidPageLimit        = apierrors.New(apierrors.InvalidArgument, "operations page limit is out of range")
+	ErrNoPriceData             = apierrors.New(apierrors.NotFound, "no price data for instrument")
+	ErrSandboxDisabled         = apierrors.New(apierrors.InvalidArgument, "sandbox is disabled")
+	ErrEmptyCurrency           = apierrors.New(apierrors.InvalidArgument, "currency could not be empty")
+	ErrInvalidAmount           = apierrors.New(apierrors.InvalidArgument, "amount must be positive")
+	ErrInvalidQuantity         = apierrors.New(apierrors.InvalidArgument, "quantity must be positive")
+	ErrInvalidDirection        = apierrors.New(apierrors.InvalidArgument, "direction must be buy or sell")
+	ErrInvalidPeriod           = apierrors.New(apierrors.InvalidArgument, "from can't be after to")
 )
 
+// ErrNoMarginInSandbox - в песочнице счет отвечает так же, как боевой счет без маржинальной торговли.
+var ErrNoMarginInSandbox = &apierrors.Error{
+	Code:        apierrors.InvalidArgument,
+	TinkoffCode: tinkoffCodeMarginDisabled,
+	Message:     "margin attributes are not available in sandbox",
+}
+
 type Service struct {
 	InstrumentService InstrumentService
 	PortfolioService  PortfolioService
 	AnalyticsService  AnalyticsService
+	SandboxService    SandboxService
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=InstrumentService
 type InstrumentService interface {
-	GetClient(ctx context.Context) (*investgo.Client, error)
-	FindBy(client *investgo.Client, query string) ([]InstrumentShort, error)
-	GetBondByUid(client *investgo.Client, uid string) (Bond, error)
-	GetCurrencyBy(client *investgo.Client, figi string) (Currency, error)
-	GetFutureBy(client *investgo.Client, figi string) (Future, error)
-	GetShareCurrencyBy(client *investgo.Client, figi string) (ShareCurrencyByResponse, error)
+	GetClient(ctx context.Context) (*investgo.Client, func(), error)
+	FindBy(ctx context.Context, client *investgo.Client, query string) ([]InstrumentShort, error)
+	GetBondByUid(ctx context.Context, client *investgo.Client, uid string) (Bond, error)
+	GetCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (Currency, error)
+	GetFutureBy(ctx context.Context, client *investgo.Client, figi string) (Future, error)
+	GetShareCurrencyBy(ctx context.Context, client *investgo.Client, figi string) (ShareCurrencyByResponse, error)
+	GetBondsByUids(ctx context.Context, client *investgo.Client, uids []string) (BondsResponse, error)
+	GetFuturesBy(ctx context.Context, client *investgo.Client, figis []string) (FuturesResponse, error)
+	GetCurrenciesBy(ctx context.Context, client *investgo.Client, figis []string) (CurrenciesResponse, error)
+	GetDividends(ctx context.Context, client *investgo.Client, request DividendsRequest) (DividendsResponse, error)
+	GetAssetReports(ctx context.Context, client *investgo.Client, request AssetReportsRequest) (AssetReportsResponse, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=PortfolioService
 type PortfolioService interface {
-	GetClient(ctx context.Context) (*investgo.Client, error)
-	GetAccounts(client *investgo.Client) (map[string]Account, error)
-	GetPortfolio(client *investgo.Client, request PortfolioRequest) (Portfolio, error)
-	GetOperations(client *investgo.Client, request OperationsRequest) ([]Operation, error)
-	MakeSafeGetOperationsRequest(client *investgo.Client, request OperationsRequest) ([]Operation, error)
+	GetClient(ctx context.Context) (*investgo.Client, func(), error)
+	GetAccounts(ctx context.Context, client *investgo.Client) (map[string]Account, error)
+	GetPortfolio(ctx context.Context, client *investgo.Client, request PortfolioRequest) (Portfolio, error)
+	GetOperations(ctx context.Context, client *investgo.Client, request OperationsRequest) ([]Operation, error)
+	MakeSafeGetOperationsRequest(ctx context.Context, client *investgo.Client, request OperationsRequest) ([]Operation, error)
+	GetOperationsPage(ctx context.Context, client *investgo.Client, request OperationsPageRequest) (OperationsPage, error)
+	GetHeldInstrumentUids(ctx context.Context, client *investgo.Client) ([]string, error)
+	GetTokenScope(ctx context.Context, client *investgo.Client) (TokenScope, error)
+	GetPositions(ctx context.Context, client *investgo.Client, accountID string) (Positions, error)
+	GetWithdrawLimits(ctx context.Context, client *investgo.Client, accountID string) (WithdrawLimits, error)
+	GetMarginAttributes(ctx context.Context, client *investgo.Client, accountID string) (MarginAttributes, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=AnalyticsService
 type AnalyticsService interface {
-	GetClient(ctx context.Context) (*investgo.Client, error)
-	GetLastPriceInPersentageToNominal(client *investgo.Client, instrumentUid string) (LastPriceResponse, error)
-	GetAllAssetUids(client *investgo.Client) (map[string]string, error)
-	GetBondsActions(client *investgo.Client, instrumentUid string) (BondIdentIdentifiers, error)
+	GetClient(ctx context.Context) (*investgo.Client, func(), error)
+	GetLastPriceInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUid string) (LastPriceResponse, error)
+	GetAllAssetUids(ctx context.Context, client *investgo.Client) (map[string]string, error)
+	GetBondsActions(ctx context.Context, client *investgo.Client, instrumentUid string) (BondIdentIdentifiers, error)
+	GetLastPricesInPersentageToNominal(ctx context.Context, client *investgo.Client, instrumentUids []string) (LastPricesResponse, error)
+	GetBondsActionsBatch(ctx context.Context, client *investgo.Client, instrumentUids []string) (BondsActionsBatchResponse, error)
+}
+
+// SandboxService - операции, которые есть только в песочнице Invest API.
+// Клиент всегда берется из пула песочницы, независимо от режима запроса.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5 --name=SandboxService
+type SandboxService interface {
+	GetClient(ctx context.Context) (*investgo.Client, func(), error)
+	OpenAccount(ctx context.Context, client *investgo.Client) (SandboxAccount, error)
+	CloseAccount(ctx context.Context, client *investgo.Client, accountID string) error
+	PayIn(ctx context.Context, client *investgo.Client, request SandboxPayInRequest) (MoneyValue, error)
+	PostOrder(ctx context.Context, client *investgo.Client, request SandboxOrderRequest) (SandboxOrder, error)
 }